@@ -0,0 +1,266 @@
+package tview
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+// The states of the ANSI escape sequence parser.
+const (
+	ansiText      = iota // Regular text.
+	ansiEscape           // After an ESC character.
+	ansiCSI              // Inside a CSI sequence ("ESC [").
+	ansiOSC              // Inside an OSC sequence ("ESC ]").
+	ansiOSCEscape        // After an ESC character inside an OSC sequence.
+)
+
+// ansi is a writer which translates ANSI escape sequences into styled segments
+// and appends them to a text view.
+type ansi struct {
+	sync.Mutex
+
+	// The text view the translated text is written to.
+	textView *TextView
+
+	// The style used when attributes are reset.
+	base tcell.Style
+
+	// The style of the text currently being written.
+	style tcell.Style
+
+	// The current parser state.
+	state int
+
+	// The parameters of the CSI or OSC sequence currently being parsed.
+	params strings.Builder
+
+	// Bytes at the end of the last write which do not form a complete UTF-8
+	// character yet.
+	pending []byte
+}
+
+// ANSIWriter returns a writer which translates ANSI escape sequences in the
+// written text into segment styles and appends the result to the given text
+// view. This allows piping the output of command line tools (e.g. test runners
+// or container logs) into a text view.
+//
+// Select Graphic Rendition (SGR) sequences are translated into colors (the 16
+// standard colors, 256-color palette, and 24-bit RGB colors) and attributes.
+// OSC 8 hyperlinks are translated into URL styles. All other escape sequences
+// and carriage returns are removed. Escape sequences may be split across
+// multiple writes.
+//
+// Text is written in the text view's text style (see [TextView.SetTextStyle])
+// until a sequence changes it. Writes are safe for concurrent use.
+func ANSIWriter(t *TextView) io.Writer {
+	t.Lock()
+	base := t.textStyle
+	t.Unlock()
+	return &ansi{
+		textView: t,
+		base:     base,
+		style:    base,
+	}
+}
+
+// Write translates the given bytes and appends the resulting text to the text
+// view.
+func (a *ansi) Write(p []byte) (n int, err error) {
+	a.Lock()
+	defer a.Unlock()
+
+	text := string(append(a.pending, p...))
+	a.pending = a.pending[:0]
+
+	// Hold back an incomplete UTF-8 character at the end.
+	for start := len(text) - 1; start >= 0 && start >= len(text)-utf8.UTFMax; start-- {
+		if utf8.RuneStart(text[start]) {
+			if !utf8.FullRuneInString(text[start:]) {
+				a.pending = append(a.pending, text[start:]...)
+				text = text[:start]
+			}
+			break
+		}
+	}
+
+	var (
+		segments []Segment
+		current  strings.Builder
+	)
+	flush := func() {
+		if current.Len() > 0 {
+			segments = append(segments, Segment{Text: current.String(), Style: a.style})
+			current.Reset()
+		}
+	}
+	for _, ch := range text {
+		switch a.state {
+		case ansiText:
+			switch ch {
+			case '\x1b':
+				a.state = ansiEscape
+			case '\r':
+				// Carriage returns are dropped.
+			default:
+				current.WriteRune(ch)
+			}
+		case ansiEscape:
+			switch ch {
+			case '[':
+				a.state = ansiCSI
+				a.params.Reset()
+			case ']':
+				a.state = ansiOSC
+				a.params.Reset()
+			default:
+				a.state = ansiText // Unsupported two-character sequence.
+			}
+		case ansiCSI:
+			if ch >= 0x40 && ch <= 0x7e {
+				// Final byte.
+				if ch == 'm' {
+					flush()
+					a.style = a.sgr(a.style, a.params.String())
+				}
+				a.state = ansiText
+			} else {
+				a.params.WriteRune(ch)
+			}
+		case ansiOSC:
+			switch ch {
+			case '\a':
+				flush()
+				a.osc(a.params.String())
+				a.state = ansiText
+			case '\x1b':
+				a.state = ansiOSCEscape
+			default:
+				a.params.WriteRune(ch)
+			}
+		case ansiOSCEscape:
+			// "ESC \" terminates the sequence.
+			flush()
+			a.osc(a.params.String())
+			a.state = ansiText
+		}
+	}
+	flush()
+
+	if len(segments) > 0 {
+		a.textView.AppendSegments(segments...)
+	}
+	return len(p), nil
+}
+
+// osc applies an Operating System Command with the given parameters. Only
+// hyperlinks ("8;params;url") are supported.
+func (a *ansi) osc(params string) {
+	fields := strings.SplitN(params, ";", 3)
+	if len(fields) != 3 || fields[0] != "8" {
+		return
+	}
+	a.style = a.style.Url(fields[2])
+	for _, param := range strings.Split(fields[1], ":") {
+		if id, ok := strings.CutPrefix(param, "id="); ok {
+			a.style = a.style.UrlId(id)
+		}
+	}
+}
+
+// sgr returns the given style modified by the Select Graphic Rendition
+// sequence with the given parameters.
+func (a *ansi) sgr(style tcell.Style, params string) tcell.Style {
+	var codes []int
+	for field := range strings.SplitSeq(params, ";") {
+		// Sub-parameters (e.g. "4:3" for curly underlines) are reduced to
+		// their main parameter.
+		field, _, _ = strings.Cut(field, ":")
+		code, err := strconv.Atoi(field)
+		if err != nil {
+			code = 0 // Empty parameters default to 0.
+		}
+		codes = append(codes, code)
+	}
+
+	// color parses an extended color starting at the given index and returns
+	// it along with the number of additional codes it consumed.
+	color := func(index int) (tcell.Color, int, bool) {
+		if index+1 >= len(codes) {
+			return tcell.ColorDefault, 0, false
+		}
+		switch codes[index+1] {
+		case 5:
+			if index+2 < len(codes) {
+				return tcell.PaletteColor(codes[index+2]), 2, true
+			}
+		case 2:
+			if index+4 < len(codes) {
+				return tcell.NewRGBColor(int32(codes[index+2]), int32(codes[index+3]), int32(codes[index+4])), 4, true
+			}
+		}
+		return tcell.ColorDefault, len(codes) - index - 1, false
+	}
+
+	for index := 0; index < len(codes); index++ {
+		switch code := codes[index]; {
+		case code == 0:
+			style = a.base
+		case code == 1:
+			style = style.Bold(true)
+		case code == 2:
+			style = style.Dim(true)
+		case code == 3:
+			style = style.Italic(true)
+		case code == 4:
+			style = style.Underline(true)
+		case code == 5 || code == 6:
+			style = style.Blink(true)
+		case code == 7:
+			style = style.Reverse(true)
+		case code == 9:
+			style = style.StrikeThrough(true)
+		case code == 22:
+			style = style.Bold(false).Dim(false)
+		case code == 23:
+			style = style.Italic(false)
+		case code == 24:
+			style = style.Underline(false)
+		case code == 25:
+			style = style.Blink(false)
+		case code == 27:
+			style = style.Reverse(false)
+		case code == 29:
+			style = style.StrikeThrough(false)
+		case code >= 30 && code <= 37:
+			style = style.Foreground(tcell.PaletteColor(code - 30))
+		case code == 38:
+			c, consumed, ok := color(index)
+			if ok {
+				style = style.Foreground(c)
+			}
+			index += consumed
+		case code == 39:
+			style = style.Foreground(a.base.GetForeground())
+		case code >= 40 && code <= 47:
+			style = style.Background(tcell.PaletteColor(code - 40))
+		case code == 48:
+			c, consumed, ok := color(index)
+			if ok {
+				style = style.Background(c)
+			}
+			index += consumed
+		case code == 49:
+			style = style.Background(a.base.GetBackground())
+		case code >= 90 && code <= 97:
+			style = style.Foreground(tcell.PaletteColor(code - 90 + 8))
+		case code >= 100 && code <= 107:
+			style = style.Background(tcell.PaletteColor(code - 100 + 8))
+		}
+	}
+	return style
+}
@@ -1,6 +1,8 @@
 package tview
 
 import (
+	"fmt"
+	"os"
 	"strings"
 	"sync"
 	"time"
@@ -43,6 +45,16 @@ const (
 	MouseScrollRight
 )
 
+// BellMode determines how the bell is signaled, see [Application.SetBellMode].
+type BellMode int
+
+// Available bell modes.
+const (
+	BellAudible      BellMode = iota // Emit the terminal bell.
+	BellVisual                       // Flash the target primitive (or the entire screen).
+	BellVisualBorder                 // Flash the outline of the target primitive.
+)
+
 // queuedUpdate represented the execution of f queued by
 // Application.QueueUpdate(). If "done" is not nil, it receives exactly one
 // element after f has executed.
@@ -82,20 +94,98 @@ type Application struct {
 	// Functions queued from goroutines, used to serialize updates to primitives.
 	updates chan queuedUpdate
 
+	// Closed when the event loop of the current Run call has stopped.
+	stopped chan struct{}
+
 	mouseCapturingPrimitive Primitive        // A primitive requested via SetMouseCaptureCommand to capture future mouse events.
 	lastMouseX, lastMouseY  int              // The last position of the mouse.
 	mouseDownX, mouseDownY  int              // The position of the mouse when its button was last pressed.
 	lastMouseClick          time.Time        // The time when a mouse button was last clicked.
 	lastMouseButtons        tcell.ButtonMask // The last mouse button state.
+	titlePress              titlePress       // A press on a title bar, see Box.SetTitleLongPressFunc.
 
 	// forceRedraw requests a full clear before the next frame.
 	forceRedraw bool
+
+	// An optional function which is called when the terminal window gains or
+	// loses focus.
+	focusChanged func(focused bool)
+
+	// If set to true, redraws are postponed while the terminal window is not
+	// focused.
+	pauseWhenUnfocused bool
+
+	// Set to true if the terminal's focus reporting was enabled.
+	focusReporting bool
+
+	// Set to true while the terminal window is known to be unfocused.
+	terminalUnfocused bool
+
+	// Set to true if a redraw was skipped while the terminal was unfocused.
+	drawPending bool
+
+	// An optional function which receives a copy of the screen contents after
+	// each draw.
+	afterDraw func(snapshot *Snapshot)
+
+	// The recorder which records each draw, if any.
+	recorder *Recorder
+
+	// An optional function which returns the text printed after a panic in
+	// the event loop.
+	panicReport func(report *PanicReport) string
+
+	// The file panic reports are appended to. Ignored if empty.
+	panicLogPath string
+
+	// The performance metrics, see EnableMetrics.
+	metrics appMetrics
+
+	bellMode     BellMode      // How the bell is signaled.
+	bellDuration time.Duration // How long a visual bell lasts.
+	bellStyle    tcell.Style   // The style applied to the flashed cells of a visual bell.
+	bellTarget   Primitive     // The primitive flashed by the current visual bell, nil for the entire screen.
+	bellUntil    time.Time     // The time until which the current visual bell is shown.
+
+	// The text selected on the screen, see SetScreenSelection.
+	selection screenSelection
+
+	// Set to true in reduced-motion mode, see SetReducedMotion.
+	reducedMotion bool
+
+	// The key macro, see SetMacroKeys.
+	macro macroState
+
+	// The time of the last draw and whether a postponed draw is scheduled,
+	// see drawCoalesced.
+	lastDraw      time.Time
+	drawScheduled bool
+
+	// An optional function which is called after the next draw, see
+	// SetReadyFunc.
+	ready func()
+
+	// The primitive shown instead of the root primitive during startup and
+	// the function which starts its initialization, see SetSplash.
+	splash     Primitive
+	splashInit func()
+
+	// What happens when the user presses Ctrl-C and the optional handler
+	// called for it, see SetInterruptPolicy.
+	interruptPolicy InterruptPolicy
+	interrupt       func()
+
+	// Whether SIGINT and SIGTERM are caught while suspended, see
+	// SetSuspendSignals.
+	suspendSignals bool
 }
 
 // NewApplication creates and returns a new application.
 func NewApplication() *Application {
 	return &Application{
-		updates: make(chan queuedUpdate, updatesQueueSize),
+		updates:      make(chan queuedUpdate, updatesQueueSize),
+		bellDuration: 100 * time.Millisecond,
+		bellStyle:    tcell.StyleDefault.Reverse(true),
 	}
 }
 
@@ -139,12 +229,18 @@ func (a *Application) Run() error {
 		}
 		a.screen = screen
 	}
+	stopped := make(chan struct{})
+	a.stopped = stopped
+	a.updateFocusReporting()
 	a.Unlock()
+	defer close(stopped)
 
 	// We catch panics to clean up because they mess up the terminal.
 	defer func() {
 		if p := recover(); p != nil {
+			report := newPanicReport(p)
 			a.Stop()
+			a.reportPanic(report)
 			panic(p)
 		}
 	}()
@@ -169,6 +265,7 @@ EventLoop:
 			if event == nil {
 				break EventLoop
 			}
+			a.eventReceived(event)
 
 			switch event := event.(type) {
 			case *tcell.EventKey:
@@ -185,17 +282,29 @@ EventLoop:
 					break
 				}
 
+				// Ctrl-C may be handled by the application itself.
+				if a.handleInterrupt(event) {
+					a.draw()
+					break
+				}
+
 				a.RLock()
-				root := a.root
+				root := a.visibleRoot()
 				a.RUnlock()
 
 				// Pass other key events to the root primitive.
-				if root != nil && root.HasFocus() {
+				redraw := a.clearScreenSelection()
+				if handled, macroRedraw := a.macroKey(event); handled {
+					redraw = redraw || macroRedraw
+				} else if root != nil && root.HasFocus() {
 					cmd := root.HandleEvent(event)
 					if a.executeCommand(cmd) {
-						a.draw()
+						redraw = true
 					}
 				}
+				if redraw {
+					a.draw()
+				}
 			case *tcell.EventPaste:
 				if event.Start() {
 					pasting = true
@@ -203,7 +312,7 @@ EventLoop:
 				} else if event.End() {
 					pasting = false
 					a.RLock()
-					root := a.root
+					root := a.visibleRoot()
 					a.RUnlock()
 					if root != nil && root.HasFocus() && pasteBuffer.Len() > 0 {
 						// Pass paste event to the root primitive.
@@ -219,25 +328,48 @@ EventLoop:
 				// reports unchanged, so force one redraw pass.
 				a.forceRedraw = true
 				a.Unlock()
-				if time.Since(lastRedraw) < redrawPause {
+				if pause := a.redrawPause(); time.Since(lastRedraw) < pause {
 					if redrawTimer != nil {
 						redrawTimer.Stop()
 					}
-					redrawTimer = time.AfterFunc(redrawPause, func() {
+					redrawTimer = time.AfterFunc(pause, func() {
 						a.events <- event
 					})
 				}
 				lastRedraw = time.Now()
 				a.draw()
 			case *tcell.EventMouse:
+				consumed, changed := a.selectScreen(event)
+				if consumed {
+					if changed {
+						a.draw()
+					}
+					a.lastMouseButtons = event.Buttons()
+					break
+				}
 				handled, isMouseDownAction := a.fireMouseActions(event)
-				if handled {
+				if handled || changed {
 					a.draw()
 				}
 				a.lastMouseButtons = event.Buttons()
 				if isMouseDownAction {
 					a.mouseDownX, a.mouseDownY = event.Position()
 				}
+			case *tcell.EventFocus:
+				a.Lock()
+				a.terminalUnfocused = !event.Focused
+				pending := a.drawPending && event.Focused
+				if pending {
+					a.drawPending = false
+				}
+				focusChanged := a.focusChanged
+				a.Unlock()
+				if focusChanged != nil {
+					focusChanged(event.Focused)
+				}
+				if pending {
+					a.draw()
+				}
 			case *tcell.EventError:
 				appErr = event
 				a.Stop()
@@ -255,6 +387,51 @@ EventLoop:
 	return appErr
 }
 
+// SetPanicReportFunc sets a function which returns the text printed to stderr
+// when the event loop recovers from a panic, e.g. while drawing or handling an
+// event. The terminal is restored before the report is printed, after which
+// the panic continues, so the Go runtime prints the panic value and the stack
+// trace. The text should therefore not include [PanicReport.Stack]. If the
+// function returns an empty string, nothing is printed. If no function is set,
+// [PanicReport.Summary] is printed, which includes version information and the
+// log path. The log file always receives the full [PanicReport.String].
+func (a *Application) SetPanicReportFunc(handler func(report *PanicReport) string) *Application {
+	a.Lock()
+	defer a.Unlock()
+	a.panicReport = handler
+	return a
+}
+
+// SetPanicLogPath sets the path of a file which panic reports are appended to
+// (see [Application.SetPanicReportFunc]), e.g. so users can attach them to bug
+// reports. The file is created if it does not exist. An empty path disables
+// writing reports to a file.
+func (a *Application) SetPanicLogPath(path string) *Application {
+	a.Lock()
+	defer a.Unlock()
+	a.panicLogPath = path
+	return a
+}
+
+// reportPanic writes the given report to the panic log file, if any, and
+// prints it to stderr.
+func (a *Application) reportPanic(report *PanicReport) {
+	a.RLock()
+	handler, logPath := a.panicReport, a.panicLogPath
+	a.RUnlock()
+
+	if logPath != "" {
+		report.writeLog(logPath)
+	}
+	text := report.Summary()
+	if handler != nil {
+		text = handler(report)
+	}
+	if text != "" {
+		fmt.Fprintln(os.Stderr, strings.TrimRight(text, "\n"))
+	}
+}
+
 // fireMouseActions analyzes the provided mouse event, derives mouse actions
 // from it and then forwards them to the corresponding primitives.
 func (a *Application) fireMouseActions(event *tcell.EventMouse) (handled, isMouseDownAction bool) {
@@ -268,6 +445,12 @@ func (a *Application) fireMouseActions(event *tcell.EventMouse) (handled, isMous
 			isMouseDownAction = true
 		}
 
+		// Title bars may consume the action.
+		if x, y := event.Position(); a.handleTitleBar(action, x, y) {
+			handled = true
+			return
+		}
+
 		// Determine the target primitive.
 		var primitive Primitive
 		if a.mouseCapturingPrimitive != nil {
@@ -276,7 +459,7 @@ func (a *Application) fireMouseActions(event *tcell.EventMouse) (handled, isMous
 		} else if targetPrimitive != nil {
 			primitive = targetPrimitive
 		} else {
-			primitive = a.root
+			primitive = a.visibleRoot()
 		}
 		if primitive != nil {
 			cmd := primitive.HandleEvent(NewMouseEvent(*event, action))
@@ -292,7 +475,11 @@ func (a *Application) fireMouseActions(event *tcell.EventMouse) (handled, isMous
 	buttonChanges := buttons ^ a.lastMouseButtons
 
 	if x != a.lastMouseX || y != a.lastMouseY {
-		fire(MouseMove)
+		// In reduced-motion mode, hovering is not reported.
+		hovering := buttons&(tcell.ButtonPrimary|tcell.ButtonMiddle|tcell.ButtonSecondary) == 0 && a.mouseCapturingPrimitive == nil
+		if !hovering || !a.GetReducedMotion() {
+			fire(MouseMove)
+		}
 		a.lastMouseX = x
 		a.lastMouseY = y
 	}
@@ -354,6 +541,8 @@ func (a *Application) Stop() {
 // Suspend temporarily suspends the application by exiting terminal UI mode and
 // invoking the provided function "f". When "f" returns, terminal UI mode is
 // entered again and the application resumes.
+// To keep Ctrl-C from terminating the process while "f" runs, see
+// [Application.SetSuspendSignals].
 //
 // A return value of true indicates that the application was suspended and "f"
 // was called. If false is returned, the application was already suspended,
@@ -372,7 +561,9 @@ func (a *Application) Suspend(f func()) bool {
 	}
 
 	// Wait for "f" to return.
+	release := a.catchSuspendSignals()
 	f()
+	release()
 
 	// If the screen object has changed in the meantime, we need to do more.
 	a.RLock()
@@ -406,6 +597,15 @@ func (a *Application) Draw() *Application {
 	return a
 }
 
+// SetTheme replaces [Styles] with the given theme on the event loop and
+// redraws the screen. Primitives update their default styles to the new theme
+// while keeping styles set by the application, see [Styles].
+func (a *Application) SetTheme(theme Theme) *Application {
+	return a.QueueUpdateDraw(func() {
+		Styles = theme
+	})
+}
+
 // ForceDraw refreshes the screen immediately. Use this function with caution as
 // it may lead to race conditions with updates to primitives in other
 // goroutines. It is always preferable to call [Application.Draw] instead.
@@ -419,17 +619,35 @@ func (a *Application) ForceDraw() *Application {
 
 // draw actually does what Draw() promises to do.
 func (a *Application) draw() *Application {
-	a.RLock()
+	a.Lock()
 	screen := a.screen
-	root := a.root
+	root := a.visibleRoot()
 	forceRedraw := a.forceRedraw
-	a.RUnlock()
+	metricsEnabled := a.metrics.enabled.Load()
+	reducedMotion := a.reducedMotion
+	if a.pauseWhenUnfocused && a.terminalUnfocused && !forceRedraw {
+		// Catch up when the terminal regains focus.
+		a.drawPending = true
+		a.Unlock()
+		return a
+	}
+	a.Unlock()
 
 	// Maybe we're not ready yet or not anymore.
 	if screen == nil || root == nil {
 		return a
 	}
 
+	drawStart := time.Now()
+	drawScreen := screen
+	if reducedMotion {
+		drawScreen = &steadyScreen{Screen: screen}
+	}
+	if metricsEnabled {
+		// Count the cells written by primitives.
+		drawScreen = &countingScreen{Screen: drawScreen}
+	}
+
 	drawWidth, drawHeight := screen.Size()
 	root.SetRect(0, 0, drawWidth, drawHeight)
 
@@ -439,13 +657,34 @@ func (a *Application) draw() *Application {
 	if forceRedraw {
 		screen.Clear()
 	}
-	root.Draw(screen)
+	root.Draw(drawScreen)
+	a.drawScreenSelection(screen)
+	a.drawBell(screen)
 	screen.Show()
 
+	if counting, ok := drawScreen.(*countingScreen); ok {
+		if handler, metrics := a.frameDrawn(drawStart, counting.cells); handler != nil {
+			handler(metrics)
+		}
+	}
+
 	a.Lock()
 	a.forceRedraw = false
+	a.lastDraw = time.Now()
+	afterDraw, recorder := a.afterDraw, a.recorder
 	a.Unlock()
 
+	if afterDraw != nil || recorder != nil {
+		snapshot := newSnapshot(screen)
+		if recorder != nil {
+			recorder.record(snapshot)
+		}
+		if afterDraw != nil {
+			afterDraw(snapshot)
+		}
+	}
+	a.startupFrameDrawn()
+
 	return a
 }
 
@@ -467,19 +706,223 @@ func (a *Application) Sync() *Application {
 	return a
 }
 
+// SetAfterDrawFunc sets a handler which is called after each draw with a copy
+// of the screen contents. The snapshot is owned by the handler. The handler is
+// called from the event loop, so it should return quickly, e.g. by passing the
+// snapshot on to another goroutine. Set it to nil to stop taking snapshots.
+func (a *Application) SetAfterDrawFunc(handler func(snapshot *Snapshot)) *Application {
+	a.Lock()
+	defer a.Unlock()
+	a.afterDraw = handler
+	return a
+}
+
+// StartRecording starts recording the screen with the given recorder, see
+// [NewRecorder]. A previous recording is replaced without reporting its
+// errors, so call [Application.StopRecording] first if they matter. The
+// current screen is recorded with the next draw, which is queued but not
+// waited for, so this function may also be called from the event loop, e.g.
+// from a key handler.
+func (a *Application) StartRecording(recorder *Recorder) *Application {
+	a.Lock()
+	a.recorder = recorder
+	a.Unlock()
+	go a.queueTimerUpdate(func() {
+		a.draw()
+	})
+	return a
+}
+
+// PauseRecording pauses or resumes the current recording, if any. See
+// [Recorder.SetPaused].
+func (a *Application) PauseRecording(paused bool) *Application {
+	a.RLock()
+	recorder := a.recorder
+	a.RUnlock()
+	if recorder != nil {
+		recorder.SetPaused(paused)
+	}
+	return a
+}
+
+// StopRecording stops the current recording, if any, and returns the first
+// error which occurred while writing it.
+func (a *Application) StopRecording() error {
+	a.Lock()
+	recorder := a.recorder
+	a.recorder = nil
+	a.Unlock()
+	if recorder == nil {
+		return nil
+	}
+	return recorder.Err()
+}
+
+// SetFocusChangedFunc sets a handler which is called when the terminal window
+// (or tab) gains or loses focus. This requires a terminal which supports focus
+// reporting. Setting a handler enables focus reporting.
+func (a *Application) SetFocusChangedFunc(handler func(focused bool)) *Application {
+	a.Lock()
+	defer a.Unlock()
+	a.focusChanged = handler
+	a.updateFocusReporting()
+	return a
+}
+
+// SetPauseWhenUnfocused sets whether redraws are postponed while the terminal
+// window is not focused, e.g. to save CPU time when periodically refreshing
+// content in a background terminal. A single redraw happens when the terminal
+// regains focus. Forced redraws (e.g. after a resize) are not postponed. This
+// requires a terminal which supports focus reporting and enables it.
+func (a *Application) SetPauseWhenUnfocused(pause bool) *Application {
+	a.Lock()
+	defer a.Unlock()
+	a.pauseWhenUnfocused = pause
+	a.updateFocusReporting()
+	return a
+}
+
+// HasTerminalFocus returns whether the terminal window is focused. This is
+// always true if focus reporting is not enabled or not supported.
+func (a *Application) HasTerminalFocus() bool {
+	a.RLock()
+	defer a.RUnlock()
+	return !a.terminalUnfocused
+}
+
+// updateFocusReporting enables or disables the terminal's focus reporting
+// depending on whether it is needed. The caller must hold the lock.
+func (a *Application) updateFocusReporting() {
+	if a.screen == nil {
+		return
+	}
+	enable := a.focusChanged != nil || a.pauseWhenUnfocused
+	if enable == a.focusReporting {
+		return
+	}
+	a.focusReporting = enable
+	if enable {
+		a.screen.EnableFocus()
+	} else {
+		a.screen.DisableFocus()
+		a.terminalUnfocused = false
+	}
+}
+
+// SetBellMode sets how [Application.Bell] and [BellCommand] are signaled. The
+// default is [BellAudible]. The visual modes flash the bell's target primitive
+// for the duration set with [Application.SetVisualBell]. Without a target,
+// [BellVisual] flashes the entire screen and [BellVisualBorder] flashes the
+// outline of the focused primitive.
+func (a *Application) SetBellMode(mode BellMode) *Application {
+	a.Lock()
+	defer a.Unlock()
+	if a.bellMode != mode {
+		a.bellMode = mode
+	}
+	return a
+}
+
+// SetVisualBell sets the duration of a visual bell and the style applied on top
+// of the flashed cells. The default is 100 milliseconds of reversed colors.
+func (a *Application) SetVisualBell(duration time.Duration, style tcell.Style) *Application {
+	a.Lock()
+	defer a.Unlock()
+	a.bellDuration = duration
+	a.bellStyle = style
+	return a
+}
+
+// Bell signals the user, e.g. to give feedback on invalid input, using the bell
+// mode set with [Application.SetBellMode]. This function may be called from
+// any goroutine. Primitives should return a [BellCommand] instead.
+func (a *Application) Bell() *Application {
+	a.updates <- queuedUpdate{f: func() {
+		if a.bell(nil) {
+			a.draw()
+		}
+	}}
+	return a
+}
+
+// bell signals the bell for the given target primitive (nil for the focused
+// primitive). It returns true if the screen needs to be redrawn.
+func (a *Application) bell(target Primitive) bool {
+	a.Lock()
+	screen := a.screen
+	if target == nil && a.bellMode == BellVisualBorder {
+		target = a.focus
+	}
+	if screen == nil {
+		a.Unlock()
+		return false
+	}
+	if a.bellMode == BellAudible || a.bellDuration <= 0 || a.reducedMotion {
+		a.Unlock()
+		screen.Beep()
+		return false
+	}
+	a.bellTarget = target
+	a.bellUntil = time.Now().Add(a.bellDuration)
+	duration := a.bellDuration
+	a.Unlock()
+
+	// Redraw without the flash once the bell is over.
+	time.AfterFunc(duration, func() {
+		a.queueTimerUpdate(func() {
+			a.draw()
+		})
+	})
+	return true
+}
+
+// drawBell flashes the target of the current visual bell, if any.
+func (a *Application) drawBell(screen tcell.Screen) {
+	a.RLock()
+	mode, style, target, until := a.bellMode, a.bellStyle, a.bellTarget, a.bellUntil
+	a.RUnlock()
+	if mode == BellAudible || !time.Now().Before(until) {
+		return
+	}
+
+	x, y, width, height := 0, 0, 0, 0
+	if target != nil {
+		x, y, width, height = target.GetRect()
+	} else {
+		width, height = screen.Size()
+	}
+	flash := func(column, row int) {
+		str, cellStyle, _ := screen.Get(column, row)
+		screen.Put(column, row, str, mergeStyle(cellStyle, style))
+	}
+	for row := y; row < y+height; row++ {
+		for column := x; column < x+width; column++ {
+			border := row == y || row == y+height-1 || column == x || column == x+width-1
+			if mode == BellVisualBorder && !border {
+				continue
+			}
+			flash(column, row)
+		}
+	}
+}
+
 // SetRoot sets the root primitive for this application. This function must be called at least once or nothing will be displayed when
 // the application starts.
 //
-// It also calls SetFocus() on the primitive.
+// It also calls SetFocus() on the primitive, unless a splash primitive is shown
+// (see [Application.SetSplash]).
 func (a *Application) SetRoot(root Primitive) *Application {
 	a.Lock()
 	a.root = root
 	if a.screen != nil {
 		a.forceRedraw = true
 	}
+	splash := a.splash
 	a.Unlock()
 
-	a.SetFocus(root)
+	if splash == nil {
+		a.SetFocus(root)
+	}
 	return a
 }
 
@@ -534,12 +977,26 @@ func (a *Application) QueueUpdate(f func()) *Application {
 	return a
 }
 
+// queueTimerUpdate queues f like [Application.QueueUpdate] but doesn't wait
+// for it to be executed. It is meant for timers and other background
+// goroutines: once the event loop has stopped, f is dropped instead of
+// blocking forever.
+func (a *Application) queueTimerUpdate(f func()) {
+	a.RLock()
+	stopped := a.stopped
+	a.RUnlock()
+	select {
+	case a.updates <- queuedUpdate{f: f}:
+	case <-stopped:
+	}
+}
+
 // QueueUpdateDraw works like QueueUpdate() except it refreshes the screen
 // immediately after executing f.
 func (a *Application) QueueUpdateDraw(f func()) *Application {
 	a.QueueUpdate(func() {
 		f()
-		a.draw()
+		a.drawCoalesced()
 	})
 	return a
 }
@@ -613,11 +1070,31 @@ func (a *Application) executeCommand(cmd Command) bool {
 		// The clipboard contents will arrive as terminal paste input events.
 		screen.GetClipboard()
 		return true
+	case BellCommand:
+		return a.bell(c.Target)
+	case PlayMacroCommand:
+		return a.playMacro(c.Times)
 	case NotifyCommand:
 		if screen != nil {
 			screen.ShowNotification(c.Title, c.Body)
 		}
 		return false
+	case AsyncCommand:
+		if c == nil {
+			return false
+		}
+		go func() {
+			result := c()
+			if result == nil {
+				return
+			}
+			a.queueTimerUpdate(func() {
+				if a.executeCommand(result) {
+					a.draw()
+				}
+			})
+		}()
+		return false
 	}
 
 	return false
@@ -0,0 +1,125 @@
+package tview
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+// InterruptPolicy determines what happens when the user presses Ctrl-C, see
+// [Application.SetInterruptPolicy].
+type InterruptPolicy int
+
+// The interrupt policies.
+const (
+	// Ctrl-C is passed to the focused primitive like any other key.
+	InterruptDeliver InterruptPolicy = iota
+
+	// Ctrl-C stops the application, causing [Application.Run] to return.
+	InterruptStop
+
+	// Ctrl-C calls the handler set with [Application.SetInterruptFunc], e.g.
+	// to ask the user for confirmation before quitting.
+	InterruptCallback
+)
+
+// SetInterruptPolicy sets what happens when the user presses Ctrl-C. By
+// default ([InterruptDeliver]), Ctrl-C is passed to the focused primitive like
+// any other key. With [InterruptStop] or [InterruptCallback], the key is
+// handled before key macros and primitives see it, so no primitive needs to
+// intercept it.
+//
+// Since the terminal is in raw mode while the application is running, Ctrl-C
+// does not send a SIGINT signal to the process. See
+// [Application.SetSuspendSignals] for signals received while the application
+// is suspended.
+func (a *Application) SetInterruptPolicy(policy InterruptPolicy) *Application {
+	a.Lock()
+	defer a.Unlock()
+	a.interruptPolicy = policy
+	return a
+}
+
+// SetInterruptFunc sets a handler which is called from the event loop when
+// the user presses Ctrl-C and the interrupt policy is [InterruptCallback].
+// The screen is redrawn after the handler returns.
+func (a *Application) SetInterruptFunc(handler func()) *Application {
+	a.Lock()
+	defer a.Unlock()
+	a.interrupt = handler
+	return a
+}
+
+// SetSuspendSignals sets whether SIGINT and SIGTERM are caught while the
+// application is suspended (see [Application.Suspend]), when the terminal is
+// back in its normal mode and Ctrl-C sends SIGINT to the process, e.g. while
+// an external editor is running. Without this, these signals terminate the
+// process without restoring the terminal for the application.
+//
+// Caught signals are handled when the application resumes: a SIGINT is
+// handled like Ctrl-C according to the interrupt policy (see
+// [Application.SetInterruptPolicy]), a SIGTERM stops the application.
+func (a *Application) SetSuspendSignals(catch bool) *Application {
+	a.Lock()
+	defer a.Unlock()
+	a.suspendSignals = catch
+	return a
+}
+
+// handleInterrupt handles Ctrl-C according to the interrupt policy. It
+// returns whether the key event was consumed.
+func (a *Application) handleInterrupt(event *tcell.EventKey) bool {
+	if event.Key() != tcell.KeyCtrlC {
+		return false
+	}
+	a.RLock()
+	policy, handler := a.interruptPolicy, a.interrupt
+	a.RUnlock()
+	switch policy {
+	case InterruptStop:
+		a.Stop()
+	case InterruptCallback:
+		if handler != nil {
+			handler()
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// catchSuspendSignals starts catching SIGINT and SIGTERM if enabled with
+// [Application.SetSuspendSignals]. The returned function stops catching them
+// and handles the signals received in the meantime.
+func (a *Application) catchSuspendSignals() (release func()) {
+	a.RLock()
+	catch := a.suspendSignals
+	a.RUnlock()
+	if !catch {
+		return func() {}
+	}
+
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	return func() {
+		signal.Stop(signals)
+		var interrupted, terminated bool
+		for len(signals) > 0 {
+			if <-signals == os.Interrupt {
+				interrupted = true
+			} else {
+				terminated = true
+			}
+		}
+
+		// Suspend may be called from the event loop, so we can't wait here.
+		switch {
+		case terminated:
+			go a.QueueUpdate(a.Stop)
+		case interrupted:
+			go a.QueueEvent(tcell.NewEventKey(tcell.KeyCtrlC, "", tcell.ModCtrl))
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package tview
+
+import (
+	"slices"
+
+	"github.com/ayn2op/tview/keybind"
+	"github.com/gdamore/tcell/v3"
+)
+
+// macroState is the state of the application's key macro, see
+// [Application.SetMacroKeys].
+type macroState struct {
+	// The keys which start or stop recording and which replay the macro.
+	record, play keybind.Keybind
+
+	// Set to true while key events are being recorded.
+	recording bool
+
+	// Set to true while the macro is being replayed.
+	playing bool
+
+	// The recorded key events.
+	events []*tcell.EventKey
+
+	// An optional function which is called when recording starts or stops.
+	recordingChanged func(recording bool)
+}
+
+// SetMacroKeys sets the keys which control the application's key macro, e.g.
+// for repetitive data entry. The first press of the record key starts
+// recording the key events passed to the primitives, the second press stops
+// it, replacing the previous macro. The play key replays the recorded key
+// events once. Primitives can replay the macro several times by returning a
+// [PlayMacroCommand]. The macro keys themselves are not passed to the
+// primitives. Keybinds without keys disable the respective function.
+//
+// Mouse and paste events are not recorded.
+func (a *Application) SetMacroKeys(record, play keybind.Keybind) *Application {
+	a.Lock()
+	defer a.Unlock()
+	a.macro.record, a.macro.play = record, play
+	return a
+}
+
+// SetMacroRecordingFunc sets a function which is called when macro recording
+// starts or stops (see [Application.SetMacroKeys]), e.g. to show an
+// indicator in a status bar. It is called from the event loop.
+func (a *Application) SetMacroRecordingFunc(handler func(recording bool)) *Application {
+	a.Lock()
+	defer a.Unlock()
+	a.macro.recordingChanged = handler
+	return a
+}
+
+// IsRecordingMacro returns whether the application is recording a macro.
+func (a *Application) IsRecordingMacro() bool {
+	a.RLock()
+	defer a.RUnlock()
+	return a.macro.recording
+}
+
+// GetMacro returns the key events of the recorded macro, e.g. to store them.
+func (a *Application) GetMacro() []*tcell.EventKey {
+	a.RLock()
+	defer a.RUnlock()
+	return slices.Clone(a.macro.events)
+}
+
+// SetMacro replaces the macro with the given key events, e.g. a macro stored
+// with [Application.GetMacro].
+func (a *Application) SetMacro(events []*tcell.EventKey) *Application {
+	a.Lock()
+	defer a.Unlock()
+	a.macro.events = slices.Clone(events)
+	return a
+}
+
+// macroKey handles the macro keys and records the given key event while
+// recording. It returns whether the event was a macro key and whether the
+// screen must be redrawn.
+func (a *Application) macroKey(event *tcell.EventKey) (handled, redraw bool) {
+	a.Lock()
+	macro := &a.macro
+	switch {
+	case keybind.Matches(event, macro.record):
+		macro.recording = !macro.recording
+		if macro.recording {
+			macro.events = nil
+		}
+		recording, handler := macro.recording, macro.recordingChanged
+		a.Unlock()
+		if handler != nil {
+			handler(recording)
+		}
+		return true, true
+	case keybind.Matches(event, macro.play):
+		recording := macro.recording
+		a.Unlock()
+		if recording {
+			return true, false // Macros can't replay themselves.
+		}
+		return true, a.playMacro(1)
+	}
+	if macro.recording {
+		macro.events = append(macro.events, event)
+	}
+	a.Unlock()
+	return false, false
+}
+
+// playMacro passes the macro's key events to the root primitive the given
+// number of times. It returns whether the screen must be redrawn.
+func (a *Application) playMacro(times int) bool {
+	a.Lock()
+	if a.macro.playing || a.macro.recording || len(a.macro.events) == 0 {
+		a.Unlock()
+		return false
+	}
+	a.macro.playing = true
+	events := a.macro.events
+	a.Unlock()
+	defer func() {
+		a.Lock()
+		a.macro.playing = false
+		a.Unlock()
+	}()
+
+	var redraw bool
+	for range max(times, 1) {
+		for _, event := range events {
+			a.RLock()
+			root := a.visibleRoot()
+			a.RUnlock()
+			if root == nil || !root.HasFocus() {
+				return redraw
+			}
+			if a.executeCommand(root.HandleEvent(event)) {
+				redraw = true
+			}
+		}
+	}
+	return redraw
+}
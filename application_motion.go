@@ -0,0 +1,116 @@
+package tview
+
+import (
+	"time"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+// reducedMotionRedrawPause is the minimum time between two redraws caused by
+// resizes or by [Application.QueueUpdateDraw] in reduced-motion mode.
+const reducedMotionRedrawPause = 250 * time.Millisecond
+
+// ReducedMotionRefreshInterval is the minimum interval of periodic refreshes,
+// e.g. of [Dashboard] tiles, in reduced-motion mode, see
+// [Application.SetReducedMotion].
+var ReducedMotionRefreshInterval = time.Second
+
+// SetReducedMotion enables or disables reduced-motion mode, for users who
+// prefer less movement on the screen and for constrained environments such as
+// serial consoles or slow SSH connections. In this mode:
+//
+//   - Visual bells (see [Application.SetBellMode]) beep instead of flashing.
+//   - Blinking text is drawn without blinking.
+//   - Mouse movements without a pressed button are not passed on to
+//     primitives, which disables hover effects.
+//   - Redraws caused by resizes and by [Application.QueueUpdateDraw] happen
+//     at most every 250 milliseconds.
+//   - Periodic refreshes, e.g. of [Dashboard] tiles, happen at most every
+//     [ReducedMotionRefreshInterval].
+//
+// Primitives with their own animations can check [Application.GetReducedMotion]
+// to disable them.
+func (a *Application) SetReducedMotion(reduced bool) *Application {
+	a.Lock()
+	defer a.Unlock()
+	a.reducedMotion = reduced
+	return a
+}
+
+// GetReducedMotion returns whether reduced-motion mode is enabled, see
+// [Application.SetReducedMotion].
+func (a *Application) GetReducedMotion() bool {
+	a.RLock()
+	defer a.RUnlock()
+	return a.reducedMotion
+}
+
+// redrawPause returns the minimum time between two coalesced redraws.
+func (a *Application) redrawPause() time.Duration {
+	if a.GetReducedMotion() {
+		return reducedMotionRedrawPause
+	}
+	return redrawPause
+}
+
+// refreshInterval returns the interval to use for a periodic refresh with
+// the given interval.
+func (a *Application) refreshInterval(interval time.Duration) time.Duration {
+	if a.GetReducedMotion() {
+		return max(interval, ReducedMotionRefreshInterval)
+	}
+	return interval
+}
+
+// drawCoalesced redraws the screen. In reduced-motion mode, the redraw is
+// postponed if the last one was too recent, and redraws requested in the
+// meantime are merged into it. It must be called from the event loop.
+func (a *Application) drawCoalesced() {
+	a.Lock()
+	if !a.reducedMotion {
+		a.Unlock()
+		a.draw()
+		return
+	}
+	if a.drawScheduled {
+		a.Unlock()
+		return
+	}
+	wait := reducedMotionRedrawPause - time.Since(a.lastDraw)
+	if wait <= 0 {
+		a.Unlock()
+		a.draw()
+		return
+	}
+	a.drawScheduled = true
+	a.Unlock()
+
+	time.AfterFunc(wait, func() {
+		a.queueTimerUpdate(func() {
+			a.Lock()
+			a.drawScheduled = false
+			a.Unlock()
+			a.draw()
+		})
+	})
+}
+
+// steadyScreen is a screen which draws blinking text without blinking.
+type steadyScreen struct {
+	tcell.Screen
+}
+
+// Put implements tcell.Screen.
+func (s *steadyScreen) Put(x, y int, str string, style tcell.Style) (string, int) {
+	return s.Screen.Put(x, y, str, style.Blink(false))
+}
+
+// PutStrStyled implements tcell.Screen.
+func (s *steadyScreen) PutStrStyled(x, y int, str string, style tcell.Style) {
+	s.Screen.PutStrStyled(x, y, str, style.Blink(false))
+}
+
+// SetContent implements tcell.Screen.
+func (s *steadyScreen) SetContent(x, y int, primary rune, combining []rune, style tcell.Style) {
+	s.Screen.SetContent(x, y, primary, combining, style.Blink(false))
+}
@@ -0,0 +1,164 @@
+package tview
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+// screenSelection is the state of the application-level text selection, see
+// [Application.SetScreenSelection].
+type screenSelection struct {
+	// The modifiers which must be held to start a selection, or 0 if
+	// selecting is disabled.
+	modifiers tcell.ModMask
+
+	// Set to true while a selection is shown.
+	active bool
+
+	// Set to true while the selection is being dragged.
+	dragging bool
+
+	// The cells where the selection was started and where it currently ends.
+	startX, startY, endX, endY int
+}
+
+// SetScreenSelection enables selecting text anywhere on the screen by dragging
+// the mouse with the left button while holding the given modifiers, e.g.
+// [tcell.ModAlt]. This works like the selection of a terminal emulator, which
+// is not available while the application captures the mouse, and works with
+// all primitives, regardless of whether they support selecting text. The
+// selection is shown inverted. When the button is released, the selected text
+// is copied to the clipboard (see [SetClipboardCommand]), with trailing spaces
+// removed from each line. The selection disappears with the next key press or
+// mouse click.
+//
+// Mouse events used for the selection are not passed on to primitives. Note
+// that many terminals reserve some modifiers for their own selection, mostly
+// Shift. Pass 0 (the default) to disable screen selection.
+func (a *Application) SetScreenSelection(modifiers tcell.ModMask) *Application {
+	a.Lock()
+	defer a.Unlock()
+	a.selection.modifiers = modifiers
+	if modifiers == 0 {
+		a.selection.active, a.selection.dragging = false, false
+	}
+	return a
+}
+
+// selectScreen handles the given mouse event for the screen selection. It
+// returns whether the event was consumed and whether the selection changed.
+func (a *Application) selectScreen(event *tcell.EventMouse) (consumed, changed bool) {
+	a.Lock()
+	defer a.Unlock()
+	selection := &a.selection
+	x, y := event.Position()
+	pressed := event.Buttons()&tcell.ButtonPrimary != 0
+
+	// Continue or finish a selection.
+	if selection.dragging {
+		changed = selection.endX != x || selection.endY != y
+		selection.endX, selection.endY = x, y
+		if !pressed {
+			selection.dragging = false
+			if text := a.screenSelectionText(); text != "" && a.screen != nil && a.screen.HasClipboard() {
+				a.screen.SetClipboard([]byte(text))
+			}
+		}
+		return true, changed
+	}
+
+	// Start a new selection.
+	started := pressed && a.lastMouseButtons&tcell.ButtonPrimary == 0
+	if started && selection.modifiers != 0 && event.Modifiers()&selection.modifiers == selection.modifiers {
+		selection.active, selection.dragging = true, true
+		selection.startX, selection.startY, selection.endX, selection.endY = x, y, x, y
+		return true, true
+	}
+
+	// Any other click removes the selection.
+	if selection.active && event.Buttons()&(tcell.ButtonPrimary|tcell.ButtonMiddle|tcell.ButtonSecondary) != 0 {
+		selection.active = false
+		return false, true
+	}
+	return false, false
+}
+
+// clearScreenSelection removes the screen selection. It returns whether there
+// was a selection.
+func (a *Application) clearScreenSelection() bool {
+	a.Lock()
+	defer a.Unlock()
+	if !a.selection.active || a.selection.dragging {
+		return false
+	}
+	a.selection.active = false
+	return true
+}
+
+// screenSelectionRange returns the first and the last selected cell in
+// reading order. The application must be locked.
+func (a *Application) screenSelectionRange() (fromX, fromY, toX, toY int) {
+	s := a.selection
+	if s.startY < s.endY || s.startY == s.endY && s.startX <= s.endX {
+		return s.startX, s.startY, s.endX, s.endY
+	}
+	return s.endX, s.endY, s.startX, s.startY
+}
+
+// screenSelectionText returns the text of the selected cells. The application
+// must be locked.
+func (a *Application) screenSelectionText() string {
+	if a.screen == nil || !a.selection.active {
+		return ""
+	}
+	width, height := a.screen.Size()
+	fromX, fromY, toX, toY := a.screenSelectionRange()
+	var lines []string
+	for row := max(fromY, 0); row <= min(toY, height-1); row++ {
+		start, end := 0, width-1
+		if row == fromY {
+			start = fromX
+		}
+		if row == toY {
+			end = min(toX, width-1)
+		}
+		var line strings.Builder
+		for column := max(start, 0); column <= end; {
+			str, _, w := a.screen.Get(column, row)
+			if str == "" {
+				str = " "
+			}
+			line.WriteString(str)
+			column += max(w, 1)
+		}
+		lines = append(lines, strings.TrimRight(line.String(), " "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// drawScreenSelection inverts the selected cells, if any.
+func (a *Application) drawScreenSelection(screen tcell.Screen) {
+	a.RLock()
+	active := a.selection.active
+	fromX, fromY, toX, toY := a.screenSelectionRange()
+	a.RUnlock()
+	if !active {
+		return
+	}
+	width, height := screen.Size()
+	for row := max(fromY, 0); row <= min(toY, height-1); row++ {
+		start, end := 0, width-1
+		if row == fromY {
+			start = max(fromX, 0)
+		}
+		if row == toY {
+			end = min(toX, width-1)
+		}
+		for column := start; column <= end; {
+			str, style, w := screen.Get(column, row)
+			screen.Put(column, row, str, style.Reverse(!style.HasReverse()))
+			column += max(w, 1)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package tview
+
+import "fmt"
+
+// SetReadyFunc sets a handler which is called once, after the next successful
+// draw of the screen. Set before [Application.Run], it is called after the
+// first frame was drawn, so that applications can start work which updates
+// primitives (e.g. loading configuration or connecting to a server) without
+// racing the first render. The handler is queued to the event loop (see
+// [Application.QueueUpdate]), so it may access primitives directly but must
+// return quickly.
+func (a *Application) SetReadyFunc(handler func()) *Application {
+	a.Lock()
+	defer a.Unlock()
+	a.ready = handler
+	return a
+}
+
+// SetSplash shows the given primitive (e.g. a [BigText] or a [Modal]) instead
+// of the root primitive, with the keyboard focus, until the given init
+// function returns. The init function is started in its own goroutine after
+// the splash was drawn for the first time. Like all functions which run
+// outside the event loop, it must not access primitives directly (see
+// [Application.QueueUpdate]).
+//
+// When the init function returns, the root primitive is shown and receives
+// the focus, and the done function, if not nil, is called with the init
+// function's error from the event loop, e.g. to show an error message or to
+// stop the application. If the init function panics, the panic is passed to
+// the done function as an error. A nil init function ends the splash right
+// after it was drawn. While the splash is shown, [Application.SetRoot] stores
+// the root primitive without changing the focus.
+func (a *Application) SetSplash(splash Primitive, init func() error, done func(err error)) *Application {
+	a.Lock()
+	a.splash = splash
+	a.splashInit = func() {
+		var err error
+		func() {
+			defer func() {
+				if p := recover(); p != nil {
+					err = fmt.Errorf("splash init function panicked: %v", p)
+				}
+			}()
+			if init != nil {
+				err = init()
+			}
+		}()
+		a.queueTimerUpdate(func() {
+			a.endSplash(splash)
+			if done != nil {
+				done(err)
+			}
+			a.drawCoalesced()
+		})
+	}
+	if a.screen != nil {
+		a.forceRedraw = true
+	}
+	a.Unlock()
+
+	a.SetFocus(splash)
+	return a
+}
+
+// endSplash stops showing the given splash primitive and gives the focus to
+// the root primitive. Nothing happens if another splash is shown.
+func (a *Application) endSplash(splash Primitive) {
+	a.Lock()
+	if a.splash != splash {
+		a.Unlock()
+		return
+	}
+	a.splash, a.splashInit = nil, nil
+	a.forceRedraw = true
+	root := a.root
+	a.Unlock()
+	a.SetFocus(root)
+}
+
+// visibleRoot returns the primitive drawn on the screen, which is the splash
+// primitive while it is shown, or the root primitive otherwise. The caller
+// must hold the lock.
+func (a *Application) visibleRoot() Primitive {
+	if a.splash != nil {
+		return a.splash
+	}
+	return a.root
+}
+
+// startupFrameDrawn queues the ready handler and starts the splash's init
+// function after a successful draw. As draws may happen outside the event loop
+// (see [Application.ForceDraw]), the ready handler is not called directly.
+func (a *Application) startupFrameDrawn() {
+	a.Lock()
+	ready, init := a.ready, a.splashInit
+	a.ready, a.splashInit = nil, nil
+	a.Unlock()
+	if init != nil {
+		go init()
+	}
+	if ready != nil {
+		go a.queueTimerUpdate(ready)
+	}
+}
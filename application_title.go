@@ -0,0 +1,138 @@
+package tview
+
+import (
+	"sync"
+	"time"
+	"weak"
+)
+
+// LongPressDuration specifies how long the left mouse button must be held
+// without moving the mouse to register a long press, see
+// [Box.SetTitleLongPressFunc].
+var LongPressDuration = 500 * time.Millisecond
+
+// titleBarBox is implemented by all primitives which embed a [Box].
+type titleBarBox interface {
+	box() *Box
+}
+
+// titleBarBoxes holds weak pointers to the boxes which have title bar
+// callbacks, so mouse presses outside their title bars don't need to inspect
+// the whole primitive tree.
+var titleBarBoxes sync.Map // weak.Pointer[Box] -> struct{}
+
+// registerTitleBar adds the box to titleBarBoxes if it has title bar
+// callbacks, or removes it otherwise.
+func registerTitleBar(b *Box) {
+	if b.titleDoubleClick != nil || b.titleLongPress != nil {
+		titleBarBoxes.Store(weak.Make(b), struct{}{})
+	} else {
+		titleBarBoxes.Delete(weak.Make(b))
+	}
+}
+
+// onAnyTitleBar returns whether the given screen position is on the title bar
+// of any box with title bar callbacks, visible or not.
+func onAnyTitleBar(x, y int) bool {
+	var found bool
+	titleBarBoxes.Range(func(key, _ any) bool {
+		b := key.(weak.Pointer[Box]).Value()
+		if b == nil {
+			titleBarBoxes.Delete(key) // The box was garbage collected.
+			return true
+		}
+		found = b.onTitleBar(x, y)
+		return !found
+	})
+	return found
+}
+
+// titlePress is the state of a mouse press on a title bar which may become a
+// long press.
+type titlePress struct {
+	box      *Box        // The box whose title bar is pressed, nil if none.
+	x, y     int         // The position of the press.
+	timer    *time.Timer // Fires when the press becomes a long press.
+	longDone bool        // Set to true when the long press callback was invoked.
+}
+
+// titleBarAt returns the topmost box whose title bar is at the given screen
+// position and for which the given function returns true, or nil if there is
+// none. It must be called from the event loop.
+func (a *Application) titleBarAt(x, y int, match func(b *Box) bool) *Box {
+	if !onAnyTitleBar(x, y) {
+		return nil
+	}
+	a.RLock()
+	root := a.visibleRoot()
+	a.RUnlock()
+	var found *Box
+	Inspect(root).Walk(func(node *InspectNode) bool {
+		if primitive, ok := node.Primitive.(titleBarBox); ok {
+			if b := primitive.box(); b.onTitleBar(x, y) && match(b) {
+				found = b // Later nodes are drawn on top.
+			}
+		}
+		return true
+	})
+	return found
+}
+
+// handleTitleBar handles the given mouse action at the given position if it
+// concerns a title bar with a double-click or long-press callback. It returns
+// true if the action was consumed and must not be passed on to primitives.
+func (a *Application) handleTitleBar(action MouseAction, x, y int) bool {
+	if a.mouseCapturingPrimitive != nil {
+		a.cancelTitlePress()
+		return false
+	}
+	switch action {
+	case MouseLeftDoubleClick:
+		b := a.titleBarAt(x, y, func(b *Box) bool {
+			return b.titleDoubleClick != nil
+		})
+		if b == nil {
+			return false
+		}
+		b.titleDoubleClick()
+		return true
+	case MouseLeftDown:
+		a.cancelTitlePress()
+		b := a.titleBarAt(x, y, func(b *Box) bool {
+			return b.titleLongPress != nil
+		})
+		if b == nil {
+			return false
+		}
+		press := &a.titlePress
+		press.box, press.x, press.y = b, x, y
+		press.timer = time.AfterFunc(LongPressDuration, func() {
+			a.queueTimerUpdate(func() {
+				if press.box != b || press.longDone {
+					return // Released or moved in the meantime.
+				}
+				press.longDone = true
+				b.titleLongPress(press.x, press.y)
+				a.draw()
+			})
+		})
+	case MouseMove, MouseLeftUp:
+		if !a.titlePress.longDone {
+			a.cancelTitlePress()
+		}
+	case MouseLeftClick:
+		if a.titlePress.longDone {
+			a.cancelTitlePress()
+			return true // The click ends the long press.
+		}
+	}
+	return false
+}
+
+// cancelTitlePress stops tracking a press on a title bar.
+func (a *Application) cancelTitlePress() {
+	if a.titlePress.timer != nil {
+		a.titlePress.timer.Stop()
+	}
+	a.titlePress = titlePress{}
+}
@@ -0,0 +1,400 @@
+package tview
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+// AutocompleteEntry is an entry of an autocomplete drop-down list, see
+// [InputField.SetAutocompleteEntriesFunc].
+type AutocompleteEntry struct {
+	// The text which is inserted when the entry is accepted.
+	Text string
+
+	// The text shown in the drop-down list. If empty, Text is shown.
+	Display string
+
+	// An optional secondary text, shown dimmed and right-aligned next to the
+	// displayed text, e.g. a type or a short explanation.
+	Description string
+
+	// The style applied on top of the list's style when the entry is not
+	// selected. Unset attributes and colors are taken from the list's style.
+	Style tcell.Style
+}
+
+// autocompleteStrings converts a lookup function returning plain strings into
+// one returning entries. It returns nil for a nil function.
+func autocompleteStrings(lookup func(ctx context.Context, text string) []string) func(ctx context.Context, text string) []AutocompleteEntry {
+	if lookup == nil {
+		return nil
+	}
+	return func(ctx context.Context, text string) []AutocompleteEntry {
+		suggestions := lookup(ctx, text)
+		if len(suggestions) == 0 {
+			return nil
+		}
+		entries := make([]AutocompleteEntry, len(suggestions))
+		for index, suggestion := range suggestions {
+			entries[index].Text = suggestion
+		}
+		return entries
+	}
+}
+
+// autocompleter holds the state of an autocomplete drop-down list, as used by
+// [InputField] and [TextArea]. Lookups run outside the event loop.
+type autocompleter struct {
+	// Guards the state below which is written by lookups running outside the
+	// event loop.
+	sync.Mutex
+
+	// An optional function which returns suggestions for a text. It runs in its
+	// own goroutine and its context is cancelled as soon as a new lookup is
+	// started or the list is closed.
+	lookup func(ctx context.Context, text string) []AutocompleteEntry
+
+	// An alternative to the lookup function which delivers suggestions by
+	// calling the respond function, any number of times, until the context is
+	// cancelled.
+	asyncLookup func(ctx context.Context, text string, respond func(entries []AutocompleteEntry))
+
+	// The time to wait after the last change before the lookup function is
+	// invoked.
+	debounce time.Duration
+
+	// Cancels the lookup currently in flight, if any.
+	cancel context.CancelFunc
+
+	// The suggestions currently shown in the drop-down list.
+	entries []AutocompleteEntry
+
+	// The index of the selected entry, or -1 if none is selected.
+	selected int
+
+	// Whether a lookup is in flight.
+	loading bool
+
+	// The text shown in the drop-down list while a lookup is in flight.
+	loadingText string
+
+	// The maximum number of rows of the drop-down list.
+	maxHeight int
+
+	// The styles of the drop-down list entries.
+	style, selectedStyle tcell.Style
+}
+
+// newAutocompleter returns an autocompleter with default settings.
+func newAutocompleter() *autocompleter {
+	return &autocompleter{
+		selected:      -1,
+		loadingText:   Translate(MessageLoading, ""),
+		maxHeight:     10,
+		style:         tcell.StyleDefault.Background(Styles.MoreContrastBackgroundColor).Foreground(Styles.PrimitiveBackgroundColor),
+		selectedStyle: tcell.StyleDefault.Background(Styles.PrimaryTextColor).Foreground(Styles.PrimitiveBackgroundColor),
+	}
+}
+
+// setLookup sets the lookup function, replacing any asynchronous lookup
+// function. A nil function closes the list.
+func (a *autocompleter) setLookup(lookup func(ctx context.Context, text string) []AutocompleteEntry) {
+	a.Lock()
+	a.lookup, a.asyncLookup = lookup, nil
+	a.Unlock()
+	if lookup == nil {
+		a.close()
+	}
+}
+
+// setAsyncLookup sets the asynchronous lookup function, replacing any lookup
+// function. A nil function closes the list.
+func (a *autocompleter) setAsyncLookup(lookup func(ctx context.Context, text string, respond func(entries []AutocompleteEntry))) {
+	a.Lock()
+	a.lookup, a.asyncLookup = nil, lookup
+	a.Unlock()
+	if lookup == nil {
+		a.close()
+	}
+}
+
+// enabled returns whether there is a lookup function.
+func (a *autocompleter) enabled() bool {
+	a.Lock()
+	defer a.Unlock()
+	return a.lookup != nil || a.asyncLookup != nil
+}
+
+// setDebounce sets the time to wait before the lookup function is invoked.
+func (a *autocompleter) setDebounce(debounce time.Duration) {
+	a.Lock()
+	defer a.Unlock()
+	a.debounce = max(debounce, 0)
+}
+
+// setLoadingText sets the text shown while a lookup is in flight.
+func (a *autocompleter) setLoadingText(text string) {
+	a.Lock()
+	defer a.Unlock()
+	a.loadingText = text
+}
+
+// setMaxHeight sets the maximum number of rows of the drop-down list.
+func (a *autocompleter) setMaxHeight(height int) {
+	a.Lock()
+	defer a.Unlock()
+	a.maxHeight = max(height, 1)
+}
+
+// setStyles sets the styles of the entries and of the selected entry.
+func (a *autocompleter) setStyles(main, selected tcell.Style) {
+	a.Lock()
+	defer a.Unlock()
+	a.style, a.selectedStyle = main, selected
+}
+
+// start cancels any lookup in flight and returns a command which looks up
+// suggestions for the given text. It returns nil if there is no lookup
+// function.
+func (a *autocompleter) start(text string) Command {
+	a.Lock()
+	defer a.Unlock()
+	if a.cancel != nil {
+		a.cancel()
+		a.cancel = nil
+	}
+	if a.lookup == nil && a.asyncLookup == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	a.cancel = cancel
+	a.loading = true
+	lookup, asyncLookup, debounce := a.lookup, a.asyncLookup, a.debounce
+
+	return AsyncCommand(func() Command {
+		if debounce > 0 {
+			timer := time.NewTimer(debounce)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil
+			case <-timer.C:
+			}
+		}
+		if asyncLookup != nil {
+			return a.stream(ctx, text, asyncLookup)
+		}
+		entries := lookup(ctx, text)
+
+		// Cancellation happens while holding the lock so checking it here
+		// guarantees that we never overwrite the results of a newer lookup.
+		a.Lock()
+		defer a.Unlock()
+		if ctx.Err() != nil {
+			return nil
+		}
+		a.cancel = nil
+		cancel()
+		a.entries = entries
+		a.selected = -1
+		a.loading = false
+		return RedrawCommand{}
+	})
+}
+
+// stream invokes the asynchronous lookup function and waits for its first
+// response. Each response is applied and followed by a command which waits for
+// the next one, so that responses are delivered through the event loop until
+// the context is cancelled.
+func (a *autocompleter) stream(ctx context.Context, text string, lookup func(ctx context.Context, text string, respond func(entries []AutocompleteEntry))) Command {
+	responses := make(chan []AutocompleteEntry)
+	go lookup(ctx, text, func(entries []AutocompleteEntry) {
+		select {
+		case responses <- entries:
+		case <-ctx.Done():
+		}
+	})
+
+	first := true
+	var wait func() Command
+	wait = func() Command {
+		var entries []AutocompleteEntry
+		select {
+		case <-ctx.Done():
+			return nil
+		case entries = <-responses:
+		}
+
+		a.Lock()
+		defer a.Unlock()
+		if ctx.Err() != nil {
+			return nil
+		}
+		a.entries = entries
+		if first || a.selected >= len(entries) {
+			a.selected = -1
+		}
+		first = false
+		a.loading = false
+		return BatchCommand{RedrawCommand{}, AsyncCommand(wait)}
+	}
+	return wait()
+}
+
+// close cancels any lookup in flight and hides the drop-down list.
+func (a *autocompleter) close() {
+	a.Lock()
+	defer a.Unlock()
+	if a.cancel != nil {
+		a.cancel()
+		a.cancel = nil
+	}
+	a.entries = nil
+	a.selected = -1
+	a.loading = false
+}
+
+// visible returns whether the drop-down list is currently shown. The caller
+// must hold the lock.
+func (a *autocompleter) visible() bool {
+	return len(a.entries) > 0 || (a.loading && a.loadingText != "")
+}
+
+// isVisible returns whether the drop-down list is currently shown.
+func (a *autocompleter) isVisible() bool {
+	a.Lock()
+	defer a.Unlock()
+	return a.visible()
+}
+
+// draw draws the drop-down list below (or, if there is not enough space,
+// above) the screen row y, starting at column x.
+func (a *autocompleter) draw(screen tcell.Screen, x, y, width int) {
+	a.Lock()
+	defer a.Unlock()
+	if !a.visible() || width <= 0 {
+		return
+	}
+
+	rows := len(a.entries)
+	loading := a.loading && a.loadingText != ""
+	if loading {
+		rows++
+	}
+	height := min(rows, a.maxHeight)
+	_, screenHeight := screen.Size()
+	top := y + 1
+	if top+height > screenHeight && y-height >= 0 {
+		top = y - height
+	}
+
+	// Scroll the selected entry into view, leaving room for the loading
+	// indicator in the last row.
+	entryRows := height
+	if loading {
+		entryRows = max(height-1, 0)
+	}
+	offset := 0
+	if a.selected >= entryRows {
+		offset = a.selected - entryRows + 1
+	}
+
+	for row := range height {
+		style := a.style
+		var text, description string
+		if index := offset + row; row < entryRows && index < len(a.entries) {
+			entry := a.entries[index]
+			text, description = entry.Display, entry.Description
+			if text == "" {
+				text = entry.Text
+			}
+			if index == a.selected {
+				style = a.selectedStyle
+			} else {
+				style = mergeStyle(style, entry.Style)
+			}
+		} else {
+			text = a.loadingText
+			style = style.Italic(true)
+		}
+		for col := range width {
+			screen.Put(x+col, top+row, " ", style)
+		}
+		textWidth := width
+		if description != "" {
+			_, _, descriptionWidth := printWithStyle(screen, description, x, top+row, 0, width, AlignmentRight, style.Dim(true), false)
+			textWidth = max(width-descriptionWidth-1, 0)
+		}
+		printWithStyle(screen, text, x, top+row, 0, textWidth, AlignmentLeft, style, false)
+	}
+}
+
+// width returns the screen width of the widest entry or loading text.
+func (a *autocompleter) width() int {
+	a.Lock()
+	defer a.Unlock()
+	width := 0
+	if a.loading {
+		width = TaggedStringWidth(a.loadingText)
+	}
+	for _, entry := range a.entries {
+		text := entry.Display
+		if text == "" {
+			text = entry.Text
+		}
+		entryWidth := TaggedStringWidth(text)
+		if entry.Description != "" {
+			entryWidth += 1 + TaggedStringWidth(entry.Description)
+		}
+		width = max(width, entryWidth)
+	}
+	return width
+}
+
+// handleKey processes key events while the drop-down list is visible. It
+// returns whether the event was consumed and, if the user accepted an entry,
+// the entry's text. The list is closed when an entry is accepted.
+func (a *autocompleter) handleKey(event *KeyEvent) (consumed bool, accepted string, ok bool) {
+	a.Lock()
+	if !a.visible() {
+		a.Unlock()
+		return false, "", false
+	}
+	count := len(a.entries)
+	switch event.Key() {
+	case tcell.KeyDown:
+		if count > 0 {
+			a.selected = (a.selected + 1) % count
+		}
+		a.Unlock()
+		return true, "", false
+	case tcell.KeyUp:
+		if count > 0 {
+			a.selected--
+			if a.selected < 0 {
+				a.selected = count - 1
+			}
+		}
+		a.Unlock()
+		return true, "", false
+	case tcell.KeyEnter, tcell.KeyTab:
+		if a.selected < 0 || a.selected >= count {
+			a.Unlock()
+			return false, "", false
+		}
+		text := a.entries[a.selected].Text
+		a.Unlock()
+		a.close()
+		return true, text, true
+	case tcell.KeyEscape:
+		a.Unlock()
+		a.close()
+		return true, "", false
+	}
+	a.Unlock()
+	return false, "", false
+}
@@ -0,0 +1,283 @@
+package tview
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+// bigTextQuadrants are the block characters for the combinations of the
+// pixels of a 2x2 square, indexed by upper left (1), upper right (2), lower
+// left (4), and lower right (8).
+var bigTextQuadrants = [16]string{
+	" ",
+	BlockQuadrantUpperLeft,
+	BlockQuadrantUpperRight,
+	BlockUpperHalfBlock,
+	BlockQuadrantLowerLeft,
+	BlockLeftHalfBlock,
+	BlockQuadrantUpperRightAndLowerLeft,
+	BlockQuadrantUpperLeftAndUpperRightAndLowerLeft,
+	BlockQuadrantLowerRight,
+	BlockQuadrantUpperLeftAndLowerRight,
+	BlockRightHalfBlock,
+	BlockQuadrantUpperLeftAndUpperRightAndLowerRight,
+	BlockLowerHalfBlock,
+	BlockQuadrantUpperLeftAndLowerLeftAndLowerRight,
+	BlockQuadrantUpperRightAndLowerLeftAndLowerRight,
+	BlockFullBlock,
+}
+
+// BigText displays a short text in large letters, e.g. for splash screens and
+// headers. The letters are taken from a [BigTextFont], by default
+// [BigTextFontBlock]. The text may contain line breaks.
+//
+// If the text does not fit into the box, bitmap fonts are downscaled, first to
+// half blocks (two pixels per cell vertically), then to quadrant blocks (two
+// by two pixels per cell). If it still does not fit, or if the font is not a
+// bitmap font, the text is printed in normal letters. The text is centered
+// vertically and aligned horizontally as set with [BigText.SetAlignment].
+type BigText struct {
+	*Box
+
+	// The displayed text.
+	text string
+
+	// The font of the letters.
+	font *BigTextFont
+
+	// The style of the letters.
+	textStyle tcell.Style
+
+	// The colors of a gradient across the letters, if any.
+	gradient []tcell.Color
+
+	// The horizontal alignment of the text.
+	alignment Alignment
+}
+
+// NewBigText returns a new big text primitive showing the given text.
+func NewBigText(text string) *BigText {
+	return &BigText{
+		Box:       NewBox(),
+		text:      text,
+		font:      BigTextFontBlock,
+		textStyle: tcell.StyleDefault.Background(Styles.PrimitiveBackgroundColor).Foreground(Styles.PrimaryTextColor),
+		alignment: AlignmentCenter,
+	}
+}
+
+// applyTheme updates the primitive's default styles after a theme change.
+func (b *BigText) applyTheme(change themeChange) {
+	b.Box.applyTheme(change)
+	b.textStyle = change.style(b.textStyle, themePrimaryText, themePrimitiveBackground)
+}
+
+// SetText sets the displayed text.
+func (b *BigText) SetText(text string) *BigText {
+	b.text = text
+	return b
+}
+
+// GetText returns the displayed text.
+func (b *BigText) GetText() string {
+	return b.text
+}
+
+// SetFont sets the font of the letters. A nil font restores the default
+// font, [BigTextFontBlock].
+func (b *BigText) SetFont(font *BigTextFont) *BigText {
+	if font == nil {
+		font = BigTextFontBlock
+	}
+	b.font = font
+	return b
+}
+
+// SetTextStyle sets the style of the letters.
+func (b *BigText) SetTextStyle(style tcell.Style) *BigText {
+	b.textStyle = style
+	return b
+}
+
+// SetGradient sets the colors of a gradient from the left edge of the text to
+// its right edge, which replaces the foreground color of the text style. See
+// [GradientSegments] for details. Without colors, the text style's foreground
+// color is used.
+func (b *BigText) SetGradient(colors ...tcell.Color) *BigText {
+	b.gradient = colors
+	return b
+}
+
+// SetAlignment sets the horizontal alignment of the text within the box.
+// The default is [AlignmentCenter].
+func (b *BigText) SetAlignment(alignment Alignment) *BigText {
+	b.alignment = alignment
+	return b
+}
+
+// Draw draws this primitive onto the screen.
+func (b *BigText) Draw(screen tcell.Screen) {
+	b.DrawForSubclass(screen, b)
+	x, y, width, height := b.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	// Find the largest rendering which fits.
+	var cells [][]string
+	for _, candidate := range b.renderings() {
+		if len(candidate) <= height && bigTextWidth(candidate) <= width {
+			cells = candidate
+			break
+		}
+	}
+	if cells == nil {
+		cells = bigTextCells(strings.Split(b.text, "\n")) // Clipped.
+	}
+
+	// Draw the cells.
+	left, right := x, x+width
+	textWidth := bigTextWidth(cells)
+	switch b.alignment {
+	case AlignmentCenter:
+		x += (width - textWidth) / 2
+	case AlignmentRight:
+		x += width - textWidth
+	}
+	y += max((height-len(cells))/2, 0)
+	for row, line := range cells {
+		if row >= height {
+			break
+		}
+		for column, cell := range line {
+			if cell == "" || cell == " " || x+column < left || x+column >= right {
+				continue
+			}
+			style := b.textStyle
+			if len(b.gradient) > 0 {
+				style = style.Foreground(gradientColor(b.gradient, column, textWidth))
+			}
+			screen.Put(x+column, y+row, cell, style)
+		}
+	}
+}
+
+// renderings returns the text rendered in the font, from the largest to the
+// smallest rendering, followed by the text in normal letters. Each rendering
+// is a list of rows of cells. Cells occupied by the previous cell's wide
+// character are empty.
+func (b *BigText) renderings() [][][]string {
+	lines := strings.Split(b.text, "\n")
+	plain := bigTextCells(lines)
+	font := b.font
+	if font.Height <= 0 {
+		return [][][]string{plain}
+	}
+
+	// Compose the lines from the glyphs, with an empty row between lines.
+	var rows []string
+	for index, line := range lines {
+		if index > 0 {
+			rows = append(rows, "")
+		}
+		composed := make([]string, font.Height)
+		first := true
+		for _, r := range line {
+			glyph := font.glyph(r)
+			if len(glyph) == 0 {
+				continue
+			}
+			for row := range composed {
+				if !first {
+					composed[row] += strings.Repeat(" ", font.Spacing)
+				}
+				if row < len(glyph) {
+					composed[row] += glyph[row]
+				}
+			}
+			first = false
+		}
+		rows = append(rows, composed...)
+	}
+	if !font.Bitmap {
+		return [][][]string{bigTextCells(rows), plain}
+	}
+
+	// Render the bitmap with full, half, and quadrant blocks.
+	var pixelWidth int
+	for _, row := range rows {
+		pixelWidth = max(pixelWidth, len(row))
+	}
+	pixel := func(column, row int) bool {
+		return row < len(rows) && column < len(rows[row]) && rows[row][column] == '#'
+	}
+	scaled := func(columns, rows int, cell func(column, row int) string) [][]string {
+		cells := make([][]string, rows)
+		for row := range cells {
+			cells[row] = make([]string, columns)
+			for column := range cells[row] {
+				cells[row][column] = cell(column, row)
+			}
+		}
+		return cells
+	}
+	full := scaled(pixelWidth, len(rows), func(column, row int) string {
+		if pixel(column, row) {
+			return BlockFullBlock
+		}
+		return " "
+	})
+	half := scaled(pixelWidth, (len(rows)+1)/2, func(column, row int) string {
+		var index int
+		if pixel(column, 2*row) {
+			index |= 3
+		}
+		if pixel(column, 2*row+1) {
+			index |= 12
+		}
+		return bigTextQuadrants[index]
+	})
+	quadrant := scaled((pixelWidth+1)/2, (len(rows)+1)/2, func(column, row int) string {
+		var index int
+		for bit, offset := range [4][2]int{{0, 0}, {1, 0}, {0, 1}, {1, 1}} {
+			if pixel(2*column+offset[0], 2*row+offset[1]) {
+				index |= 1 << bit
+			}
+		}
+		return bigTextQuadrants[index]
+	})
+	return [][][]string{full, half, quadrant, plain}
+}
+
+// bigTextCells splits the given lines into cells of grapheme clusters.
+func bigTextCells(lines []string) [][]string {
+	cells := make([][]string, len(lines))
+	for row, line := range lines {
+		var state *stepState
+		for len(line) > 0 {
+			var cluster string
+			cluster, line, state = step(line, state)
+			cells[row] = append(cells[row], cluster)
+			for range state.Width() - 1 {
+				cells[row] = append(cells[row], "") // Occupied by a wide character.
+			}
+		}
+	}
+	return cells
+}
+
+// bigTextWidth returns the number of columns of the given cells.
+func bigTextWidth(cells [][]string) (width int) {
+	for _, row := range cells {
+		width = max(width, len(row))
+	}
+	return
+}
+
+// Inspect describes this primitive in the inspection tree. See [Inspect].
+func (b *BigText) Inspect(node *InspectNode) {
+	b.Box.Inspect(node)
+	node.Role = RoleText
+	node.Value = b.text
+}
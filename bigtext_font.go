@@ -0,0 +1,170 @@
+package tview
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// BigTextFont is a font of a [BigText] primitive. Its glyphs are rows of
+// equal width. In bitmap fonts, "#" marks a set pixel and every other
+// character an empty one. Bitmap fonts are drawn with block characters which
+// can be downscaled if the text does not fit. Other fonts, e.g. figlet fonts
+// (see [ParseFigletFont]), are drawn as they are, with spaces left
+// transparent.
+type BigTextFont struct {
+	// The number of rows of each glyph.
+	Height int
+
+	// The glyphs by character. Lowercase letters without a glyph use the
+	// glyph of the uppercase letter. Characters without a glyph use the glyph
+	// of "?", if any, or are skipped.
+	Glyphs map[rune][]string
+
+	// Whether the glyphs are bitmaps.
+	Bitmap bool
+
+	// The number of empty columns between glyphs.
+	Spacing int
+}
+
+// glyph returns the glyph of the given character or nil if there is none.
+func (f *BigTextFont) glyph(r rune) []string {
+	if glyph, ok := f.Glyphs[r]; ok {
+		return glyph
+	}
+	if glyph, ok := f.Glyphs[unicode.ToUpper(r)]; ok {
+		return glyph
+	}
+	return f.Glyphs['?']
+}
+
+// BigTextFontBlock is a bitmap font of five rows which covers letters, digits,
+// and common punctuation. It is the default font of [BigText].
+var BigTextFontBlock = &BigTextFont{
+	Height:  5,
+	Bitmap:  true,
+	Spacing: 1,
+	Glyphs: map[rune][]string{
+		'A':  {" ### ", "#   #", "#####", "#   #", "#   #"},
+		'B':  {"#### ", "#   #", "#### ", "#   #", "#### "},
+		'C':  {" ####", "#    ", "#    ", "#    ", " ####"},
+		'D':  {"#### ", "#   #", "#   #", "#   #", "#### "},
+		'E':  {"#####", "#    ", "#### ", "#    ", "#####"},
+		'F':  {"#####", "#    ", "#### ", "#    ", "#    "},
+		'G':  {" ####", "#    ", "#  ##", "#   #", " ####"},
+		'H':  {"#   #", "#   #", "#####", "#   #", "#   #"},
+		'I':  {"###", " # ", " # ", " # ", "###"},
+		'J':  {"  ###", "   # ", "   # ", "#  # ", " ##  "},
+		'K':  {"#   #", "#  # ", "###  ", "#  # ", "#   #"},
+		'L':  {"#    ", "#    ", "#    ", "#    ", "#####"},
+		'M':  {"#   #", "## ##", "# # #", "#   #", "#   #"},
+		'N':  {"#   #", "##  #", "# # #", "#  ##", "#   #"},
+		'O':  {" ### ", "#   #", "#   #", "#   #", " ### "},
+		'P':  {"#### ", "#   #", "#### ", "#    ", "#    "},
+		'Q':  {" ### ", "#   #", "# # #", "#  # ", " ## #"},
+		'R':  {"#### ", "#   #", "#### ", "#  # ", "#   #"},
+		'S':  {" ####", "#    ", " ### ", "    #", "#### "},
+		'T':  {"#####", "  #  ", "  #  ", "  #  ", "  #  "},
+		'U':  {"#   #", "#   #", "#   #", "#   #", " ### "},
+		'V':  {"#   #", "#   #", "#   #", " # # ", "  #  "},
+		'W':  {"#   #", "#   #", "# # #", "## ##", "#   #"},
+		'X':  {"#   #", " # # ", "  #  ", " # # ", "#   #"},
+		'Y':  {"#   #", " # # ", "  #  ", "  #  ", "  #  "},
+		'Z':  {"#####", "   # ", "  #  ", " #   ", "#####"},
+		'0':  {" ### ", "#  ##", "# # #", "##  #", " ### "},
+		'1':  {" # ", "## ", " # ", " # ", "###"},
+		'2':  {" ### ", "#   #", "  ## ", " #   ", "#####"},
+		'3':  {"#### ", "    #", " ### ", "    #", "#### "},
+		'4':  {"#   #", "#   #", "#####", "    #", "    #"},
+		'5':  {"#####", "#    ", "#### ", "    #", "#### "},
+		'6':  {" ### ", "#    ", "#### ", "#   #", " ### "},
+		'7':  {"#####", "    #", "   # ", "  #  ", "  #  "},
+		'8':  {" ### ", "#   #", " ### ", "#   #", " ### "},
+		'9':  {" ### ", "#   #", " ####", "    #", " ### "},
+		' ':  {"   ", "   ", "   ", "   ", "   "},
+		'.':  {" ", " ", " ", " ", "#"},
+		',':  {"  ", "  ", "  ", " #", "# "},
+		':':  {" ", "#", " ", "#", " "},
+		';':  {"  ", " #", "  ", " #", "# "},
+		'!':  {"#", "#", "#", " ", "#"},
+		'?':  {" ### ", "#   #", "  ## ", "     ", "  #  "},
+		'\'': {"#", "#", " ", " ", " "},
+		'"':  {"# #", "# #", "   ", "   ", "   "},
+		'-':  {"    ", "    ", "####", "    ", "    "},
+		'_':  {"     ", "     ", "     ", "     ", "#####"},
+		'+':  {"   ", " # ", "###", " # ", "   "},
+		'=':  {"    ", "####", "    ", "####", "    "},
+		'/':  {"    #", "   # ", "  #  ", " #   ", "#    "},
+		'(':  {" #", "# ", "# ", "# ", " #"},
+		')':  {"# ", " #", " #", " #", "# "},
+		'<':  {"  #", " # ", "#  ", " # ", "  #"},
+		'>':  {"#  ", " # ", "  #", " # ", "#  "},
+		'%':  {"#   #", "   # ", "  #  ", " #   ", "#   #"},
+		'*':  {"     ", "# # #", " ### ", "# # #", "     "},
+	},
+}
+
+// ParseFigletFont parses a font in the figlet format ("flf2a" header), e.g.
+// one of the fonts distributed with figlet, for use with [BigText]. Only the
+// required characters from " " to "~" are read. Hardblanks are drawn as
+// spaces. The font is drawn as it is, without the figlet's kerning or
+// smushing of adjacent glyphs.
+func ParseFigletFont(reader io.Reader) (*BigTextFont, error) {
+	scanner := bufio.NewScanner(reader)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("empty figlet font")
+	}
+
+	// Parse the header.
+	header := scanner.Text()
+	if !strings.HasPrefix(header, "flf2a") || len(header) < 6 {
+		return nil, errors.New("not a figlet font")
+	}
+	hardBlank := header[5:6]
+	var height, baseline, maxLength, oldLayout, commentLines int
+	if _, err := fmt.Sscan(header[6:], &height, &baseline, &maxLength, &oldLayout, &commentLines); err != nil {
+		return nil, fmt.Errorf("invalid figlet header: %w", err)
+	}
+	if height <= 0 {
+		return nil, errors.New("invalid figlet font height")
+	}
+	for range commentLines {
+		scanner.Scan()
+	}
+
+	// Read the glyphs.
+	font := &BigTextFont{Height: height, Glyphs: make(map[rune][]string)}
+	for r := rune(' '); r <= '~'; r++ {
+		glyph := make([]string, height)
+		var width int
+		for row := range glyph {
+			if !scanner.Scan() {
+				if err := scanner.Err(); err != nil {
+					return nil, err
+				}
+				return nil, fmt.Errorf("figlet font ends before glyph %q", r)
+			}
+
+			// Remove the end marks, replace hardblanks.
+			line := strings.TrimRight(scanner.Text(), " ")
+			if line != "" {
+				line = strings.TrimRight(line, line[len(line)-1:])
+			}
+			line = strings.ReplaceAll(line, hardBlank, " ")
+			glyph[row] = line
+			width = max(width, TaggedStringWidth(line))
+		}
+		for row, line := range glyph {
+			glyph[row] = line + strings.Repeat(" ", width-TaggedStringWidth(line))
+		}
+		font.Glyphs[r] = glyph
+	}
+	return font, nil
+}
@@ -1,5 +1,10 @@
 package tview
 
+import (
+	"sort"
+	"sync"
+)
+
 // BorderSet defines various borders used when primitives are drawn.
 type BorderSet struct {
 	Top         string
@@ -101,6 +106,103 @@ func BorderSetDouble() BorderSet {
 	}
 }
 
+// BorderCorner identifies one corner of a border.
+type BorderCorner int
+
+// The corners of a border.
+const (
+	BorderCornerTopLeft BorderCorner = iota
+	BorderCornerTopRight
+	BorderCornerBottomLeft
+	BorderCornerBottomRight
+)
+
+// BorderSetASCII returns a border set which only uses ASCII characters, for
+// terminals or fonts without box drawing characters.
+func BorderSetASCII() BorderSet {
+	return BorderSet{
+		Top:         "-",
+		Bottom:      "-",
+		Left:        "|",
+		Right:       "|",
+		TopLeft:     "+",
+		TopRight:    "+",
+		BottomLeft:  "+",
+		BottomRight: "+",
+		TopT:        "+",
+		BottomT:     "+",
+		LeftT:       "+",
+		RightT:      "+",
+	}
+}
+
+// BorderSetBlock returns a border set made of half blocks which draw a solid
+// frame around the content.
+func BorderSetBlock() BorderSet {
+	return BorderSet{
+		Top:         BlockUpperHalfBlock,
+		Bottom:      BlockLowerHalfBlock,
+		Left:        BlockLeftHalfBlock,
+		Right:       BlockRightHalfBlock,
+		TopLeft:     BlockQuadrantUpperLeftAndUpperRightAndLowerLeft,
+		TopRight:    BlockQuadrantUpperLeftAndUpperRightAndLowerRight,
+		BottomLeft:  BlockQuadrantUpperLeftAndLowerLeftAndLowerRight,
+		BottomRight: BlockQuadrantUpperRightAndLowerLeftAndLowerRight,
+		TopT:        BlockFullBlock,
+		BottomT:     BlockFullBlock,
+		LeftT:       BlockFullBlock,
+		RightT:      BlockFullBlock,
+	}
+}
+
+var (
+	// The registered border sets, keyed by name.
+	borderSets = map[string]BorderSet{
+		"hidden": BorderSetHidden(),
+		"plain":  BorderSetPlain(),
+		"round":  BorderSetRound(),
+		"thick":  BorderSetThick(),
+		"double": BorderSetDouble(),
+		"ascii":  BorderSetASCII(),
+		"block":  BorderSetBlock(),
+	}
+
+	// Guards borderSets.
+	borderSetsMutex sync.RWMutex
+)
+
+// RegisterBorderSet registers a border set under the given name so it can be
+// referenced by name, e.g. in themes or configuration files. Registering a
+// name again replaces the previous set. The built-in sets are registered as
+// "hidden", "plain", "round", "thick", "double", "ascii", and "block".
+func RegisterBorderSet(name string, set BorderSet) {
+	borderSetsMutex.Lock()
+	defer borderSetsMutex.Unlock()
+	borderSets[name] = set
+}
+
+// LookupBorderSet returns the border set registered under the given name. It
+// returns false if there is no such set.
+func LookupBorderSet(name string) (BorderSet, bool) {
+	borderSetsMutex.RLock()
+	defer borderSetsMutex.RUnlock()
+	set, ok := borderSets[name]
+	return set, ok
+}
+
+// BorderSetNames returns the names of all registered border sets, sorted
+// alphabetically.
+func BorderSetNames() []string {
+	borderSetsMutex.RLock()
+	defer borderSetsMutex.RUnlock()
+	names := make([]string, 0, len(borderSets))
+	for name := range borderSets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 type Borders uint
 
 const (
@@ -33,11 +33,27 @@ type Box struct {
 	borderSet   BorderSet
 	borderStyle tcell.Style
 
+	// The border set used while the box has focus. Ignored if it is the zero
+	// value.
+	focusedBorderSet BorderSet
+
+	// Corner glyphs which replace those of the border set, indexed by
+	// BorderCorner. Empty strings use the border set's glyphs.
+	borderCorners [4]string
+
 	// Title
 	title          string
 	titleStyle     tcell.Style
 	titleAlignment Alignment
 
+	// Text drawn in the border style directly before and after the title.
+	titlePrefix, titleSuffix string
+
+	// Optional callback functions invoked when the title bar is
+	// double-clicked or pressed and held, see SetTitleDoubleClickFunc.
+	titleDoubleClick func()
+	titleLongPress   func(x, y int)
+
 	// Footer
 	footer          string
 	footerStyle     tcell.Style
@@ -51,6 +67,9 @@ type Box struct {
 	// Optional callback functions invoked when the primitive receives or loses
 	// focus.
 	focus, blur func()
+
+	// The theme from which the default styles were derived.
+	theme Theme
 }
 
 // NewBox returns a Box without a border.
@@ -68,10 +87,36 @@ func NewBox() *Box {
 		titleAlignment:  AlignmentCenter,
 		footerStyle:     tcell.StyleDefault.Foreground(Styles.TitleColor),
 		footerAlignment: AlignmentCenter,
+		theme:           Styles,
 	}
 	return b
 }
 
+// updateTheme updates the default styles of the given primitive, which must
+// be this box or embed it, if the theme has changed since the last call.
+// Primitives which read their styles before calling
+// [Box.DrawForSubclass] must call it first.
+func (b *Box) updateTheme(p Primitive) {
+	if b.theme == Styles {
+		return
+	}
+	change := themeChange{old: b.theme, new: Styles}
+	b.theme = Styles
+	if themed, ok := p.(themedPrimitive); ok {
+		themed.applyTheme(change)
+	} else {
+		b.applyTheme(change)
+	}
+}
+
+// applyTheme updates the box's default styles after a theme change.
+func (b *Box) applyTheme(change themeChange) {
+	b.backgroundColor = change.color(b.backgroundColor, themePrimitiveBackground)
+	b.borderStyle = change.style(b.borderStyle, themeBorder, themePrimitiveBackground)
+	b.titleStyle = change.style(b.titleStyle, themeTitle, 0)
+	b.footerStyle = change.style(b.footerStyle, themeTitle, 0)
+}
+
 // SetBorderPadding sets the size of the borders around the box content.
 func (b *Box) SetBorderPadding(top, bottom, left, right int) *Box {
 	if b.paddingTop != top || b.paddingBottom != bottom || b.paddingLeft != left || b.paddingRight != right {
@@ -200,11 +245,38 @@ func (b *Box) SetBorderSet(borderSet BorderSet) *Box {
 	return b
 }
 
+// SetFocusedBorderSet sets the border set used instead of the regular border
+// set (see [Box.SetBorderSet]) while the box has focus. Pass an empty
+// BorderSet{} to use the regular border set regardless of focus.
+func (b *Box) SetFocusedBorderSet(borderSet BorderSet) *Box {
+	if b.focusedBorderSet != borderSet {
+		b.focusedBorderSet = borderSet
+	}
+	return b
+}
+
 // GetBorderSet returns the box' borderSet
 func (b *Box) GetBorderSet() BorderSet {
 	return b.borderSet
 }
 
+// SetBorderCorner overrides the glyph of one corner of the border set (see
+// [Box.SetBorderSet]). An empty glyph restores the border set's glyph.
+func (b *Box) SetBorderCorner(corner BorderCorner, glyph string) *Box {
+	if corner >= 0 && int(corner) < len(b.borderCorners) && b.borderCorners[corner] != glyph {
+		b.borderCorners[corner] = glyph
+	}
+	return b
+}
+
+// corner returns the glyph to be drawn at the given corner.
+func (b *Box) corner(corner BorderCorner, glyph string) string {
+	if override := b.borderCorners[corner]; override != "" {
+		return override
+	}
+	return glyph
+}
+
 // SetBorderStyle sets the box's border style.
 func (b *Box) SetBorderStyle(style tcell.Style) *Box {
 	if b.borderStyle != style {
@@ -248,6 +320,57 @@ func (b *Box) SetTitleAlignment(alignment Alignment) *Box {
 	return b
 }
 
+// SetTitlePadding sets text drawn directly before and after the title, using
+// the border style. For example, a prefix of "┤ " and a suffix of " ├" results
+// in "┤ Title ├". The padding is omitted if there is no title or not enough
+// space to show it along with at least one character of the title.
+func (b *Box) SetTitlePadding(prefix, suffix string) *Box {
+	if b.titlePrefix != prefix || b.titleSuffix != suffix {
+		b.titlePrefix, b.titleSuffix = prefix, suffix
+	}
+	return b
+}
+
+// SetTitleDoubleClickFunc sets a callback function which is invoked when the
+// user double-clicks the box's title bar, i.e. its top row if it has a title or
+// a top border. This is typically used to maximize and restore windows, e.g.
+// with ToggleMaximized of the workspace package's Workspace. The double-click
+// is not passed on to the primitive. The screen is redrawn after the callback
+// returns.
+//
+// Set to nil to remove the callback function.
+func (b *Box) SetTitleDoubleClickFunc(callback func()) *Box {
+	b.titleDoubleClick = callback
+	registerTitleBar(b)
+	return b
+}
+
+// SetTitleLongPressFunc sets a callback function which is invoked when the
+// user presses the left mouse button on the box's title bar (see
+// [Box.SetTitleDoubleClickFunc]) and holds it without moving the mouse for
+// [LongPressDuration], e.g. to open a context menu. The callback receives the
+// position of the mouse. The click which ends the press is not passed on to
+// the primitive. The screen is redrawn after the callback returns.
+//
+// Set to nil to remove the callback function.
+func (b *Box) SetTitleLongPressFunc(callback func(x, y int)) *Box {
+	b.titleLongPress = callback
+	registerTitleBar(b)
+	return b
+}
+
+// onTitleBar returns whether the given screen position is on the box's title
+// bar.
+func (b *Box) onTitleBar(x, y int) bool {
+	return (b.title != "" || b.borders.Has(BordersTop)) && y == b.y && x >= b.x && x < b.x+b.width
+}
+
+// box returns the box itself. It allows finding the box of primitives which
+// embed it.
+func (b *Box) box() *Box {
+	return b
+}
+
 // GetFooter returns the box's current footer.
 func (b *Box) GetFooter() string {
 	return b.footer
@@ -290,6 +413,8 @@ func (b *Box) Draw(screen tcell.Screen) {
 // Only call this function from your own custom primitives. It is not needed in
 // applications that have no custom primitives.
 func (b *Box) DrawForSubclass(screen tcell.Screen, p Primitive) {
+	b.updateTheme(p)
+
 	// Don't draw anything if there is no space.
 	if b.width <= 0 || b.height <= 0 {
 		return
@@ -306,50 +431,72 @@ func (b *Box) DrawForSubclass(screen tcell.Screen, p Primitive) {
 	}
 
 	// Draw border.
+	borderSet := b.borderSet
+	if b.focusedBorderSet != (BorderSet{}) && p.HasFocus() {
+		borderSet = b.focusedBorderSet
+	}
 	if b.borders != BordersNone && b.width >= 2 && b.height >= 2 {
 		if b.borders.Has(BordersTop) {
 			for x := b.x + 1; x < b.x+b.width-1; x++ {
-				screen.Put(x, b.y, b.borderSet.Top, b.borderStyle)
+				screen.Put(x, b.y, borderSet.Top, b.borderStyle)
 			}
 		}
 
 		if b.borders.Has(BordersBottom) {
 			for x := b.x + 1; x < b.x+b.width-1; x++ {
-				screen.Put(x, b.y+b.height-1, b.borderSet.Bottom, b.borderStyle)
+				screen.Put(x, b.y+b.height-1, borderSet.Bottom, b.borderStyle)
 			}
 		}
 
 		if b.borders.Has(BordersLeft) {
 			for y := b.y + 1; y < b.y+b.height-1; y++ {
-				screen.Put(b.x, y, b.borderSet.Left, b.borderStyle)
+				screen.Put(b.x, y, borderSet.Left, b.borderStyle)
 			}
 		}
 
 		if b.borders.Has(BordersRight) {
 			for y := b.y + 1; y < b.y+b.height-1; y++ {
-				screen.Put(b.x+b.width-1, y, b.borderSet.Right, b.borderStyle)
+				screen.Put(b.x+b.width-1, y, borderSet.Right, b.borderStyle)
 			}
 		}
 
 		if b.borders.Has(BordersTop | BordersLeft) {
-			screen.Put(b.x, b.y, b.borderSet.TopLeft, b.borderStyle)
+			screen.Put(b.x, b.y, b.corner(BorderCornerTopLeft, borderSet.TopLeft), b.borderStyle)
 		}
 
 		if b.borders.Has(BordersTop | BordersRight) {
-			screen.Put(b.x+b.width-1, b.y, b.borderSet.TopRight, b.borderStyle)
+			screen.Put(b.x+b.width-1, b.y, b.corner(BorderCornerTopRight, borderSet.TopRight), b.borderStyle)
 		}
 
 		if b.borders.Has(BordersBottom | BordersLeft) {
-			screen.Put(b.x, b.y+b.height-1, b.borderSet.BottomLeft, b.borderStyle)
+			screen.Put(b.x, b.y+b.height-1, b.corner(BorderCornerBottomLeft, borderSet.BottomLeft), b.borderStyle)
 		}
 
 		if b.borders.Has(BordersBottom | BordersRight) {
-			screen.Put(b.x+b.width-1, b.y+b.height-1, b.borderSet.BottomRight, b.borderStyle)
+			screen.Put(b.x+b.width-1, b.y+b.height-1, b.corner(BorderCornerBottomRight, borderSet.BottomRight), b.borderStyle)
 		}
 	}
 
 	// Draw title.
-	if b.title != "" && b.width >= 4 {
+	prefixWidth, suffixWidth := TaggedStringWidth(b.titlePrefix), TaggedStringWidth(b.titleSuffix)
+	if b.title != "" && (prefixWidth > 0 || suffixWidth > 0) && b.width-2 > prefixWidth+suffixWidth {
+		available := b.width - 2 - prefixWidth - suffixWidth
+		titleWidth := min(TaggedStringWidth(b.title), available)
+		x := b.x + 1
+		switch b.titleAlignment {
+		case AlignmentCenter:
+			x += (available - titleWidth) / 2
+		case AlignmentRight:
+			x += available - titleWidth
+		}
+		printWithStyle(screen, b.titlePrefix, x, b.y, 0, prefixWidth, AlignmentLeft, b.borderStyle, false)
+		_, _, printed := printWithStyle(screen, b.title, x+prefixWidth, b.y, 0, titleWidth, AlignmentLeft, b.titleStyle, true)
+		if printed < TaggedStringWidth(b.title) && printed > 0 {
+			_, style, _ := screen.Get(x+prefixWidth+titleWidth-1, b.y)
+			Print(screen, string(SemigraphicsHorizontalEllipsis), x+prefixWidth+titleWidth-1, b.y, 1, AlignmentLeft, style.GetForeground())
+		}
+		printWithStyle(screen, b.titleSuffix, x+prefixWidth+titleWidth, b.y, 0, suffixWidth, AlignmentLeft, b.borderStyle, false)
+	} else if b.title != "" && b.width >= 4 {
 		start, end, _ := printWithStyle(screen, b.title, b.x+1, b.y, 0, b.width-2, b.titleAlignment, b.titleStyle, true)
 		printed := end - start
 		if len(b.title)-printed > 0 && printed > 0 {
@@ -440,3 +587,8 @@ func (b *Box) Blur() {
 func (b *Box) HasFocus() bool {
 	return b.hasFocus
 }
+
+// Inspect describes this primitive in the inspection tree. See [Inspect].
+func (b *Box) Inspect(node *InspectNode) {
+	node.Label = b.title
+}
@@ -1,10 +1,15 @@
 package tview
 
 import (
+	"strings"
+
 	"github.com/gdamore/tcell/v3"
 )
 
-// Button is labeled box that triggers an action when selected.
+// Button is labeled box that triggers an action when selected. Besides the
+// label, a button may show an icon before the label (see [Button.SetIcon]) and
+// a badge after it (see [Button.SetBadge]). Long labels may be wrapped over
+// two lines (see [Button.SetWrap]).
 //
 // See https://github.com/ayn2op/tview/wiki/Button for an example.
 type Button struct {
@@ -16,6 +21,23 @@ type Button struct {
 	// The text to be displayed inside the button.
 	text string
 
+	// The styled text to be displayed inside the button. If it has no
+	// segments, text is displayed instead.
+	line Line
+
+	// An optional glyph displayed before the label.
+	icon string
+
+	// An optional text displayed after the label, e.g. a counter.
+	badge string
+
+	// The style of the badge.
+	badgeStyle tcell.Style
+
+	// Whether or not labels which don't fit into one line are wrapped over two
+	// lines.
+	wrap bool
+
 	// The button's style (when deactivated).
 	style tcell.Style
 
@@ -44,22 +66,137 @@ func NewButton(label string) *Button {
 		style:          tcell.StyleDefault.Background(Styles.ContrastBackgroundColor).Foreground(Styles.PrimaryTextColor),
 		activatedStyle: tcell.StyleDefault.Background(Styles.PrimaryTextColor).Foreground(Styles.InverseTextColor),
 		disabledStyle:  tcell.StyleDefault.Background(Styles.ContrastBackgroundColor).Foreground(Styles.ContrastSecondaryTextColor),
+		badgeStyle:     tcell.StyleDefault.Background(Styles.SecondaryTextColor).Foreground(Styles.InverseTextColor),
 	}
 }
 
+// applyTheme updates the button's default styles after a theme change.
+func (b *Button) applyTheme(change themeChange) {
+	b.Box.applyTheme(change)
+	b.style = change.style(b.style, themePrimaryText, themeContrastBackground)
+	b.activatedStyle = change.style(b.activatedStyle, themeInverseText, themePrimaryText)
+	b.disabledStyle = change.style(b.disabledStyle, themeContrastSecondaryText, themeContrastBackground)
+	b.badgeStyle = change.style(b.badgeStyle, themeInverseText, themeSecondaryText)
+}
+
 // SetLabel sets the button text.
 func (b *Button) SetLabel(label string) *Button {
-	if b.text != label {
+	if b.text != label || len(b.line.Segments) > 0 {
 		b.text = label
+		b.line = Line{}
 	}
 	return b
 }
 
+// SetLabelLine sets the button text as a styled line. Each segment's style is
+// applied on top of the button's current style, e.g. a segment with only a red
+// foreground color will be red on the button's background.
+func (b *Button) SetLabelLine(line Line) *Button {
+	b.line = line.Clone()
+	b.text = line.String()
+	return b
+}
+
 // GetLabel returns the button text.
 func (b *Button) GetLabel() string {
 	return b.text
 }
 
+// SetIcon sets a glyph (e.g. a Nerd Font icon or an emoji) to be displayed
+// before the label, separated by a space. An empty string removes the icon.
+func (b *Button) SetIcon(icon string) *Button {
+	b.icon = icon
+	return b
+}
+
+// GetIcon returns the glyph displayed before the label.
+func (b *Button) GetIcon() string {
+	return b.icon
+}
+
+// SetBadge sets a short text (e.g. the number of unread items "3") to be
+// displayed after the label in the badge style. An empty string removes the
+// badge.
+func (b *Button) SetBadge(badge string) *Button {
+	b.badge = badge
+	return b
+}
+
+// GetBadge returns the text displayed after the label.
+func (b *Button) GetBadge() string {
+	return b.badge
+}
+
+// SetBadgeStyle sets the style of the badge. It is used regardless of whether
+// the button has focus.
+func (b *Button) SetBadgeStyle(style tcell.Style) *Button {
+	if b.badgeStyle != style {
+		b.badgeStyle = style
+	}
+	return b
+}
+
+// SetWrap sets whether labels which don't fit into the button's width are
+// wrapped at word boundaries over two lines. This requires the button to be
+// at least two rows high, see [Button.Height]. The label is truncated after
+// the second line.
+func (b *Button) SetWrap(wrap bool) *Button {
+	b.wrap = wrap
+	return b
+}
+
+// Height returns the number of rows the button needs at the given width to
+// show its entire label, i.e. two rows plus borders if the label is wrapped
+// (see [Button.SetWrap]), or one row plus borders otherwise.
+func (b *Button) Height(width int) int {
+	_, _, rectWidth, rectHeight := b.GetRect()
+	_, _, innerWidth, innerHeight := b.GetInnerRect()
+	width -= rectWidth - innerWidth
+	return len(b.labelLines(width-b.decorationWidth(), 2)) + max(rectHeight-innerHeight, 0)
+}
+
+// decorationWidth returns the screen width of the icon and the badge,
+// including the spaces which separate them from the label.
+func (b *Button) decorationWidth() (width int) {
+	if b.icon != "" {
+		width += TaggedStringWidth(b.icon) + 1
+	}
+	if b.badge != "" {
+		width += TaggedStringWidth(b.badge) + 3
+	}
+	return
+}
+
+// contentWidth returns the screen width of the button's content (icon, label,
+// and badge) when shown in one line.
+func (b *Button) contentWidth() int {
+	return TaggedStringWidth(b.text) + b.decorationWidth()
+}
+
+// labelLines returns the lines of the label when shown in the given width
+// and at most the given number of rows. There is always at least one line.
+func (b *Button) labelLines(width, rows int) []Line {
+	label := b.line
+	if len(label.Segments) == 0 {
+		label = NewLine(NewSegment(b.text, tcell.StyleDefault))
+	}
+	if !b.wrap || rows < 2 || width <= 0 || TaggedStringWidth(b.text) <= width {
+		return []Line{label}
+	}
+	wrapped := WordWrap(b.text, width)
+	if len(wrapped) < 2 {
+		return []Line{label}
+	}
+
+	// The second line gets the remaining text.
+	first := len(wrapped[0])
+	second := first + len(b.text[first:]) - len(strings.TrimLeft(b.text[first:], " "))
+	return []Line{
+		label.slice(0, len(strings.TrimRight(wrapped[0], " "))),
+		label.slice(second, len(b.text)),
+	}
+}
+
 // SetLabelColor sets the color of the button text.
 func (b *Button) SetLabelColor(color tcell.Color) *Button {
 	style := b.style.Foreground(color)
@@ -150,6 +287,8 @@ func (b *Button) SetExitFunc(handler func(key tcell.Key)) *Button {
 
 // Draw draws this primitive onto the screen.
 func (b *Button) Draw(screen tcell.Screen) {
+	b.updateTheme(b)
+
 	// Draw the box.
 	style := b.style
 	if b.disabled {
@@ -164,9 +303,37 @@ func (b *Button) Draw(screen tcell.Screen) {
 
 	// Draw label.
 	x, y, width, height := b.GetInnerRect()
-	if width > 0 && height > 0 {
-		y = y + height/2
-		printWithStyle(screen, b.text, x, y, 0, width, AlignmentCenter, style, true)
+	if width <= 0 || height <= 0 {
+		return
+	}
+	decorationWidth := b.decorationWidth()
+	labelWidth := width - decorationWidth
+	lines := b.labelLines(labelWidth, height)
+	y += (height - len(lines)) / 2
+	if labelWidth <= 0 || decorationWidth == 0 {
+		// There is no space for the icon and the badge.
+		for index, line := range lines {
+			printLine(screen, line, x, y+index, width, AlignmentCenter, style, true)
+		}
+		return
+	}
+
+	// Center icon, label, and badge as one block.
+	var textWidth int
+	for _, line := range lines {
+		textWidth = max(textWidth, TaggedStringWidth(line.String()))
+	}
+	textWidth = min(textWidth, labelWidth)
+	x += (labelWidth - textWidth) / 2
+	if b.icon != "" {
+		_, _, iconWidth := printWithStyle(screen, b.icon, x, y, 0, width, AlignmentLeft, style, true)
+		x += iconWidth + 1
+	}
+	for index, line := range lines {
+		printLine(screen, line, x, y+index, textWidth, AlignmentCenter, style, true)
+	}
+	if b.badge != "" {
+		printWithStyle(screen, " "+b.badge+" ", x+textWidth+1, y, 0, width, AlignmentLeft, b.badgeStyle, false)
 	}
 }
 
@@ -208,3 +375,11 @@ func (b *Button) HandleEvent(event tcell.Event) Command {
 	}
 	return nil
 }
+
+// Inspect describes this primitive in the inspection tree. See [Inspect].
+func (b *Button) Inspect(node *InspectNode) {
+	node.Role = RoleButton
+	node.Label = b.GetLabel()
+	node.Value = b.badge
+	node.Disabled = b.disabled
+}
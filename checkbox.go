@@ -4,8 +4,21 @@ import (
 	"github.com/gdamore/tcell/v3"
 )
 
+// CheckboxState is the state of a [Checkbox].
+type CheckboxState int
+
+// The states of a checkbox. A checkbox is only indeterminate if set with
+// [Checkbox.SetState], e.g. to indicate that some but not all of a group of
+// options are selected.
+const (
+	CheckboxUnchecked CheckboxState = iota
+	CheckboxChecked
+	CheckboxIndeterminate
+)
+
 // Checkbox implements a simple box for boolean values which can be checked and
-// unchecked.
+// unchecked. It may also be put into an indeterminate third state, see
+// [Checkbox.SetState].
 //
 // See https://github.com/ayn2op/tview/wiki/Checkbox for an example.
 type Checkbox struct {
@@ -14,12 +27,19 @@ type Checkbox struct {
 	// Whether or not this checkbox is disabled/read-only.
 	disabled bool
 
-	// Whether or not this box is checked.
-	checked bool
+	// The checked state of this box.
+	state CheckboxState
+
+	// The radio group this checkbox belongs to or nil if none.
+	group *RadioGroup
 
 	// The text to be displayed before the input area.
 	label string
 
+	// The styled text to be displayed before the input area. If it has no
+	// segments, label is displayed instead.
+	labelLine Line
+
 	// The screen width of the label area. A value of 0 means use the width of
 	// the label text.
 	labelWidth int
@@ -42,10 +62,17 @@ type Checkbox struct {
 	// The string used to display a checked box.
 	checkedString string
 
+	// The string used to display an indeterminate box.
+	indeterminateString string
+
 	// An optional function which is called when the user changes the checked
 	// state of this checkbox.
 	changed func(checked bool)
 
+	// An optional function which is called when the state of this checkbox
+	// changes.
+	stateChanged func(state CheckboxState)
+
 	// An optional function which is called when the user indicated that they
 	// are done entering text. The key which was pressed is provided (tab,
 	// shift-tab, or escape).
@@ -59,41 +86,88 @@ type Checkbox struct {
 // NewCheckbox returns a new input field.
 func NewCheckbox() *Checkbox {
 	return &Checkbox{
-		Box:             NewBox(),
-		labelStyle:      tcell.StyleDefault.Foreground(Styles.SecondaryTextColor),
-		uncheckedStyle:  tcell.StyleDefault.Background(Styles.ContrastBackgroundColor).Foreground(Styles.PrimaryTextColor),
-		checkedStyle:    tcell.StyleDefault.Background(Styles.ContrastBackgroundColor).Foreground(Styles.PrimaryTextColor),
-		focusStyle:      tcell.StyleDefault.Background(Styles.PrimaryTextColor).Foreground(Styles.ContrastBackgroundColor),
-		uncheckedString: " ",
-		checkedString:   "X",
+		Box:                 NewBox(),
+		labelStyle:          tcell.StyleDefault.Foreground(Styles.SecondaryTextColor),
+		uncheckedStyle:      tcell.StyleDefault.Background(Styles.ContrastBackgroundColor).Foreground(Styles.PrimaryTextColor),
+		checkedStyle:        tcell.StyleDefault.Background(Styles.ContrastBackgroundColor).Foreground(Styles.PrimaryTextColor),
+		focusStyle:          tcell.StyleDefault.Background(Styles.PrimaryTextColor).Foreground(Styles.ContrastBackgroundColor),
+		uncheckedString:     " ",
+		checkedString:       "X",
+		indeterminateString: "-",
 	}
 }
 
+// applyTheme updates the checkbox's default styles after a theme change.
+func (c *Checkbox) applyTheme(change themeChange) {
+	c.Box.applyTheme(change)
+	c.labelStyle = change.style(c.labelStyle, themeSecondaryText, 0)
+	c.uncheckedStyle = change.style(c.uncheckedStyle, themePrimaryText, themeContrastBackground)
+	c.checkedStyle = change.style(c.checkedStyle, themePrimaryText, themeContrastBackground)
+	c.focusStyle = change.style(c.focusStyle, themeContrastBackground, themePrimaryText)
+}
+
 // SetChecked sets the state of the checkbox. This also triggers the "changed"
 // callback if the state changes with this call.
 func (c *Checkbox) SetChecked(checked bool) *Checkbox {
-	if c.checked != checked {
-		c.checked = checked
-		if c.changed != nil {
-			c.changed(checked)
-		}
+	if checked {
+		return c.SetState(CheckboxChecked)
 	}
-	return c
+	return c.SetState(CheckboxUnchecked)
 }
 
-// IsChecked returns whether or not the box is checked.
+// IsChecked returns whether or not the box is checked. An indeterminate box is
+// not checked.
 func (c *Checkbox) IsChecked() bool {
-	return c.checked
+	return c.state == CheckboxChecked
+}
+
+// SetState sets the state of the checkbox, e.g. [CheckboxIndeterminate] for a
+// box which summarizes a group of options of which only some are checked.
+// When the user toggles an indeterminate box, it becomes checked. This also
+// triggers the "changed" callbacks if the state changes with this call. If the
+// checkbox belongs to a [RadioGroup] and becomes checked, all other checkboxes
+// of the group are unchecked.
+func (c *Checkbox) SetState(state CheckboxState) *Checkbox {
+	if c.state == state {
+		return c
+	}
+	wasChecked := c.state == CheckboxChecked
+	c.state = state
+	if c.stateChanged != nil {
+		c.stateChanged(state)
+	}
+	if checked := state == CheckboxChecked; c.changed != nil && checked != wasChecked {
+		c.changed(checked)
+	}
+	if c.group != nil && state == CheckboxChecked {
+		c.group.checked(c)
+	}
+	return c
+}
+
+// GetState returns the state of the checkbox.
+func (c *Checkbox) GetState() CheckboxState {
+	return c.state
 }
 
 // SetLabel sets the text to be displayed before the input area.
 func (c *Checkbox) SetLabel(label string) *Checkbox {
-	if c.label != label {
+	if c.label != label || len(c.labelLine.Segments) > 0 {
 		c.label = label
+		c.labelLine = Line{}
 	}
 	return c
 }
 
+// SetLabelLine sets the text to be displayed before the input area as a styled
+// line. Each segment's style is applied on top of the label style, e.g. to
+// show a required-field asterisk in red.
+func (c *Checkbox) SetLabelLine(line Line) *Checkbox {
+	c.labelLine = line.Clone()
+	c.label = line.String()
+	return c
+}
+
 // GetLabel returns the text to be displayed before the input area.
 func (c *Checkbox) GetLabel() string {
 	return c.label
@@ -194,6 +268,15 @@ func (c *Checkbox) SetUncheckedString(unchecked string) *Checkbox {
 	return c
 }
 
+// SetIndeterminateString sets the string to be displayed when the checkbox
+// is in the indeterminate state (defaults to "-").
+func (c *Checkbox) SetIndeterminateString(indeterminate string) *Checkbox {
+	if c.indeterminateString != indeterminate {
+		c.indeterminateString = indeterminate
+	}
+	return c
+}
+
 // SetFormAttributes sets attributes shared by all form items.
 func (c *Checkbox) SetFormAttributes(labelWidth int, labelColor, bgColor, fieldTextColor, fieldBgColor tcell.Color) FormItem {
 	c.labelWidth = labelWidth
@@ -234,11 +317,22 @@ func (c *Checkbox) GetDisabled() bool {
 
 // SetChangedFunc sets a handler which is called when the checked state of this
 // checkbox was changed. The handler function receives the new state.
+//
+// The handler is only called when the box becomes checked or stops being
+// checked. Use [Checkbox.SetStateChangedFunc] to be notified of changes from
+// and to the indeterminate state as well.
 func (c *Checkbox) SetChangedFunc(handler func(checked bool)) *Checkbox {
 	c.changed = handler
 	return c
 }
 
+// SetStateChangedFunc sets a handler which is called when the state of this
+// checkbox was changed. The handler function receives the new state.
+func (c *Checkbox) SetStateChangedFunc(handler func(state CheckboxState)) *Checkbox {
+	c.stateChanged = handler
+	return c
+}
+
 // SetDoneFunc sets a handler which is called when the user is done using the
 // checkbox. The callback function is provided with the key that was pressed,
 // which is one of the following:
@@ -282,13 +376,20 @@ func (c *Checkbox) Draw(screen tcell.Screen) {
 
 	// Draw label.
 	labelBg := c.labelStyle.GetBackground()
+	printLabel := func(maxWidth int) int {
+		if len(c.labelLine.Segments) > 0 {
+			return printLine(screen, c.labelLine, x, y, maxWidth, AlignmentLeft, c.labelStyle, labelBg == tcell.ColorDefault)
+		}
+		_, _, drawnWidth := printWithStyle(screen, c.label, x, y, 0, maxWidth, AlignmentLeft, c.labelStyle, labelBg == tcell.ColorDefault)
+		return drawnWidth
+	}
 	if c.labelWidth > 0 {
 		labelWidth := min(c.labelWidth, width)
-		printWithStyle(screen, c.label, x, y, 0, labelWidth, AlignmentLeft, c.labelStyle, labelBg == tcell.ColorDefault)
+		printLabel(labelWidth)
 		x += labelWidth
 		width -= labelWidth
 	} else {
-		_, _, drawnWidth := printWithStyle(screen, c.label, x, y, 0, width, AlignmentLeft, c.labelStyle, labelBg == tcell.ColorDefault)
+		drawnWidth := printLabel(width)
 		x += drawnWidth
 		width -= drawnWidth
 	}
@@ -296,9 +397,13 @@ func (c *Checkbox) Draw(screen tcell.Screen) {
 	// Draw checkbox.
 	str := c.uncheckedString
 	style := c.uncheckedStyle
-	if c.checked {
+	switch c.state {
+	case CheckboxChecked:
 		str = c.checkedString
 		style = c.checkedStyle
+	case CheckboxIndeterminate:
+		str = c.indeterminateString
+		style = c.checkedStyle
 	}
 	if c.disabled {
 		style = style.Background(c.backgroundColor)
@@ -323,7 +428,7 @@ func (c *Checkbox) HandleEvent(event tcell.Event) Command {
 			if key == tcell.KeyRune && event.Str() != " " {
 				break
 			}
-			c.SetChecked(!c.checked)
+			c.toggle()
 		case tcell.KeyTab, tcell.KeyBacktab, tcell.KeyEscape: // We're done.
 			if c.done != nil {
 				c.done(key)
@@ -346,10 +451,35 @@ func (c *Checkbox) HandleEvent(event tcell.Event) Command {
 			case MouseLeftDown:
 				return SetFocusCommand{Target: c}
 			case MouseLeftClick:
-				c.SetChecked(!c.checked)
+				c.toggle()
 				return RedrawCommand{}
 			}
 		}
 	}
 	return nil
 }
+
+// toggle changes the state of the checkbox in response to user input. A
+// checked box in a radio group stays checked.
+func (c *Checkbox) toggle() {
+	if c.state == CheckboxChecked && c.group == nil {
+		c.SetState(CheckboxUnchecked)
+	} else {
+		c.SetState(CheckboxChecked)
+	}
+}
+
+// Inspect describes this primitive in the inspection tree. See [Inspect]. The
+// value of an indeterminate checkbox is "mixed".
+func (c *Checkbox) Inspect(node *InspectNode) {
+	node.Role = RoleCheckbox
+	if c.group != nil {
+		node.Role = RoleRadio
+	}
+	node.Label = c.GetLabel()
+	node.Checked = c.state == CheckboxChecked
+	if c.state == CheckboxIndeterminate {
+		node.Value = "mixed"
+	}
+	node.Disabled = c.disabled
+}
@@ -25,4 +25,22 @@ type SetClipboardCommand string
 
 type GetClipboardCommand struct{}
 
+// BellCommand signals the bell, e.g. on invalid input. With a visual bell
+// mode, Target is flashed. See [Application.SetBellMode].
+type BellCommand struct {
+	Target Primitive
+}
+
 type NotifyCommand struct{ Title, Body string }
+
+// AsyncCommand is run in its own goroutine so that slow work (e.g. network
+// lookups) does not block the event loop. The command it returns, if any, is
+// executed by the event loop once the function has completed.
+type AsyncCommand func() Command
+
+// PlayMacroCommand replays the key sequence recorded as the application's
+// macro the given number of times (at least once). See
+// [Application.SetMacroKeys].
+type PlayMacroCommand struct {
+	Times int
+}
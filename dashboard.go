@@ -0,0 +1,367 @@
+package tview
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+// dashboardFrame is the box drawn around a dashboard tile. It reports the
+// tile's focus so that its focused border set (see [Box.SetFocusedBorderSet])
+// follows the tile.
+type dashboardFrame struct {
+	*Box
+	item Primitive
+}
+
+// HasFocus returns whether the framed tile has focus.
+func (f *dashboardFrame) HasFocus() bool {
+	return f.item.HasFocus()
+}
+
+// dashboardTile is a tile of a [Dashboard].
+type dashboardTile struct {
+	// The tile's content.
+	item Primitive
+
+	// The box drawn around the content, with the tile's title.
+	frame *dashboardFrame
+
+	// The number of columns the tile spans.
+	span int
+
+	// An optional function which refreshes the content, and the interval in
+	// which it is called after [Dashboard.Start].
+	refresh  func()
+	interval time.Duration
+}
+
+// Dashboard arranges tiles, e.g. [Gauge], [Sparkline], or [TextView]
+// primitives showing statistics, in a grid with as many columns as fit the
+// available width. Each tile is drawn in a frame with a title. Tiles are placed
+// row by row and may span several columns. If the width changes, the number of
+// columns and thus the arrangement of the tiles changes with it.
+//
+// Tiles may have refresh functions (see [Dashboard.SetTileRefreshFunc]) which
+// are called periodically after [Dashboard.Start] to update their contents.
+//
+// While a tile has focus, Tab and Backtab move the focus to the next and
+// previous tile unless the tile handles these keys itself.
+type Dashboard struct {
+	*Box
+
+	// The tiles in the order in which they are placed.
+	tiles []*dashboardTile
+
+	// The minimum width of a column and the height of a row. A height of 0
+	// distributes the available height evenly among the rows.
+	tileWidth, tileHeight int
+
+	// The number of cells between columns and between rows.
+	columnGap, rowGap int
+
+	// Closed by [Dashboard.Stop] to end the refresh goroutines, or nil if
+	// they are not running.
+	stop chan struct{}
+
+	// Synchronizes starting and stopping the refresh goroutines.
+	stopMutex sync.Mutex
+}
+
+// NewDashboard returns a new dashboard without tiles. Columns are at least 20
+// cells wide and the rows share the available height.
+func NewDashboard() *Dashboard {
+	d := &Dashboard{
+		Box:       NewBox(),
+		tileWidth: 20,
+	}
+	d.dontClear = true
+	return d
+}
+
+// SetTileSize sets the minimum width of a column and the height of a row,
+// including the tiles' frames. The number of columns is the number of columns
+// of the minimum width which fit into the dashboard. If the height is 0 (the
+// default), the rows share the available height evenly. Otherwise, rows which
+// do not fit are not drawn.
+func (d *Dashboard) SetTileSize(minWidth, height int) *Dashboard {
+	d.tileWidth, d.tileHeight = max(minWidth, 1), max(height, 0)
+	return d
+}
+
+// SetGap sets the number of cells between columns and between rows.
+func (d *Dashboard) SetGap(column, row int) *Dashboard {
+	d.columnGap, d.rowGap = max(column, 0), max(row, 0)
+	return d
+}
+
+// AddTile adds a tile with the given title and content which spans the given
+// number of columns. Spans larger than the number of columns are reduced to
+// the number of columns.
+func (d *Dashboard) AddTile(title string, item Primitive, span int) *Dashboard {
+	frame := &dashboardFrame{Box: NewBox(), item: item}
+	frame.SetBorders(BordersAll).SetTitle(title).SetFocusedBorderSet(BorderSetThick())
+	d.tiles = append(d.tiles, &dashboardTile{
+		item:  item,
+		frame: frame,
+		span:  max(span, 1),
+	})
+	return d
+}
+
+// RemoveTile removes the tile with the given content.
+func (d *Dashboard) RemoveTile(item Primitive) *Dashboard {
+	if index := d.tileIndex(item); index >= 0 {
+		d.tiles = append(d.tiles[:index], d.tiles[index+1:]...)
+	}
+	return d
+}
+
+// GetTileCount returns the number of tiles.
+func (d *Dashboard) GetTileCount() int {
+	return len(d.tiles)
+}
+
+// GetTile returns the content of the tile with the given index, or nil if
+// there is no such tile.
+func (d *Dashboard) GetTile(index int) Primitive {
+	if index < 0 || index >= len(d.tiles) {
+		return nil
+	}
+	return d.tiles[index].item
+}
+
+// GetTileFrame returns the box drawn around the tile with the given content,
+// e.g. to change its title or border style, or nil if there is no such tile.
+func (d *Dashboard) GetTileFrame(item Primitive) *Box {
+	if index := d.tileIndex(item); index >= 0 {
+		return d.tiles[index].frame.Box
+	}
+	return nil
+}
+
+// Clear removes all tiles.
+func (d *Dashboard) Clear() *Dashboard {
+	d.tiles = nil
+	return d
+}
+
+// SetTileRefreshFunc sets a function which refreshes the tile with the given
+// content, e.g. by setting a gauge's value. After [Dashboard.Start], it is
+// called in the given interval from the application's event loop, followed by
+// a redraw. It is also called by [Dashboard.Refresh]. Changes take effect the
+// next time the dashboard is started.
+func (d *Dashboard) SetTileRefreshFunc(item Primitive, interval time.Duration, refresh func()) *Dashboard {
+	if index := d.tileIndex(item); index >= 0 {
+		d.tiles[index].refresh = refresh
+		d.tiles[index].interval = interval
+	}
+	return d
+}
+
+// Refresh calls the refresh functions of all tiles (see
+// [Dashboard.SetTileRefreshFunc]). It must be called from the application's
+// event loop, e.g. once before the application is started.
+func (d *Dashboard) Refresh() *Dashboard {
+	for _, tile := range d.tiles {
+		if tile.refresh != nil {
+			tile.refresh()
+		}
+	}
+	return d
+}
+
+// Start starts calling the tiles' refresh functions periodically (see
+// [Dashboard.SetTileRefreshFunc]) using [Application.QueueUpdateDraw]. In
+// reduced-motion mode (see [Application.SetReducedMotion]), the intervals are
+// at least [ReducedMotionRefreshInterval]. Call [Dashboard.Stop] before the
+// application stops. Starting a dashboard which is already running restarts
+// it.
+func (d *Dashboard) Start(app *Application) *Dashboard {
+	d.Stop()
+	d.stopMutex.Lock()
+	defer d.stopMutex.Unlock()
+	stop := make(chan struct{})
+	d.stop = stop
+	for _, tile := range d.tiles {
+		if tile.refresh == nil || tile.interval <= 0 {
+			continue
+		}
+		go func(refresh func(), interval time.Duration) {
+			interval = app.refreshInterval(interval)
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-stop:
+					return
+				case <-ticker.C:
+					app.QueueUpdateDraw(refresh)
+				}
+			}
+		}(tile.refresh, tile.interval)
+	}
+	return d
+}
+
+// Stop stops calling the tiles' refresh functions periodically.
+func (d *Dashboard) Stop() *Dashboard {
+	d.stopMutex.Lock()
+	defer d.stopMutex.Unlock()
+	if d.stop != nil {
+		close(d.stop)
+		d.stop = nil
+	}
+	return d
+}
+
+// tileIndex returns the index of the tile with the given content, or -1 if
+// there is none.
+func (d *Dashboard) tileIndex(item Primitive) int {
+	for index, tile := range d.tiles {
+		if tile.item == item {
+			return index
+		}
+	}
+	return -1
+}
+
+// layout positions the tiles' frames and contents. Tiles which do not fit are
+// given an empty rectangle.
+func (d *Dashboard) layout() {
+	x, y, width, height := d.GetInnerRect()
+	columns := max((width+d.columnGap)/(d.tileWidth+d.columnGap), 1)
+
+	// Place the tiles row by row.
+	type placement struct{ row, column, span int }
+	placements := make([]placement, len(d.tiles))
+	var row, column int
+	for index, tile := range d.tiles {
+		span := min(tile.span, columns)
+		if column+span > columns {
+			row, column = row+1, 0
+		}
+		placements[index] = placement{row: row, column: column, span: span}
+		column += span
+	}
+	rows := row + 1
+
+	columnOffsets, widths := gridLayout(width, columns, d.columnGap)
+	var rowOffsets, heights []int
+	if d.tileHeight > 0 {
+		rowOffsets, heights = make([]int, rows), make([]int, rows)
+		for row := range rows {
+			rowOffsets[row], heights[row] = row*(d.tileHeight+d.rowGap), d.tileHeight
+		}
+	} else {
+		rowOffsets, heights = gridLayout(height, rows, d.rowGap)
+	}
+
+	for index, tile := range d.tiles {
+		p := placements[index]
+		last := p.column + p.span - 1
+		tileX, tileY := x+columnOffsets[p.column], y+rowOffsets[p.row]
+		tileWidth := columnOffsets[last] + widths[last] - columnOffsets[p.column]
+		tileHeight := heights[p.row]
+		if rowOffsets[p.row]+tileHeight > height {
+			tileWidth, tileHeight = 0, 0
+		}
+		tile.frame.SetRect(tileX, tileY, tileWidth, tileHeight)
+		tile.item.SetRect(tile.frame.GetInnerRect())
+	}
+}
+
+// Draw draws this primitive onto the screen.
+func (d *Dashboard) Draw(screen tcell.Screen) {
+	d.DrawForSubclass(screen, d)
+	d.layout()
+	for _, tile := range d.tiles {
+		if _, _, width, height := tile.frame.GetRect(); width <= 0 || height <= 0 {
+			continue
+		}
+		tile.frame.DrawForSubclass(screen, tile.frame)
+		tile.item.Draw(screen)
+	}
+}
+
+// Focus is called when this primitive receives focus. The focus is passed to
+// the first tile.
+func (d *Dashboard) Focus(delegate func(p Primitive)) {
+	if len(d.tiles) > 0 {
+		delegate(d.tiles[0].item)
+		return
+	}
+	d.Box.Focus(delegate)
+}
+
+// HasFocus returns whether or not this primitive has focus.
+func (d *Dashboard) HasFocus() bool {
+	return d.focusedTile() >= 0 || d.Box.HasFocus()
+}
+
+// focusedTile returns the index of the tile which has focus, or -1 if there
+// is none.
+func (d *Dashboard) focusedTile() int {
+	for index, tile := range d.tiles {
+		if tile.item.HasFocus() {
+			return index
+		}
+	}
+	return -1
+}
+
+// HandleEvent handles input events for this primitive.
+func (d *Dashboard) HandleEvent(event tcell.Event) Command {
+	switch event := event.(type) {
+	case *MouseEvent:
+		x, y := event.Position()
+		if !d.InRect(x, y) {
+			return nil
+		}
+		for _, tile := range d.tiles {
+			if !tile.frame.InRect(x, y) {
+				continue
+			}
+			if command := tile.item.HandleEvent(event); command != nil {
+				return command
+			}
+
+			// Clicks on the frame focus the tile.
+			if event.Action == MouseLeftDown {
+				return BatchCommand{SetFocusCommand{Target: tile.item}, RedrawCommand{}}
+			}
+			return nil
+		}
+	case *KeyEvent, *PasteEvent:
+		focused := d.focusedTile()
+		if focused < 0 {
+			return nil
+		}
+		if command := d.tiles[focused].item.HandleEvent(event); command != nil {
+			return command
+		}
+		if key, ok := event.(*KeyEvent); ok {
+			switch key.Key() {
+			case tcell.KeyTab:
+				focused = (focused + 1) % len(d.tiles)
+			case tcell.KeyBacktab:
+				focused = (focused + len(d.tiles) - 1) % len(d.tiles)
+			default:
+				return nil
+			}
+			return BatchCommand{SetFocusCommand{Target: d.tiles[focused].item}, RedrawCommand{}}
+		}
+	}
+	return nil
+}
+
+// Inspect describes this primitive in the inspection tree. See [Inspect].
+func (d *Dashboard) Inspect(node *InspectNode) {
+	d.Box.Inspect(node)
+	for _, tile := range d.tiles {
+		if child := node.AddPrimitive(tile.item); child != nil && child.Label == "" {
+			child.Label = tile.frame.GetTitle()
+		}
+	}
+}
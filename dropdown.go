@@ -0,0 +1,760 @@
+package tview
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+// dropDownOption is an option of a drop-down.
+type dropDownOption struct {
+	// The option's text.
+	text string
+
+	// The styled text shown in the list, if it has segments.
+	line Line
+
+	// Whether the option is checked in multi-select mode.
+	checked bool
+}
+
+// DropDown implements a selection widget whose options become visible in a
+// drop-down list when it is activated. Options are plain text or styled
+// lines, see [DropDown.AddOptionLine].
+//
+// By default, the user selects one option. In multi-select mode (see
+// [DropDown.SetMultiSelect]), options are shown with checkboxes and the user
+// can check any number of them.
+//
+// The following keys are available while the list is closed:
+//
+//   - Enter, Space, Down arrow: Open the list.
+//   - Any other character: Open the list, filtered by the character.
+//   - Tab, Backtab, Escape: Finish, e.g. to move to the next form item.
+//
+// While the list is open:
+//
+//   - Up arrow / Down arrow: Highlight the previous/next option.
+//   - Home / End: Highlight the first/last option.
+//   - Page Up / Page Down: Move the highlight by one page.
+//   - Characters: Filter the options by the typed text, ignoring letter case
+//     and diacritics.
+//   - Backspace: Delete the last character of the filter.
+//   - Enter: Select the highlighted option and close the list. In
+//     multi-select mode, close the list.
+//   - Space: Select the highlighted option and close the list. In
+//     multi-select mode, check or uncheck the highlighted option. While a
+//     filter is typed, a space is added to the filter.
+//   - Escape: Clear the filter or, without a filter, close the list.
+//
+// Clicking the field opens and closes the list, clicking an option selects,
+// or in multi-select mode checks or unchecks, it.
+type DropDown struct {
+	*Box
+
+	// Whether or not this drop-down is disabled/read-only.
+	disabled bool
+
+	// The options from which the user can choose.
+	options []dropDownOption
+
+	// The index of the currently selected option, or -1 if no option is
+	// selected. Not used in multi-select mode.
+	current int
+
+	// Whether the user can check multiple options.
+	multiSelect bool
+
+	// The strings shown before checked and unchecked options in multi-select
+	// mode.
+	checkedString, uncheckedString string
+
+	// The text to be displayed before the input area.
+	label string
+
+	// The screen width of the label area. A value of 0 means use the width of
+	// the label text.
+	labelWidth int
+
+	// The screen width of the input area. A value of 0 means use the width of
+	// the widest option.
+	fieldWidth int
+
+	// The maximum number of rows of the open list.
+	maxHeight int
+
+	// The label style.
+	labelStyle tcell.Style
+
+	// The style of the input area and of the input area when it has focus.
+	fieldStyle, focusStyle tcell.Style
+
+	// The styles of the list's options and of the highlighted option.
+	listStyle, listSelectedStyle tcell.Style
+
+	// Whether the list is open.
+	open bool
+
+	// The text typed to filter the options while the list is open.
+	filter string
+
+	// The indices of the options which match the filter.
+	filtered []int
+
+	// The index of the highlighted option and of the first option shown in
+	// the open list, both in the list of filtered options.
+	highlighted, listOffset int
+
+	// The screen area of the list as of the last draw.
+	listX, listY, listWidth, listHeight int
+
+	// Whether the drop-down requested to capture the mouse.
+	captured bool
+
+	// An optional function which is called when the user selects an option.
+	selected func(text string, index int)
+
+	// An optional function which is called when the user checks or unchecks
+	// an option in multi-select mode.
+	checked func(text string, index int, checked bool)
+
+	// An optional function which is called when the user indicated that they
+	// are done selecting options. The key which was pressed is provided (tab,
+	// shift-tab, or escape).
+	done func(tcell.Key)
+
+	// A callback function set by the Form class and called when the user leaves
+	// this form item.
+	finished func(tcell.Key)
+}
+
+// NewDropDown returns a new drop-down without options.
+func NewDropDown() *DropDown {
+	return &DropDown{
+		Box:               NewBox(),
+		current:           -1,
+		checkedString:     "[x] ",
+		uncheckedString:   "[ ] ",
+		maxHeight:         10,
+		labelStyle:        tcell.StyleDefault.Foreground(Styles.SecondaryTextColor),
+		fieldStyle:        tcell.StyleDefault.Background(Styles.ContrastBackgroundColor).Foreground(Styles.PrimaryTextColor),
+		focusStyle:        tcell.StyleDefault.Background(Styles.PrimaryTextColor).Foreground(Styles.ContrastBackgroundColor),
+		listStyle:         tcell.StyleDefault.Background(Styles.MoreContrastBackgroundColor).Foreground(Styles.PrimitiveBackgroundColor),
+		listSelectedStyle: tcell.StyleDefault.Background(Styles.PrimaryTextColor).Foreground(Styles.PrimitiveBackgroundColor),
+	}
+}
+
+// applyTheme updates the drop-down's default styles after a theme change.
+func (d *DropDown) applyTheme(change themeChange) {
+	d.Box.applyTheme(change)
+	d.labelStyle = change.style(d.labelStyle, themeSecondaryText, 0)
+	d.fieldStyle = change.style(d.fieldStyle, themePrimaryText, themeContrastBackground)
+	d.focusStyle = change.style(d.focusStyle, themeContrastBackground, themePrimaryText)
+	d.listStyle = change.style(d.listStyle, themePrimitiveBackground, themeMoreContrastBackground)
+	d.listSelectedStyle = change.style(d.listSelectedStyle, themePrimitiveBackground, themePrimaryText)
+}
+
+// SetOptions replaces all options with the given texts and sets a handler
+// which is called when the user selects an option (see
+// [DropDown.SetSelectedFunc]). The selection is cleared.
+func (d *DropDown) SetOptions(texts []string, selected func(text string, index int)) *DropDown {
+	d.options = d.options[:0]
+	for _, text := range texts {
+		d.options = append(d.options, dropDownOption{text: text})
+	}
+	d.current = -1
+	d.selected = selected
+	d.updateFilter()
+	return d
+}
+
+// AddOption adds an option to the end of the list of options.
+func (d *DropDown) AddOption(text string) *DropDown {
+	d.options = append(d.options, dropDownOption{text: text})
+	d.updateFilter()
+	return d
+}
+
+// AddOptionLine adds an option with styled text to the end of the list of
+// options. The segments' styles are merged over the list's styles, so they
+// only need to specify what differs, e.g. a foreground color. The option's
+// text, as used for filtering and returned by [DropDown.GetText], is the
+// line's unstyled text.
+func (d *DropDown) AddOptionLine(line Line) *DropDown {
+	d.options = append(d.options, dropDownOption{text: line.String(), line: line.Clone()})
+	d.updateFilter()
+	return d
+}
+
+// ClearOptions removes all options.
+func (d *DropDown) ClearOptions() *DropDown {
+	d.options = nil
+	d.current = -1
+	d.updateFilter()
+	return d
+}
+
+// GetOptionCount returns the number of options.
+func (d *DropDown) GetOptionCount() int {
+	return len(d.options)
+}
+
+// GetOption returns the text of the option at the given index.
+func (d *DropDown) GetOption(index int) string {
+	return d.options[index].text
+}
+
+// SetCurrentOption selects the option at the given index. A negative index
+// clears the selection. This triggers the "selected" callback if an option is
+// selected. In multi-select mode, use [DropDown.SetOptionChecked] instead.
+func (d *DropDown) SetCurrentOption(index int) *DropDown {
+	if index < 0 || index >= len(d.options) {
+		d.current = -1
+		return d
+	}
+	d.current = index
+	if d.selected != nil {
+		d.selected(d.options[index].text, index)
+	}
+	return d
+}
+
+// GetCurrentOption returns the index and the text of the selected option, or
+// -1 and an empty string if no option is selected.
+func (d *DropDown) GetCurrentOption() (int, string) {
+	if d.current < 0 || d.current >= len(d.options) {
+		return -1, ""
+	}
+	return d.current, d.options[d.current].text
+}
+
+// SetMultiSelect sets whether the user can check any number of options
+// instead of selecting one. Checked options are shown with the strings set
+// with [DropDown.SetCheckedStrings].
+func (d *DropDown) SetMultiSelect(multiSelect bool) *DropDown {
+	d.multiSelect = multiSelect
+	return d
+}
+
+// IsMultiSelect returns whether the drop-down is in multi-select mode.
+func (d *DropDown) IsMultiSelect() bool {
+	return d.multiSelect
+}
+
+// SetCheckedStrings sets the strings shown before checked and unchecked
+// options in multi-select mode. They should have the same width. The defaults
+// are "[x] " and "[ ] ".
+func (d *DropDown) SetCheckedStrings(checked, unchecked string) *DropDown {
+	d.checkedString, d.uncheckedString = checked, unchecked
+	return d
+}
+
+// SetOptionChecked checks or unchecks the option at the given index for
+// multi-select mode. This triggers the "checked" callback if the state
+// changes.
+func (d *DropDown) SetOptionChecked(index int, checked bool) *DropDown {
+	if index < 0 || index >= len(d.options) || d.options[index].checked == checked {
+		return d
+	}
+	d.options[index].checked = checked
+	if d.checked != nil {
+		d.checked(d.options[index].text, index, checked)
+	}
+	return d
+}
+
+// GetCheckedOptions returns the indices of the checked options, in order.
+func (d *DropDown) GetCheckedOptions() []int {
+	var indices []int
+	for index, option := range d.options {
+		if option.checked {
+			indices = append(indices, index)
+		}
+	}
+	return indices
+}
+
+// GetText returns the text of the selected option, or an empty string if no
+// option is selected. In multi-select mode, it returns the texts of the
+// checked options, separated by commas.
+func (d *DropDown) GetText() string {
+	if d.multiSelect {
+		var texts []string
+		for _, index := range d.GetCheckedOptions() {
+			texts = append(texts, d.options[index].text)
+		}
+		return strings.Join(texts, ", ")
+	}
+	_, text := d.GetCurrentOption()
+	return text
+}
+
+// SetLabel sets the text to be displayed before the input area.
+func (d *DropDown) SetLabel(label string) *DropDown {
+	d.label = label
+	return d
+}
+
+// GetLabel returns the text to be displayed before the input area.
+func (d *DropDown) GetLabel() string {
+	return d.label
+}
+
+// SetLabelWidth sets the screen width of the label. A value of 0 will cause the
+// primitive to use the width of the label string.
+func (d *DropDown) SetLabelWidth(width int) *DropDown {
+	d.labelWidth = width
+	return d
+}
+
+// SetLabelStyle sets the style of the label.
+func (d *DropDown) SetLabelStyle(style tcell.Style) *DropDown {
+	d.labelStyle = style
+	return d
+}
+
+// SetFieldWidth sets the screen width of the input area. A value of 0 means
+// that the input area is as wide as the widest option plus the arrow.
+func (d *DropDown) SetFieldWidth(width int) *DropDown {
+	d.fieldWidth = width
+	return d
+}
+
+// SetFieldStyle sets the style of the input area.
+func (d *DropDown) SetFieldStyle(style tcell.Style) *DropDown {
+	d.fieldStyle = style
+	return d
+}
+
+// SetActivatedStyle sets the style of the input area while the drop-down has
+// focus.
+func (d *DropDown) SetActivatedStyle(style tcell.Style) *DropDown {
+	d.focusStyle = style
+	return d
+}
+
+// SetListStyles sets the styles of the options in the drop-down list and of
+// the highlighted option.
+func (d *DropDown) SetListStyles(unselected, selected tcell.Style) *DropDown {
+	d.listStyle, d.listSelectedStyle = unselected, selected
+	return d
+}
+
+// SetMaxHeight sets the maximum number of rows of the open list. The list is
+// shown below the input area or, if there is more space above the input area
+// than below it and the list doesn't fit below, above it. The default is 10.
+func (d *DropDown) SetMaxHeight(height int) *DropDown {
+	d.maxHeight = max(height, 1)
+	return d
+}
+
+// SetSelectedFunc sets a handler which is called when the user selects an
+// option. It receives the option's text and index.
+func (d *DropDown) SetSelectedFunc(handler func(text string, index int)) *DropDown {
+	d.selected = handler
+	return d
+}
+
+// SetCheckedFunc sets a handler which is called when the user checks or
+// unchecks an option in multi-select mode. It receives the option's text,
+// index, and new state.
+func (d *DropDown) SetCheckedFunc(handler func(text string, index int, checked bool)) *DropDown {
+	d.checked = handler
+	return d
+}
+
+// SetDoneFunc sets a handler which is called when the user is done selecting
+// options. The callback function is provided with the key that was pressed,
+// which is one of the following:
+//
+//   - KeyEscape: Abort selection.
+//   - KeyTab: Move to the next field.
+//   - KeyBacktab: Move to the previous field.
+func (d *DropDown) SetDoneFunc(handler func(key tcell.Key)) *DropDown {
+	d.done = handler
+	return d
+}
+
+// SetFinishedFunc sets a callback invoked when the user leaves this form item.
+func (d *DropDown) SetFinishedFunc(handler func(key tcell.Key)) FormItem {
+	d.finished = handler
+	return d
+}
+
+// SetFormAttributes sets attributes shared by all form items.
+func (d *DropDown) SetFormAttributes(labelWidth int, labelColor, bgColor, fieldTextColor, fieldBgColor tcell.Color) FormItem {
+	d.labelWidth = labelWidth
+	d.labelStyle = d.labelStyle.Foreground(labelColor)
+	d.backgroundColor = bgColor
+	d.fieldStyle = d.fieldStyle.Foreground(fieldTextColor).Background(fieldBgColor)
+	d.focusStyle = d.focusStyle.Foreground(fieldBgColor).Background(fieldTextColor)
+	return d
+}
+
+// GetFieldWidth returns this primitive's field width.
+func (d *DropDown) GetFieldWidth() int {
+	if d.fieldWidth > 0 {
+		return d.fieldWidth
+	}
+	width := 1
+	for _, option := range d.options {
+		width = max(width, TaggedStringWidth(option.text))
+	}
+	return width + 2 // Space and arrow.
+}
+
+// GetFieldHeight returns this primitive's field height.
+func (d *DropDown) GetFieldHeight() int {
+	return 1
+}
+
+// SetDisabled sets whether or not the item is disabled / read-only.
+func (d *DropDown) SetDisabled(disabled bool) FormItem {
+	d.disabled = disabled
+	if disabled {
+		d.open = false
+	}
+	if d.finished != nil {
+		d.finished(-1)
+	}
+	return d
+}
+
+// GetDisabled returns whether or not the item is disabled / read-only.
+func (d *DropDown) GetDisabled() bool {
+	return d.disabled
+}
+
+// IsOpen returns whether the drop-down list is open.
+func (d *DropDown) IsOpen() bool {
+	return d.open
+}
+
+// Focus is called when this primitive receives focus.
+func (d *DropDown) Focus(delegate func(p Primitive)) {
+	// If we're part of a form and this item is disabled, there's nothing the
+	// user can do here so we're finished.
+	if d.finished != nil && d.disabled {
+		d.finished(-1)
+		return
+	}
+	d.Box.Focus(delegate)
+}
+
+// Blur is called when this primitive loses focus.
+func (d *DropDown) Blur() {
+	d.open = false
+	d.Box.Blur()
+}
+
+// updateFilter determines the options which match the filter and keeps the
+// highlight within them.
+func (d *DropDown) updateFilter() {
+	d.filtered = d.filtered[:0]
+	filter := Fold(d.filter)
+	for index, option := range d.options {
+		if filter == "" || strings.Contains(Fold(option.text), filter) {
+			d.filtered = append(d.filtered, index)
+		}
+	}
+	d.highlighted = max(min(d.highlighted, len(d.filtered)-1), 0)
+}
+
+// Draw draws this primitive onto the screen. The open list is drawn below or
+// above the input area, outside the primitive's rectangle.
+func (d *DropDown) Draw(screen tcell.Screen) {
+	d.DrawForSubclass(screen, d)
+	x, y, width, height := d.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	// Draw the label and the input area.
+	x, width = printLabel(screen, d.label, d.labelWidth, d.labelStyle, x, y, width)
+	fieldWidth := min(d.GetFieldWidth(), width)
+	if fieldWidth <= 0 {
+		return
+	}
+	style := d.fieldStyle
+	if d.disabled {
+		style = style.Background(d.backgroundColor)
+	}
+	open := d.open && d.HasFocus()
+	if d.HasFocus() && !open {
+		style = d.focusStyle
+	}
+	for column := range fieldWidth {
+		screen.Put(x+column, y, " ", style)
+	}
+	text := d.GetText()
+	if open && d.filter != "" {
+		skip := max(TaggedStringWidth(d.filter)-(fieldWidth-2), 0) // Keep the end of the filter in view.
+		printWithStyle(screen, d.filter, x, y, skip, max(fieldWidth-2, 0), AlignmentLeft, style.Underline(true), false)
+	} else if d.current >= 0 && !d.multiSelect && len(d.options[d.current].line.Segments) > 0 {
+		printLine(screen, d.options[d.current].line, x, y, max(fieldWidth-2, 0), AlignmentLeft, style, false)
+	} else {
+		printWithStyle(screen, text, x, y, 0, max(fieldWidth-2, 0), AlignmentLeft, style, false)
+	}
+	if fieldWidth > 1 && !d.disabled {
+		screen.Put(x+fieldWidth-1, y, "▼", style)
+	}
+
+	// Determine the area of the list.
+	d.listHeight = 0
+	if !open {
+		return
+	}
+	screenWidth, screenHeight := screen.Size()
+	listHeight := min(len(d.filtered), d.maxHeight)
+	below, above := screenHeight-y-1, y
+	if listHeight > below && above > below {
+		d.listHeight = min(listHeight, above)
+		d.listY = y - d.listHeight
+	} else {
+		d.listHeight = min(listHeight, below)
+		d.listY = y + 1
+	}
+	d.listX = x
+	d.listWidth = min(max(fieldWidth, d.GetFieldWidth()), screenWidth-x)
+	if d.multiSelect {
+		d.listWidth = min(d.listWidth+TaggedStringWidth(d.checkedString), screenWidth-x)
+	}
+	if d.listWidth <= 0 || d.listHeight <= 0 {
+		d.listHeight = 0
+		return
+	}
+
+	// Draw the list.
+	d.scrollToHighlighted()
+	for row := range d.listHeight {
+		index := d.listOffset + row
+		option := d.options[d.filtered[index]]
+		style := d.listStyle
+		if index == d.highlighted {
+			style = d.listSelectedStyle
+		}
+		for column := range d.listWidth {
+			screen.Put(d.listX+column, d.listY+row, " ", style)
+		}
+		optionX, optionWidth := d.listX, d.listWidth
+		if d.multiSelect {
+			check := d.uncheckedString
+			if option.checked {
+				check = d.checkedString
+			}
+			_, _, checkWidth := printWithStyle(screen, check, optionX, d.listY+row, 0, optionWidth, AlignmentLeft, style, false)
+			optionX, optionWidth = optionX+checkWidth, optionWidth-checkWidth
+		}
+		if len(option.line.Segments) > 0 {
+			printLine(screen, option.line, optionX, d.listY+row, optionWidth, AlignmentLeft, style, false)
+		} else {
+			printWithStyle(screen, option.text, optionX, d.listY+row, 0, optionWidth, AlignmentLeft, style, false)
+		}
+	}
+}
+
+// scrollToHighlighted adjusts the list offset so that the highlighted option
+// is visible, based on the list height of the last draw.
+func (d *DropDown) scrollToHighlighted() {
+	height := max(d.listHeight, 1)
+	if d.highlighted < d.listOffset {
+		d.listOffset = d.highlighted
+	} else if d.highlighted >= d.listOffset+height {
+		d.listOffset = d.highlighted - height + 1
+	}
+	d.listOffset = max(min(d.listOffset, len(d.filtered)-height), 0)
+}
+
+// openList opens the drop-down list with the given filter and returns the
+// resulting command.
+func (d *DropDown) openList(filter string) Command {
+	if len(d.options) == 0 {
+		return nil
+	}
+	d.open = true
+	d.filter = filter
+	d.highlighted, d.listOffset = 0, 0
+	d.updateFilter()
+	if filter == "" && d.current >= 0 && !d.multiSelect {
+		d.highlighted = d.current
+	}
+	d.captured = true
+	return BatchCommand{SetMouseCaptureCommand{Target: d}, RedrawCommand{}}
+}
+
+// closeList closes the drop-down list and returns the resulting command.
+func (d *DropDown) closeList() Command {
+	d.open = false
+	d.filter = ""
+	d.updateFilter()
+	d.captured = false
+	return BatchCommand{SetMouseCaptureCommand{Target: nil}, RedrawCommand{}}
+}
+
+// activateHighlighted selects the highlighted option and closes the list or,
+// in multi-select mode, checks or unchecks it. It returns the resulting
+// command.
+func (d *DropDown) activateHighlighted() Command {
+	if d.highlighted < 0 || d.highlighted >= len(d.filtered) {
+		return RedrawCommand{}
+	}
+	index := d.filtered[d.highlighted]
+	if d.multiSelect {
+		d.SetOptionChecked(index, !d.options[index].checked)
+		return RedrawCommand{}
+	}
+	cmd := d.closeList()
+	d.SetCurrentOption(index)
+	return cmd
+}
+
+// HandleEvent handles input events for this primitive.
+func (d *DropDown) HandleEvent(event tcell.Event) Command {
+	if d.disabled {
+		return nil
+	}
+
+	switch event := event.(type) {
+	case *KeyEvent:
+		key := event.Key()
+		if !d.open {
+			switch key {
+			case tcell.KeyEnter, tcell.KeyDown:
+				return d.openList("")
+			case tcell.KeyRune:
+				if event.Modifiers()&(tcell.ModCtrl|tcell.ModAlt|tcell.ModMeta) != 0 {
+					return nil
+				}
+				if event.Str() == " " {
+					return d.openList("")
+				}
+				return d.openList(event.Str())
+			case tcell.KeyTab, tcell.KeyBacktab, tcell.KeyEscape:
+				if d.done != nil {
+					d.done(key)
+				}
+				if d.finished != nil {
+					d.finished(key)
+				}
+				return RedrawCommand{}
+			}
+			return nil
+		}
+		last := len(d.filtered) - 1
+		switch key {
+		case tcell.KeyUp:
+			d.highlighted = max(d.highlighted-1, 0)
+		case tcell.KeyDown:
+			d.highlighted = max(min(d.highlighted+1, last), 0)
+		case tcell.KeyHome:
+			d.highlighted = 0
+		case tcell.KeyEnd:
+			d.highlighted = max(last, 0)
+		case tcell.KeyPgUp:
+			d.highlighted = max(d.highlighted-max(d.listHeight, 1), 0)
+		case tcell.KeyPgDn:
+			d.highlighted = max(min(d.highlighted+max(d.listHeight, 1), last), 0)
+		case tcell.KeyEnter:
+			if d.multiSelect {
+				return d.closeList()
+			}
+			return d.activateHighlighted()
+		case tcell.KeyRune:
+			if event.Modifiers()&(tcell.ModCtrl|tcell.ModAlt|tcell.ModMeta) != 0 {
+				return nil
+			}
+			if event.Str() == " " && d.filter == "" {
+				return d.activateHighlighted()
+			}
+			d.filter += event.Str()
+			d.highlighted, d.listOffset = 0, 0
+			d.updateFilter()
+		case tcell.KeyBackspace, tcell.KeyBackspace2:
+			if d.filter == "" {
+				return nil
+			}
+			_, size := utf8.DecodeLastRuneInString(d.filter)
+			d.filter = d.filter[:len(d.filter)-size]
+			d.updateFilter()
+		case tcell.KeyEscape:
+			if d.filter == "" {
+				return d.closeList()
+			}
+			d.filter = ""
+			d.updateFilter()
+		case tcell.KeyTab, tcell.KeyBacktab:
+			cmd := d.closeList()
+			if d.done != nil {
+				d.done(key)
+			}
+			if d.finished != nil {
+				d.finished(key)
+			}
+			return cmd
+		default:
+			return nil
+		}
+		d.scrollToHighlighted()
+		return RedrawCommand{}
+	case *MouseEvent:
+		x, y := event.Position()
+		if !d.open {
+			if d.captured {
+				// The list was closed without releasing the mouse, e.g. when
+				// the drop-down lost focus.
+				d.captured = false
+				return SetMouseCaptureCommand{Target: nil}
+			}
+			if !d.InRect(x, y) {
+				return nil
+			}
+			switch event.Action {
+			case MouseLeftDown:
+				return SetFocusCommand{Target: d}
+			case MouseLeftClick:
+				return d.openList("")
+			}
+			return nil
+		}
+
+		// The list is open.
+		inList := x >= d.listX && x < d.listX+d.listWidth && y >= d.listY && y < d.listY+d.listHeight
+		switch event.Action {
+		case MouseMove:
+			if inList {
+				d.highlighted = d.listOffset + y - d.listY
+			}
+		case MouseLeftClick:
+			if inList {
+				d.highlighted = d.listOffset + y - d.listY
+				cmd := d.activateHighlighted()
+				if d.open {
+					// Multi-selection keeps the list open.
+					return BatchCommand{cmd, SetMouseCaptureCommand{Target: d}}
+				}
+				return cmd
+			}
+			return d.closeList()
+		case MouseScrollUp:
+			if inList {
+				d.listOffset = max(d.listOffset-1, 0)
+			}
+		case MouseScrollDown:
+			if inList {
+				d.listOffset = max(min(d.listOffset+1, len(d.filtered)-d.listHeight), 0)
+			}
+		}
+		return BatchCommand{SetMouseCaptureCommand{Target: d}, RedrawCommand{}}
+	}
+	return nil
+}
+
+// Inspect describes this primitive in the inspection tree. See [Inspect].
+func (d *DropDown) Inspect(node *InspectNode) {
+	node.Role = RoleCombobox
+	node.Label = d.label
+	node.Value = d.GetText()
+	node.Expanded = d.open
+	node.Disabled = d.disabled
+}
@@ -258,3 +258,11 @@ func (f *Flex) HandleEvent(event tcell.Event) Command {
 	}
 	return nil
 }
+
+// Inspect describes this primitive in the inspection tree. See [Inspect].
+func (f *Flex) Inspect(node *InspectNode) {
+	f.Box.Inspect(node)
+	for _, item := range f.items {
+		node.AddPrimitive(item.Item)
+	}
+}
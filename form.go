@@ -1,7 +1,10 @@
 package tview
 
 import (
+	"maps"
 	"slices"
+	"strings"
+	"time"
 
 	"github.com/gdamore/tcell/v3"
 )
@@ -17,6 +20,23 @@ var (
 	DefaultFormFieldHeight = 5
 )
 
+// FormKeyAction is the action a [Form] takes when the user finishes a form item
+// with a certain key.
+type FormKeyAction int
+
+// Available form key actions.
+const (
+	// FormKeyActionDefault is the built-in behavior: Tab and Enter move to the
+	// next item (Enter submits on the submit item, see [Form.SetSubmitItem]),
+	// Backtab moves to the previous item, and Escape cancels the form.
+	FormKeyActionDefault FormKeyAction = iota
+	FormKeyActionNext
+	FormKeyActionPrevious
+	FormKeyActionSubmit
+	FormKeyActionCancel
+	FormKeyActionIgnore
+)
+
 // FormItem is the interface all form items must implement to be able to be
 // included in a form.
 type FormItem interface {
@@ -54,8 +74,34 @@ type FormItem interface {
 	GetDisabled() bool
 }
 
-// Form allows you to combine multiple one-line form elements into a vertical
-// or horizontal layout. Form elements include types such as InputField or
+// FieldError is the validation error of a form item, see [Form.Validate].
+type FieldError struct {
+	// The index of the form item.
+	Index int
+
+	// The label of the form item.
+	Label string
+
+	// The error returned by the form item's validation.
+	Err error
+}
+
+// Error returns the item's label followed by the error message.
+func (e FieldError) Error() string {
+	label := strings.TrimRight(e.Label, ": ")
+	if label == "" {
+		return e.Err.Error()
+	}
+	return label + ": " + e.Err.Error()
+}
+
+// Unwrap returns the item's validation error.
+func (e FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Form allows you to combine multiple one-line form elements into a vertical,
+// horizontal, or grid layout. Form elements include types such as InputField or
 // Checkbox. These elements can be optionally followed by one or more buttons
 // for which you can define form-wide actions (e.g. Save, Clear, Cancel).
 //
@@ -73,6 +119,10 @@ type Form struct {
 	// they are positioned from left to right.
 	horizontal bool
 
+	// If set to true, buttons are placed one per row instead of next to each
+	// other in vertical layouts.
+	stackButtons bool
+
 	// The alignment of the buttons.
 	buttonsAlignment Alignment
 
@@ -109,6 +159,78 @@ type Form struct {
 
 	// An optional function which is called when the user hits Escape.
 	cancel func()
+
+	// An optional function which is called when the user submits the form.
+	submit func()
+
+	// The index of the form item on which Enter submits the form. A negative
+	// value refers to the last enabled form item.
+	submitItem int
+
+	// The actions taken for keys finishing form items, overriding the default
+	// behavior.
+	keyActions map[tcell.Key]FormKeyAction
+
+	// The struct fields bound to form items, see BindStruct.
+	bindings []formBinding
+
+	// The validators attached to form items and the errors they returned the
+	// last time they were called, see SetItemValidator.
+	validators map[FormItem]Validator
+	itemErrors map[FormItem]error
+
+	// The color of the labels of invalid items.
+	invalidLabelColor tcell.Color
+
+	// The index of the button which is disabled while the form is invalid. A
+	// negative value if there is none.
+	submitButton int
+
+	// The number of rows the form's content is scrolled down.
+	scrollOffset int
+
+	// When the scrollBar is shown and the scrollBar itself.
+	scrollBarVisibility ScrollBarVisibility
+	scrollBar           *ScrollBar
+
+	// Whether the scrollBar was shown as of the last draw.
+	scrollBarShown bool
+
+	// Whether the scrollBar's thumb is being dragged with the mouse, and the
+	// distance in subcells between the mouse and the start of the thumb.
+	draggingScrollBar  bool
+	scrollBarDragDelta int
+
+	// The index of the item to scroll into view with the next draw, or -1.
+	scrollToItem int
+
+	// The focus index as of the last draw (see focusIndex) and whether the
+	// focused item must be scrolled into view with the next draw even if the
+	// focus did not change.
+	lastFocus   int
+	followFocus bool
+
+	// The positions of the items and buttons, the content height, and the
+	// height of the visible area as of the last draw.
+	positions                 []formPosition
+	contentHeight, viewHeight int
+
+	// The sections the items belong to, the section to which added items are
+	// assigned, and the indentation of the sections' items.
+	itemSections   map[FormItem]*FormSection
+	currentSection *FormSection
+	sectionIndent  int
+
+	// The completions of input fields which depend on other items.
+	completions []*formCompletion
+
+	// The functions which determine whether items are shown.
+	itemVisibility map[FormItem]func() bool
+
+	// The number of columns of the grid layout (0 if the grid layout is not
+	// used) and the explicit cells of items in the grid.
+	gridColumns int
+	itemCells   map[FormItem]formCell
 }
 
 // NewForm returns a new form.
@@ -124,6 +246,14 @@ func NewForm() *Form {
 		buttonActivatedStyle: tcell.StyleDefault.Reverse(true),
 		buttonDisabledStyle:  tcell.StyleDefault.Background(Styles.ContrastBackgroundColor).Foreground(Styles.ContrastSecondaryTextColor),
 		requestedFocus:       -1,
+		submitItem:           -1,
+		invalidLabelColor:    tcell.ColorRed,
+		submitButton:         -1,
+		scrollBarVisibility:  ScrollBarVisibilityAutomatic,
+		scrollBar:            NewScrollBar(),
+		scrollToItem:         -1,
+		lastFocus:            -1,
+		sectionIndent:        2,
 		setFocus:             func(Primitive) {},
 		lastFinishedKey:      tcell.KeyTab, // To skip over inactive elements at the beginning of the form.
 	}
@@ -131,6 +261,15 @@ func NewForm() *Form {
 	return f
 }
 
+// applyTheme updates the form's default styles after a theme change.
+func (f *Form) applyTheme(change themeChange) {
+	f.Box.applyTheme(change)
+	f.labelColor = change.color(f.labelColor, themeSecondaryText)
+	f.fieldStyle = change.style(f.fieldStyle, themePrimaryText, themeContrastBackground)
+	f.buttonStyle = change.style(f.buttonStyle, themePrimaryText, themeContrastBackground)
+	f.buttonDisabledStyle = change.style(f.buttonDisabledStyle, themeContrastSecondaryText, themeContrastBackground)
+}
+
 // SetItemPadding sets the number of empty rows between form items for vertical
 // layouts and the number of empty cells between form items for horizontal
 // layouts. In vertical layouts, there is always at least one empty line between
@@ -239,9 +378,7 @@ func (f *Form) AddTextArea(label, text string, fieldWidth, fieldHeight, maxLengt
 			changed(textArea.GetText())
 		})
 	}
-	textArea.SetFinishedFunc(f.finished)
-	f.items = append(f.items, textArea)
-	return f
+	return f.AddFormItem(textArea)
 }
 
 // AddTextView adds a text view to the form. It has a label and text, a size
@@ -258,9 +395,7 @@ func (f *Form) AddTextView(label, text string, fieldWidth, fieldHeight int, scro
 		SetSize(fieldHeight, fieldWidth).
 		SetScrollable(scrollable).
 		SetText(text)
-	textArea.SetFinishedFunc(f.finished)
-	f.items = append(f.items, textArea)
-	return f
+	return f.AddFormItem(textArea)
 }
 
 // AddInputField adds an input field to the form. It has a label, an optional
@@ -273,9 +408,7 @@ func (f *Form) AddInputField(label, value string, fieldWidth int, changed func(t
 		SetText(value).
 		SetFieldWidth(fieldWidth).
 		SetChangedFunc(changed)
-	inputField.SetFinishedFunc(f.finished)
-	f.items = append(f.items, inputField)
-	return f
+	return f.AddFormItem(inputField)
 }
 
 // AddPasswordField adds a password field to the form. This is similar to an
@@ -294,9 +427,7 @@ func (f *Form) AddPasswordField(label, value string, fieldWidth int, mask rune,
 		SetFieldWidth(fieldWidth).
 		SetMaskCharacter(mask).
 		SetChangedFunc(changed)
-	password.SetFinishedFunc(f.finished)
-	f.items = append(f.items, password)
-	return f
+	return f.AddFormItem(password)
 }
 
 // AddCheckbox adds a checkbox to the form. It has a label, an initial state,
@@ -307,9 +438,66 @@ func (f *Form) AddCheckbox(label string, checked bool, changed func(checked bool
 		SetLabel(label).
 		SetChecked(checked).
 		SetChangedFunc(changed)
-	checkbox.SetFinishedFunc(f.finished)
-	f.items = append(f.items, checkbox)
-	return f
+	return f.AddFormItem(checkbox)
+}
+
+// AddDropDown adds a drop-down to the form. It has a label, options, the index
+// of the initially selected option (-1 for none), and an (optional) callback
+// function which is invoked when the user selects an option.
+func (f *Form) AddDropDown(label string, options []string, initialOption int, selected func(option string, optionIndex int)) *Form {
+	dropDown := NewDropDown().
+		SetLabel(label).
+		SetOptions(options, nil).
+		SetCurrentOption(initialOption).
+		SetSelectedFunc(selected)
+	return f.AddFormItem(dropDown)
+}
+
+// AddRadioButtons adds a [RadioButtons] form item with one option per row to
+// the form. It has a label, the options, the index of the initially selected
+// option (-1 for none), and an (optional) callback function which is invoked
+// when the user selects an option.
+func (f *Form) AddRadioButtons(label string, options []string, initialOption int, changed func(option string, optionIndex int)) *Form {
+	radioButtons := NewRadioButtons(options...).
+		SetLabel(label).
+		SetCurrentOption(initialOption).
+		SetChangedFunc(changed)
+	return f.AddFormItem(radioButtons)
+}
+
+// AddSlider adds a slider to the form. It has a label, the range of values and
+// the step size (see [Slider.SetRange]), an initial value, and an (optional)
+// callback function which is invoked when the value was changed.
+func (f *Form) AddSlider(label string, min, max, step, value float64, changed func(value float64)) *Form {
+	slider := NewSlider().
+		SetLabel(label).
+		SetRange(min, max, step).
+		SetValue(value).
+		SetChangedFunc(changed)
+	return f.AddFormItem(slider)
+}
+
+// AddDateField adds an input field for dates in the locale's date layout to
+// the form (see [InputFieldTypeDate]). It has a label, an initial date (the
+// zero time leaves the field empty), a field width (a value of 0 extends it as
+// far as possible), and an (optional) callback function which is invoked when
+// the user entered a valid date.
+func (f *Form) AddDateField(label string, date time.Time, fieldWidth int, changed func(date time.Time)) *Form {
+	dateField := NewInputField().
+		SetLabel(label).
+		SetFieldWidth(fieldWidth).
+		SetFieldType(InputFieldTypeDate)
+	if !date.IsZero() {
+		dateField.SetTime(date)
+	}
+	if changed != nil {
+		dateField.SetChangedFunc(func(text string) {
+			if date, err := dateField.GetTime(); err == nil {
+				changed(date)
+			}
+		})
+	}
+	return f.AddFormItem(dateField)
 }
 
 // AddButton adds a new button to the form. The "selected" function is called
@@ -357,6 +545,14 @@ func (f *Form) GetButtonIndex(label string) int {
 // specified.
 func (f *Form) Clear(includeButtons bool) *Form {
 	f.items = nil
+	f.bindings = nil
+	f.validators = nil
+	f.itemErrors = nil
+	f.itemSections = nil
+	f.currentSection = nil
+	f.completions = nil
+	f.itemVisibility = nil
+	f.itemCells = nil
 	if includeButtons {
 		f.ClearButtons()
 	}
@@ -384,6 +580,12 @@ func (f *Form) ClearButtons() *Form {
 func (f *Form) AddFormItem(item FormItem) *Form {
 	item.SetFinishedFunc(f.finished)
 	f.items = append(f.items, item)
+	if _, ok := item.(*FormSection); !ok && f.currentSection != nil {
+		if f.itemSections == nil {
+			f.itemSections = make(map[FormItem]*FormSection)
+		}
+		f.itemSections[item] = f.currentSection
+	}
 	return f
 }
 
@@ -404,7 +606,176 @@ func (f *Form) GetFormItem(index int) FormItem {
 // index 0. Elements are referenced in the order they were added. Buttons are
 // not included.
 func (f *Form) RemoveFormItem(index int) *Form {
+	f.forgetItem(f.items[index])
 	f.items = slices.Delete(f.items, index, index+1)
+	f.remapIndices(func(i int) int {
+		switch {
+		case i == index:
+			return -1
+		case i > index:
+			return i - 1
+		}
+		return i
+	})
+	return f
+}
+
+// InsertFormItem inserts a new item into the form at the given position,
+// starting with index 0, moving the item at that position and all following
+// items back. An index equal to the number of items appends the item. The form
+// overrides the same attributes as with [Form.AddFormItem]. The item belongs
+// to the section of the preceding item, if any (see [Form.AddSection]). Other
+// items keep their state, e.g. their text and the focus.
+func (f *Form) InsertFormItem(index int, item FormItem) *Form {
+	item.SetFinishedFunc(f.finished)
+	var section *FormSection
+	if index > 0 {
+		section = f.itemSections[f.items[index-1]]
+		if header, ok := f.items[index-1].(*FormSection); ok {
+			section = header
+		}
+	}
+	f.items = slices.Insert(f.items, index, item)
+	if _, ok := item.(*FormSection); !ok && section != nil {
+		if f.itemSections == nil {
+			f.itemSections = make(map[FormItem]*FormSection)
+		}
+		f.itemSections[item] = section
+	}
+	f.remapIndices(func(i int) int {
+		if i >= index {
+			return i + 1
+		}
+		return i
+	})
+	return f
+}
+
+// MoveFormItem moves the form item at position "from" to position "to",
+// starting with index 0, shifting the items in between. The moved item keeps
+// its state, including the focus, and its section (see
+// [Form.GetItemSection]).
+func (f *Form) MoveFormItem(from, to int) *Form {
+	if from == to {
+		return f
+	}
+	item := f.items[from]
+	f.items = slices.Insert(slices.Delete(f.items, from, from+1), to, item)
+	f.remapIndices(func(i int) int {
+		switch {
+		case i == from:
+			return to
+		case from < i && i <= to:
+			return i - 1
+		case to <= i && i < from:
+			return i + 1
+		}
+		return i
+	})
+	return f
+}
+
+// ReplaceFormItem replaces the form item at the given position, starting with
+// index 0, with a new item. The form overrides the same attributes as with
+// [Form.AddFormItem]. The new item takes over the section of the replaced
+// item. If a section header (see [Form.AddSection]) is replaced with another
+// one, the section's items move to the new header. The state the form keeps
+// for the replaced item, e.g. its validator (see [Form.SetItemValidator]), is
+// discarded. If the replaced item had focus, the new item receives it.
+func (f *Form) ReplaceFormItem(index int, item FormItem) *Form {
+	old := f.items[index]
+	section := f.itemSections[old]
+	focused := old.HasFocus()
+	f.forgetItemState(old)
+	if oldHeader, ok := old.(*FormSection); ok {
+		if header, ok := item.(*FormSection); ok {
+			for member, s := range f.itemSections {
+				if s == oldHeader {
+					f.itemSections[member] = header
+				}
+			}
+			if f.currentSection == oldHeader {
+				f.currentSection = header
+			}
+		} else {
+			f.forgetSection(oldHeader)
+		}
+	}
+	item.SetFinishedFunc(f.finished)
+	f.items[index] = item
+	if _, ok := item.(*FormSection); !ok && section != nil {
+		f.itemSections[item] = section
+	}
+	if focused {
+		f.setFocus(item)
+	}
+	return f
+}
+
+// remapIndices applies the given mapping to the stored indices of items,
+// after items were inserted, removed, or moved. A mapping to -1 clears the
+// index.
+func (f *Form) remapIndices(mapping func(int) int) {
+	if f.requestedFocus >= 0 {
+		f.requestedFocus = mapping(f.requestedFocus)
+	}
+	if f.submitItem >= 0 {
+		f.submitItem = mapping(f.submitItem)
+	}
+}
+
+// forgetItem removes the state the form keeps for the given item, e.g. before
+// the item is removed. Items of a removed section no longer belong to any
+// section.
+func (f *Form) forgetItem(item FormItem) {
+	f.forgetItemState(item)
+	if section, ok := item.(*FormSection); ok {
+		f.forgetSection(section)
+	}
+}
+
+// forgetItemState removes the state the form keeps for the given item itself.
+func (f *Form) forgetItemState(item FormItem) {
+	delete(f.validators, item)
+	delete(f.itemErrors, item)
+	delete(f.itemSections, item)
+	delete(f.itemVisibility, item)
+	delete(f.itemCells, item)
+	f.completions = slices.DeleteFunc(f.completions, func(c *formCompletion) bool {
+		return FormItem(c.item) == item
+	})
+}
+
+// forgetSection removes the given section from all items which belong to it.
+func (f *Form) forgetSection(section *FormSection) {
+	maps.DeleteFunc(f.itemSections, func(_ FormItem, s *FormSection) bool {
+		return s == section
+	})
+	if f.currentSection == section {
+		f.currentSection = nil
+	}
+}
+
+// SetItemFieldSize changes the field size of the form item at the given
+// position, starting with index 0, e.g. to grow a text area when the terminal
+// is tall. Text areas and text views (see [Form.AddTextArea] and
+// [Form.AddTextView]) receive the given number of rows and columns, input
+// fields only the columns. As when adding items, 0 rows mean
+// [DefaultFormFieldHeight] and 0 columns extend the field as far as possible.
+// Other items are not changed. The new size is applied the next time the form
+// is drawn.
+func (f *Form) SetItemFieldSize(index, rows, columns int) *Form {
+	if rows == 0 {
+		rows = DefaultFormFieldHeight
+	}
+	switch item := f.items[index].(type) {
+	case *TextArea:
+		item.SetSize(rows, columns)
+	case *TextView:
+		item.SetSize(rows, columns)
+	case *InputField:
+		item.SetFieldWidth(columns)
+	}
 	return f
 }
 
@@ -452,21 +823,150 @@ func (f *Form) SetCancelFunc(callback func()) *Form {
 	return f
 }
 
+// SetSubmitFunc sets a handler which is called when the user submits the form,
+// i.e. presses Enter on the submit item (see [Form.SetSubmitItem]) or finishes
+// any item with a key mapped to [FormKeyActionSubmit]. The form is not
+// submitted while any of its items are invalid (see [Form.Validate]). Instead,
+// the first invalid item receives focus.
+func (f *Form) SetSubmitFunc(handler func()) *Form {
+	f.submit = handler
+	return f
+}
+
+// SetSubmitItem sets the index of the form item (not including buttons) on
+// which pressing Enter submits the form instead of moving to the next item. A
+// negative value (the default) refers to the last enabled form item. The form is
+// only submitted this way if a submit handler was set with
+// [Form.SetSubmitFunc].
+func (f *Form) SetSubmitItem(index int) *Form {
+	if f.submitItem != index {
+		f.submitItem = index
+	}
+	return f
+}
+
+// SetKeyAction sets the action taken when the user finishes a form item with
+// the given key. Form items finish with Enter, Tab, Backtab, or Escape (see
+// [FormItem]). Use [FormKeyActionDefault] to restore the built-in behavior for
+// the key. Note that multi-line items such as [TextArea] only finish with Enter
+// if configured to do so with [TextArea.SetFinishOnEnter].
+func (f *Form) SetKeyAction(key tcell.Key, action FormKeyAction) *Form {
+	if action == FormKeyActionDefault {
+		delete(f.keyActions, key)
+		return f
+	}
+	if f.keyActions == nil {
+		f.keyActions = make(map[tcell.Key]FormKeyAction)
+	}
+	f.keyActions[key] = action
+	return f
+}
+
 // Draw draws this primitive onto the screen.
 func (f *Form) Draw(screen tcell.Screen) {
 	f.DrawForSubclass(screen, f)
+	f.updateCompletions()
 
 	// Determine the dimensions.
 	x, y, width, height := f.GetInnerRect()
 	topLimit := y
 	bottomLimit := y + height
+
+	// Lay out the items, making room for the scrollBar if needed.
+	positions, contentHeight := f.layout(x, y, width)
+	showScrollBar := false
+	if f.scrollBar != nil && width > 1 {
+		switch f.scrollBarVisibility {
+		case ScrollBarVisibilityAlways:
+			showScrollBar = true
+		case ScrollBarVisibilityAutomatic:
+			showScrollBar = contentHeight > height
+		}
+	}
+	if showScrollBar {
+		width--
+		positions, contentHeight = f.layout(x, y, width)
+	}
+	f.scrollBarShown = showScrollBar
+
+	// Determine the vertical offset, following the focused item.
+	focus := f.focusIndex()
+	if f.scrollToItem >= 0 && f.scrollToItem < len(f.items) {
+		f.scrollIntoView(positions[f.scrollToItem], topLimit, height)
+	} else if focus >= 0 && (focus != f.lastFocus || f.followFocus) {
+		f.scrollIntoView(positions[focus], topLimit, height)
+	}
+	f.scrollToItem, f.lastFocus, f.followFocus = -1, focus, false
+	f.scrollOffset = max(min(f.scrollOffset, contentHeight-height), 0)
+	f.positions, f.contentHeight, f.viewHeight = positions, contentHeight, height
+	offset := f.scrollOffset
+
+	// Draw items.
+	for index, item := range f.items {
+		// Set position.
+		y := positions[index].y - offset
+		height := positions[index].height
+		item.SetRect(positions[index].x, y, positions[index].width, height)
+
+		// Is this item visible?
+		if y+height <= topLimit || y >= bottomLimit {
+			continue
+		}
+
+		// Draw items with focus last (in case of overlaps).
+		if item.HasFocus() {
+			defer item.Draw(screen)
+		} else {
+			item.Draw(screen)
+		}
+	}
+
+	// Draw buttons.
+	for index, button := range f.buttons {
+		// Set position.
+		buttonIndex := index + len(f.items)
+		y := positions[buttonIndex].y - offset
+		height := positions[buttonIndex].height
+		button.SetRect(positions[buttonIndex].x, y, positions[buttonIndex].width, height)
+
+		// Is this button visible?
+		if y+height <= topLimit || y >= bottomLimit {
+			continue
+		}
+
+		// Draw button.
+		button.Draw(screen)
+	}
+
+	// Draw the scrollBar.
+	if showScrollBar {
+		f.scrollBar.SetRect(x+width, topLimit, 1, height)
+		f.scrollBar.SetLengths(ScrollLengths{ContentLen: contentHeight, ViewportLen: height}).
+			SetOffset(offset).
+			Draw(screen)
+	}
+}
+
+// formPosition is the position of a form item or button relative to the
+// form's content, see [Form.layout].
+type formPosition struct{ x, y, width, height int }
+
+// layout calculates the positions of the form items followed by the buttons,
+// starting at the given screen coordinates, for the given width, before
+// scrolling. It also returns the height of the form's content. Item attributes
+// are adjusted to the form's.
+func (f *Form) layout(x, y, width int) (positions []formPosition, contentHeight int) {
+	topLimit := y
 	rightLimit := x + width
 	startX := x
 
-	// Find the longest label.
+	// Find the longest label, including indentation.
 	var maxLabelWidth int
 	for _, item := range f.items {
-		labelWidth := TaggedStringWidth(item.GetLabel())
+		if _, ok := item.(*FormSection); ok || f.isHidden(item) {
+			continue
+		}
+		labelWidth := TaggedStringWidth(item.GetLabel()) + f.itemIndent(item)
 		if labelWidth > maxLabelWidth {
 			maxLabelWidth = labelWidth
 		}
@@ -474,17 +974,38 @@ func (f *Form) Draw(screen tcell.Screen) {
 	maxLabelWidth++ // Add one space.
 
 	// Calculate positions of form items.
-	type position struct{ x, y, width, height int }
-	positions := make([]position, len(f.items)+len(f.buttons))
-	var (
-		focusedPosition position
-		lineHeight      = 1
-	)
-	for index, item := range f.items {
+	positions = make([]formPosition, len(f.items)+len(f.buttons))
+	lineHeight := 1
+	items, horizontal := f.items, f.horizontal
+	if f.gridColumns > 0 {
+		horizontal = false // Buttons are placed below the grid.
+		y = f.layoutGrid(positions, x, y, width)
+		items = nil // Already placed.
+	}
+	for index, item := range items {
+		if f.isHidden(item) {
+			continue
+		}
+
+		// Section headers occupy a row of their own in horizontal layouts.
+		if _, ok := item.(*FormSection); ok && horizontal {
+			if x != startX {
+				x = startX
+				y += lineHeight + 1
+			}
+			item.SetFormAttributes(0, f.labelColor, f.backgroundColor, f.fieldStyle.GetForeground(), f.fieldStyle.GetBackground())
+			positions[index] = formPosition{x: x, y: y, width: width, height: 1}
+			y += 2
+			lineHeight = 1
+			continue
+		}
+
 		// Calculate the space needed.
+		indent := f.itemIndent(item)
+		x += indent
 		labelWidth := TaggedStringWidth(item.GetLabel())
 		var itemWidth int
-		if f.horizontal {
+		if horizontal {
 			fieldWidth := item.GetFieldWidth()
 			if fieldWidth <= 0 {
 				fieldWidth = DefaultFormFieldWidth
@@ -493,8 +1014,8 @@ func (f *Form) Draw(screen tcell.Screen) {
 			itemWidth = labelWidth + fieldWidth
 		} else {
 			// We want all fields to align vertically.
-			labelWidth = maxLabelWidth
-			itemWidth = width
+			labelWidth = maxLabelWidth - indent
+			itemWidth = width - indent
 		}
 		itemHeight := item.GetFieldHeight()
 		if itemHeight <= 0 {
@@ -502,8 +1023,8 @@ func (f *Form) Draw(screen tcell.Screen) {
 		}
 
 		// Advance to next line if there is no space.
-		if f.horizontal && x+labelWidth+1 >= rightLimit {
-			x = startX
+		if horizontal && x+labelWidth+1 >= rightLimit {
+			x = startX + indent
 			y += lineHeight + 1
 			lineHeight = itemHeight
 		}
@@ -517,45 +1038,37 @@ func (f *Form) Draw(screen tcell.Screen) {
 		if x+itemWidth >= rightLimit {
 			itemWidth = rightLimit - x
 		}
-		fieldTextColor := f.fieldStyle.GetForeground()
-		fieldBackgroundColor := f.fieldStyle.GetBackground()
-		item.SetFormAttributes(
-			labelWidth,
-			f.labelColor,
-			f.backgroundColor,
-			fieldTextColor,
-			fieldBackgroundColor,
-		)
+		f.setItemAttributes(item, labelWidth)
 
 		// Save position.
-		positions[index].x = x
-		positions[index].y = y
-		positions[index].width = itemWidth
-		positions[index].height = itemHeight
-		if item.HasFocus() {
-			focusedPosition = positions[index]
-		}
+		positions[index] = formPosition{x: x, y: y, width: itemWidth, height: itemHeight}
 
 		// Advance to next item.
-		if f.horizontal {
+		if horizontal {
 			x += itemWidth + f.itemPadding
 		} else {
+			x -= indent
 			y += itemHeight + f.itemPadding
 		}
 	}
 
+	// The submit button requires valid items.
+	if f.submitButton >= 0 && f.submitButton < len(f.buttons) {
+		f.buttons[f.submitButton].SetDisabled(!f.isValid())
+	}
+
 	// How wide are the buttons?
 	buttonWidths := make([]int, len(f.buttons))
 	buttonsWidth := 0
 	for index, button := range f.buttons {
-		w := TaggedStringWidth(button.GetLabel()) + 4
+		w := button.contentWidth() + 4
 		buttonWidths[index] = w
 		buttonsWidth += w + 1
 	}
 	buttonsWidth--
 
 	// Where do we place them?
-	if !f.horizontal && x+buttonsWidth < rightLimit {
+	if !horizontal && x+buttonsWidth < rightLimit {
 		switch f.buttonsAlignment {
 		case AlignmentRight:
 			x = rightLimit - buttonsWidth
@@ -569,11 +1082,33 @@ func (f *Form) Draw(screen tcell.Screen) {
 		}
 	}
 
+	// Stack the buttons, one per row, e.g. in narrow modals.
+	if f.stackButtons && !horizontal {
+		for index, button := range f.buttons {
+			buttonWidth := min(buttonWidths[index], width)
+			buttonX := startX
+			switch f.buttonsAlignment {
+			case AlignmentRight:
+				buttonX = rightLimit - buttonWidth
+			case AlignmentCenter:
+				buttonX = (startX + rightLimit - buttonWidth) / 2
+			}
+			button.SetStyle(f.buttonStyle).
+				SetActivatedStyle(f.buttonActivatedStyle).
+				SetDisabledStyle(f.buttonDisabledStyle)
+			positions[index+len(f.items)] = formPosition{x: buttonX, y: y, width: buttonWidth, height: 1}
+			y++
+		}
+	}
+
 	// Calculate positions of buttons.
 	for index, button := range f.buttons {
+		if f.stackButtons && !horizontal {
+			break // Already placed.
+		}
 		space := rightLimit - x
 		buttonWidth := buttonWidths[index]
-		if f.horizontal {
+		if horizontal {
 			if space < buttonWidth-4 {
 				x = startX
 				y += lineHeight + 1
@@ -592,64 +1127,34 @@ func (f *Form) Draw(screen tcell.Screen) {
 			SetActivatedStyle(f.buttonActivatedStyle).
 			SetDisabledStyle(f.buttonDisabledStyle)
 
-		buttonIndex := index + len(f.items)
-		positions[buttonIndex].x = x
-		positions[buttonIndex].y = y
-		positions[buttonIndex].width = buttonWidth
-		positions[buttonIndex].height = 1
-
-		if button.HasFocus() {
-			focusedPosition = positions[buttonIndex]
-		}
+		positions[index+len(f.items)] = formPosition{x: x, y: y, width: buttonWidth, height: 1}
 
 		x += buttonWidth + 1
 	}
 
-	// Determine vertical offset based on the position of the focused item.
-	var offset int
-	if focusedPosition.y+focusedPosition.height > bottomLimit {
-		offset = focusedPosition.y + focusedPosition.height - bottomLimit
-		if focusedPosition.y-offset < topLimit {
-			offset = focusedPosition.y - topLimit
-		}
+	// Determine the content height.
+	for _, position := range positions {
+		contentHeight = max(contentHeight, position.y+position.height-topLimit)
 	}
+	return
+}
 
-	// Draw items.
-	for index, item := range f.items {
-		// Set position.
-		y := positions[index].y - offset
-		height := positions[index].height
-		item.SetRect(positions[index].x, y, positions[index].width, height)
-
-		// Is this item visible?
-		if y+height <= topLimit || y >= bottomLimit {
-			continue
-		}
-
-		// Draw items with focus last (in case of overlaps).
-		if item.HasFocus() {
-			defer item.Draw(screen)
-		} else {
-			item.Draw(screen)
-		}
-	}
-
-	// Draw buttons.
-	for index, button := range f.buttons {
-		// Set position.
-		buttonIndex := index + len(f.items)
-		y := positions[buttonIndex].y - offset
-		height := positions[buttonIndex].height
-		button.SetRect(positions[buttonIndex].x, y, positions[buttonIndex].width, height)
-
-		// Is this button visible?
-		if y+height <= topLimit || y >= bottomLimit {
-			continue
-		}
-
-		// Draw button.
-		button.Draw(screen)
+// setItemAttributes sets the form's attributes on the given item, with the
+// given label width.
+func (f *Form) setItemAttributes(item FormItem, labelWidth int) {
+	fieldTextColor := f.fieldStyle.GetForeground()
+	fieldBackgroundColor := f.fieldStyle.GetBackground()
+	labelColor := f.labelColor
+	if f.isInvalid(item) {
+		labelColor = f.invalidLabelColor
 	}
+	item.SetFormAttributes(
+		labelWidth,
+		labelColor,
+		f.backgroundColor,
+		fieldTextColor,
+		fieldBackgroundColor,
+	)
 }
 
 // Focus is called by the application when the primitive receives focus.
@@ -664,7 +1169,7 @@ func (f *Form) Focus(delegate func(p Primitive)) {
 
 	// Delegate focus.
 	for index, item := range f.items {
-		if (focus < 0 || focus == index) && !item.GetDisabled() {
+		if (focus < 0 || focus == index) && !item.GetDisabled() && !f.isHidden(item) {
 			f.requestedFocus = index
 			delegate(item)
 			return
@@ -684,52 +1189,97 @@ func (f *Form) Focus(delegate func(p Primitive)) {
 // finished handles a form item's "finished" event.
 func (f *Form) finished(key tcell.Key) {
 	focus := f.focusIndex()
-	if key >= 0 {
-		f.lastFinishedKey = key
+	if key < 0 {
+		// Repeat the last movement, e.g. to skip over a disabled item.
+		if f.lastFinishedKey >= 0 {
+			switch f.keyAction(f.lastFinishedKey, -1) {
+			case FormKeyActionNext:
+				f.moveFocus(focus, 1)
+			case FormKeyActionPrevious:
+				f.moveFocus(focus, -1)
+			}
+		}
+		return
+	}
+	f.lastFinishedKey = key
+	if focus >= 0 && focus < len(f.items) && f.validators[f.items[focus]] != nil {
+		f.validateItem(f.items[focus])
 	}
 
-	totalCount := len(f.items) + len(f.buttons)
-	switch key {
-	case tcell.KeyTab, tcell.KeyEnter:
-		// Find the next focusable item.
-		for range totalCount {
-			focus = (focus + 1) % totalCount
-			if focus < len(f.items) {
-				if !f.items[focus].GetDisabled() {
-					f.setFocus(f.items[focus])
-					return
-				}
-			} else {
-				if !f.buttons[focus-len(f.items)].GetDisabled() {
-					f.setFocus(f.buttons[focus-len(f.items)])
-					return
-				}
-			}
+	switch f.keyAction(key, focus) {
+	case FormKeyActionNext:
+		f.moveFocus(focus, 1)
+	case FormKeyActionPrevious:
+		f.moveFocus(focus, -1)
+	case FormKeyActionSubmit:
+		if f.submit == nil {
+			break
 		}
-	case tcell.KeyBacktab:
-		// Find the previous focusable item.
-		for range totalCount {
-			focus = (focus + totalCount - 1) % totalCount
-			if focus < len(f.items) {
-				if !f.items[focus].GetDisabled() {
-					f.setFocus(f.items[focus])
-					return
-				}
-			} else {
-				if !f.buttons[focus-len(f.items)].GetDisabled() {
-					f.setFocus(f.buttons[focus-len(f.items)])
-					return
-				}
-			}
+		if errs := f.Validate(); len(errs) > 0 {
+			f.reveal(f.items[errs[0].Index])
+			f.setFocus(f.items[errs[0].Index])
+			break
 		}
-	case tcell.KeyEscape:
+		f.submit()
+	case FormKeyActionCancel:
 		if f.cancel != nil {
 			f.cancel()
 		}
-	default:
-		if key < 0 && f.lastFinishedKey >= 0 {
-			// Repeat the last action.
-			f.finished(f.lastFinishedKey)
+	}
+}
+
+// keyAction returns the action to take when the item with the given focus
+// index is finished with the given key.
+func (f *Form) keyAction(key tcell.Key, focus int) FormKeyAction {
+	if action, ok := f.keyActions[key]; ok {
+		return action
+	}
+	switch key {
+	case tcell.KeyEnter:
+		if f.submit != nil && focus >= 0 && focus == f.submitItemIndex() {
+			return FormKeyActionSubmit
+		}
+		return FormKeyActionNext
+	case tcell.KeyTab:
+		return FormKeyActionNext
+	case tcell.KeyBacktab:
+		return FormKeyActionPrevious
+	case tcell.KeyEscape:
+		return FormKeyActionCancel
+	}
+	return FormKeyActionIgnore
+}
+
+// submitItemIndex returns the index of the form item on which Enter submits
+// the form, or -1 if there is none.
+func (f *Form) submitItemIndex() int {
+	if f.submitItem >= 0 {
+		return f.submitItem
+	}
+	for index := len(f.items) - 1; index >= 0; index-- {
+		if !f.items[index].GetDisabled() && !f.isHidden(f.items[index]) {
+			return index
+		}
+	}
+	return -1
+}
+
+// moveFocus moves the focus from the item or button with the given focus index
+// to the next (direction 1) or previous (direction -1) enabled one.
+func (f *Form) moveFocus(focus, direction int) {
+	totalCount := len(f.items) + len(f.buttons)
+	for range totalCount {
+		focus = (focus + totalCount + direction) % totalCount
+		if focus < len(f.items) {
+			if !f.items[focus].GetDisabled() && !f.isHidden(f.items[focus]) {
+				f.setFocus(f.items[focus])
+				return
+			}
+		} else {
+			if !f.buttons[focus-len(f.items)].GetDisabled() {
+				f.setFocus(f.buttons[focus-len(f.items)])
+				return
+			}
 		}
 	}
 }
@@ -761,8 +1311,19 @@ func (f *Form) HasFocus() bool {
 
 // HandleEvent handles input events for this primitive.
 func (f *Form) HandleEvent(event tcell.Event) Command {
+	defer f.updateCompletions()
+	defer f.refocus()
 	switch event := event.(type) {
 	case *MouseEvent:
+		if cmd := f.scrollBarMouse(event); cmd != nil {
+			return cmd
+		}
+		if !f.itemScrollsAt(event.Position()) {
+			if cmd := f.scrollWheel(event); cmd != nil {
+				return cmd
+			}
+		}
+
 		// Determine items to pass mouse events to.
 		for _, item := range f.items {
 			if item.GetDisabled() {
@@ -788,17 +1349,45 @@ func (f *Form) HandleEvent(event tcell.Event) Command {
 			return SetFocusCommand{Target: f}
 		}
 	case *KeyEvent, *PasteEvent:
+		var focused Primitive
 		for _, item := range f.items {
 			if item.HasFocus() {
-				return item.HandleEvent(event)
+				focused = item
+				break
 			}
 		}
-
 		for _, button := range f.buttons {
-			if button.HasFocus() {
-				return button.HandleEvent(event)
+			if focused == nil && button.HasFocus() {
+				focused = button
 			}
 		}
+		if focused == nil {
+			return nil
+		}
+
+		// PageUp and PageDown scroll the form unless the item uses them.
+		direction, pageKey := isPageKey(event)
+		if pageKey && !handlesPageKeys(focused) {
+			return f.page(direction)
+		}
+		f.followFocus = true
+		cmd := focused.HandleEvent(event)
+		if cmd == nil && pageKey {
+			return f.page(direction)
+		}
+		return cmd
 	}
 	return nil
 }
+
+// Inspect describes this primitive in the inspection tree. See [Inspect].
+func (f *Form) Inspect(node *InspectNode) {
+	f.Box.Inspect(node)
+	node.Role = RoleForm
+	for _, item := range f.items {
+		node.AddPrimitive(item)
+	}
+	for _, button := range f.buttons {
+		node.AddPrimitive(button)
+	}
+}
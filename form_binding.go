@@ -0,0 +1,304 @@
+package tview
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// formBinding connects a form item to a struct field, see [Form.BindStruct].
+type formBinding struct {
+	// The form item showing the field's value.
+	item FormItem
+
+	// The struct field.
+	value reflect.Value
+}
+
+var (
+	durationType = reflect.TypeFor[time.Duration]()
+	timeType     = reflect.TypeFor[time.Time]()
+)
+
+// BindStruct adds a form item for each exported field of the struct pointed
+// to by ptr, initialized with the field's value. Use [Form.Collect] to write
+// the edited values back into the struct. The items are configured with
+// "form" struct tags of the form "label,widget,options":
+//
+//	type User struct {
+//		Name    string        `form:"Name,,required,width=30"`
+//		Secret  string        `form:"Password,password"`
+//		Bio     string        `form:"About,textarea,height=4"`
+//		Age     int           `form:"Age"`
+//		Admin   bool          `form:"Administrator"`
+//		Timeout time.Duration `form:",,disabled"`
+//		ID      int64         `form:"-"`
+//	}
+//
+// The label defaults to the field's name. Fields tagged "-" are skipped. The
+// widget is one of:
+//
+//   - "input": An input field (see [Form.AddInputField]), the default for
+//     strings, numbers, durations, and dates ([time.Time]). Input fields of
+//     non-string fields have the corresponding type, see
+//     [InputField.SetFieldType].
+//   - "password": A masked input field for strings.
+//   - "textarea": A text area for strings.
+//   - "textview": A read-only text view showing the field's value, which is
+//     never written back.
+//   - "checkbox": A checkbox, the default and only widget for booleans.
+//
+// The options are:
+//
+//   - "required": Input fields must not be empty.
+//   - "disabled": The item is disabled and its field is never written back.
+//   - "width=N": The field width, see [Form.AddInputField].
+//   - "height=N": The field height of text areas and text views.
+//
+// Other field types, e.g. nested structs, cause an error. In that case, no
+// items are added.
+func (f *Form) BindStruct(ptr any) error {
+	value := reflect.ValueOf(ptr)
+	if value.Kind() != reflect.Pointer || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return errors.New("BindStruct requires a non-nil pointer to a struct")
+	}
+	value = value.Elem()
+
+	var bindings []formBinding
+	for index := range value.NumField() {
+		field := value.Type().Field(index)
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+		tag := field.Tag.Get("form")
+		if tag == "-" {
+			continue
+		}
+		parts := strings.Split(tag, ",")
+		label := parts[0]
+		if label == "" {
+			label = field.Name
+		}
+		var widget string
+		if len(parts) > 1 {
+			widget = parts[1]
+		}
+		item, err := newBoundFormItem(value.Field(index), label, widget, parts[min(len(parts), 2):])
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		bindings = append(bindings, formBinding{item: item, value: value.Field(index)})
+	}
+
+	for _, binding := range bindings {
+		f.AddFormItem(binding.item)
+	}
+	f.bindings = append(f.bindings, bindings...)
+	return nil
+}
+
+// newBoundFormItem returns a new form item for the given struct field.
+func newBoundFormItem(value reflect.Value, label, widget string, options []string) (FormItem, error) {
+	var required, disabled bool
+	width, height := 0, DefaultFormFieldHeight
+	for _, option := range options {
+		name, number, _ := strings.Cut(option, "=")
+		var err error
+		switch name {
+		case "required":
+			required = true
+		case "disabled":
+			disabled = true
+		case "width":
+			width, err = strconv.Atoi(number)
+		case "height":
+			height, err = strconv.Atoi(number)
+		case "":
+		default:
+			return nil, fmt.Errorf("unknown option %q", option)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid option %q", option)
+		}
+	}
+
+	kind, valueType := value.Kind(), value.Type()
+	if widget == "" {
+		widget = "input"
+		if kind == reflect.Bool {
+			widget = "checkbox"
+		}
+	}
+	if (kind == reflect.Bool) != (widget == "checkbox") && widget != "textview" {
+		return nil, fmt.Errorf("widget %q cannot show a %s", widget, valueType)
+	}
+
+	var item FormItem
+	switch widget {
+	case "checkbox":
+		item = NewCheckbox().SetLabel(label).SetChecked(value.Bool())
+	case "textview":
+		item = NewTextView().SetLabel(label).SetSize(height, width).SetText(fmt.Sprint(value.Interface()))
+	case "textarea", "password":
+		if kind != reflect.String {
+			return nil, fmt.Errorf("widget %q cannot show a %s", widget, valueType)
+		}
+		if widget == "password" {
+			item = NewInputField().SetLabel(label).SetFieldWidth(width).SetMaskCharacter('*').SetText(value.String())
+		} else {
+			item = NewTextArea().SetLabel(label).SetSize(height, width).SetText(value.String(), true)
+		}
+	case "input":
+		input := NewInputField().SetLabel(label).SetFieldWidth(width)
+		switch {
+		case valueType == durationType:
+			input.SetFieldType(InputFieldTypeDuration).SetDuration(time.Duration(value.Int()))
+		case valueType == timeType:
+			input.SetFieldType(InputFieldTypeDate)
+			if t := value.Interface().(time.Time); !t.IsZero() {
+				input.SetTime(t)
+			}
+		case value.CanInt():
+			input.SetFieldType(InputFieldTypeInteger).SetInt(value.Int())
+		case value.CanUint():
+			input.SetFieldType(InputFieldTypeInteger).SetText(strconv.FormatUint(value.Uint(), 10))
+		case value.CanFloat():
+			input.SetFieldType(InputFieldTypeFloat).SetFloat(value.Float())
+		case kind == reflect.String:
+			input.SetText(value.String())
+		default:
+			return nil, fmt.Errorf("unsupported type %s", valueType)
+		}
+		item = input
+	default:
+		return nil, fmt.Errorf("unknown widget %q", widget)
+	}
+
+	if input, ok := item.(*InputField); ok && required {
+		input.SetValidateFunc(func(text string) error {
+			if strings.TrimSpace(text) == "" {
+				return errors.New(Translate(MessageRequired, ""))
+			}
+			return nil
+		})
+	}
+	if disabled {
+		item.SetDisabled(true)
+	}
+	return item, nil
+}
+
+// Collect writes the values of the items added by [Form.BindStruct] back into
+// the bound struct fields. Empty input fields of non-string fields result in
+// the zero value. Disabled items, read-only text views, and items which have
+// been removed from the form are skipped.
+//
+// If any item is invalid (see [Form.Validate]) or its value cannot be stored
+// in its field, e.g. because a number is out of range, the errors are returned
+// in item order and no field is changed.
+func (f *Form) Collect() []FieldError {
+	errs := f.Validate()
+	if len(errs) > 0 {
+		return errs
+	}
+
+	values := make([]reflect.Value, len(f.bindings))
+	for position, binding := range f.bindings {
+		index := slices.Index(f.items, binding.item)
+		if index < 0 || binding.item.GetDisabled() {
+			continue
+		}
+		value, err := binding.read()
+		if err != nil {
+			errs = append(errs, FieldError{Index: index, Label: binding.item.GetLabel(), Err: err})
+			continue
+		}
+		values[position] = value
+	}
+	if len(errs) > 0 {
+		slices.SortFunc(errs, func(a, b FieldError) int { return a.Index - b.Index })
+		return errs
+	}
+
+	for position, binding := range f.bindings {
+		if values[position].IsValid() {
+			binding.value.Set(values[position])
+		}
+	}
+	return nil
+}
+
+// read returns the value of the bound item, converted to the type of the
+// struct field. It returns an invalid value for read-only items.
+func (b formBinding) read() (reflect.Value, error) {
+	valueType := b.value.Type()
+	value := reflect.New(valueType).Elem()
+	switch item := b.item.(type) {
+	case *Checkbox:
+		value.SetBool(item.IsChecked())
+	case *TextArea:
+		value.SetString(item.GetText())
+	case *InputField:
+		if valueType.Kind() == reflect.String {
+			value.SetString(item.GetText())
+			break
+		}
+		if strings.TrimSpace(item.GetText()) == "" {
+			break // Zero value.
+		}
+		invalidNumber := errors.New(Translate(MessageInvalidNumber, ""))
+		outOfRange := errors.New(Translate(MessageOutOfRange, ""))
+		switch {
+		case valueType == durationType:
+			d, err := item.GetDuration()
+			if err != nil {
+				return value, errors.New(Translate(MessageInvalidDuration, ""))
+			}
+			value.SetInt(int64(d))
+		case valueType == timeType:
+			t, err := item.GetTime()
+			if err != nil {
+				return value, errors.New(Translate(MessageInvalidDate, ""))
+			}
+			value.Set(reflect.ValueOf(t))
+		case value.CanInt():
+			n, err := item.GetInt()
+			if err != nil {
+				return value, invalidNumber
+			}
+			if value.OverflowInt(n) {
+				return value, outOfRange
+			}
+			value.SetInt(n)
+		case value.CanUint():
+			text := item.numberText()
+			n, err := strconv.ParseUint(text, 10, 64)
+			if err != nil {
+				if errors.Is(err, strconv.ErrRange) || strings.HasPrefix(text, "-") {
+					return value, outOfRange
+				}
+				return value, invalidNumber
+			}
+			if value.OverflowUint(n) {
+				return value, outOfRange
+			}
+			value.SetUint(n)
+		case value.CanFloat():
+			n, err := item.GetFloat()
+			if err != nil {
+				return value, invalidNumber
+			}
+			if value.OverflowFloat(n) {
+				return value, outOfRange
+			}
+			value.SetFloat(n)
+		}
+	default:
+		return reflect.Value{}, nil // Read-only.
+	}
+	return value, nil
+}
@@ -0,0 +1,114 @@
+package tview
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"sync"
+)
+
+// FormCompletion describes autocomplete suggestions for an input field which
+// depend on the values of other form items, e.g. host names filtered by the
+// selected environment. See [Form.SetCompletion].
+type FormCompletion struct {
+	// The items whose values the suggestions depend on. The value of an
+	// item is its text, e.g. for input fields and text areas, or "true" or
+	// "false" for checkboxes. Other items need a GetText() string method.
+	Dependencies []FormItem
+
+	// Returns suggestions for the given text of the input field and the
+	// values of the dependencies, in order. It is called in its own
+	// goroutine like the function set with [InputField.SetAutocompleteFunc]
+	// and must not access any primitives.
+	Suggest func(ctx context.Context, text string, values []string) []string
+
+	// If set to true, the input field's text is cleared when the value of a
+	// dependency changes, e.g. because the previous choice no longer applies.
+	ClearOnChange bool
+}
+
+// formCompletion is a completion registered with a form.
+type formCompletion struct {
+	FormCompletion
+
+	// The input field receiving the suggestions.
+	item *InputField
+
+	// Guards the values, which are read by lookups outside the event loop.
+	sync.Mutex
+
+	// The values of the dependencies as of the last check.
+	values []string
+}
+
+// SetCompletion sets the autocomplete function of the given input field, which
+// must be an item of this form, to a function which draws its suggestions from
+// the values of other items (see [FormCompletion]). This replaces any
+// autocomplete function of the input field. A completion with a nil Suggest
+// function removes the input field's completion.
+//
+// When the value of a dependency changes, the input field's open suggestion
+// list is closed, its text is cleared if requested, and, if it already shows a
+// validation error or is not empty, it is validated again (see
+// [InputField.SetValidateFunc]), so that validation functions can check the
+// text against other items. The form detects changes when it handles events
+// and when it is drawn.
+func (f *Form) SetCompletion(item *InputField, completion FormCompletion) *Form {
+	f.completions = slices.DeleteFunc(f.completions, func(c *formCompletion) bool {
+		return c.item == item
+	})
+	if completion.Suggest == nil {
+		item.SetAutocompleteFunc(nil)
+		return f
+	}
+
+	c := &formCompletion{
+		FormCompletion: completion,
+		item:           item,
+		values:         dependencyValues(completion.Dependencies),
+	}
+	f.completions = append(f.completions, c)
+	item.SetAutocompleteFunc(func(ctx context.Context, text string) []string {
+		c.Lock()
+		values := c.values
+		c.Unlock()
+		return c.Suggest(ctx, text, values)
+	})
+	return f
+}
+
+// dependencyValues returns the current values of the given items.
+func dependencyValues(items []FormItem) []string {
+	values := make([]string, len(items))
+	for index, item := range items {
+		switch item := item.(type) {
+		case *Checkbox:
+			values[index] = strconv.FormatBool(item.IsChecked())
+		case interface{ GetText() string }:
+			values[index] = item.GetText()
+		}
+	}
+	return values
+}
+
+// updateCompletions checks the dependencies of all completions for changes
+// and invalidates the input fields whose dependencies changed.
+func (f *Form) updateCompletions() {
+	for _, c := range f.completions {
+		values := dependencyValues(c.Dependencies)
+		c.Lock()
+		changed := !slices.Equal(c.values, values)
+		c.values = values
+		c.Unlock()
+		if !changed {
+			continue
+		}
+		c.item.autocompleter.close()
+		if c.ClearOnChange && c.item.GetText() != "" {
+			c.item.SetText("")
+		}
+		if c.item.GetValidationError() != nil || c.item.GetText() != "" {
+			c.item.Validate()
+		}
+	}
+}
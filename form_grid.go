@@ -0,0 +1,207 @@
+package tview
+
+// formGridColumnGap is the number of empty cells between the columns of a
+// form's grid layout.
+const formGridColumnGap = 2
+
+// formCell is the cell of a form item in the grid layout, see
+// [Form.SetItemCell].
+type formCell struct {
+	row, column, rowSpan, columnSpan int
+}
+
+// SetGrid lays out the form's items in a grid with the given number of columns
+// of equal width, instead of one item per row (the default) or the flow of
+// [Form.SetHorizontal]. Items fill the grid from left to right and from top to
+// bottom in the order in which they were added, each in the next free cell,
+// unless placed explicitly with [Form.SetItemCell]. The labels of the items
+// starting in the same column are aligned. Rows are as high as their highest
+// item and separated by the item padding (see [Form.SetItemPadding]). Section
+// headers (see [Form.AddSection]) span a full row. Buttons follow below the
+// grid. Focus moves through the items in the order in which they were added.
+//
+// A value of 0 or less turns the grid layout off.
+func (f *Form) SetGrid(columns int) *Form {
+	f.gridColumns = max(columns, 0)
+	return f
+}
+
+// SetItemCell sets the cell of the given form item in the grid layout (see
+// [Form.SetGrid]) and the number of rows and columns the item spans,
+// starting with row and column 0. Items with a negative row or column are
+// placed in the next free cell, like items without an explicit cell, but with
+// the given spans. Explicitly placed items should not overlap. Spans are at
+// least 1 and limited to the grid's columns.
+func (f *Form) SetItemCell(item FormItem, row, column, rowSpan, columnSpan int) *Form {
+	if f.itemCells == nil {
+		f.itemCells = make(map[FormItem]formCell)
+	}
+	if row < 0 || column < 0 {
+		row, column = -1, -1
+	}
+	f.itemCells[item] = formCell{
+		row:        row,
+		column:     column,
+		rowSpan:    max(rowSpan, 1),
+		columnSpan: max(columnSpan, 1),
+	}
+	return f
+}
+
+// gridCells assigns the visible items to cells of the grid layout. Hidden
+// items are assigned the zero cell.
+func (f *Form) gridCells() []formCell {
+	columns := f.gridColumns
+	cells := make([]formCell, len(f.items))
+	var occupied [][]bool
+	occupy := func(cell formCell) {
+		for row := cell.row; row < cell.row+cell.rowSpan; row++ {
+			for len(occupied) <= row {
+				occupied = append(occupied, make([]bool, columns))
+			}
+			for column := cell.column; column < cell.column+cell.columnSpan; column++ {
+				occupied[row][column] = true
+			}
+		}
+	}
+	free := func(cell formCell) bool {
+		for row := cell.row; row < min(cell.row+cell.rowSpan, len(occupied)); row++ {
+			for column := cell.column; column < cell.column+cell.columnSpan; column++ {
+				if occupied[row][column] {
+					return false
+				}
+			}
+		}
+		return true
+	}
+
+	// Place explicit cells first.
+	automatic := make([]bool, len(f.items))
+	for index, item := range f.items {
+		if f.isHidden(item) {
+			continue
+		}
+		cell, ok := f.itemCells[item]
+		if _, isSection := item.(*FormSection); isSection {
+			cell = formCell{row: -1, column: -1, rowSpan: 1, columnSpan: columns}
+		} else if !ok {
+			cell = formCell{row: -1, column: -1, rowSpan: 1, columnSpan: 1}
+		}
+		cell.columnSpan = min(cell.columnSpan, columns)
+		if cell.row < 0 {
+			automatic[index] = true
+		} else {
+			cell.column = min(cell.column, columns-cell.columnSpan)
+			occupy(cell)
+		}
+		cells[index] = cell
+	}
+
+	// Fill the free cells with the other items.
+	var row, column int
+	for index := range f.items {
+		if !automatic[index] {
+			continue
+		}
+		cell := cells[index]
+		for {
+			if column+cell.columnSpan > columns {
+				row, column = row+1, 0
+			}
+			cell.row, cell.column = row, column
+			if free(cell) {
+				break
+			}
+			column++
+		}
+		occupy(cell)
+		cells[index] = cell
+		column += cell.columnSpan
+	}
+	return cells
+}
+
+// layoutGrid calculates the positions of the form items in the grid layout,
+// starting at the given screen coordinates, for the given width, and stores
+// them in the given positions. Item attributes are adjusted to the form's. It
+// returns the screen row below the grid.
+func (f *Form) layoutGrid(positions []formPosition, x, y, width int) int {
+	columns := f.gridColumns
+	cells := f.gridCells()
+	columnWidth := max((width-(columns-1)*formGridColumnGap)/columns, 1)
+
+	// Determine the label widths per column and the row heights.
+	labelWidths := make([]int, columns)
+	var rowHeights []int
+	for index, item := range f.items {
+		if f.isHidden(item) {
+			continue
+		}
+		cell := cells[index]
+		for len(rowHeights) < cell.row+cell.rowSpan {
+			rowHeights = append(rowHeights, 1)
+		}
+		if _, ok := item.(*FormSection); ok {
+			continue
+		}
+		labelWidth := TaggedStringWidth(item.GetLabel()) + f.itemIndent(item) + 1
+		labelWidths[cell.column] = max(labelWidths[cell.column], labelWidth)
+		if cell.rowSpan == 1 {
+			rowHeights[cell.row] = max(rowHeights[cell.row], formItemHeight(item))
+		}
+	}
+
+	// Items spanning several rows enlarge their last row if needed.
+	for index, item := range f.items {
+		cell := cells[index]
+		if f.isHidden(item) || cell.rowSpan == 1 {
+			continue
+		}
+		height := (cell.rowSpan - 1) * f.itemPadding
+		for row := cell.row; row < cell.row+cell.rowSpan; row++ {
+			height += rowHeights[row]
+		}
+		if missing := formItemHeight(item) - height; missing > 0 {
+			rowHeights[cell.row+cell.rowSpan-1] += missing
+		}
+	}
+	rowYs := make([]int, len(rowHeights)+1)
+	rowYs[0] = y
+	for row, height := range rowHeights {
+		rowYs[row+1] = rowYs[row] + height + f.itemPadding
+	}
+
+	// Place the items.
+	for index, item := range f.items {
+		if f.isHidden(item) {
+			continue
+		}
+		cell := cells[index]
+		itemX := x + cell.column*(columnWidth+formGridColumnGap)
+		itemY := rowYs[cell.row]
+		if _, ok := item.(*FormSection); ok {
+			item.SetFormAttributes(0, f.labelColor, f.backgroundColor, f.fieldStyle.GetForeground(), f.fieldStyle.GetBackground())
+			positions[index] = formPosition{x: itemX, y: itemY, width: width, height: 1}
+			continue
+		}
+		indent := f.itemIndent(item)
+		itemWidth := cell.columnSpan*columnWidth + (cell.columnSpan-1)*formGridColumnGap - indent
+		itemWidth = min(itemWidth, x+width-itemX-indent)
+		f.setItemAttributes(item, labelWidths[cell.column]-indent)
+		positions[index] = formPosition{
+			x:      itemX + indent,
+			y:      itemY,
+			width:  itemWidth,
+			height: min(formItemHeight(item), rowYs[cell.row+cell.rowSpan]-f.itemPadding-itemY),
+		}
+	}
+	return rowYs[len(rowHeights)]
+}
+
+// formItemHeight returns the height of the given form item.
+func formItemHeight(item FormItem) int {
+	if height := item.GetFieldHeight(); height > 0 {
+		return height
+	}
+	return DefaultFormFieldHeight
+}
@@ -0,0 +1,222 @@
+package tview
+
+import "github.com/gdamore/tcell/v3"
+
+// SetScrollBarVisibility sets when the vertical scrollBar is shown to the right
+// of the form's content. By default, it is shown when the content is taller
+// than the form.
+func (f *Form) SetScrollBarVisibility(visibility ScrollBarVisibility) *Form {
+	f.scrollBarVisibility = visibility
+	return f
+}
+
+// SetScrollBar sets the ScrollBar primitive used as the form's scrollBar. Its
+// lengths, offset, and position are set by the form. If nil, no scrollBar is
+// shown.
+func (f *Form) SetScrollBar(scrollBar *ScrollBar) *Form {
+	if scrollBar != nil {
+		scrollBar.SetOrientation(ScrollBarVertical)
+	}
+	f.scrollBar = scrollBar
+	return f
+}
+
+// ScrollToItem scrolls the form so that the form item at the given index
+// (not including buttons) is visible, without moving the focus. This is
+// applied the next time the form is drawn.
+//
+// Forms taller than the available space also scroll with the mouse wheel, the
+// scrollBar, and PageUp and PageDown, which move the focus to the first item
+// on the new page. The focused item is scrolled into view when the focus
+// changes or the item receives a key.
+func (f *Form) ScrollToItem(index int) *Form {
+	f.scrollToItem = index
+	return f
+}
+
+// GetScrollOffset returns the number of rows the form's content is scrolled
+// down, as of the last time the form was drawn.
+func (f *Form) GetScrollOffset() int {
+	return f.scrollOffset
+}
+
+// scrollIntoView adjusts the scroll offset so that the given position is
+// visible in the area of the given top row and height, moving as little as
+// possible.
+func (f *Form) scrollIntoView(position formPosition, top, height int) {
+	itemTop := position.y - top
+	if itemTop+position.height > f.scrollOffset+height {
+		f.scrollOffset = itemTop + position.height - height
+	}
+	if itemTop < f.scrollOffset {
+		f.scrollOffset = itemTop
+	}
+}
+
+// setScrollOffset sets the scroll offset, clamped to the content height as of
+// the last draw.
+func (f *Form) setScrollOffset(offset int) {
+	f.scrollOffset = max(min(offset, f.contentHeight-f.viewHeight), 0)
+}
+
+// handlesPageKeys returns whether the given form item uses PageUp and
+// PageDown itself. If not, the form scrolls by a page instead.
+func handlesPageKeys(item Primitive) bool {
+	switch item := item.(type) {
+	case *InputField:
+		return item.fieldType != InputFieldTypeText // Steps the value.
+	case *DropDown:
+		return item.open // Moves in the list.
+	case *Checkbox, *RadioButtons:
+		return false
+	}
+	return true
+}
+
+// page scrolls the form one page up (direction -1) or down (direction 1) and
+// moves the focus to the first enabled item or button which is fully visible
+// on the new page. It returns the resulting command.
+func (f *Form) page(direction int) Command {
+	if f.viewHeight <= 0 || len(f.positions) != len(f.items)+len(f.buttons) {
+		return nil
+	}
+	f.setScrollOffset(f.scrollOffset + direction*f.viewHeight)
+	_, top, _, _ := f.GetInnerRect()
+
+	// Find the item to focus.
+	var target Primitive
+	for index, position := range f.positions {
+		var element interface {
+			Primitive
+			GetDisabled() bool
+		}
+		if index < len(f.items) {
+			element = f.items[index]
+		} else {
+			element = f.buttons[index-len(f.items)]
+		}
+		y := position.y - top
+		if element.GetDisabled() || position.height <= 0 || y < f.scrollOffset || y+position.height > f.scrollOffset+f.viewHeight {
+			continue
+		}
+		target = element
+		break
+	}
+
+	if target == nil || target.HasFocus() {
+		return RedrawCommand{}
+	}
+	return BatchCommand{SetFocusCommand{Target: target}, RedrawCommand{}}
+}
+
+// scrollBarMouseDown handles a left mouse button press at the given screen
+// coordinates if it is on the scrollBar, returning the resulting command, or
+// nil if it is not on the scrollBar.
+func (f *Form) scrollBarMouseDown(x, y int) Command {
+	if !f.scrollBarShown || !f.scrollBar.InRect(x, y) {
+		return nil
+	}
+	_, barY, _, _ := f.scrollBar.GetInnerRect()
+	cmd := BatchCommand{RedrawCommand{}}
+	part, trackPos := f.scrollBar.partAt(y - barY)
+	m := f.scrollBar.metrics(f.scrollBar.length())
+	switch part {
+	case scrollBarPartStartArrow:
+		f.setScrollOffset(f.scrollOffset - f.scrollBar.scrollStep)
+	case scrollBarPartEndArrow:
+		f.setScrollOffset(f.scrollOffset + f.scrollBar.scrollStep)
+	case scrollBarPartTrackBefore, scrollBarPartTrackAfter:
+		if f.scrollBar.trackClickBehavior == TrackClickBehaviorJumpToClick {
+			f.setScrollOffset(f.scrollBar.offsetForThumb(trackPos - m.thumbLen/2))
+			break
+		}
+		page := f.scrollBar.pageLength(f.scrollBar.length())
+		if part == scrollBarPartTrackBefore {
+			page = -page
+		}
+		f.setScrollOffset(f.scrollOffset + page)
+	case scrollBarPartThumb:
+		f.draggingScrollBar = true
+		f.scrollBarDragDelta = trackPos - m.thumbStart
+		cmd = append(cmd, SetMouseCaptureCommand{Target: f})
+	}
+	return cmd
+}
+
+// dragScrollBar moves the scrollBar's thumb to the given screen row.
+func (f *Form) dragScrollBar(y int) {
+	_, barY, _, _ := f.scrollBar.GetInnerRect()
+	index := min(max(y-barY, 0), f.scrollBar.length()-1)
+	_, trackPos := f.scrollBar.partAt(index)
+	f.setScrollOffset(f.scrollBar.offsetForThumb(trackPos - f.scrollBarDragDelta))
+}
+
+// scrollBarMouse handles mouse events on the scrollBar and while dragging its
+// thumb. It returns nil if the event is not meant for the scrollBar.
+func (f *Form) scrollBarMouse(event *MouseEvent) Command {
+	x, y := event.Position()
+	if f.draggingScrollBar {
+		switch event.Action {
+		case MouseMove:
+			f.dragScrollBar(y)
+			return BatchCommand{SetMouseCaptureCommand{Target: f}, RedrawCommand{}}
+		case MouseLeftUp:
+			f.draggingScrollBar = false
+			return BatchCommand{SetMouseCaptureCommand{Target: nil}, RedrawCommand{}}
+		}
+	}
+	if event.Action == MouseLeftDown {
+		return f.scrollBarMouseDown(x, y)
+	}
+	return nil
+}
+
+// scrollWheel scrolls the form by one row for mouse wheel events within the
+// form. It returns nil if the form was not scrolled.
+func (f *Form) scrollWheel(event *MouseEvent) Command {
+	if f.contentHeight <= f.viewHeight || !f.InRect(event.Position()) {
+		return nil
+	}
+	switch event.Action {
+	case MouseScrollUp:
+		f.setScrollOffset(f.scrollOffset - 1)
+	case MouseScrollDown:
+		f.setScrollOffset(f.scrollOffset + 1)
+	default:
+		return nil
+	}
+	return RedrawCommand{}
+}
+
+// itemScrollsAt returns whether the form item at the given screen coordinates
+// scrolls its own content with the mouse wheel. Elsewhere, the wheel scrolls
+// the form.
+func (f *Form) itemScrollsAt(x, y int) bool {
+	for _, item := range f.items {
+		itemX, itemY, width, height := item.GetRect()
+		if x < itemX || x >= itemX+width || y < itemY || y >= itemY+height || item.GetDisabled() {
+			continue
+		}
+		switch item.(type) {
+		case *TextArea, *TextView:
+			return true
+		}
+	}
+	return false
+}
+
+// isPageKey returns whether the given event is PageUp or PageDown, and the
+// direction in which it scrolls.
+func isPageKey(event tcell.Event) (direction int, ok bool) {
+	key, ok := event.(*KeyEvent)
+	if !ok || key.Modifiers() != tcell.ModNone {
+		return 0, false
+	}
+	switch key.Key() {
+	case tcell.KeyPgUp:
+		return -1, true
+	case tcell.KeyPgDn:
+		return 1, true
+	}
+	return 0, false
+}
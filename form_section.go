@@ -0,0 +1,305 @@
+package tview
+
+import "github.com/gdamore/tcell/v3"
+
+// FormSection is the header of a group of form items, see [Form.AddSection].
+// It shows the section's title with an indicator of whether the section is
+// expanded (▼) or collapsed (▶). Enter, Space, or a click toggle the section,
+// Left collapses it, and Right expands it. The items of a collapsed section
+// are not shown and cannot receive focus.
+type FormSection struct {
+	*Box
+
+	// The section's title.
+	title string
+
+	// Whether the section's items are hidden.
+	collapsed bool
+
+	// Whether the header and the items are hidden.
+	hidden bool
+
+	// Whether the header can receive focus.
+	disabled bool
+
+	// The style of the title and of the title while the header has focus.
+	titleStyle, focusStyle tcell.Style
+
+	// An optional function which is called when the section is collapsed or
+	// expanded.
+	changed func(collapsed bool)
+
+	// A callback function set by the Form class and called when the user leaves
+	// this form item.
+	finished func(tcell.Key)
+}
+
+// NewFormSection returns a new expanded form section header with the given
+// title.
+func NewFormSection(title string) *FormSection {
+	return &FormSection{
+		Box:        NewBox(),
+		title:      title,
+		titleStyle: tcell.StyleDefault.Foreground(Styles.TitleColor).Bold(true),
+		focusStyle: tcell.StyleDefault.Background(Styles.PrimaryTextColor).Foreground(Styles.ContrastBackgroundColor).Bold(true),
+	}
+}
+
+// applyTheme updates the section's default styles after a theme change.
+func (s *FormSection) applyTheme(change themeChange) {
+	s.Box.applyTheme(change)
+	s.titleStyle = change.style(s.titleStyle, themeTitle, 0)
+	s.focusStyle = change.style(s.focusStyle, themeContrastBackground, themePrimaryText)
+}
+
+// SetTitle sets the section's title.
+func (s *FormSection) SetTitle(title string) *FormSection {
+	s.title = title
+	return s
+}
+
+// GetTitle returns the section's title.
+func (s *FormSection) GetTitle() string {
+	return s.title
+}
+
+// GetLabel returns the section's title.
+func (s *FormSection) GetLabel() string {
+	return s.title
+}
+
+// SetCollapsed sets whether the section's items are hidden. This triggers the
+// "changed" callback if the state changes with this call.
+func (s *FormSection) SetCollapsed(collapsed bool) *FormSection {
+	if s.collapsed != collapsed {
+		s.collapsed = collapsed
+		if s.changed != nil {
+			s.changed(collapsed)
+		}
+	}
+	return s
+}
+
+// IsCollapsed returns whether the section's items are hidden.
+func (s *FormSection) IsCollapsed() bool {
+	return s.collapsed
+}
+
+// SetVisible sets whether the section, i.e. its header and its items, is
+// shown. Hidden sections keep their collapsed state.
+func (s *FormSection) SetVisible(visible bool) *FormSection {
+	s.hidden = !visible
+	return s
+}
+
+// IsVisible returns whether the section is shown.
+func (s *FormSection) IsVisible() bool {
+	return !s.hidden
+}
+
+// SetTitleStyle sets the style of the title.
+func (s *FormSection) SetTitleStyle(style tcell.Style) *FormSection {
+	s.titleStyle = style
+	return s
+}
+
+// SetActivatedStyle sets the style of the title while the header has focus.
+func (s *FormSection) SetActivatedStyle(style tcell.Style) *FormSection {
+	s.focusStyle = style
+	return s
+}
+
+// SetChangedFunc sets a handler which is called when the section is collapsed
+// or expanded. The handler receives the new state.
+func (s *FormSection) SetChangedFunc(handler func(collapsed bool)) *FormSection {
+	s.changed = handler
+	return s
+}
+
+// SetFormAttributes sets attributes shared by all form items. Only the
+// background color applies to section headers.
+func (s *FormSection) SetFormAttributes(labelWidth int, labelColor, bgColor, fieldTextColor, fieldBgColor tcell.Color) FormItem {
+	s.backgroundColor = bgColor
+	return s
+}
+
+// GetFieldWidth returns this primitive's field width.
+func (s *FormSection) GetFieldWidth() int {
+	return 0
+}
+
+// GetFieldHeight returns this primitive's field height.
+func (s *FormSection) GetFieldHeight() int {
+	return 1
+}
+
+// SetDisabled sets whether the header can receive focus. The section can still
+// be collapsed and expanded with [FormSection.SetCollapsed].
+func (s *FormSection) SetDisabled(disabled bool) FormItem {
+	s.disabled = disabled
+	if s.finished != nil {
+		s.finished(-1)
+	}
+	return s
+}
+
+// GetDisabled returns whether the header can receive focus.
+func (s *FormSection) GetDisabled() bool {
+	return s.disabled
+}
+
+// SetFinishedFunc sets a callback invoked when the user leaves this form item.
+func (s *FormSection) SetFinishedFunc(handler func(key tcell.Key)) FormItem {
+	s.finished = handler
+	return s
+}
+
+// Focus is called when this primitive receives focus.
+func (s *FormSection) Focus(delegate func(p Primitive)) {
+	if s.finished != nil && s.disabled {
+		s.finished(-1)
+		return
+	}
+	s.Box.Focus(delegate)
+}
+
+// Draw draws this primitive onto the screen.
+func (s *FormSection) Draw(screen tcell.Screen) {
+	s.DrawForSubclass(screen, s)
+	x, y, width, height := s.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+	indicator := "▼ "
+	if s.collapsed {
+		indicator = "▶ "
+	}
+	style := s.titleStyle.Background(s.backgroundColor)
+	if s.HasFocus() {
+		style = s.focusStyle
+	}
+	_, _, drawn := printWithStyle(screen, indicator+s.title, x, y, 0, width, AlignmentLeft, style, false)
+
+	// Fill the rest of the row with a line.
+	lineStyle := tcell.StyleDefault.Foreground(s.titleStyle.GetForeground()).Background(s.backgroundColor).Dim(true)
+	for column := x + drawn + 1; column < x+width; column++ {
+		screen.Put(column, y, BoxDrawingsLightHorizontal, lineStyle)
+	}
+}
+
+// HandleEvent handles input events for this primitive.
+func (s *FormSection) HandleEvent(event tcell.Event) Command {
+	if s.disabled {
+		return nil
+	}
+	switch event := event.(type) {
+	case *KeyEvent:
+		switch key := event.Key(); key {
+		case tcell.KeyEnter:
+			s.SetCollapsed(!s.collapsed)
+		case tcell.KeyRune:
+			if event.Str() != " " {
+				return nil
+			}
+			s.SetCollapsed(!s.collapsed)
+		case tcell.KeyLeft:
+			s.SetCollapsed(true)
+		case tcell.KeyRight:
+			s.SetCollapsed(false)
+		case tcell.KeyTab, tcell.KeyBacktab, tcell.KeyEscape, tcell.KeyUp, tcell.KeyDown:
+			if s.finished != nil {
+				switch key {
+				case tcell.KeyUp:
+					key = tcell.KeyBacktab
+				case tcell.KeyDown:
+					key = tcell.KeyTab
+				}
+				s.finished(key)
+			}
+		default:
+			return nil
+		}
+		return RedrawCommand{}
+	case *MouseEvent:
+		if !s.InRect(event.Position()) {
+			return nil
+		}
+		switch event.Action {
+		case MouseLeftDown:
+			return SetFocusCommand{Target: s}
+		case MouseLeftClick:
+			s.SetCollapsed(!s.collapsed)
+			return RedrawCommand{}
+		}
+	}
+	return nil
+}
+
+// Inspect describes this primitive in the inspection tree. See [Inspect].
+func (s *FormSection) Inspect(node *InspectNode) {
+	node.Role = RoleGroup
+	node.Label = s.title
+	node.Expanded = !s.collapsed
+	node.Disabled = s.disabled
+}
+
+// AddSection adds a section header with the given title to the form. The
+// items added after it, up to the next section or [Form.EndSection], belong
+// to the section. They are indented (see [Form.SetSectionIndent]) and hidden
+// while the section is collapsed. Use [Form.GetSection] to collapse, expand,
+// or hide the section.
+//
+// In horizontal layouts, section headers occupy a row of their own.
+func (f *Form) AddSection(title string, collapsed bool) *Form {
+	section := NewFormSection(title).SetCollapsed(collapsed)
+	f.AddFormItem(section)
+	f.currentSection = section
+	return f
+}
+
+// EndSection ends the current section. Items added afterwards don't belong
+// to any section.
+func (f *Form) EndSection() *Form {
+	f.currentSection = nil
+	return f
+}
+
+// GetSection returns the header of the first section with the given title, or
+// nil if there is no such section.
+func (f *Form) GetSection(title string) *FormSection {
+	for _, item := range f.items {
+		if section, ok := item.(*FormSection); ok && section.title == title {
+			return section
+		}
+	}
+	return nil
+}
+
+// GetItemSection returns the header of the section the given item belongs
+// to, or nil if it does not belong to a section.
+func (f *Form) GetItemSection(item FormItem) *FormSection {
+	return f.itemSections[item]
+}
+
+// SetSectionIndent sets the number of cells by which the items of sections
+// are indented. The default is 2.
+func (f *Form) SetSectionIndent(indent int) *Form {
+	f.sectionIndent = max(indent, 0)
+	return f
+}
+
+// itemIndent returns the number of cells by which the given item is
+// indented.
+func (f *Form) itemIndent(item FormItem) int {
+	if f.itemSections[item] != nil {
+		return f.sectionIndent
+	}
+	return 0
+}
+
+// reveal expands the collapsed section of the given item, if any.
+func (f *Form) reveal(item FormItem) {
+	if section := f.itemSections[item]; section != nil {
+		section.SetCollapsed(false)
+	}
+}
@@ -0,0 +1,131 @@
+package tview
+
+import "github.com/gdamore/tcell/v3"
+
+// Validator is implemented by form items which validate their own value, e.g.
+// [InputField], and by validators attached to form items with
+// [Form.SetItemValidator]. Validate returns nil if the value is valid.
+type Validator interface {
+	Validate() error
+}
+
+// ValidatorFunc is a function which implements [Validator].
+type ValidatorFunc func() error
+
+// Validate calls the function.
+func (v ValidatorFunc) Validate() error {
+	return v()
+}
+
+// SetItemValidator attaches a validator to the given form item, e.g. a
+// [ValidatorFunc] which checks that a [Checkbox] is checked. It is called by
+// [Form.Validate] after the item's own validation, if any, and when the user
+// finishes the item. While the validator returns an error, the item's label is
+// drawn in the invalid label color (see [Form.SetInvalidLabelColor]). A nil
+// validator removes the item's validator.
+func (f *Form) SetItemValidator(item FormItem, validator Validator) *Form {
+	delete(f.itemErrors, item)
+	if validator == nil {
+		delete(f.validators, item)
+		return f
+	}
+	if f.validators == nil {
+		f.validators = make(map[FormItem]Validator)
+	}
+	f.validators[item] = validator
+	return f
+}
+
+// SetInvalidLabelColor sets the color of the labels of invalid form items.
+// Input fields are invalid while they show a validation error (see
+// [InputField.SetValidateFunc]), other items while their validator (see
+// [Form.SetItemValidator]) returned an error the last time it was called.
+func (f *Form) SetInvalidLabelColor(color tcell.Color) *Form {
+	f.invalidLabelColor = color
+	return f
+}
+
+// SetSubmitButton sets the index of the button which submits the form, e.g.
+// an "OK" button. The form disables this button while any of its enabled
+// items are invalid, without showing the items' errors until the user edits
+// them or the form is validated (see [Form.Validate]). A negative value (the
+// default) means that no button depends on the form's validity.
+func (f *Form) SetSubmitButton(index int) *Form {
+	f.submitButton = index
+	return f
+}
+
+// Validate validates all enabled form items, calling the validation of items
+// which implement [Validator], e.g. input fields with a validation function
+// (see [InputField.SetValidateFunc]), and the validators attached with
+// [Form.SetItemValidator]. Hidden items (see [Form.SetItemVisible]) are
+// skipped but the items of collapsed sections are validated. It returns the
+// errors in item order, at most one per item, or nil if all items are valid.
+func (f *Form) Validate() []FieldError {
+	var errs []FieldError
+	for index, item := range f.items {
+		if item.GetDisabled() || f.isOmitted(item) {
+			continue
+		}
+		if err := f.validateItem(item); err != nil {
+			errs = append(errs, FieldError{Index: index, Label: item.GetLabel(), Err: err})
+		}
+	}
+	return errs
+}
+
+// validateItem validates the given item with its own validation and its
+// attached validator and returns the first error.
+func (f *Form) validateItem(item FormItem) error {
+	var err error
+	if validator, ok := item.(Validator); ok {
+		err = validator.Validate()
+	}
+	if validator := f.validators[item]; validator != nil {
+		attachedErr := validator.Validate()
+		if attachedErr != nil {
+			if f.itemErrors == nil {
+				f.itemErrors = make(map[FormItem]error)
+			}
+			f.itemErrors[item] = attachedErr
+		} else {
+			delete(f.itemErrors, item)
+		}
+		if err == nil {
+			err = attachedErr
+		}
+	}
+	return err
+}
+
+// isValid returns whether all enabled, not hidden form items are valid,
+// without updating the error display of the items.
+func (f *Form) isValid() bool {
+	for _, item := range f.items {
+		if item.GetDisabled() || f.isOmitted(item) {
+			continue
+		}
+		switch item := item.(type) {
+		case *InputField:
+			if item.typeError() != nil || item.validate != nil && item.validate(item.GetText()) != nil {
+				return false
+			}
+		case Validator:
+			if item.Validate() != nil {
+				return false
+			}
+		}
+		if validator := f.validators[item]; validator != nil && validator.Validate() != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// isInvalid returns whether the given item currently shows as invalid.
+func (f *Form) isInvalid(item FormItem) bool {
+	if input, ok := item.(*InputField); ok && input.GetValidationError() != nil {
+		return true
+	}
+	return f.itemErrors[item] != nil
+}
@@ -0,0 +1,77 @@
+package tview
+
+// SetItemVisible sets whether the form item at the given index (not including
+// buttons) is shown. Hidden items take no space, are skipped when moving the
+// focus, and are not validated. If a hidden item has focus, the focus moves to
+// the next item the next time the form handles an event. This replaces a
+// function set with [Form.SetItemVisibleFunc].
+func (f *Form) SetItemVisible(index int, visible bool) *Form {
+	if visible {
+		return f.SetItemVisibleFunc(f.items[index], nil)
+	}
+	return f.SetItemVisibleFunc(f.items[index], func() bool { return false })
+}
+
+// SetItemVisibleFunc sets a function which determines whether the given form
+// item is shown (see [Form.SetItemVisible]). It is called whenever the form is
+// laid out or moves the focus, so the form follows the state of other items
+// without further calls. For example, to show a "Proxy host" field only while
+// a "Use proxy" checkbox is checked:
+//
+//	form.SetItemVisibleFunc(proxyHost, useProxy.IsChecked)
+//
+// The function must not change the form. Hiding a section header (see
+// [Form.AddSection]) also hides the section's items. A nil function shows the
+// item again.
+func (f *Form) SetItemVisibleFunc(item FormItem, visible func() bool) *Form {
+	if visible == nil {
+		delete(f.itemVisibility, item)
+		return f
+	}
+	if f.itemVisibility == nil {
+		f.itemVisibility = make(map[FormItem]func() bool)
+	}
+	f.itemVisibility[item] = visible
+	return f
+}
+
+// IsItemVisible returns whether the form item at the given index (not
+// including buttons) is currently shown. Items of collapsed or hidden sections
+// are not shown.
+func (f *Form) IsItemVisible(index int) bool {
+	return !f.isHidden(f.items[index])
+}
+
+// isHidden returns whether the given item is not shown, because it is hidden
+// or belongs to a collapsed section.
+func (f *Form) isHidden(item FormItem) bool {
+	if f.isOmitted(item) {
+		return true
+	}
+	section := f.itemSections[item]
+	return section != nil && section.collapsed
+}
+
+// isOmitted returns whether the given item is hidden, either itself, with its
+// section, or because it is the header of a hidden section. Unlike the items
+// of collapsed sections, omitted items are not validated.
+func (f *Form) isOmitted(item FormItem) bool {
+	if visible := f.itemVisibility[item]; visible != nil && !visible() {
+		return true
+	}
+	if section, ok := item.(*FormSection); ok {
+		return section.hidden
+	}
+	if section := f.itemSections[item]; section != nil {
+		return f.isOmitted(section)
+	}
+	return false
+}
+
+// refocus moves the focus away from a focused item which is no longer shown.
+func (f *Form) refocus() {
+	focus := f.focusIndex()
+	if focus >= 0 && focus < len(f.items) && f.isHidden(f.items[focus]) {
+		f.moveFocus(focus, 1)
+	}
+}
@@ -217,3 +217,9 @@ func (f *Frame) HandleEvent(event tcell.Event) Command {
 	}
 	return nil
 }
+
+// Inspect describes this primitive in the inspection tree. See [Inspect].
+func (f *Frame) Inspect(node *InspectNode) {
+	f.Box.Inspect(node)
+	node.AddPrimitive(f.primitive)
+}
@@ -0,0 +1,139 @@
+package tview
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+// Gauge displays a value as a horizontal bar, e.g. the CPU load in a
+// [Dashboard]. The bar fills the gauge's inner area, with a resolution of an
+// eighth of a cell. The value is printed as a percentage in the middle of the
+// bar, or as the text set with [Gauge.SetText].
+type Gauge struct {
+	*Box
+
+	// The current value and the value of a full bar.
+	value, max float64
+
+	// The text printed on the bar. If empty, the percentage is printed.
+	text string
+
+	// If set to true, no text is printed on the bar.
+	hideText bool
+
+	// The style of the filled and the empty part of the bar.
+	filledStyle, emptyStyle tcell.Style
+}
+
+// NewGauge returns a new empty gauge with a maximum value of 100.
+func NewGauge() *Gauge {
+	return &Gauge{
+		Box:         NewBox(),
+		max:         100,
+		filledStyle: tcell.StyleDefault.Foreground(Styles.PrimaryTextColor),
+		emptyStyle:  tcell.StyleDefault.Foreground(Styles.PrimaryTextColor).Background(Styles.ContrastBackgroundColor),
+	}
+}
+
+// applyTheme updates the gauge's default styles after a theme change.
+func (g *Gauge) applyTheme(change themeChange) {
+	g.Box.applyTheme(change)
+	g.filledStyle = change.style(g.filledStyle, themePrimaryText, 0)
+	g.emptyStyle = change.style(g.emptyStyle, themePrimaryText, themeContrastBackground)
+}
+
+// SetValue sets the current value. Values below 0 are drawn as an empty bar,
+// values above the maximum value as a full bar.
+func (g *Gauge) SetValue(value float64) *Gauge {
+	g.value = value
+	return g
+}
+
+// GetValue returns the current value.
+func (g *Gauge) GetValue() float64 {
+	return g.value
+}
+
+// SetMax sets the value of a full bar. Values smaller than or equal to 0 are
+// ignored.
+func (g *Gauge) SetMax(max float64) *Gauge {
+	if max > 0 {
+		g.max = max
+	}
+	return g
+}
+
+// SetText sets the text printed on the bar in place of the percentage. An
+// empty string restores the percentage.
+func (g *Gauge) SetText(text string) *Gauge {
+	g.text = text
+	return g
+}
+
+// SetTextVisible sets whether text is printed on the bar.
+func (g *Gauge) SetTextVisible(visible bool) *Gauge {
+	g.hideText = !visible
+	return g
+}
+
+// SetFilledStyle sets the style of the filled part of the bar. The bar is
+// drawn with the style's foreground color.
+func (g *Gauge) SetFilledStyle(style tcell.Style) *Gauge {
+	g.filledStyle = style
+	return g
+}
+
+// SetEmptyStyle sets the style of the empty part of the bar, which is drawn
+// with the style's background color, and of the text printed on it.
+func (g *Gauge) SetEmptyStyle(style tcell.Style) *Gauge {
+	g.emptyStyle = style
+	return g
+}
+
+// Draw draws this primitive onto the screen.
+func (g *Gauge) Draw(screen tcell.Screen) {
+	g.DrawForSubclass(screen, g)
+	x, y, width, height := g.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	ratio := min(max(g.value/g.max, 0), 1)
+	if math.IsNaN(ratio) {
+		ratio = 0
+	}
+	filled := ratio * float64(width)
+	barStyle := g.emptyStyle.Foreground(g.filledStyle.GetForeground())
+	for column := range width {
+		glyph := FractionGlyph(filled-float64(column), ScrollBarHorizontal)
+		for row := range height {
+			screen.Put(x+column, y+row, glyph, barStyle)
+		}
+	}
+
+	// Print the text, inverting the colors over the filled part.
+	if g.hideText {
+		return
+	}
+	text := g.text
+	if text == "" {
+		text = strconv.Itoa(int(math.Round(ratio*100))) + "%"
+	}
+	row := y + height/2
+	inverted := g.emptyStyle.Foreground(g.emptyStyle.GetBackground()).Background(g.filledStyle.GetForeground())
+	for _, cluster := range PrintWithPositions(screen, text, x, row, width, AlignmentCenter, g.emptyStyle) {
+		if filled-float64(cluster.X-x) >= 0.5 {
+			str, _, _ := screen.Get(cluster.X, row)
+			screen.Put(cluster.X, row, str, inverted)
+		}
+	}
+}
+
+// Inspect describes this primitive in the inspection tree. See [Inspect].
+func (g *Gauge) Inspect(node *InspectNode) {
+	g.Box.Inspect(node)
+	node.Role = RoleProgressBar
+	node.Value = strconv.FormatFloat(g.value, 'g', -1, 64)
+}
@@ -5,6 +5,7 @@ go 1.25.3
 require (
 	github.com/gdamore/tcell/v3 v3.1.2
 	github.com/rivo/uniseg v0.4.7
+	golang.org/x/text v0.34.0
 )
 
 require (
@@ -12,5 +13,4 @@ require (
 	github.com/lucasb-eyer/go-colorful v1.3.0 // indirect
 	golang.org/x/sys v0.41.0 // indirect
 	golang.org/x/term v0.40.0 // indirect
-	golang.org/x/text v0.34.0 // indirect
 )
@@ -0,0 +1,108 @@
+package tview
+
+import (
+	"math"
+	"strings"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+// RainbowColors are the colors of the gradient used by [RainbowSegments].
+var RainbowColors = []tcell.Color{
+	tcell.NewRGBColor(0xe8, 0x1d, 0x1d),
+	tcell.NewRGBColor(0xe8, 0xb6, 0x1d),
+	tcell.NewRGBColor(0x36, 0xe8, 0x1d),
+	tcell.NewRGBColor(0x1d, 0xe8, 0xd2),
+	tcell.NewRGBColor(0x1d, 0x4f, 0xe8),
+	tcell.NewRGBColor(0x9b, 0x1d, 0xe8),
+}
+
+// GradientSegments returns segments of the given text whose foreground colors
+// form a gradient through the given colors, from the first column of the text
+// to its last, e.g. for banners and headers. The other attributes are taken
+// from the given style. Adjacent grapheme clusters of the same color share a
+// segment. The segments can be added to a [TextView] with
+// [TextView.AppendSegments] or turned into lines with [LineBuilder].
+//
+// Colors are interpolated in RGB. On terminals without truecolor support,
+// tcell draws the nearest colors of the terminal's palette, e.g. of the 256
+// colors of xterm. Colors without an RGB value, e.g. [tcell.ColorDefault], are
+// not interpolated but switch halfway to the next color. If the text spans
+// multiple lines, all lines use the columns of the widest line, so that the
+// colors of multi-line banners line up. Without colors, the text is returned
+// as one segment.
+func GradientSegments(text string, style tcell.Style, colors ...tcell.Color) []Segment {
+	if len(colors) == 0 {
+		return []Segment{NewSegment(text, style)}
+	}
+
+	// Determine the width of the widest line.
+	var width int
+	for line := range strings.SplitSeq(text, "\n") {
+		width = max(width, TaggedStringWidth(line))
+	}
+
+	// Assign colors by column.
+	var (
+		segments []Segment
+		state    *stepState
+		column   int
+		start    int
+	)
+	rest := text
+	for len(rest) > 0 {
+		var cluster string
+		cluster, rest, state = step(rest, state)
+		color := gradientColor(colors, column, width)
+		if cluster == "\n" || cluster == "\r\n" {
+			column = 0
+		} else {
+			column += state.Width()
+		}
+		end := len(text) - len(rest)
+		if n := len(segments); n > 0 && segments[n-1].Style.GetForeground() == color {
+			segments[n-1].Text = text[start:end]
+			continue
+		}
+		start = end - len(cluster)
+		segments = append(segments, NewSegment(cluster, style.Foreground(color)))
+	}
+	return segments
+}
+
+// RainbowSegments returns segments of the given text colored with a rainbow
+// gradient, see [GradientSegments] and [RainbowColors].
+func RainbowSegments(text string, style tcell.Style) []Segment {
+	return GradientSegments(text, style, RainbowColors...)
+}
+
+// AppendGradient appends the given text to the text view with a gradient
+// through the given colors, using the default text style for the other
+// attributes. See [GradientSegments].
+func (t *TextView) AppendGradient(text string, colors ...tcell.Color) *TextView {
+	return t.AppendSegments(GradientSegments(text, t.textStyle, colors...)...)
+}
+
+// gradientColor returns the color of the given column of a gradient through
+// the given colors which spans the given width.
+func gradientColor(colors []tcell.Color, column, width int) tcell.Color {
+	if len(colors) == 1 || width <= 1 {
+		return colors[0]
+	}
+	position := float64(min(column, width-1)) / float64(width-1) * float64(len(colors)-1)
+	index := min(int(position), len(colors)-2)
+	fraction := position - float64(index)
+	from, to := colors[index].TrueColor(), colors[index+1].TrueColor()
+	if !from.Valid() || !to.Valid() {
+		if fraction < 0.5 {
+			return colors[index]
+		}
+		return colors[index+1]
+	}
+	r1, g1, b1 := from.RGB()
+	r2, g2, b2 := to.RGB()
+	lerp := func(a, b int32) int32 {
+		return a + int32(math.Round(float64(b-a)*fraction))
+	}
+	return tcell.NewRGBColor(lerp(r1, r2), lerp(g1, g2), lerp(b1, b2))
+}
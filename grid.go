@@ -75,6 +75,12 @@ func NewGrid() *Grid {
 	return g
 }
 
+// applyTheme updates the grid's default styles after a theme change.
+func (g *Grid) applyTheme(change themeChange) {
+	g.Box.applyTheme(change)
+	g.bordersColor = change.color(g.bordersColor, themeGraphics)
+}
+
 // SetColumns defines how the columns of the grid are distributed. Each value
 // defines the size of one column, starting with the leftmost column. Values
 // greater than 0 represent absolute column widths (gaps not included). Values
@@ -753,3 +759,13 @@ func (g *Grid) HandleEvent(event tcell.Event) Command {
 	}
 	return nil
 }
+
+// Inspect describes this primitive in the inspection tree. See [Inspect].
+func (g *Grid) Inspect(node *InspectNode) {
+	g.Box.Inspect(node)
+	for _, item := range g.items {
+		if item.visible {
+			node.AddPrimitive(item.Item)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package help
+
+import (
+	"strings"
+
+	"github.com/ayn2op/tview"
+)
+
+// FullHelpText renders the key map's full help as plain text, e.g. for a
+// "--help-keys" command line flag. Columns are laid out side by side as in full
+// help mode, but never truncated. Trailing whitespace is removed from every
+// line. It returns an empty string if no key map is set.
+func (h *Help) FullHelpText() string {
+	if h.keyMap == nil {
+		return ""
+	}
+	lines := h.FullHelpLines(h.keyMap.FullHelp(), 0)
+	if len(lines) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(strings.TrimRight(line, " "))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// FullHelpMarkdown renders the key map's full help as Markdown, e.g. for
+// generated documentation. Each full help column becomes a table with "Key"
+// and "Description" columns (translated with [tview.Translate]), separated by
+// blank lines. Table cells are padded so the source is aligned. Keys are
+// formatted as code spans. It returns an empty string if no key map is set.
+func (h *Help) FullHelpMarkdown() string {
+	if h.keyMap == nil {
+		return ""
+	}
+
+	keyHeader := markdownEscape(tview.Translate(tview.MessageHelpKey, ""))
+	descHeader := markdownEscape(tview.Translate(tview.MessageHelpDescription, ""))
+	var b strings.Builder
+	for index, col := range h.fullHelpColumns(h.keyMap.FullHelp()) {
+		if index > 0 {
+			b.WriteByte('\n')
+		}
+
+		keys := make([]string, len(col.entries))
+		descs := make([]string, len(col.entries))
+		keyW, descW := tview.TaggedStringWidth(keyHeader), tview.TaggedStringWidth(descHeader)
+		for row, e := range col.entries {
+			if e.key != "" {
+				keys[row] = markdownCode(e.key)
+			}
+			descs[row] = markdownEscape(e.desc)
+			keyW = max(keyW, tview.TaggedStringWidth(keys[row]))
+			descW = max(descW, tview.TaggedStringWidth(descs[row]))
+		}
+
+		writeMarkdownRow(&b, keyHeader, keyW, descHeader, descW)
+		writeMarkdownRow(&b, strings.Repeat("-", keyW), keyW, strings.Repeat("-", descW), descW)
+		for row := range col.entries {
+			writeMarkdownRow(&b, keys[row], keyW, descs[row], descW)
+		}
+	}
+	return b.String()
+}
+
+// writeMarkdownRow writes one Markdown table row, padding each cell to the
+// given width.
+func writeMarkdownRow(b *strings.Builder, key string, keyW int, desc string, descW int) {
+	b.WriteString("| ")
+	b.WriteString(key)
+	b.WriteString(strings.Repeat(" ", max(keyW-tview.TaggedStringWidth(key), 0)))
+	b.WriteString(" | ")
+	b.WriteString(desc)
+	b.WriteString(strings.Repeat(" ", max(descW-tview.TaggedStringWidth(desc), 0)))
+	b.WriteString(" |\n")
+}
+
+// markdownCode returns the text as a Markdown code span usable in a table cell.
+func markdownCode(text string) string {
+	// The fence must be longer than any backtick run in the text.
+	fence, run := "`", 0
+	for _, r := range text {
+		if r == '`' {
+			run++
+			if run >= len(fence) {
+				fence += "`"
+			}
+		} else {
+			run = 0
+		}
+	}
+	text = strings.ReplaceAll(text, "|", `\|`)
+	if strings.HasPrefix(text, "`") || strings.HasSuffix(text, "`") {
+		text = " " + text + " "
+	}
+	return fence + text + fence
+}
+
+// markdownEscape escapes characters which would otherwise be interpreted as
+// Markdown in a table cell.
+func markdownEscape(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		if strings.ContainsRune("\\`*_[]<>|#", r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -31,8 +31,8 @@ func New() *Help {
 	return &Help{
 		Box:            tview.NewBox(),
 		Styles:         DefaultStyles(),
-		shortSeparator: " • ",
-		fullSeparator:  "    ",
+		shortSeparator: tview.Translate(tview.MessageHelpShortSeparator, ""),
+		fullSeparator:  tview.Translate(tview.MessageHelpFullSeparator, ""),
 		ellipsis:       "…",
 	}
 }
@@ -165,17 +165,20 @@ func (h *Help) shortHelpSegments(bindings []keybind.Keybind, maxWidth int) []seg
 	return out
 }
 
-func (h *Help) fullHelpSegments(groups [][]keybind.Keybind, maxWidth int) [][]segment {
-	type entry struct {
-		key  string
-		desc string
-	}
-	type column struct {
-		entries []entry
-		keyW    int
-		colW    int
-	}
+type entry struct {
+	key  string
+	desc string
+}
 
+type column struct {
+	entries []entry
+	keyW    int
+	colW    int
+}
+
+// fullHelpColumns lays out grouped help into columns, skipping empty entries
+// and groups.
+func (h *Help) fullHelpColumns(groups [][]keybind.Keybind) []column {
 	columns := make([]column, 0, len(groups))
 	for _, group := range groups {
 		col := column{}
@@ -207,7 +210,11 @@ func (h *Help) fullHelpSegments(groups [][]keybind.Keybind, maxWidth int) [][]se
 		}
 		columns = append(columns, col)
 	}
+	return columns
+}
 
+func (h *Help) fullHelpSegments(groups [][]keybind.Keybind, maxWidth int) [][]segment {
+	columns := h.fullHelpColumns(groups)
 	if len(columns) == 0 {
 		return nil
 	}
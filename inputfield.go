@@ -1,6 +1,9 @@
 package tview
 
 import (
+	"context"
+	"time"
+
 	"github.com/gdamore/tcell/v3"
 	"github.com/rivo/uniseg"
 )
@@ -9,12 +12,23 @@ import (
 // [InputField.SetAcceptanceFunc] to accept or reject input,
 // [InputField.SetChangedFunc] to listen for changes, and
 // [InputField.SetMaskCharacter] to hide input from onlookers (e.g. for password
-// input).
+// input). Use [InputField.SetAutocompleteFunc] to offer suggestions in a
+// drop-down list below the input field. Use [InputField.SetSegments] for input
+// made of fixed-width segments, e.g. IP addresses or dates, and
+// [InputField.SetFieldType] for numbers, dates, or durations.
 //
 // Navigation and editing is the same as for a [TextArea], with the following
 // exceptions:
 //
 //   - Tab, BackTab, Enter, Escape: Finish editing.
+//   - Up arrow, Down arrow, Page Up, Page Down: Change the value of typed
+//     fields (see [InputField.SetFieldType]).
+//
+// While the autocomplete list is visible:
+//
+//   - Down arrow, Up arrow: Select the next/previous entry.
+//   - Enter, Tab: Accept the selected entry (if one is selected).
+//   - Escape: Close the autocomplete list.
 //
 // Note that while pressing Tab or Enter is intercepted by the input field, it
 // is possible to paste such characters into the input field, possibly resulting
@@ -43,15 +57,54 @@ type InputField struct {
 	// A callback function set by the Form class and called when the user leaves
 	// this form item.
 	finished func(tcell.Key)
+
+	// The autocomplete drop-down list.
+	autocompleter *autocompleter
+
+	// The state of segmented input mode, or nil if it is not enabled.
+	segments *inputSegments
+
+	// An optional function which decides whether a character may be entered
+	// into a segment in segmented input mode.
+	segmentAccept func(segment int, char string) bool
+
+	// An optional function which decides whether typed or pasted text may be
+	// entered, see [InputField.SetAcceptanceFunc].
+	accept func(textToCheck string, lastChar rune) bool
+
+	// The type of values entered into the input field.
+	fieldType InputFieldType
+
+	// The amount by which the arrow keys change typed values, or 0 for the
+	// type's default.
+	step float64
+
+	// An optional function which validates the text, see
+	// [InputField.SetValidateFunc].
+	validate func(text string) error
+
+	// The error returned by the last validation, or nil if the text is valid.
+	validationError error
+
+	// The style applied on top of the field and border styles while the text
+	// is invalid. It is also the style of the default error message.
+	errorStyle tcell.Style
+
+	// An optional function which returns the error message shown below the
+	// input field.
+	errorRenderer func(err error) Line
 }
 
 // NewInputField returns a new input field.
 func NewInputField() *InputField {
 	i := &InputField{
-		Box:      NewBox(),
-		textArea: NewTextArea().SetWrap(false),
+		Box:           NewBox(),
+		textArea:      NewTextArea().SetWrap(false),
+		autocompleter: newAutocompleter(),
+		errorStyle:    tcell.StyleDefault.Foreground(tcell.ColorRed),
 	}
 	i.textArea.SetChangedFunc(func() {
+		i.runValidation()
 		if i.changed != nil {
 			i.changed(i.textArea.GetText())
 		}
@@ -60,9 +113,27 @@ func NewInputField() *InputField {
 	return i
 }
 
+// applyTheme updates the input field's default styles after a theme change.
+func (i *InputField) applyTheme(change themeChange) {
+	i.Box.applyTheme(change)
+	i.textArea.textStyle = change.style(i.textArea.textStyle, themePrimaryText, themeContrastBackground)
+	i.autocompleter.style = change.style(i.autocompleter.style, themePrimitiveBackground, themeMoreContrastBackground)
+	i.autocompleter.selectedStyle = change.style(i.autocompleter.selectedStyle, themePrimitiveBackground, themePrimaryText)
+}
+
 // SetText sets the current text of the input field. This can be undone by the
 // user. Calling this function will also trigger a "changed" event.
 func (i *InputField) SetText(text string) *InputField {
+	if i.segments != nil {
+		if i.segments.text() != text {
+			i.segments.setText(text)
+			i.runValidation()
+			if i.changed != nil {
+				i.changed(i.segments.text())
+			}
+		}
+		return i
+	}
 	if i.textArea.GetText() != text {
 		i.textArea.Replace(0, i.textArea.GetTextLength(), text)
 	}
@@ -71,6 +142,9 @@ func (i *InputField) SetText(text string) *InputField {
 
 // GetText returns the current text of the input field.
 func (i *InputField) GetText() string {
+	if i.segments != nil {
+		return i.segments.text()
+	}
 	return i.textArea.GetText()
 }
 
@@ -160,8 +234,13 @@ func (i *InputField) GetFieldWidth() int {
 	return i.fieldWidth
 }
 
-// GetFieldHeight returns this primitive's field height.
+// GetFieldHeight returns this primitive's field height. It includes the row
+// of the error message while the text is invalid, see
+// [InputField.SetValidateFunc].
 func (i *InputField) GetFieldHeight() int {
+	if i.validationError != nil {
+		return 2
+	}
 	return 1
 }
 
@@ -203,6 +282,91 @@ func (i *InputField) SetChangedFunc(handler func(text string)) *InputField {
 	return i
 }
 
+// SetAutocompleteFunc sets a function which returns suggestions for the
+// current text. The suggestions are shown in a drop-down list below the input
+// field. Returning no suggestions closes the list. A nil function disables
+// autocomplete.
+//
+// The function is called in its own goroutine so it may block, e.g. while
+// querying a network service. The provided context is cancelled as soon as the
+// user changes the text again, the list is closed, or the input field loses
+// focus, and results of cancelled lookups are discarded. The function must not
+// access the input field or other primitives.
+//
+// To match suggestions the same way as the package's other search features,
+// use [ContainsFold], [HasPrefixFold], or [FuzzyMatch], e.g.:
+//
+//	field.SetAutocompleteFunc(func(ctx context.Context, text string) (entries []string) {
+//		for _, word := range words {
+//			if tview.HasPrefixFold(word, text) {
+//				entries = append(entries, word)
+//			}
+//		}
+//		return
+//	})
+func (i *InputField) SetAutocompleteFunc(handler func(ctx context.Context, text string) []string) *InputField {
+	i.autocompleter.setLookup(autocompleteStrings(handler))
+	return i
+}
+
+// SetAutocompleteEntriesFunc is like [InputField.SetAutocompleteFunc] but the
+// function returns entries which may be displayed differently from the text
+// they insert, carry a description, and be styled individually. It replaces
+// any function set with [InputField.SetAutocompleteFunc] or
+// [InputField.SetAutocompleteAsyncFunc].
+func (i *InputField) SetAutocompleteEntriesFunc(handler func(ctx context.Context, text string) []AutocompleteEntry) *InputField {
+	i.autocompleter.setLookup(handler)
+	return i
+}
+
+// SetAutocompleteAsyncFunc sets a function which delivers suggestions for the
+// current text by calling the provided respond function instead of returning
+// them. This allows the function to return immediately and respond later, e.g.
+// when a network call has completed, and to respond more than once, e.g. with
+// local results first and remote results later. Each response replaces the
+// entries of the drop-down list. An empty response closes the list. The first
+// response ends the loading indicator. A nil function disables autocomplete.
+//
+// The respond function may be called from any goroutine. Each response
+// triggers a redraw through the application's event loop. Responses
+// after the provided context was cancelled (see
+// [InputField.SetAutocompleteFunc]) are discarded. The function must not
+// access the input field or other primitives.
+func (i *InputField) SetAutocompleteAsyncFunc(handler func(ctx context.Context, text string, respond func(entries []AutocompleteEntry))) *InputField {
+	i.autocompleter.setAsyncLookup(handler)
+	return i
+}
+
+// SetAutocompleteDebounce sets the time to wait after the last change of the
+// text before the autocomplete function is invoked. Changes within this time
+// restart the wait. A value of 0 invokes it on every change.
+func (i *InputField) SetAutocompleteDebounce(debounce time.Duration) *InputField {
+	i.autocompleter.setDebounce(debounce)
+	return i
+}
+
+// SetAutocompleteLoadingText sets the text shown in the autocomplete drop-down
+// list while suggestions are being looked up. An empty string disables the
+// loading indicator.
+func (i *InputField) SetAutocompleteLoadingText(text string) *InputField {
+	i.autocompleter.setLoadingText(text)
+	return i
+}
+
+// SetAutocompleteMaxHeight sets the maximum number of rows of the autocomplete
+// drop-down list.
+func (i *InputField) SetAutocompleteMaxHeight(height int) *InputField {
+	i.autocompleter.setMaxHeight(height)
+	return i
+}
+
+// SetAutocompleteStyles sets the styles of the autocomplete drop-down list
+// entries and of the selected entry.
+func (i *InputField) SetAutocompleteStyles(main, selected tcell.Style) *InputField {
+	i.autocompleter.setStyles(main, selected)
+	return i
+}
+
 // SetDoneFunc sets a handler which is called when the user is done entering
 // text. The callback function is provided with the key that was pressed, which
 // is one of the following:
@@ -241,12 +405,21 @@ func (i *InputField) HasFocus() bool {
 
 // Blur is called when this primitive loses focus.
 func (i *InputField) Blur() {
+	i.autocompleter.close()
 	i.textArea.Blur()
 	i.Box.Blur()
 }
 
 // Draw draws this primitive onto the screen.
 func (i *InputField) Draw(screen tcell.Screen) {
+	i.updateTheme(i)
+	if i.validationError != nil {
+		borderStyle := i.borderStyle
+		i.borderStyle = mergeStyle(borderStyle, i.errorStyle)
+		defer func() {
+			i.borderStyle = borderStyle
+		}()
+	}
 	i.DrawForSubclass(screen, i)
 
 	// Prepare
@@ -255,6 +428,12 @@ func (i *InputField) Draw(screen tcell.Screen) {
 		return
 	}
 
+	if i.segments != nil {
+		fieldX, fieldWidth := i.drawSegments(screen, x, y, width)
+		i.drawValidationError(screen, fieldX, y+1, fieldWidth, height-1)
+		return
+	}
+
 	// Resize text area.
 	labelWidth := i.textArea.GetLabelWidth()
 	if labelWidth == 0 {
@@ -268,8 +447,35 @@ func (i *InputField) Draw(screen tcell.Screen) {
 	i.textArea.setMinCursorPadding(fieldWidth-1, 1)
 
 	// Draw text area.
+	if i.validationError != nil {
+		textStyle := i.textArea.textStyle
+		i.textArea.textStyle = mergeStyle(textStyle, i.errorStyle)
+		defer func() {
+			i.textArea.textStyle = textStyle
+		}()
+	}
 	i.textArea.hasFocus = i.HasFocus() // Force cursor positioning.
 	i.textArea.Draw(screen)
+	i.drawValidationError(screen, x+labelWidth, y+1, fieldWidth, height-1)
+
+	// Draw autocomplete list.
+	i.autocompleter.draw(screen, x+labelWidth, y, fieldWidth)
+}
+
+// forwardToTextArea passes the event on to the text area and starts an
+// autocomplete lookup if this changed the text.
+func (i *InputField) forwardToTextArea(event tcell.Event) Command {
+	if !i.acceptsEvent(event) {
+		return BellCommand{Target: i}
+	}
+	before := i.textArea.GetText()
+	cmd := i.textArea.HandleEvent(event)
+	if text := i.textArea.GetText(); text != before {
+		if lookup := i.autocompleter.start(text); lookup != nil {
+			return BatchCommand{cmd, lookup, RedrawCommand{}}
+		}
+	}
+	return cmd
 }
 
 // HandleEvent handles input events for this primitive.
@@ -282,6 +488,7 @@ func (i *InputField) HandleEvent(event tcell.Event) Command {
 	case *KeyEvent:
 		// Finish up.
 		finish := func(key tcell.Key) {
+			i.runValidation()
 			if i.done != nil {
 				i.done(key)
 			}
@@ -290,14 +497,38 @@ func (i *InputField) HandleEvent(event tcell.Event) Command {
 			}
 		}
 
+		if i.segments != nil {
+			return i.handleSegmentKey(event)
+		}
+
+		// Navigate the autocomplete list, if visible.
+		if consumed, text, accepted := i.autocompleter.handleKey(event); consumed {
+			if accepted {
+				i.SetText(text)
+			}
+			return RedrawCommand{}
+		}
+
 		// Process special key events for the input field.
 		switch key := event.Key(); key {
 		case tcell.KeyEnter, tcell.KeyEscape, tcell.KeyTab, tcell.KeyBacktab:
+			i.autocompleter.close()
+			i.normalizeValue()
 			finish(key)
 			return RedrawCommand{}
+		case tcell.KeyUp, tcell.KeyDown, tcell.KeyPgUp, tcell.KeyPgDn:
+			if i.fieldType == InputFieldTypeText {
+				return i.forwardToTextArea(event)
+			}
+			direction := 1
+			if key == tcell.KeyDown || key == tcell.KeyPgDn {
+				direction = -1
+			}
+			i.stepValue(direction, key == tcell.KeyPgUp || key == tcell.KeyPgDn)
+			return RedrawCommand{}
 		default:
 			// Forward other key events to the text area.
-			return i.textArea.HandleEvent(event)
+			return i.forwardToTextArea(event)
 		}
 	case *MouseEvent:
 		// Is mouse event within the input field?
@@ -306,6 +537,13 @@ func (i *InputField) HandleEvent(event tcell.Event) Command {
 			return nil
 		}
 
+		if i.segments != nil {
+			if event.Action == MouseLeftDown {
+				return SetFocusCommand{Target: i}
+			}
+			return nil
+		}
+
 		// Forward mouse event to the text area.
 		cmd := i.textArea.HandleEvent(event)
 
@@ -315,8 +553,23 @@ func (i *InputField) HandleEvent(event tcell.Event) Command {
 		}
 		return cmd
 	case *PasteEvent:
+		if i.segments != nil {
+			return i.handleSegmentPaste(event.Content)
+		}
+
 		// Forward the pasted text to the text area.
-		return i.textArea.HandleEvent(event)
+		return i.forwardToTextArea(event)
 	}
 	return nil
 }
+
+// Inspect describes this primitive in the inspection tree. See [Inspect]. The
+// value of masked input fields is not reported.
+func (i *InputField) Inspect(node *InspectNode) {
+	node.Role = RoleTextbox
+	node.Label = i.GetLabel()
+	if i.textArea.transform == nil {
+		node.Value = i.GetText()
+	}
+	node.Disabled = i.GetDisabled()
+}
@@ -0,0 +1,417 @@
+package tview
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v3"
+	"github.com/rivo/uniseg"
+)
+
+// inputSegment is one fixed-width sub-field of a segmented input field.
+type inputSegment struct {
+	// The literal text shown before the segment.
+	literal string
+
+	// The maximum number of characters (grapheme clusters) of the segment.
+	width int
+
+	// The characters entered into the segment.
+	value []string
+}
+
+// inputSegments holds the state of an input field's segmented input mode, see
+// [InputField.SetSegments].
+type inputSegments struct {
+	// The sub-fields.
+	segments []inputSegment
+
+	// The literal text shown after the last segment.
+	suffix string
+
+	// The index of the segment containing the cursor.
+	current int
+
+	// The character index of the cursor within the current segment.
+	cursor int
+
+	// An optional function which decides whether a character may be entered
+	// into a segment.
+	accept func(segment int, char string) bool
+}
+
+// parseSegmentPattern returns the segments described by the given pattern.
+func parseSegmentPattern(pattern string) *inputSegments {
+	s := &inputSegments{}
+	var literal strings.Builder
+	for len(pattern) > 0 {
+		if pattern[0] == '_' {
+			width := len(pattern) - len(strings.TrimLeft(pattern, "_"))
+			s.segments = append(s.segments, inputSegment{literal: literal.String(), width: width})
+			literal.Reset()
+			pattern = pattern[width:]
+			continue
+		}
+		cluster, rest, _, _ := uniseg.FirstGraphemeClusterInString(pattern, -1)
+		literal.WriteString(cluster)
+		pattern = rest
+	}
+	s.suffix = literal.String()
+	if len(s.segments) == 0 {
+		return nil
+	}
+	return s
+}
+
+// text returns the composed value: the literals and the entered characters. It
+// returns an empty string if all segments are empty.
+func (s *inputSegments) text() string {
+	empty := true
+	for _, segment := range s.segments {
+		if len(segment.value) > 0 {
+			empty = false
+			break
+		}
+	}
+	if empty {
+		return ""
+	}
+	var b strings.Builder
+	for _, segment := range s.segments {
+		b.WriteString(segment.literal)
+		for _, char := range segment.value {
+			b.WriteString(char)
+		}
+	}
+	b.WriteString(s.suffix)
+	return b.String()
+}
+
+// setText distributes the given composed value across the segments by
+// splitting it at the literals. Characters which exceed a segment's width or
+// which are not accepted are dropped.
+func (s *inputSegments) setText(text string) {
+	for index := range s.segments {
+		segment := &s.segments[index]
+		segment.value = nil
+		text = strings.TrimPrefix(text, segment.literal)
+
+		// The segment ends at the next literal.
+		end := len(text)
+		if index+1 < len(s.segments) {
+			if next := s.segments[index+1].literal; next != "" {
+				if pos := strings.Index(text, next); pos >= 0 {
+					end = pos
+				}
+			}
+		} else if s.suffix != "" {
+			if pos := strings.Index(text, s.suffix); pos >= 0 {
+				end = pos
+			}
+		}
+		value := text[:end]
+		text = text[end:]
+
+		for len(value) > 0 {
+			cluster, rest, _, _ := uniseg.FirstGraphemeClusterInString(value, -1)
+			value = rest
+			if len(segment.value) < segment.width && (s.accept == nil || s.accept(index, cluster)) {
+				segment.value = append(segment.value, cluster)
+			}
+		}
+	}
+	s.current, s.cursor = 0, 0
+}
+
+// move moves the cursor to the given segment and character index, clamping
+// both to valid positions.
+func (s *inputSegments) move(segment, cursor int) {
+	s.current = max(min(segment, len(s.segments)-1), 0)
+	s.cursor = max(min(cursor, len(s.segments[s.current].value)), 0)
+}
+
+// left moves the cursor one character to the left, into the previous segment
+// if necessary.
+func (s *inputSegments) left() {
+	if s.cursor > 0 {
+		s.cursor--
+	} else if s.current > 0 {
+		s.move(s.current-1, len(s.segments[s.current-1].value))
+	}
+}
+
+// right moves the cursor one character to the right, into the next segment if
+// necessary.
+func (s *inputSegments) right() {
+	if s.cursor < len(s.segments[s.current].value) {
+		s.cursor++
+	} else if s.current+1 < len(s.segments) {
+		s.move(s.current+1, 0)
+	}
+}
+
+// insert enters the given character at the cursor position. It returns
+// whether the text changed. Typing the literal which follows a non-empty
+// segment moves on to the next segment. A segment which becomes full also
+// moves the cursor on to the next segment.
+func (s *inputSegments) insert(char string) bool {
+	segment := &s.segments[s.current]
+	if s.current+1 < len(s.segments) && len(segment.value) > 0 {
+		if literal := s.segments[s.current+1].literal; literal != "" && strings.HasPrefix(literal, char) {
+			s.move(s.current+1, 0)
+			return false
+		}
+	}
+	if len(segment.value) >= segment.width || s.accept != nil && !s.accept(s.current, char) {
+		return false
+	}
+	segment.value = append(segment.value[:s.cursor], append([]string{char}, segment.value[s.cursor:]...)...)
+	s.cursor++
+	if len(segment.value) >= segment.width && s.cursor >= segment.width && s.current+1 < len(s.segments) {
+		s.move(s.current+1, 0)
+	}
+	return true
+}
+
+// backspace deletes the character before the cursor, moving into the
+// previous segment if the cursor is at the start of a segment. It returns
+// whether the text changed.
+func (s *inputSegments) backspace() bool {
+	if s.cursor == 0 {
+		if s.current == 0 {
+			return false
+		}
+		s.move(s.current-1, len(s.segments[s.current-1].value))
+		if s.cursor == 0 {
+			return false
+		}
+	}
+	segment := &s.segments[s.current]
+	segment.value = append(segment.value[:s.cursor-1], segment.value[s.cursor:]...)
+	s.cursor--
+	return true
+}
+
+// delete deletes the character at the cursor. It returns whether the text
+// changed.
+func (s *inputSegments) delete() bool {
+	segment := &s.segments[s.current]
+	if s.cursor >= len(segment.value) {
+		return false
+	}
+	segment.value = append(segment.value[:s.cursor], segment.value[s.cursor+1:]...)
+	return true
+}
+
+// SetSegments switches the input field into segmented input mode, in which the
+// input consists of fixed-width segments separated by literal text, e.g. for
+// IP addresses, dates, or times. In the pattern, each run of underscores
+// describes a segment which can hold as many characters as there are
+// underscores. All other characters are literals. For example:
+//
+//	"___.___.___.___" // An IPv4 address.
+//	"____-__-__"      // A date.
+//	"__:__:__"        // A time.
+//
+// Typing into a full segment or typing the literal following a segment moves
+// the cursor to the next segment. Tab and Backtab move to the next or
+// previous segment and only finish editing on the last or first segment.
+// Backspace at the start of a segment continues in the previous segment.
+//
+// [InputField.GetText] returns the composed value, i.e. the literals and the
+// entered characters (without underscores), e.g. "192.168.0.1", or an empty
+// string if nothing was entered. It is also passed to the "changed" handler.
+// [InputField.SetText] splits a composed value at the literals. An empty
+// pattern returns to regular input, keeping the composed value as the text.
+func (i *InputField) SetSegments(pattern string) *InputField {
+	text := i.GetText()
+	i.segments = parseSegmentPattern(pattern)
+	if i.segments == nil {
+		i.textArea.SetText(text, true)
+		return i
+	}
+	i.segments.accept = i.segmentAccept
+	i.segments.setText(text)
+	return i
+}
+
+// SetSegmentAcceptanceFunc sets a function which decides whether a character
+// (a grapheme cluster) may be entered into the segment with the given index in
+// segmented input mode, see [InputField.SetSegments]. For example,
+// [InputFieldSegmentDigits] only accepts digits. A nil function accepts all
+// characters.
+func (i *InputField) SetSegmentAcceptanceFunc(handler func(segment int, char string) bool) *InputField {
+	if i.segments != nil {
+		i.segments.accept = handler
+	}
+	i.segmentAccept = handler
+	return i
+}
+
+// InputFieldSegmentDigits is a segment acceptance function which only accepts
+// the digits 0 to 9, see [InputField.SetSegmentAcceptanceFunc].
+func InputFieldSegmentDigits(segment int, char string) bool {
+	return len(char) == 1 && char[0] >= '0' && char[0] <= '9'
+}
+
+// GetSegments returns the values entered into each segment in segmented input
+// mode, or nil if the input field is not in segmented input mode.
+func (i *InputField) GetSegments() []string {
+	if i.segments == nil {
+		return nil
+	}
+	values := make([]string, len(i.segments.segments))
+	for index, segment := range i.segments.segments {
+		values[index] = strings.Join(segment.value, "")
+	}
+	return values
+}
+
+// drawSegments draws the label and the segments of the input field in
+// segmented input mode. It returns the position and width of the field right
+// of the label.
+func (i *InputField) drawSegments(screen tcell.Screen, x, y, width int) (fieldX, fieldWidth int) {
+	labelStyle := i.textArea.GetLabelStyle()
+	labelBg := labelStyle.GetBackground()
+	if labelWidth := i.textArea.GetLabelWidth(); labelWidth > 0 {
+		labelWidth = min(labelWidth, width)
+		printWithStyle(screen, i.textArea.GetLabel(), x, y, 0, labelWidth, AlignmentLeft, labelStyle, labelBg == tcell.ColorDefault)
+		x += labelWidth
+		width -= labelWidth
+	} else {
+		_, _, drawnWidth := printWithStyle(screen, i.textArea.GetLabel(), x, y, 0, width, AlignmentLeft, labelStyle, labelBg == tcell.ColorDefault)
+		x += drawnWidth
+		width -= drawnWidth
+	}
+	if i.fieldWidth > 0 && i.fieldWidth < width {
+		width = i.fieldWidth
+	}
+	if width <= 0 {
+		return x, 0
+	}
+
+	style := i.textArea.GetTextStyle()
+	if i.textArea.GetDisabled() {
+		style = style.Background(i.backgroundColor)
+	}
+	placeholderStyle := style.Dim(true)
+	for column := range width {
+		screen.Put(x+column, y, " ", style)
+	}
+
+	// Draw the literals and the segments.
+	column, cursorX := 0, -1
+	put := func(text string, style tcell.Style) {
+		for text != "" && column < width {
+			cluster, rest, clusterWidth, _ := uniseg.FirstGraphemeClusterInString(text, -1)
+			text = rest
+			if column+clusterWidth > width {
+				column = width
+				break
+			}
+			screen.Put(x+column, y, cluster, style)
+			column += clusterWidth
+		}
+	}
+	for index, segment := range i.segments.segments {
+		put(segment.literal, style)
+		for position := range segment.width {
+			if index == i.segments.current && position == i.segments.cursor {
+				cursorX = column
+			}
+			if position < len(segment.value) {
+				put(segment.value[position], style)
+			} else {
+				put("_", placeholderStyle)
+			}
+		}
+		if index == i.segments.current && i.segments.cursor >= segment.width {
+			cursorX = column
+		}
+	}
+	put(i.segments.suffix, style)
+
+	// Place the cursor.
+	if i.HasFocus() && cursorX >= 0 && cursorX < width {
+		screen.ShowCursor(x+cursorX, y)
+	}
+	return x, width
+}
+
+// handleSegmentKey handles key events in segmented input mode.
+func (i *InputField) handleSegmentKey(event *KeyEvent) Command {
+	s := i.segments
+	finish := func(key tcell.Key) {
+		i.runValidation()
+		if i.done != nil {
+			i.done(key)
+		}
+		if i.finished != nil {
+			i.finished(key)
+		}
+	}
+
+	var changed bool
+	switch key := event.Key(); key {
+	case tcell.KeyEnter, tcell.KeyEscape:
+		finish(key)
+	case tcell.KeyTab:
+		if s.current+1 >= len(s.segments) {
+			finish(key)
+			break
+		}
+		s.move(s.current+1, 0)
+	case tcell.KeyBacktab:
+		if s.current == 0 {
+			finish(key)
+			break
+		}
+		s.move(s.current-1, 0)
+	case tcell.KeyLeft:
+		s.left()
+	case tcell.KeyRight:
+		s.right()
+	case tcell.KeyHome, tcell.KeyCtrlA:
+		s.move(0, 0)
+	case tcell.KeyEnd, tcell.KeyCtrlE:
+		last := len(s.segments) - 1
+		s.move(last, len(s.segments[last].value))
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		changed = s.backspace()
+	case tcell.KeyDelete:
+		changed = s.delete()
+	case tcell.KeyRune:
+		if event.Modifiers()&(tcell.ModAlt|tcell.ModCtrl|tcell.ModMeta) != 0 {
+			return nil
+		}
+		changed = s.insert(event.Str())
+	default:
+		return nil
+	}
+	if changed {
+		i.runValidation()
+	}
+	if changed && i.changed != nil {
+		i.changed(s.text())
+	}
+	return RedrawCommand{}
+}
+
+// handleSegmentPaste enters pasted text into the segments in segmented input
+// mode, as if it was typed.
+func (i *InputField) handleSegmentPaste(text string) Command {
+	var changed bool
+	for len(text) > 0 {
+		cluster, rest, _, _ := uniseg.FirstGraphemeClusterInString(text, -1)
+		text = rest
+		if i.segments.insert(cluster) {
+			changed = true
+		}
+	}
+	if changed {
+		i.runValidation()
+	}
+	if changed && i.changed != nil {
+		i.changed(i.segments.text())
+	}
+	return RedrawCommand{}
+}
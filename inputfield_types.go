@@ -0,0 +1,289 @@
+package tview
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+// InputFieldType is the type of values entered into an [InputField], see
+// [InputField.SetFieldType].
+type InputFieldType int
+
+// The available input field types.
+const (
+	InputFieldTypeText     InputFieldType = iota // Any text (the default).
+	InputFieldTypeInteger                        // Whole numbers, e.g. "-1,234".
+	InputFieldTypeFloat                          // Decimal numbers, e.g. "1,234.5".
+	InputFieldTypeDate                           // Dates in the locale's date layout.
+	InputFieldTypeDuration                       // Durations, e.g. "1h30m".
+)
+
+// SetFieldType sets the type of values entered into the input field. Typed
+// fields only accept characters which may occur in values of their type,
+// report unparsable text as a validation error (see
+// [InputField.GetValidationError]), reformat their value when the user
+// finishes editing, and change their value with the following keys:
+//
+//   - Up arrow, Down arrow: Increment/decrement the value by the step (see
+//     [InputField.SetFieldStep]).
+//   - Page Up, Page Down: Increment/decrement the value by ten steps, or by one
+//     month for dates.
+//
+// Numbers and dates are formatted according to the current locale, see
+// [SetLocale]. Date fields without a placeholder receive one showing the
+// expected layout. Use the typed getters, e.g. [InputField.GetInt], to
+// retrieve values.
+func (i *InputField) SetFieldType(fieldType InputFieldType) *InputField {
+	if i.fieldType != fieldType {
+		i.fieldType = fieldType
+		if fieldType == InputFieldTypeDate && len(i.textArea.placeholder.Segments) == 0 {
+			layout := strings.NewReplacer("2006", "YYYY", "01", "MM", "02", "DD").Replace(GetLocale().DateLayout)
+			i.SetPlaceholder(Line{Segments: []Segment{{Text: layout, Style: i.textArea.GetTextStyle().Dim(true)}}})
+		}
+		i.runValidation()
+	}
+	return i
+}
+
+// GetFieldType returns the type set with [InputField.SetFieldType].
+func (i *InputField) GetFieldType() InputFieldType {
+	return i.fieldType
+}
+
+// SetFieldStep sets the amount by which the arrow keys change the value of
+// typed fields. For dates, the step is in days, for durations in minutes. A
+// value of 0 (the default) means a step of 1.
+func (i *InputField) SetFieldStep(step float64) *InputField {
+	if i.step != step {
+		i.step = step
+	}
+	return i
+}
+
+// SetAcceptanceFunc sets a handler which decides whether typed or pasted text
+// may be entered into the input field. It receives the text as it would be
+// after the input and the last character entered. If it returns false, the
+// input is rejected and the bell is signaled. For typed fields (see
+// [InputField.SetFieldType]), the handler is only called for input which the
+// field type accepts. Set to nil to accept all input.
+func (i *InputField) SetAcceptanceFunc(handler func(textToCheck string, lastChar rune) bool) *InputField {
+	i.accept = handler
+	return i
+}
+
+// GetInt parses the text of the input field as an integer, ignoring the
+// locale's group separators.
+func (i *InputField) GetInt() (int64, error) {
+	return strconv.ParseInt(i.numberText(), 10, 64)
+}
+
+// SetInt sets the text of the input field to the given integer, formatted
+// according to the current locale.
+func (i *InputField) SetInt(n int64) *InputField {
+	return i.SetText(FormatInt(n))
+}
+
+// GetFloat parses the text of the input field as a number, using the locale's
+// decimal separator and ignoring its group separators.
+func (i *InputField) GetFloat() (float64, error) {
+	return strconv.ParseFloat(i.numberText(), 64)
+}
+
+// SetFloat sets the text of the input field to the given number, formatted
+// according to the current locale.
+func (i *InputField) SetFloat(f float64) *InputField {
+	return i.SetText(FormatFloat(f, -1))
+}
+
+// GetTime parses the text of the input field as a date in the locale's date
+// layout. The returned time is in the local time zone.
+func (i *InputField) GetTime() (time.Time, error) {
+	return time.ParseInLocation(GetLocale().DateLayout, strings.TrimSpace(i.GetText()), time.Local)
+}
+
+// SetTime sets the text of the input field to the date of the given time,
+// formatted according to the current locale.
+func (i *InputField) SetTime(t time.Time) *InputField {
+	return i.SetText(FormatDate(t))
+}
+
+// GetDuration parses the text of the input field as a duration, see
+// [time.ParseDuration].
+func (i *InputField) GetDuration() (time.Duration, error) {
+	return time.ParseDuration(strings.TrimSpace(i.GetText()))
+}
+
+// SetDuration sets the text of the input field to the given duration.
+func (i *InputField) SetDuration(d time.Duration) *InputField {
+	return i.SetText(d.String())
+}
+
+// numberText returns the text of the input field in a form understood by the
+// strconv package.
+func (i *InputField) numberText() string {
+	l := GetLocale()
+	text := strings.TrimSpace(i.GetText())
+	if l.GroupSeparator != "" {
+		text = strings.ReplaceAll(text, l.GroupSeparator, "")
+	}
+	if l.DecimalSeparator != "." {
+		text = strings.ReplaceAll(text, l.DecimalSeparator, ".")
+	}
+	return text
+}
+
+// acceptsEvent returns whether the given key or paste event may be forwarded
+// to the text area. Events which don't insert text are always accepted.
+func (i *InputField) acceptsEvent(event tcell.Event) bool {
+	switch event := event.(type) {
+	case *KeyEvent:
+		if event.Key() != tcell.KeyRune || event.Modifiers()&(tcell.ModCtrl|tcell.ModAlt|tcell.ModMeta) != 0 {
+			return true
+		}
+		return i.accepts(event.Str())
+	case *PasteEvent:
+		return i.accepts(event.Content)
+	}
+	return true
+}
+
+// accepts returns whether the given text may replace the current selection.
+func (i *InputField) accepts(insert string) bool {
+	if insert == "" || i.fieldType == InputFieldTypeText && i.accept == nil {
+		return true
+	}
+	if !i.fieldType.accepts(insert) {
+		return false
+	}
+	if i.accept == nil {
+		return true
+	}
+	text := i.textArea.GetText()
+	_, start, end := i.textArea.GetSelection()
+	lastChar, _ := utf8.DecodeLastRuneInString(insert)
+	return i.accept(text[:start]+insert+text[end:], lastChar)
+}
+
+// accepts returns whether all characters of the given text may occur in
+// values of this type.
+func (t InputFieldType) accepts(text string) bool {
+	l := GetLocale()
+	switch t {
+	case InputFieldTypeInteger, InputFieldTypeFloat:
+		if l.GroupSeparator != "" {
+			text = strings.ReplaceAll(text, l.GroupSeparator, "")
+		}
+		if t == InputFieldTypeFloat {
+			text = strings.ReplaceAll(text, l.DecimalSeparator, "")
+		}
+		return !strings.ContainsFunc(text, func(r rune) bool {
+			return !strings.ContainsRune("0123456789+-", r)
+		})
+	case InputFieldTypeDate:
+		layout := l.DateLayout
+		hasLetters := strings.ContainsFunc(layout, unicode.IsLetter)
+		return !strings.ContainsFunc(text, func(r rune) bool {
+			return !unicode.IsDigit(r) && !strings.ContainsRune(layout, r) && !(hasLetters && unicode.IsLetter(r))
+		})
+	case InputFieldTypeDuration:
+		return !strings.ContainsFunc(text, func(r rune) bool {
+			return !strings.ContainsRune("0123456789.+-hmsuµn", r)
+		})
+	}
+	return true
+}
+
+// typeError returns an error if the non-empty text of the input field cannot
+// be parsed as a value of the field's type.
+func (i *InputField) typeError() error {
+	if strings.TrimSpace(i.GetText()) == "" {
+		return nil
+	}
+	var err error
+	message := ""
+	switch i.fieldType {
+	case InputFieldTypeInteger:
+		_, err = i.GetInt()
+		message = MessageInvalidNumber
+	case InputFieldTypeFloat:
+		_, err = i.GetFloat()
+		message = MessageInvalidNumber
+	case InputFieldTypeDate:
+		_, err = i.GetTime()
+		message = MessageInvalidDate
+	case InputFieldTypeDuration:
+		_, err = i.GetDuration()
+		message = MessageInvalidDuration
+	}
+	if err != nil {
+		return errors.New(Translate(message, ""))
+	}
+	return nil
+}
+
+// normalizeValue reformats the text of typed fields if it can be parsed.
+func (i *InputField) normalizeValue() {
+	switch i.fieldType {
+	case InputFieldTypeInteger:
+		if n, err := i.GetInt(); err == nil {
+			i.SetInt(n)
+		}
+	case InputFieldTypeFloat:
+		if f, err := i.GetFloat(); err == nil {
+			i.SetFloat(f)
+		}
+	case InputFieldTypeDate:
+		if t, err := i.GetTime(); err == nil {
+			i.SetTime(t)
+		}
+	case InputFieldTypeDuration:
+		if d, err := i.GetDuration(); err == nil {
+			i.SetDuration(d)
+		}
+	}
+}
+
+// stepValue changes the value of a typed field by one step (or ten steps, or
+// one month for dates, if large is true) in the given direction. Empty or
+// invalid values start from zero, or from today for dates.
+func (i *InputField) stepValue(direction int, large bool) {
+	step := i.step
+	if step == 0 {
+		step = 1
+	}
+	if large && i.fieldType != InputFieldTypeDate {
+		step *= 10
+	}
+	step *= float64(direction)
+
+	switch i.fieldType {
+	case InputFieldTypeInteger:
+		n, _ := i.GetInt()
+		i.SetInt(n + int64(step))
+	case InputFieldTypeFloat:
+		f, _ := i.GetFloat()
+		i.SetFloat(f + step)
+	case InputFieldTypeDate:
+		t, err := i.GetTime()
+		if err != nil {
+			now := time.Now()
+			t = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+		}
+		if large {
+			t = t.AddDate(0, direction, 0)
+		} else {
+			t = t.AddDate(0, 0, int(step))
+		}
+		i.SetTime(t)
+	case InputFieldTypeDuration:
+		d, _ := i.GetDuration()
+		i.SetDuration(d + time.Duration(step*float64(time.Minute)))
+	}
+	i.textArea.Select(i.textArea.GetTextLength(), i.textArea.GetTextLength())
+}
@@ -0,0 +1,78 @@
+package tview
+
+import "github.com/gdamore/tcell/v3"
+
+// SetValidateFunc sets a function which validates the text of the input field
+// whenever it changes and when the user finishes editing. While it returns an
+// error, the input field and its border (if any) are drawn with the error
+// style (see [InputField.SetErrorStyle]) and the error message is shown in the
+// row below the input field, which [InputField.GetFieldHeight] then includes.
+// Use [InputField.SetErrorRenderer] to customize the message.
+//
+// A form does not submit while any of its input fields are invalid, see
+// [Form.Validate]. A nil function removes validation.
+func (i *InputField) SetValidateFunc(handler func(text string) error) *InputField {
+	i.validate = handler
+	i.validationError = nil
+	return i
+}
+
+// Validate validates the current text with the function set by
+// [InputField.SetValidateFunc] and returns the resulting error, or nil if the
+// text is valid or there is no such function. The error display is updated
+// accordingly.
+func (i *InputField) Validate() error {
+	i.runValidation()
+	return i.validationError
+}
+
+// GetValidationError returns the error of the last validation, or nil if the
+// text was valid. See [InputField.SetValidateFunc].
+func (i *InputField) GetValidationError() error {
+	return i.validationError
+}
+
+// SetErrorStyle sets the style applied on top of the field and border styles
+// while the text is invalid. The default error message is also drawn in this
+// style.
+func (i *InputField) SetErrorStyle(style tcell.Style) *InputField {
+	if i.errorStyle != style {
+		i.errorStyle = style
+	}
+	return i
+}
+
+// SetErrorRenderer sets a function which returns the message shown below the
+// input field for the given validation error. Segments which don't specify a
+// style use the error style's colors. The default shows the error's text. A
+// renderer returning an empty line hides the message but the row remains
+// reserved.
+func (i *InputField) SetErrorRenderer(renderer func(err error) Line) *InputField {
+	i.errorRenderer = renderer
+	return i
+}
+
+// runValidation validates the current text according to the field type (see
+// [InputField.SetFieldType]) and the validation function, if any.
+func (i *InputField) runValidation() {
+	i.validationError = i.typeError()
+	if i.validationError == nil && i.validate != nil {
+		i.validationError = i.validate(i.GetText())
+	}
+}
+
+// drawValidationError draws the message for the current validation error, if
+// any, into the given area.
+func (i *InputField) drawValidationError(screen tcell.Screen, x, y, width, height int) {
+	if i.validationError == nil || width <= 0 || height <= 0 {
+		return
+	}
+	var line Line
+	if i.errorRenderer != nil {
+		line = i.errorRenderer(i.validationError)
+	} else {
+		line = Line{Segments: []Segment{{Text: i.validationError.Error()}}}
+	}
+	base := i.errorStyle.Background(i.backgroundColor)
+	printLine(screen, line, x, y, width, AlignmentLeft, base, true)
+}
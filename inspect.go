@@ -0,0 +1,235 @@
+package tview
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Roles reported by the built-in primitives in the inspection tree, modeled
+// after the roles of the WAI-ARIA specification.
+const (
+	RoleGroup    = "group"
+	RoleForm     = "form"
+	RoleDialog   = "dialog"
+	RoleButton   = "button"
+	RoleCheckbox = "checkbox"
+	RoleTextbox  = "textbox"
+	RoleText     = "text"
+	RoleList     = "list"
+	RoleListItem = "listitem"
+	RoleTree     = "tree"
+	RoleTreeItem = "treeitem"
+	RoleImage    = "img"
+
+	RoleProgressBar  = "progressbar"
+	RoleCombobox     = "combobox"
+	RoleRadioGroup   = "radiogroup"
+	RoleRadio        = "radio"
+	RoleSlider       = "slider"
+	RoleTable        = "table"
+	RoleColumnHeader = "columnheader"
+	RoleCell         = "cell"
+)
+
+// InspectNode describes one visible element of the user interface, as returned
+// by [Inspect] and [Application.Inspect]. The tree can be used by end-to-end
+// test frameworks or assistive technology bridges to query the state of the
+// user interface. It can be serialized as JSON.
+type InspectNode struct {
+	// The name of the primitive's type without package, e.g. "InputField".
+	// Elements which are not primitives themselves (e.g. tree nodes) have an
+	// empty type.
+	Type string `json:"type,omitempty"`
+
+	// The element's role, e.g. [RoleButton]. Primitives which don't implement
+	// [Inspectable] are reported as [RoleGroup].
+	Role string `json:"role"`
+
+	// The element's label or title.
+	Label string `json:"label,omitempty"`
+
+	// The element's value, e.g. the text of an input field.
+	Value string `json:"value,omitempty"`
+
+	// The element's position and size on screen.
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+
+	// Whether the element itself has focus. This is false for elements whose
+	// descendants have focus.
+	Focused bool `json:"focused,omitempty"`
+
+	// Whether the element is selected, e.g. the current item of a list.
+	Selected bool `json:"selected,omitempty"`
+
+	// Whether the element is checked (checkboxes) or expanded (tree items).
+	Checked  bool `json:"checked,omitempty"`
+	Expanded bool `json:"expanded,omitempty"`
+
+	// Whether the element is disabled.
+	Disabled bool `json:"disabled,omitempty"`
+
+	// The visible child elements in drawing order.
+	Children []*InspectNode `json:"children,omitempty"`
+
+	// The inspected primitive or nil if this element is not a primitive.
+	Primitive Primitive `json:"-"`
+
+	// Set to true if the primitive or one of its descendants has focus.
+	focusWithin bool
+}
+
+// Inspectable is implemented by primitives which describe themselves in the
+// inspection tree. All primitives embedding a [Box] implement it.
+type Inspectable interface {
+	// Inspect fills in the role, label, value, and state of the given node
+	// whose type and rectangle have already been set. Containers add their
+	// visible children with [InspectNode.AddPrimitive] or by appending to the
+	// node's Children.
+	Inspect(node *InspectNode)
+}
+
+// Inspect walks the primitive tree starting at the given primitive and returns
+// a description of all visible primitives. It returns nil if the primitive is
+// nil or has no area on screen. The primitives must have been drawn at least
+// once for their positions to be known.
+//
+// Like drawing, this function is not thread-safe.
+func Inspect(p Primitive) *InspectNode {
+	if value := reflect.ValueOf(p); !value.IsValid() || value.Kind() == reflect.Pointer && value.IsNil() {
+		return nil
+	}
+	x, y, width, height := p.GetRect()
+	if width <= 0 || height <= 0 {
+		return nil
+	}
+	typ := reflect.TypeOf(p)
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	node := &InspectNode{
+		Type:      typ.Name(),
+		Role:      RoleGroup,
+		X:         x,
+		Y:         y,
+		Width:     width,
+		Height:    height,
+		Primitive: p,
+	}
+	if inspectable, ok := p.(Inspectable); ok {
+		inspectable.Inspect(node)
+	}
+
+	// The primitive only has focus itself if none of its children have it.
+	node.focusWithin = p.HasFocus()
+	node.Focused = node.focusWithin
+	for _, child := range node.Children {
+		if child.focusWithin || child.Focused {
+			node.Focused = false
+			break
+		}
+	}
+	return node
+}
+
+// AddPrimitive inspects the given primitive and, if it is visible, adds it as
+// a child of this node. It returns the child node or nil if the primitive is
+// not visible.
+func (n *InspectNode) AddPrimitive(p Primitive) *InspectNode {
+	child := Inspect(p)
+	if child != nil {
+		n.Children = append(n.Children, child)
+	}
+	return child
+}
+
+// Walk calls the given function for this node and all of its descendants,
+// depth-first. Children of a node are skipped if the function returns false
+// for it.
+func (n *InspectNode) Walk(callback func(node *InspectNode) bool) {
+	if n == nil || !callback(n) {
+		return
+	}
+	for _, child := range n.Children {
+		child.Walk(callback)
+	}
+}
+
+// Find returns the first node, depth-first, for which the given function
+// returns true, or nil if there is no such node.
+func (n *InspectNode) Find(match func(node *InspectNode) bool) (found *InspectNode) {
+	n.Walk(func(node *InspectNode) bool {
+		if found == nil && match(node) {
+			found = node
+		}
+		return found == nil
+	})
+	return
+}
+
+// FindFocused returns the node which has focus or nil if there is none.
+func (n *InspectNode) FindFocused() *InspectNode {
+	return n.Find(func(node *InspectNode) bool {
+		return node.Focused
+	})
+}
+
+// String returns an indented outline of the tree, one element per line, which
+// is useful for debugging and for golden files.
+func (n *InspectNode) String() string {
+	var b strings.Builder
+	n.writeOutline(&b, 0)
+	return b.String()
+}
+
+// writeOutline writes the outline of this node and its children at the given
+// depth to the builder.
+func (n *InspectNode) writeOutline(b *strings.Builder, depth int) {
+	if n == nil {
+		return
+	}
+	b.WriteString(strings.Repeat("  ", depth))
+	b.WriteString(n.Role)
+	if n.Type != "" {
+		fmt.Fprintf(b, " (%s)", n.Type)
+	}
+	if n.Label != "" {
+		fmt.Fprintf(b, " %q", n.Label)
+	}
+	if n.Value != "" {
+		fmt.Fprintf(b, " value=%q", n.Value)
+	}
+	fmt.Fprintf(b, " [%d,%d %dx%d]", n.X, n.Y, n.Width, n.Height)
+	for _, state := range []struct {
+		set  bool
+		name string
+	}{
+		{n.Focused, "focused"},
+		{n.Selected, "selected"},
+		{n.Checked, "checked"},
+		{n.Expanded, "expanded"},
+		{n.Disabled, "disabled"},
+	} {
+		if state.set {
+			b.WriteString(" " + state.name)
+		}
+	}
+	b.WriteByte('\n')
+	for _, child := range n.Children {
+		child.writeOutline(b, depth+1)
+	}
+}
+
+// Inspect returns a description of the visible primitives of the application,
+// starting at the root primitive, as they were laid out during the last draw.
+// See [Inspect] for details. Like drawing, this must happen on the event loop,
+// e.g. in a function passed to [Application.QueueUpdate].
+func (a *Application) Inspect() *InspectNode {
+	a.RLock()
+	root := a.visibleRoot()
+	a.RUnlock()
+	return Inspect(root)
+}
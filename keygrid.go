@@ -0,0 +1,390 @@
+package tview
+
+import (
+	"github.com/ayn2op/tview/keybind"
+	"github.com/gdamore/tcell/v3"
+	"github.com/rivo/uniseg"
+)
+
+// KeyGridKey is a button of a [KeyGrid].
+type KeyGridKey struct {
+	// The text drawn in the middle of the button, e.g. "7".
+	Glyph string
+
+	// An optional smaller text drawn below the glyph if there is room, e.g.
+	// "PQRS".
+	Label string
+
+	// Optional keys which activate the button while the grid has focus. If no
+	// keys are set and the glyph is a single character, typing that character
+	// activates the button.
+	Shortcut keybind.Keybind
+
+	// If set to true, the button cannot be activated.
+	Disabled bool
+
+	// An optional function which is called when the button is activated.
+	Selected func()
+}
+
+// KeyGrid lays out large buttons in a grid, e.g. for dial pads, calculators,
+// or launchers. The buttons share the available space evenly. If there is not
+// enough space, the gaps between buttons are removed first, then the labels.
+//
+// The following keys are available:
+//
+//   - Arrow keys: Move to the neighboring button.
+//   - Enter, Space: Activate the current button.
+//
+// Buttons can also be activated with their shortcuts (see [KeyGridKey]) or by
+// clicking them.
+type KeyGrid struct {
+	*Box
+
+	// The buttons in row-major order.
+	keys []KeyGridKey
+
+	// The number of buttons per row.
+	columns int
+
+	// The number of cells between columns and between rows.
+	columnGap, rowGap int
+
+	// The index of the current button.
+	current int
+
+	// The style of the buttons.
+	style tcell.Style
+
+	// The style of the current button while the grid has focus.
+	activatedStyle tcell.Style
+
+	// The style of disabled buttons.
+	disabledStyle tcell.Style
+
+	// An optional function which is called when any button is activated.
+	selected func(index int, key KeyGridKey)
+}
+
+// NewKeyGrid returns a new key grid with three columns and no buttons.
+func NewKeyGrid() *KeyGrid {
+	return &KeyGrid{
+		Box:            NewBox(),
+		columns:        3,
+		columnGap:      1,
+		rowGap:         1,
+		style:          tcell.StyleDefault.Background(Styles.ContrastBackgroundColor).Foreground(Styles.PrimaryTextColor),
+		activatedStyle: tcell.StyleDefault.Background(Styles.PrimaryTextColor).Foreground(Styles.InverseTextColor),
+		disabledStyle:  tcell.StyleDefault.Background(Styles.ContrastBackgroundColor).Foreground(Styles.ContrastSecondaryTextColor),
+	}
+}
+
+// applyTheme updates the key grid's default styles after a theme change.
+func (g *KeyGrid) applyTheme(change themeChange) {
+	g.Box.applyTheme(change)
+	g.style = change.style(g.style, themePrimaryText, themeContrastBackground)
+	g.activatedStyle = change.style(g.activatedStyle, themeInverseText, themePrimaryText)
+	g.disabledStyle = change.style(g.disabledStyle, themeContrastSecondaryText, themeContrastBackground)
+}
+
+// SetColumns sets the number of buttons per row. Values smaller than 1 are
+// treated as 1.
+func (g *KeyGrid) SetColumns(columns int) *KeyGrid {
+	g.columns = max(columns, 1)
+	return g
+}
+
+// SetGap sets the number of cells between columns and between rows. Gaps are
+// removed if the buttons would not fit otherwise.
+func (g *KeyGrid) SetGap(column, row int) *KeyGrid {
+	g.columnGap, g.rowGap = max(column, 0), max(row, 0)
+	return g
+}
+
+// AddKey adds a button with the given glyph, label, and selected handler.
+func (g *KeyGrid) AddKey(glyph, label string, selected func()) *KeyGrid {
+	return g.AddKeys(KeyGridKey{Glyph: glyph, Label: label, Selected: selected})
+}
+
+// AddKeys adds the given buttons.
+func (g *KeyGrid) AddKeys(keys ...KeyGridKey) *KeyGrid {
+	g.keys = append(g.keys, keys...)
+	return g
+}
+
+// SetKey replaces the button with the given index. Invalid indices are
+// ignored.
+func (g *KeyGrid) SetKey(index int, key KeyGridKey) *KeyGrid {
+	if index >= 0 && index < len(g.keys) {
+		g.keys[index] = key
+	}
+	return g
+}
+
+// GetKey returns the button with the given index and whether it exists.
+func (g *KeyGrid) GetKey(index int) (KeyGridKey, bool) {
+	if index < 0 || index >= len(g.keys) {
+		return KeyGridKey{}, false
+	}
+	return g.keys[index], true
+}
+
+// GetKeyCount returns the number of buttons.
+func (g *KeyGrid) GetKeyCount() int {
+	return len(g.keys)
+}
+
+// Clear removes all buttons.
+func (g *KeyGrid) Clear() *KeyGrid {
+	g.keys = nil
+	g.current = 0
+	return g
+}
+
+// SetCurrentKey sets the index of the current button.
+func (g *KeyGrid) SetCurrentKey(index int) *KeyGrid {
+	g.current = max(min(index, len(g.keys)-1), 0)
+	return g
+}
+
+// GetCurrentKey returns the index of the current button.
+func (g *KeyGrid) GetCurrentKey() int {
+	return g.current
+}
+
+// SetStyle sets the style of the buttons.
+func (g *KeyGrid) SetStyle(style tcell.Style) *KeyGrid {
+	g.style = style
+	return g
+}
+
+// SetActivatedStyle sets the style of the current button while the grid has
+// focus.
+func (g *KeyGrid) SetActivatedStyle(style tcell.Style) *KeyGrid {
+	g.activatedStyle = style
+	return g
+}
+
+// SetDisabledStyle sets the style of disabled buttons.
+func (g *KeyGrid) SetDisabledStyle(style tcell.Style) *KeyGrid {
+	g.disabledStyle = style
+	return g
+}
+
+// SetSelectedFunc sets a handler which is called when any button is
+// activated, after the button's own handler. It receives the button's index
+// and the button.
+func (g *KeyGrid) SetSelectedFunc(handler func(index int, key KeyGridKey)) *KeyGrid {
+	g.selected = handler
+	return g
+}
+
+// activate activates the button with the given index unless it is disabled.
+func (g *KeyGrid) activate(index int) {
+	if index < 0 || index >= len(g.keys) || g.keys[index].Disabled {
+		return
+	}
+	key := g.keys[index]
+	if key.Selected != nil {
+		key.Selected()
+	}
+	if g.selected != nil {
+		g.selected(index, key)
+	}
+}
+
+// rows returns the number of rows of buttons.
+func (g *KeyGrid) rows() int {
+	return (len(g.keys) + g.columns - 1) / g.columns
+}
+
+// gridLayout distributes the given length among the given number of cells
+// with the given gap between them. It returns the offset and size of each
+// cell. The gap is removed if the cells would not fit otherwise.
+func gridLayout(length, cells, gap int) (offsets, sizes []int) {
+	if cells <= 0 {
+		return nil, nil
+	}
+	if length-gap*(cells-1) < cells {
+		gap = 0
+	}
+	available := max(length-gap*(cells-1), 0)
+	offsets, sizes = make([]int, cells), make([]int, cells)
+	var offset int
+	for cell := range cells {
+		size := available / cells
+		if cell < available%cells {
+			size++
+		}
+		offsets[cell], sizes[cell] = offset, size
+		offset += size + gap
+	}
+	return
+}
+
+// keyRects returns the rectangles of all buttons.
+func (g *KeyGrid) keyRects() []listRect {
+	x, y, width, height := g.GetInnerRect()
+	columnOffsets, widths := gridLayout(width, g.columns, g.columnGap)
+	rowOffsets, heights := gridLayout(height, g.rows(), g.rowGap)
+	rects := make([]listRect, len(g.keys))
+	for index := range g.keys {
+		row, column := index/g.columns, index%g.columns
+		rects[index] = listRect{
+			x:      x + columnOffsets[column],
+			y:      y + rowOffsets[row],
+			width:  widths[column],
+			height: heights[row],
+		}
+	}
+	return rects
+}
+
+// Draw draws this primitive onto the screen.
+func (g *KeyGrid) Draw(screen tcell.Screen) {
+	g.DrawForSubclass(screen, g)
+
+	for index, rect := range g.keyRects() {
+		if rect.width <= 0 || rect.height <= 0 {
+			continue
+		}
+		key := g.keys[index]
+		style := g.style
+		if key.Disabled {
+			style = g.disabledStyle
+		} else if index == g.current && g.HasFocus() {
+			style = g.activatedStyle
+		}
+		for row := range rect.height {
+			for column := range rect.width {
+				screen.Put(rect.x+column, rect.y+row, " ", style)
+			}
+		}
+
+		// Center the glyph, with the label below it if there is room.
+		showLabel := key.Label != "" && rect.height >= 2
+		glyphY := rect.y + rect.height/2
+		if showLabel {
+			glyphY = rect.y + (rect.height-2)/2
+		}
+		printWithStyle(screen, key.Glyph, rect.x, glyphY, 0, rect.width, AlignmentCenter, style, false)
+		if showLabel {
+			printWithStyle(screen, key.Label, rect.x, glyphY+1, 0, rect.width, AlignmentCenter, style.Dim(true), false)
+		}
+	}
+}
+
+// keyAt returns the index of the button at the given screen position, or -1
+// if there is none.
+func (g *KeyGrid) keyAt(x, y int) int {
+	for index, rect := range g.keyRects() {
+		if x >= rect.x && x < rect.x+rect.width && y >= rect.y && y < rect.y+rect.height {
+			return index
+		}
+	}
+	return -1
+}
+
+// shortcutKey returns the index of the button whose shortcut matches the
+// given event, or -1 if there is none.
+func (g *KeyGrid) shortcutKey(event *KeyEvent) int {
+	for index, key := range g.keys {
+		if len(key.Shortcut.Keys()) > 0 {
+			if keybind.Matches(event, key.Shortcut) {
+				return index
+			}
+			continue
+		}
+		if event.Key() == tcell.KeyRune && event.Modifiers()&(tcell.ModCtrl|tcell.ModAlt|tcell.ModMeta) == 0 &&
+			event.Str() == key.Glyph && uniseg.GraphemeClusterCount(key.Glyph) == 1 {
+			return index
+		}
+	}
+	return -1
+}
+
+// HandleEvent handles input events for this primitive.
+func (g *KeyGrid) HandleEvent(event tcell.Event) Command {
+	switch event := event.(type) {
+	case *KeyEvent:
+		if index := g.shortcutKey(event); index >= 0 {
+			g.current = index
+			g.activate(index)
+			return RedrawCommand{}
+		}
+		if len(g.keys) == 0 {
+			return nil
+		}
+		switch event.Key() {
+		case tcell.KeyUp:
+			if g.current >= g.columns {
+				g.current -= g.columns
+			}
+		case tcell.KeyDown:
+			g.current = min(g.current+g.columns, len(g.keys)-1)
+		case tcell.KeyLeft:
+			if g.current%g.columns > 0 {
+				g.current--
+			}
+		case tcell.KeyRight:
+			if g.current%g.columns < g.columns-1 && g.current < len(g.keys)-1 {
+				g.current++
+			}
+		case tcell.KeyEnter:
+			g.activate(g.current)
+		case tcell.KeyRune:
+			if event.Str() != " " {
+				return nil
+			}
+			g.activate(g.current)
+		default:
+			return nil
+		}
+		return RedrawCommand{}
+	case *MouseEvent:
+		x, y := event.Position()
+		if !g.InRect(x, y) {
+			return nil
+		}
+		switch event.Action {
+		case MouseLeftDown:
+			if index := g.keyAt(x, y); index >= 0 {
+				g.current = index
+			}
+			return BatchCommand{SetFocusCommand{Target: g}, RedrawCommand{}}
+		case MouseLeftClick:
+			if index := g.keyAt(x, y); index >= 0 {
+				g.current = index
+				g.activate(index)
+			}
+			return RedrawCommand{}
+		}
+	}
+	return nil
+}
+
+// Inspect describes this primitive in the inspection tree. See [Inspect]. The
+// buttons are reported as children with the [RoleButton] role.
+func (g *KeyGrid) Inspect(node *InspectNode) {
+	g.Box.Inspect(node)
+	for index, rect := range g.keyRects() {
+		if rect.width <= 0 || rect.height <= 0 {
+			continue
+		}
+		key := g.keys[index]
+		label := key.Glyph
+		if key.Label != "" {
+			label += " " + key.Label
+		}
+		node.Children = append(node.Children, &InspectNode{
+			Role:     RoleButton,
+			Label:    label,
+			X:        rect.x,
+			Y:        rect.y,
+			Width:    rect.width,
+			Height:   rect.height,
+			Selected: index == g.current,
+			Disabled: key.Disabled,
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package tview
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/ayn2op/tview/keybind"
+	"github.com/gdamore/tcell/v3"
+)
+
+// KeyAction is an action of a primitive's built-in key handling which can be
+// bound to custom keys with a [KeyMap]. Not every primitive supports every
+// action, see the primitives' SetKeyMap functions.
+type KeyAction string
+
+// Key actions of the built-in primitives.
+const (
+	KeyActionUp              KeyAction = "up"
+	KeyActionDown            KeyAction = "down"
+	KeyActionLeft            KeyAction = "left"
+	KeyActionRight           KeyAction = "right"
+	KeyActionWordLeft        KeyAction = "wordLeft"
+	KeyActionWordRight       KeyAction = "wordRight"
+	KeyActionPageUp          KeyAction = "pageUp"
+	KeyActionPageDown        KeyAction = "pageDown"
+	KeyActionHalfPageUp      KeyAction = "halfPageUp"
+	KeyActionHalfPageDown    KeyAction = "halfPageDown"
+	KeyActionHome            KeyAction = "home"
+	KeyActionEnd             KeyAction = "end"
+	KeyActionDeleteLeft      KeyAction = "deleteLeft"
+	KeyActionDeleteRight     KeyAction = "deleteRight"
+	KeyActionDeleteWordLeft  KeyAction = "deleteWordLeft"
+	KeyActionDeleteToLineEnd KeyAction = "deleteToLineEnd"
+	KeyActionDeleteLine      KeyAction = "deleteLine"
+	KeyActionSelectAll       KeyAction = "selectAll"
+	KeyActionCopy            KeyAction = "copy"
+	KeyActionCut             KeyAction = "cut"
+	KeyActionPaste           KeyAction = "paste"
+	KeyActionUndo            KeyAction = "undo"
+	KeyActionRedo            KeyAction = "redo"
+	KeyActionSelectNext      KeyAction = "selectNext"
+	KeyActionActivate        KeyAction = "activate"
+	KeyActionQuickJump       KeyAction = "quickJump"
+)
+
+// KeyMap binds actions of a primitive's built-in key handling to custom keys.
+// The keys of an action in the map replace its default keys, i.e. the default
+// keys no longer trigger the action. Actions which are not in the map keep
+// their default keys.
+//
+// This allows applications to offer consistent, user-configurable navigation
+// across primitives, e.g.:
+//
+//	keyMap := tview.KeyMap{
+//		tview.KeyActionUp:   keybind.NewKeybind(keybind.WithKeys("up", "ctrl+p")),
+//		tview.KeyActionDown: keybind.NewKeybind(keybind.WithKeys("down", "ctrl+n")),
+//	}
+//	list.SetKeyMap(keyMap)
+//	textView.SetKeyMap(keyMap)
+type KeyMap map[KeyAction]keybind.Keybind
+
+// keyActionDefaults maps the actions supported by a primitive to their default
+// key events. The first event of an action is handled in place of a custom key
+// bound to the action.
+type keyActionDefaults map[KeyAction][]*tcell.EventKey
+
+// resolve returns the key event which the primitive with the given default
+// actions should handle in place of the given event. It returns false if the
+// event is a default key of an action which was bound to other keys and must
+// therefore be ignored.
+func (m KeyMap) resolve(event *tcell.EventKey, defaults keyActionDefaults) (*tcell.EventKey, bool) {
+	if len(m) == 0 {
+		return event, true
+	}
+
+	// Custom keys come first. Actions are checked in a fixed order so
+	// conflicting bindings behave consistently.
+	actions := slices.Sorted(maps.Keys(m))
+	for _, action := range actions {
+		if events := defaults[action]; len(events) > 0 && keybind.Matches(event, m[action]) {
+			return events[0], true
+		}
+	}
+
+	// Default keys of rebound actions are ignored.
+	for _, action := range actions {
+		for _, defaultEvent := range defaults[action] {
+			if event.Key() == defaultEvent.Key() && event.Modifiers() == defaultEvent.Modifiers() && (event.Key() != tcell.KeyRune || event.Str() == defaultEvent.Str()) {
+				return nil, false
+			}
+		}
+	}
+
+	return event, true
+}
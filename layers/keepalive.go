@@ -0,0 +1,117 @@
+package layers
+
+import (
+	"slices"
+	"time"
+
+	"github.com/ayn2op/tview"
+)
+
+// KeepAlive determines what happens to the primitive of a hidden layer. Layers
+// whose primitives were disposed of are rebuilt with their builder function
+// when they are shown again, see [WithBuilder].
+type KeepAlive int
+
+const (
+	// KeepAlways keeps the primitives of hidden layers. This is the default.
+	KeepAlways KeepAlive = iota
+
+	// DisposeWhenHidden disposes of the primitive as soon as the layer is
+	// hidden.
+	DisposeWhenHidden
+
+	// DisposeAfterTimeout disposes of the primitive once the layer has been
+	// hidden for the timeout given to [WithKeepAlive].
+	DisposeAfterTimeout
+)
+
+// WithBuilder sets a function which creates the layer's primitive. It is
+// called when the layer is shown while it has no primitive, i.e. when it was
+// added without a primitive (to create it lazily) or after its primitive was
+// disposed of according to its keep-alive policy (see [WithKeepAlive]).
+func WithBuilder(build func() tview.Primitive) Option {
+	return func(l *layer) {
+		l.build = build
+	}
+}
+
+// WithKeepAlive sets the layer's keep-alive policy. The timeout is only used
+// with [DisposeAfterTimeout]. Policies other than [KeepAlways] require a
+// builder (see [WithBuilder]) and are ignored otherwise. Disposing of a
+// primitive simply drops the reference to it so it can be garbage-collected.
+func WithKeepAlive(policy KeepAlive, timeout time.Duration) Option {
+	return func(l *layer) {
+		l.keepAlive = policy
+		l.timeout = timeout
+	}
+}
+
+// SetMaxHiddenLayers sets the maximum number of hidden layers which keep their
+// primitives, counting only layers with a builder and a keep-alive policy other
+// than [KeepAlways]. When more layers are hidden, the primitives of the layers
+// which have been hidden the longest are disposed of. A value of 0 (the
+// default) means that there is no limit.
+func (l *Layers) SetMaxHiddenLayers(maxHidden int) *Layers {
+	l.maxHidden = max(maxHidden, 0)
+	l.disposeHidden(time.Now())
+	return l
+}
+
+// DisposeHiddenLayers disposes of the primitives of hidden layers according to
+// their keep-alive policies and the limit set with [Layers.SetMaxHiddenLayers].
+// This happens automatically when layers are drawn, shown, or hidden. Call
+// this function e.g. from a timer if timeouts need to be enforced while the
+// application is idle.
+func (l *Layers) DisposeHiddenLayers() *Layers {
+	l.disposeHidden(time.Now())
+	return l
+}
+
+// IsDisposed returns whether the layer with the given name currently has no
+// primitive, either because it was disposed of or because it was not built yet.
+func (l *Layers) IsDisposed(name string) bool {
+	for _, layer := range l.layers {
+		if layer.name == name {
+			return layer.item == nil
+		}
+	}
+	return false
+}
+
+// disposable returns whether the layer's primitive may be disposed of.
+func (l *layer) disposable() bool {
+	return !l.visible && l.item != nil && l.build != nil && l.keepAlive != KeepAlways
+}
+
+// ensureBuilt builds the layer's primitive if it has none.
+func (l *layer) ensureBuilt() {
+	if l.item == nil && l.build != nil {
+		l.item = l.build()
+	}
+}
+
+// disposeHidden disposes of the primitives of hidden layers whose keep-alive
+// policy says so at the given time.
+func (l *Layers) disposeHidden(now time.Time) {
+	var kept []*layer
+	for _, layer := range l.layers {
+		if !layer.disposable() {
+			continue
+		}
+		if layer.keepAlive == DisposeWhenHidden || now.Sub(layer.hiddenAt) >= layer.timeout {
+			layer.item = nil
+			continue
+		}
+		kept = append(kept, layer)
+	}
+
+	// Enforce the limit, keeping the most recently hidden layers.
+	if l.maxHidden > 0 && len(kept) > l.maxHidden {
+		slices.SortStableFunc(kept, func(a, b *layer) int {
+			return b.hiddenAt.Compare(a.hiddenAt)
+		})
+		for _, layer := range kept[l.maxHidden:] {
+			layer.item = nil
+		}
+	}
+}
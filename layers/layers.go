@@ -1,6 +1,9 @@
 package layers
 
 import (
+	"slices"
+	"time"
+
 	"github.com/ayn2op/tview"
 	"github.com/gdamore/tcell/v3"
 )
@@ -8,11 +11,76 @@ import (
 // layer represents one layer of a Layers object.
 type layer struct {
 	name    string          // The layer's name.
-	item    tview.Primitive // The layer's primitive.
+	item    tview.Primitive // The layer's primitive. Nil if it was disposed of or not built yet.
 	resize  bool            // Whether or not to resize the layer when it is drawn.
 	visible bool            // Whether or not this layer is visible.
 	enabled bool            // Whether or not this layer can receive focus/input.
 	overlay bool            // Whether this layer applies a background style to layers behind it.
+	freeze  bool            // Whether the layers behind this layer are frozen while it is visible.
+	zones   []MouseZone     // Capture and pass-through zones for mouse events.
+
+	build     func() tview.Primitive // Creates the layer's primitive if it has none.
+	keepAlive KeepAlive              // What happens to the primitive while the layer is hidden.
+	timeout   time.Duration          // The time after which the primitive of a hidden layer is disposed of.
+	hiddenAt  time.Time              // When the layer was last hidden.
+}
+
+// MouseZoneMode determines how a layer treats mouse events inside a zone.
+type MouseZoneMode int
+
+const (
+	// MouseZoneCapture delivers matching events to the layer and never passes
+	// them on to the layers behind it, even if the layer ignores them.
+	MouseZoneCapture MouseZoneMode = iota
+
+	// MouseZonePassThrough skips the layer for matching events so they reach
+	// the layers behind it (including layers behind an overlay layer).
+	MouseZonePassThrough
+)
+
+// MouseZone is a rectangular area of a layer with special mouse handling. The
+// coordinates are relative to the top-left corner of the layer's primitive. A
+// width or height of 0 or less extends the zone to the primitive's right or
+// bottom edge. If Actions is empty, the zone applies to all mouse actions.
+type MouseZone struct {
+	X, Y, Width, Height int
+	Mode                MouseZoneMode
+	Actions             []tview.MouseAction
+}
+
+// contains returns whether the given mouse event falls into the zone for a
+// primitive placed at the given rectangle.
+func (z MouseZone) contains(event *tview.MouseEvent, rectX, rectY, rectWidth, rectHeight int) bool {
+	if len(z.Actions) > 0 && !slices.Contains(z.Actions, event.Action) {
+		return false
+	}
+	width, height := z.Width, z.Height
+	if width <= 0 {
+		width = rectWidth - z.X
+	}
+	if height <= 0 {
+		height = rectHeight - z.Y
+	}
+	x, y := event.Position()
+	x -= rectX + z.X
+	y -= rectY + z.Y
+	return x >= 0 && y >= 0 && x < width && y < height
+}
+
+// zoneMode returns the mode of the front-most zone of the layer (zones added
+// later take precedence) which contains the given event. If no zone matches,
+// false is returned.
+func (l *layer) zoneMode(event *tview.MouseEvent) (MouseZoneMode, bool) {
+	if len(l.zones) == 0 {
+		return 0, false
+	}
+	x, y, width, height := l.item.GetRect()
+	for index := len(l.zones) - 1; index >= 0; index-- {
+		if l.zones[index].contains(event, x, y, width, height) {
+			return l.zones[index].Mode, true
+		}
+	}
+	return 0, false
 }
 
 // Layers is a container for other primitives laid out on top of each other.
@@ -32,6 +100,31 @@ type Layers struct {
 	// An optional handler which is called whenever the visibility or the order of
 	// layers changes.
 	changed func()
+
+	// The maximum number of hidden layers with disposable primitives which
+	// keep their primitives, or 0 for no limit.
+	maxHidden int
+
+	// The cached contents of the layers behind the front-most visible freezing
+	// layer, or nil if there is none.
+	frozen *frozenFrame
+}
+
+// frozenFrame is the cached contents of the layers behind a freezing layer.
+type frozenFrame struct {
+	// The visible layers drawn into the frame, from back to front, followed by
+	// the freezing layer.
+	layers []*layer
+
+	// The overlay layer index and background style when the frame was drawn.
+	overlayIndex int
+	overlayStyle tcell.Style
+
+	// The area covered by the frame.
+	x, y, width, height int
+
+	// The cells of the frame, row by row.
+	cells []tview.SnapshotCell
 }
 
 // Option configures a layer on Add.
@@ -72,6 +165,26 @@ func WithOverlay() Option {
 	}
 }
 
+// WithFreeze marks this layer as a freezing layer. While it is visible, the
+// layers behind it are drawn only once and then replayed from a cached frame,
+// skipping their Draw functions entirely. This saves CPU when expensive
+// layers (e.g. dashboards) are covered by a long-lived dialog. Frozen layers
+// are drawn again when the freezing layer is hidden, when the visible layers
+// or the size change, or after a call to [Layers.RefreshFrozen].
+func WithFreeze() Option {
+	return func(l *layer) {
+		l.freeze = true
+	}
+}
+
+// WithMouseZones sets the layer's mouse capture and pass-through zones. See
+// [MouseZone] for details.
+func WithMouseZones(zones ...MouseZone) Option {
+	return func(l *layer) {
+		l.zones = slices.Clone(zones)
+	}
+}
+
 // New returns a new Layers object.
 func New() *Layers {
 	l := &Layers{Box: tview.NewBox()}
@@ -121,7 +234,9 @@ func (l *Layers) Clear() *Layers {
 }
 
 // AddLayer adds a new layer for the given primitive. Options can configure
-// name, visibility, resize, overlay, and enabled state.
+// name, visibility, resize, overlay, and enabled state. The primitive may be nil
+// if a builder is provided with [WithBuilder], in which case it is built when
+// the layer is first shown.
 func (l *Layers) AddLayer(item tview.Primitive, opts ...Option) *Layers {
 	hasFocus := l.HasFocus()
 	newLayer := &layer{
@@ -142,7 +257,13 @@ func (l *Layers) AddLayer(item tview.Primitive, opts ...Option) *Layers {
 			}
 		}
 	}
+	if newLayer.visible {
+		newLayer.ensureBuilt()
+	} else {
+		newLayer.hiddenAt = time.Now()
+	}
 	l.layers = append(l.layers, newLayer)
+	l.disposeHidden(time.Now())
 	if l.changed != nil {
 		l.changed()
 	}
@@ -185,6 +306,10 @@ func (l *Layers) HasLayer(name string) bool {
 func (l *Layers) ShowLayer(name string) *Layers {
 	for _, layer := range l.layers {
 		if layer.name == name && !layer.visible {
+			layer.ensureBuilt()
+			if layer.item == nil {
+				break // It cannot be shown without a primitive.
+			}
 			layer.visible = true
 			if l.changed != nil {
 				l.changed()
@@ -203,6 +328,8 @@ func (l *Layers) HideLayer(name string) *Layers {
 	for _, layer := range l.layers {
 		if layer.name == name && layer.visible {
 			layer.visible = false
+			layer.hiddenAt = time.Now()
+			l.disposeHidden(layer.hiddenAt)
 			if l.changed != nil {
 				l.changed()
 			}
@@ -267,8 +394,8 @@ func (l *Layers) GetFrontLayer() (name string, item tview.Primitive) {
 	return
 }
 
-// GetLayer returns the layer with the given name. If no such layer exists, nil is
-// returned.
+// GetLayer returns the layer with the given name. If no such layer exists or
+// the layer's primitive was disposed of (see [WithKeepAlive]), nil is returned.
 func (l *Layers) GetLayer(name string) tview.Primitive {
 	for _, layer := range l.layers {
 		if layer.name == name {
@@ -284,7 +411,7 @@ func (l *Layers) SetLayerEnabled(name string, enabled bool) *Layers {
 	hasFocus := l.HasFocus()
 	for _, layer := range l.layers {
 		if layer.name == name && layer.enabled != enabled {
-			if !enabled && layer.item.HasFocus() {
+			if !enabled && layer.item != nil && layer.item.HasFocus() {
 				layer.item.Blur()
 			}
 			layer.enabled = enabled
@@ -310,6 +437,54 @@ func (l *Layers) GetLayerEnabled(name string) bool {
 	return false
 }
 
+// SetMouseZones replaces the mouse capture and pass-through zones of the layer
+// with the given name. Calling it without zones removes all zones. This can be
+// used e.g. to let mouse wheel events reach a list below a notification layer:
+//
+//	layers.SetMouseZones("toast", layers.MouseZone{
+//		Mode:    layers.MouseZonePassThrough,
+//		Actions: []tview.MouseAction{tview.MouseScrollUp, tview.MouseScrollDown},
+//	})
+func (l *Layers) SetMouseZones(name string, zones ...MouseZone) *Layers {
+	for _, layer := range l.layers {
+		if layer.name == name {
+			layer.zones = slices.Clone(zones)
+			break
+		}
+	}
+	return l
+}
+
+// GetMouseZones returns the mouse zones of the layer with the given name.
+func (l *Layers) GetMouseZones(name string) []MouseZone {
+	for _, layer := range l.layers {
+		if layer.name == name {
+			return slices.Clone(layer.zones)
+		}
+	}
+	return nil
+}
+
+// SetLayerFreeze sets whether the layer with the given name freezes the layers
+// behind it while it is visible. See [WithFreeze] for details.
+func (l *Layers) SetLayerFreeze(name string, freeze bool) *Layers {
+	for _, layer := range l.layers {
+		if layer.name == name {
+			layer.freeze = freeze
+			break
+		}
+	}
+	return l
+}
+
+// RefreshFrozen causes the layers behind a freezing layer to be drawn again
+// with the next update, e.g. because their contents changed in a way which
+// should be visible behind the dialog. See [WithFreeze].
+func (l *Layers) RefreshFrozen() *Layers {
+	l.frozen = nil
+	return l
+}
+
 // ClearLayerOverlay disables overlay styling for the given layer.
 func (l *Layers) ClearLayerOverlay(name string) *Layers {
 	for _, layer := range l.layers {
@@ -339,7 +514,7 @@ func (l *Layers) SetBackgroundLayerStyle(style tcell.Style) *Layers {
 // HasFocus returns whether or not this primitive has focus.
 func (l *Layers) HasFocus() bool {
 	for _, layer := range l.layers {
-		if layer.enabled && layer.item.HasFocus() {
+		if layer.enabled && layer.item != nil && layer.item.HasFocus() {
 			return true
 		}
 	}
@@ -361,17 +536,33 @@ func (l *Layers) Focus(delegate func(p tview.Primitive)) {
 
 // Draw draws this primitive onto the screen.
 func (l *Layers) Draw(screen tcell.Screen) {
-	l.DrawForSubclass(screen, l)
-
+	l.disposeHidden(time.Now())
 	overlayIndex := l.topVisibleEnabledOverlayIndex()
+
+	// Replay the frozen layers if they haven't changed.
+	freezeIndex, frame := l.frozenKey(screen, overlayIndex)
+	start := 0
+	if frame != nil && l.frozen != nil && l.frozen.matches(frame) {
+		l.frozen.restore(screen)
+		start = freezeIndex
+	} else {
+		l.frozen = nil
+		l.DrawForSubclass(screen, l)
+	}
+
 	var ovScreen *overlayScreen
 	if overlayIndex >= 0 {
 		ovScreen = newOverlayScreen(screen, l.backgroundLayerStyle)
 	}
 	for index, layer := range l.layers {
-		if !layer.visible {
+		if index < start || !layer.visible {
 			continue
 		}
+		if index == freezeIndex && l.frozen == nil {
+			// Everything behind the freezing layer has been drawn.
+			frame.capture(screen)
+			l.frozen = frame
+		}
 		layerScreen := screen
 		if ovScreen != nil && index < overlayIndex {
 			// Draw lower layers through the overlay screen so only the touched
@@ -397,30 +588,37 @@ func (l *Layers) HandleEvent(event tcell.Event) tview.Command {
 		overlayIndex := l.topVisibleEnabledOverlayIndex()
 
 		// Pass mouse events along to the front-most visible layer that takes it,
-		// but never to layers behind an active overlay layer.
+		// but never to layers behind an active overlay layer unless the overlay
+		// lets the event pass through.
 		for index := len(l.layers) - 1; index >= 0; index-- {
 			layer := l.layers[index]
-			if !layer.visible || !layer.enabled {
+			if !layer.visible {
+				continue
+			}
+			mode, inZone := layer.zoneMode(event)
+			if inZone && mode == MouseZonePassThrough {
+				if index == overlayIndex {
+					overlayIndex = -1
+				}
+				continue
+			}
+			if !layer.enabled {
+				if inZone {
+					return nil // Captured by a disabled layer.
+				}
 				continue
 			}
 			if overlayIndex >= 0 && index < overlayIndex {
 				break
 			}
 			childCmds := layer.item.HandleEvent(event)
-			if childCmds != nil {
+			if childCmds != nil || inZone {
 				return childCmds
 			}
 		}
-
-		// If an overlay layer is active, block input to layers behind it even if
-		// the top layer didn't consume the event.
-		if overlayIndex >= 0 {
-			return nil
-		}
-		return nil
 	case *tview.KeyEvent, *tview.PasteEvent:
 		for _, layer := range l.layers {
-			if layer.enabled && layer.item.HasFocus() {
+			if layer.enabled && layer.item != nil && layer.item.HasFocus() {
 				return layer.item.HandleEvent(event)
 			}
 		}
@@ -428,6 +626,71 @@ func (l *Layers) HandleEvent(event tcell.Event) tview.Command {
 	return nil
 }
 
+// frozenKey returns the index of the front-most visible freezing layer and an
+// empty frame describing the layers behind it. It returns -1 and nil if there
+// is no visible freezing layer.
+func (l *Layers) frozenKey(screen tcell.Screen, overlayIndex int) (int, *frozenFrame) {
+	freezeIndex := -1
+	for index := len(l.layers) - 1; index >= 0; index-- {
+		if l.layers[index].visible && l.layers[index].freeze {
+			freezeIndex = index
+			break
+		}
+	}
+	if freezeIndex < 0 {
+		return -1, nil
+	}
+
+	frame := &frozenFrame{
+		overlayIndex: overlayIndex,
+		overlayStyle: l.backgroundLayerStyle,
+	}
+	for _, layer := range l.layers[:freezeIndex+1] {
+		if layer.visible {
+			frame.layers = append(frame.layers, layer)
+		}
+	}
+
+	// Clip the area to the screen.
+	x, y, width, height := l.GetRect()
+	screenWidth, screenHeight := screen.Size()
+	frame.x, frame.y = max(x, 0), max(y, 0)
+	frame.width = max(min(x+width, screenWidth)-frame.x, 0)
+	frame.height = max(min(y+height, screenHeight)-frame.y, 0)
+	return freezeIndex, frame
+}
+
+// matches returns whether this frame was drawn under the same conditions as
+// described by the given (empty) frame.
+func (f *frozenFrame) matches(other *frozenFrame) bool {
+	return slices.Equal(f.layers, other.layers) &&
+		f.overlayIndex == other.overlayIndex &&
+		f.overlayStyle == other.overlayStyle &&
+		f.x == other.x && f.y == other.y && f.width == other.width && f.height == other.height
+}
+
+// capture copies the frame's area from the screen.
+func (f *frozenFrame) capture(screen tcell.Screen) {
+	f.cells = make([]tview.SnapshotCell, 0, f.width*f.height)
+	for y := f.y; y < f.y+f.height; y++ {
+		for x := f.x; x < f.x+f.width; x++ {
+			text, style, width := screen.Get(x, y)
+			f.cells = append(f.cells, tview.SnapshotCell{Text: text, Style: style, Width: width})
+		}
+	}
+}
+
+// restore copies the frame's cells back onto the screen.
+func (f *frozenFrame) restore(screen tcell.Screen) {
+	for row := range f.height {
+		for column := 0; column < f.width; {
+			cell := f.cells[row*f.width+column]
+			screen.Put(f.x+column, f.y+row, cell.Text, cell.Style)
+			column += max(cell.Width, 1)
+		}
+	}
+}
+
 func (l *Layers) topVisibleEnabledLayer() *layer {
 	for index := len(l.layers) - 1; index >= 0; index-- {
 		layer := l.layers[index]
@@ -519,3 +782,14 @@ func applyBackgroundStyle(base tcell.Style, overlay tcell.Style) tcell.Style {
 
 	return base
 }
+
+// Inspect describes this primitive in the inspection tree. See [tview.Inspect].
+// Only visible layers are reported, from back to front.
+func (l *Layers) Inspect(node *tview.InspectNode) {
+	l.Box.Inspect(node)
+	for _, layer := range l.layers {
+		if layer.visible {
+			node.AddPrimitive(layer.item)
+		}
+	}
+}
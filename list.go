@@ -7,18 +7,45 @@ import (
 
 // ListItem represents a primitive which can be measured for a given width.
 //
-// Scroll list items are responsible for reporting their own height so the list can
-// layout and scroll variable-height items.
+// List items are responsible for reporting their own height so the list can
+// lay out and scroll variable-height items.
 type ListItem interface {
 	Primitive
 	Height(width int) int
 }
 
+// SelectableListItem is an optional interface for list items. Items which
+// report that they are not selectable (e.g. headers or separators) are skipped
+// by cursor navigation and ignore mouse clicks.
+type SelectableListItem interface {
+	ListItem
+	Selectable() bool
+}
+
 // ListBuilder returns a list item for the given index and cursor position.
 // It must return nil when the index is out of range.
 type ListBuilder func(index int, cursor int) ListItem
 
 // List displays a virtual list of primitives returned by a builder function.
+// See [List.SetMultiSelect] for selecting multiple items,
+// [List.SetFilterFunc] for find-as-you-type filtering, and
+// [List.SetBrowseMode] for lists without a cursor.
+//
+// The following keys are available by default (see [List.SetKeyMap]):
+//
+//   - Up arrow, k / Down arrow, j: Move the cursor to the previous/next
+//     selectable item.
+//   - Page Up / Page Down: Scroll up/down by one page.
+//   - Ctrl-U / Ctrl-D: Scroll up/down by half a page.
+//   - Home, g / End, G: Move the cursor to the first/last selectable item.
+//   - Enter: Activate the item under the cursor, see [List.SetSelectedFunc].
+//
+// In horizontal lists (see [List.SetOrientation]), Left arrow, h / Right arrow,
+// l move the cursor instead of Up arrow, k / Down arrow, j.
+//
+// Like in vim, these keys may be preceded by a count, e.g. "5j" moves the
+// cursor down by five items and "3G" moves it to the third item. While a
+// filter query can be typed, letters and digits edit the query instead.
 type List struct {
 	*Box
 
@@ -28,18 +55,126 @@ type List struct {
 	centerCursor bool
 	trackEnd     bool
 	atEnd        bool
+	wrapAround   bool
 
 	cursor int
 	scroll listState
 
 	changed func(index int)
 
+	// An optional function which is called when an item is activated with
+	// the Enter key or a double-click.
+	selected func(index int)
+
+	// Whether multiple items can be selected, see [List.SetMultiSelect].
+	multiSelect bool
+
+	// The indices of the selected items.
+	selection map[int]struct{}
+
+	// The selection when the current range selection was started, or nil if
+	// there is none, and the index where it was started.
+	rangeBase   map[int]struct{}
+	rangeAnchor int
+
+	// An optional function which is called when the selection changes.
+	selectionChanged func(indices []int)
+
+	// The filter function, the current filter query, and the sorted indices
+	// of the items matching it, or nil if no filter query is active.
+	filter   func(query string, index int) bool
+	query    string
+	filtered []int
+
+	// The maximum number of items in the render cache, or 0 if it is
+	// disabled, and the cached output of items by index.
+	renderCacheSize int
+	renderCache     map[int]*listSnapshot
+
+	// An optional function called when the range of visible items or the
+	// known item count changes.
+	viewportChanged func(first, last, total int)
+
+	// The last viewport reported to viewportChanged.
+	lastViewport [3]int
+
+	// The number of items found by the last full scan during the current
+	// draw, or -1 if no full scan was made.
+	itemCount int
+
+	// The number of items set with [List.SetItemCount], or -1 if unknown.
+	knownCount int
+
+	// The height of every item set with [List.SetUniformItemHeight], or 0 if
+	// items are measured.
+	uniformHeight int
+
 	lastDraw []listDrawnItem
 	lastRect listRect
 
 	scrollBarVisibility  ScrollBarVisibility
 	scrollBar            *ScrollBar
 	scrollBarInteraction scrollBarInteractionState
+
+	// Custom keys for the built-in key actions.
+	keyMap KeyMap
+
+	// The count typed before a navigation key, or 0 if none.
+	count int
+
+	// An optional function which is called when the user moves an item. If
+	// set, items can be reordered.
+	moved func(from, to int)
+
+	// The item currently dragged with the mouse.
+	drag listDragState
+
+	// The style of the line indicating where a dragged item will be dropped.
+	dropIndicatorStyle tcell.Style
+
+	// Whether items are laid out top to bottom or left to right.
+	orientation ListOrientation
+
+	// The content shown while there are no items to show.
+	placeholder placeholder
+
+	// Whether the list has no cursor, see [List.SetBrowseMode].
+	browse bool
+
+	// Whether quick-jump labels can be shown, see [List.SetQuickJump].
+	quickJump bool
+
+	// Whether jumping to an item also activates it.
+	quickJumpActivates bool
+
+	// The characters from which quick-jump labels are built.
+	quickJumpAlphabet string
+
+	// The style of quick-jump labels.
+	quickJumpStyle tcell.Style
+
+	// The quick-jump labels currently shown, nil if quick-jump mode is not
+	// active.
+	jumpLabels []listJumpLabel
+
+	// The characters of a quick-jump label typed so far.
+	jumpPrefix string
+}
+
+// listKeyActions are the key actions supported by List.
+var listKeyActions = keyActionDefaults{
+	KeyActionUp:           {tcell.NewEventKey(tcell.KeyUp, "", tcell.ModNone), tcell.NewEventKey(tcell.KeyRune, "k", tcell.ModNone)},
+	KeyActionDown:         {tcell.NewEventKey(tcell.KeyDown, "", tcell.ModNone), tcell.NewEventKey(tcell.KeyRune, "j", tcell.ModNone)},
+	KeyActionLeft:         {tcell.NewEventKey(tcell.KeyLeft, "", tcell.ModNone), tcell.NewEventKey(tcell.KeyRune, "h", tcell.ModNone)},
+	KeyActionRight:        {tcell.NewEventKey(tcell.KeyRight, "", tcell.ModNone), tcell.NewEventKey(tcell.KeyRune, "l", tcell.ModNone)},
+	KeyActionPageUp:       {tcell.NewEventKey(tcell.KeyPgUp, "", tcell.ModNone)},
+	KeyActionPageDown:     {tcell.NewEventKey(tcell.KeyPgDn, "", tcell.ModNone)},
+	KeyActionHalfPageUp:   {tcell.NewEventKey(tcell.KeyRune, "u", tcell.ModCtrl)},
+	KeyActionHalfPageDown: {tcell.NewEventKey(tcell.KeyRune, "d", tcell.ModCtrl)},
+	KeyActionHome:         {tcell.NewEventKey(tcell.KeyHome, "", tcell.ModNone), tcell.NewEventKey(tcell.KeyRune, "g", tcell.ModNone)},
+	KeyActionEnd:          {tcell.NewEventKey(tcell.KeyEnd, "", tcell.ModNone), tcell.NewEventKey(tcell.KeyRune, "G", tcell.ModNone)},
+	KeyActionActivate:     {tcell.NewEventKey(tcell.KeyEnter, "", tcell.ModNone)},
+	KeyActionQuickJump:    {tcell.NewEventKey(tcell.KeyRune, "f", tcell.ModNone)},
 }
 
 // ScrollBarVisibility controls when List renders its vertical scrollBar.
@@ -62,6 +197,9 @@ type listState struct {
 	wantsCursor bool
 }
 
+// listDrawnItem is an item placed in the viewport. Its row and height are
+// measured along the list's orientation, i.e. they are a column and a width
+// in horizontal lists.
 type listDrawnItem struct {
 	index  int
 	item   ListItem
@@ -95,20 +233,38 @@ const (
 	listScrollBarNoDrag = -1
 )
 
-// NewList returns a new scroll list.
+// NewList returns a new list without a builder.
 func NewList() *List {
 	return &List{
 		Box:                 NewBox(),
 		centerCursor:        true,
 		cursor:              -1,
+		itemCount:           -1,
+		knownCount:          -1,
+		lastViewport:        [3]int{-1, -1, -1},
 		scrollBarVisibility: ScrollBarVisibilityAutomatic,
 		scrollBar:           NewScrollBar(),
 		scrollBarInteraction: scrollBarInteractionState{
 			dragDelta: listScrollBarNoDrag,
 		},
+		dropIndicatorStyle: tcell.StyleDefault.Foreground(Styles.TertiaryTextColor),
+		placeholder:        newPlaceholder(),
+		quickJumpAlphabet:  "asdfghjklqwertyuiopzxcvbnm",
+		quickJumpStyle:     tcell.StyleDefault.Background(Styles.SecondaryTextColor).Foreground(Styles.InverseTextColor).Bold(true),
 	}
 }
 
+// applyTheme updates the list's default styles after a theme change.
+func (l *List) applyTheme(change themeChange) {
+	l.Box.applyTheme(change)
+	l.dropIndicatorStyle = change.style(l.dropIndicatorStyle, themeTertiaryText, 0)
+	l.quickJumpStyle = change.style(l.quickJumpStyle, themeInverseText, themeSecondaryText)
+	l.placeholder.applyTheme(change)
+
+	// Cached item renders use the old theme.
+	l.InvalidateRenderCache()
+}
+
 // SetScrollBarVisibility sets when the list scrollBar is rendered.
 func (l *List) SetScrollBarVisibility(visibility ScrollBarVisibility) *List {
 	if l.scrollBarVisibility != visibility {
@@ -129,6 +285,7 @@ func (l *List) SetScrollBar(scrollBar *ScrollBar) *List {
 func (l *List) SetBuilder(builder ListBuilder) *List {
 	if l.Builder != nil || builder != nil {
 		l.Builder = builder
+		l.InvalidateRenderCache()
 	}
 	return l
 }
@@ -142,6 +299,10 @@ func (l *List) Clear() *List {
 	l.setLastDraw(nil)
 	l.lastRect = listRect{}
 	l.atEnd = false
+	l.ClearSelection()
+	l.query, l.filtered = "", nil
+	l.knownCount = -1
+	l.InvalidateRenderCache()
 	return l
 }
 
@@ -173,6 +334,15 @@ func (l *List) SetCenterCursor(center bool) *List {
 	return l
 }
 
+// SetWrapAround controls whether moving the cursor past the last item
+// continues at the first item and vice versa.
+func (l *List) SetWrapAround(wrapAround bool) *List {
+	if l.wrapAround != wrapAround {
+		l.wrapAround = wrapAround
+	}
+	return l
+}
+
 // SetTrackEnd toggles auto-scrolling when the view is already at the end.
 func (l *List) SetTrackEnd(track bool) *List {
 	if l.trackEnd != track {
@@ -181,6 +351,31 @@ func (l *List) SetTrackEnd(track bool) *List {
 	return l
 }
 
+// SetPlaceholder sets a primitive which is drawn into the list's inner area
+// while there are no items to show, i.e. there is no builder, the builder
+// returns no items, or no items match the filter query (see
+// [List.SetFilterFunc]). It replaces the text set with
+// [List.SetPlaceholderText]. Set it to nil to show the text again.
+func (l *List) SetPlaceholder(placeholder Primitive) *List {
+	l.placeholder.primitive = placeholder
+	return l
+}
+
+// SetPlaceholderText sets the text which is shown centered in the list while
+// there are no items to show, e.g. "No messages". It may contain line breaks.
+// It is not shown if a primitive was set with [List.SetPlaceholder].
+func (l *List) SetPlaceholderText(text string) *List {
+	l.placeholder.text = text
+	return l
+}
+
+// SetPlaceholderStyle sets the style of the placeholder text. Its background
+// color is ignored.
+func (l *List) SetPlaceholderStyle(style tcell.Style) *List {
+	l.placeholder.style = style
+	return l
+}
+
 // ScrollToStart resets the scroll position to the top (index 0), without
 // changing the cursor.
 func (l *List) ScrollToStart() *List {
@@ -195,7 +390,7 @@ func (l *List) ScrollToStart() *List {
 
 // ScrollToEnd scrolls the view so the last items are visible.
 func (l *List) ScrollToEnd() *List {
-	_, _, width, height := l.GetInnerRect()
+	_, _, width, height := l.layoutRect()
 	if width <= 0 || height <= 0 {
 		return l
 	}
@@ -208,25 +403,29 @@ func (l *List) ScrollToEnd() *List {
 	return l
 }
 
-// SetCursor sets the currently selected item index.
+// SetCursor sets the currently selected item index. While a filter query is
+// active (see [List.SetFilterFunc]), items which don't match cannot be
+// selected.
 func (l *List) SetCursor(index int) *List {
+	if l.browse {
+		return l
+	}
 	if index < -1 {
 		index = -1
 	}
+	index = l.visibleIndex(index)
 	if l.cursor != index {
 		l.cursor = index
 		l.atEnd = false
 		l.ensureScroll()
-		if l.changed != nil {
-			l.changed(l.cursor)
-		}
+		l.notifyChanged()
 	}
 	return l
 }
 
 // Cursor returns the current cursor index.
 func (l *List) Cursor() int {
-	return l.cursor
+	return l.itemIndex(l.cursor)
 }
 
 // SetPendingScroll sets a pending scroll amount, in lines. Positive numbers
@@ -250,48 +449,97 @@ func (l *List) ScrollDown() *List {
 	return l
 }
 
-// NextItem moves the cursor to the next item, if any.
+// NextItem moves the cursor to the next selectable item, if any. If wrap-around
+// is enabled, the search continues at the first item.
 func (l *List) NextItem() bool {
 	if l.Builder == nil {
 		return false
 	}
-	if l.cursor < 0 {
-		if l.Builder(0, l.cursor) == nil {
-			return false
+	for i := l.cursor + 1; ; i++ {
+		item := l.item(i)
+		if item == nil {
+			break
 		}
-		l.cursor = 0
-		l.ensureScroll()
-		if l.changed != nil {
-			l.changed(l.cursor)
+		if isSelectable(item) {
+			l.moveCursor(i)
+			return true
 		}
-		return true
 	}
-	if l.Builder(l.cursor+1, l.cursor) == nil {
+	if !l.wrapAround {
 		return false
 	}
-	l.cursor++
-	l.ensureScroll()
-	if l.changed != nil {
-		l.changed(l.cursor)
+	for i := 0; i < l.cursor; i++ {
+		item := l.item(i)
+		if item == nil {
+			break
+		}
+		if isSelectable(item) {
+			l.moveCursor(i)
+			return true
+		}
 	}
-	return true
+	return false
 }
 
-// PrevItem moves the cursor to the previous item, if any.
+// PrevItem moves the cursor to the previous selectable item, if any. If
+// wrap-around is enabled, the search continues at the last item.
 func (l *List) PrevItem() bool {
-	if l.cursor <= 0 {
-		return false
-	}
 	if l.Builder == nil {
 		return false
 	}
-	if l.Builder(l.cursor-1, l.cursor) == nil {
+	for i := l.cursor - 1; i >= 0; i-- {
+		if item := l.item(i); item != nil && isSelectable(item) {
+			l.moveCursor(i)
+			return true
+		}
+	}
+	if !l.wrapAround {
 		return false
 	}
-	l.cursor--
+	last := l.lastIndex()
+	for i := last; i > l.cursor; i-- {
+		if item := l.item(i); item != nil && isSelectable(item) {
+			l.moveCursor(i)
+			return true
+		}
+	}
+	return false
+}
+
+// moveCursor sets the cursor to the given index, scrolls it into view, and
+// notifies the changed handler.
+func (l *List) moveCursor(index int) {
+	if l.browse {
+		return
+	}
+	l.cursor = index
 	l.ensureScroll()
+	l.notifyChanged()
+}
+
+// notifyChanged calls the changed handler with the cursor's item index.
+func (l *List) notifyChanged() {
 	if l.changed != nil {
-		l.changed(l.cursor)
+		l.changed(l.itemIndex(l.cursor))
+	}
+}
+
+// lastIndex returns the index of the last item, or -1 if there are no items.
+func (l *List) lastIndex() int {
+	if count := l.knownItemCount(); count >= 0 {
+		return count - 1
+	}
+	last := -1
+	for l.item(last+1) != nil {
+		last++
+	}
+	return last
+}
+
+// isSelectable returns whether the cursor may be placed on the given item.
+func isSelectable(item ListItem) bool {
+	if selectable, ok := item.(SelectableListItem); ok {
+		return selectable.Selectable()
 	}
 	return true
 }
@@ -302,17 +550,117 @@ func (l *List) SetChangedFunc(handler func(index int)) *List {
 	return l
 }
 
+// SetSelectedFunc sets a handler which is called when the user activates the
+// item under the cursor, by pressing Enter (see [KeyActionActivate]) or by
+// double-clicking it. Unlike the handler set with [List.SetChangedFunc], it is
+// not called when the cursor merely moves.
+func (l *List) SetSelectedFunc(handler func(index int)) *List {
+	l.selected = handler
+	return l
+}
+
+// activate calls the selected handler for the item under the cursor, if it is
+// selectable.
+func (l *List) activate() {
+	if l.selected != nil && l.cursor >= 0 && l.selectableAt(l.cursor) {
+		l.selected(l.itemIndex(l.cursor))
+	}
+}
+
+// SetKeyMap sets custom keys for the list's key actions, replacing their
+// default keys. The supported actions are KeyActionUp, KeyActionDown,
+// KeyActionLeft, KeyActionRight (horizontal lists only), KeyActionPageUp,
+// KeyActionPageDown, KeyActionHalfPageUp, KeyActionHalfPageDown,
+// KeyActionHome, KeyActionEnd, KeyActionActivate, and KeyActionQuickJump (see
+// [List.SetQuickJump]).
+func (l *List) SetKeyMap(keyMap KeyMap) *List {
+	l.keyMap = keyMap
+	return l
+}
+
+// SetViewportChangedFunc sets a handler which is called after drawing whenever
+// the range of visible items changes. It receives the indices of the first and
+// last (partially) visible items, or -1 for both if no items are visible, and
+// the total number of items. The total is -1 if it is not known without
+// iterating over all items, which only happens when the scrollbar is shown or
+// the end of the list is visible, unless it was set with [List.SetItemCount].
+// This can be used to show "N-M of T" indicators.
+func (l *List) SetViewportChangedFunc(handler func(first, last, total int)) *List {
+	l.viewportChanged = handler
+	l.lastViewport = [3]int{-1, -1, -1}
+	return l
+}
+
+// notifyViewport reports the currently drawn viewport to the viewport changed
+// handler, if it differs from the last one reported.
+func (l *List) notifyViewport() {
+	if l.viewportChanged == nil {
+		return
+	}
+
+	first, last, total := -1, -1, l.itemCount
+	for _, child := range l.lastDraw {
+		if child.row+child.height <= 0 || child.row >= l.lastRect.height {
+			continue // Only the gap is visible.
+		}
+		if first < 0 {
+			first = child.index
+		}
+		last = child.index
+	}
+	if known := l.knownItemCount(); known >= 0 {
+		total = known
+	} else if total < 0 {
+		if n := len(l.lastDraw); n > 0 {
+			if next := l.lastDraw[n-1].index + 1; l.item(next) == nil {
+				total = next
+			}
+		} else if l.item(0) == nil {
+			total = 0
+		}
+	}
+
+	viewport := [3]int{first, last, total}
+	if viewport != l.lastViewport {
+		l.lastViewport = viewport
+		l.viewportChanged(first, last, total)
+	}
+}
+
 func (l *List) setLastDraw(children []listDrawnItem) {
 	l.lastDraw = children
 }
 
+// forgetLastDraw clears the state of the last draw when nothing was drawn, so
+// that mouse handling and end tracking don't refer to items which are gone.
+func (l *List) forgetLastDraw() {
+	l.setLastDraw(nil)
+	l.lastRect = listRect{}
+	l.atEnd = false
+}
+
+// Blur is called when this primitive loses focus.
+func (l *List) Blur() {
+	l.stopQuickJump()
+	l.Box.Blur()
+}
+
 // Draw draws this primitive onto the screen.
 func (l *List) Draw(screen tcell.Screen) {
 	l.DrawForSubclass(screen, l)
 	l.scrollBarInteraction.state = listScrollBarState{}
+	l.itemCount = -1
+	defer l.notifyViewport()
 
-	x, y, width, height := l.GetInnerRect()
+	if l.item(0) == nil {
+		l.forgetLastDraw()
+		l.placeholder.draw(screen, l.Box)
+		return
+	}
+
+	x, y, width, height := l.layoutRect()
 	if width <= 0 || height <= 0 || l.Builder == nil {
+		l.forgetLastDraw()
 		return
 	}
 
@@ -362,6 +710,22 @@ func (l *List) Draw(screen tcell.Screen) {
 		}
 	}
 
+	// With uniform item heights and a known number of items, resolve pending
+	// scrolling arithmetically instead of building every item it passes.
+	if l.uniformHeight > 0 && l.scroll.pending != 0 && !l.scroll.wantsCursor {
+		count := l.knownItemCount()
+		if count < 0 {
+			count = l.itemCount // Counted for the scroll bar, if at all.
+		}
+		if count >= 0 {
+			stride := l.uniformHeight + l.gap
+			maxOffset := max(l.uniformContentHeight(count)-height, 0)
+			position := min(max(l.scroll.top*stride+l.scroll.offset+l.scroll.pending, 0), maxOffset)
+			l.scroll.top, l.scroll.offset = position/stride, position%stride
+			l.scroll.pending = 0
+		}
+	}
+
 	pendingDelta := l.scroll.pending
 	ah := -(l.scroll.offset + pendingDelta)
 	l.scroll.pending = 0
@@ -387,7 +751,7 @@ rebuild:
 
 	endReached := false
 	for i := startIndex; ; i++ {
-		item := l.Builder(i, l.cursor)
+		item := l.item(i)
 		if item == nil {
 			endReached = true
 			break
@@ -452,7 +816,7 @@ rebuild:
 		nextIndex := children[len(children)-1].index + 1
 		currentBottom := children[len(children)-1].row + children[len(children)-1].height
 		for {
-			item := l.Builder(nextIndex, l.cursor)
+			item := l.item(nextIndex)
 			if item == nil {
 				break
 			}
@@ -523,7 +887,7 @@ rebuild:
 	}
 
 	last := children[len(children)-1]
-	if !endReached && l.Builder(last.index+1, l.cursor) == nil {
+	if !endReached && l.item(last.index+1) == nil {
 		endReached = true
 	}
 	l.atEnd = endReached && last.row+last.height <= height
@@ -531,11 +895,14 @@ rebuild:
 	l.setLastDraw(children)
 	l.lastRect = listRect{x: x, y: y, width: width, height: height}
 
-	clipped := newClippedScreen(screen, x, y, width, height)
+	innerX, innerY, innerWidth, innerHeight := l.GetInnerRect()
+	clipped := newClippedScreen(screen, innerX, innerY, innerWidth, innerHeight)
 	for _, child := range children {
-		child.item.SetRect(x, y+child.row, usableWidth, child.height)
-		child.item.Draw(clipped)
+		child.item.SetRect(l.transposeRect(x, y+child.row, usableWidth, child.height))
+		l.drawItem(clipped, child)
 	}
+	l.drawDropIndicator(clipped, children, x, y, usableWidth, height)
+	l.drawQuickJumpLabels(clipped, children, x, y)
 
 	if drawScrollBar {
 		if l.scrollBar == nil {
@@ -547,7 +914,12 @@ rebuild:
 			return
 		}
 		l.scrollBarInteraction.state = scrollBarState
-		l.scrollBar.SetRect(scrollBarX, y, 1, height)
+		if l.orientation == ListHorizontal {
+			l.scrollBar.SetOrientation(ScrollBarHorizontal)
+		} else {
+			l.scrollBar.SetOrientation(ScrollBarVertical)
+		}
+		l.scrollBar.SetRect(l.transposeRect(scrollBarX, y, 1, height))
 		l.scrollBar.SetLengths(ScrollLengths{
 			ContentLen:  scrollBarState.contentLength,
 			ViewportLen: scrollBarState.viewportLength,
@@ -561,6 +933,12 @@ func (l *List) itemHeight(item ListItem, width int) int {
 	if item == nil {
 		return 0
 	}
+	if l.uniformHeight > 0 {
+		return l.uniformHeight
+	}
+	if l.orientation == ListHorizontal {
+		return l.itemWidth(item, width)
+	}
 	height := max(item.Height(width), 1)
 	return height
 }
@@ -569,10 +947,15 @@ func (l *List) totalContentHeight(width int) int {
 	if l.Builder == nil || width <= 0 {
 		return 0
 	}
+	if count := l.knownItemCount(); count >= 0 && l.uniformHeight > 0 {
+		l.itemCount = count
+		return l.uniformContentHeight(count)
+	}
 	total := 0
 	for i := 0; ; i++ {
-		item := l.Builder(i, l.cursor)
+		item := l.item(i)
 		if item == nil {
+			l.itemCount = i
 			break
 		}
 		if i > 0 {
@@ -590,8 +973,14 @@ func (l *List) scrollBarMetrics(width int, viewport int, children []listDrawnIte
 	}
 
 	first := children[0]
-	for i := 0; i < first.index; i++ {
-		item := l.Builder(i, l.cursor)
+	if l.uniformHeight > 0 {
+		position = l.uniformContentHeight(first.index)
+		if first.index > 0 {
+			position += l.gap
+		}
+	}
+	for i := 0; l.uniformHeight == 0 && i < first.index; i++ {
+		item := l.item(i)
 		if item == nil {
 			break
 		}
@@ -627,7 +1016,7 @@ func (l *List) insertChildren(children *[]listDrawnItem, width int, ah int) {
 		if l.gap > 0 {
 			ah -= l.gap
 		}
-		item := l.Builder(l.scroll.top, l.cursor)
+		item := l.item(l.scroll.top)
 		if item == nil {
 			break
 		}
@@ -678,7 +1067,7 @@ func (l *List) centerScrollState(width int, height int) (int, int, bool) {
 	if l.Builder == nil || l.cursor < 0 || width <= 0 || height <= 0 {
 		return 0, 0, false
 	}
-	cursorItem := l.Builder(l.cursor, l.cursor)
+	cursorItem := l.item(l.cursor)
 	if cursorItem == nil {
 		return 0, 0, false
 	}
@@ -693,7 +1082,7 @@ func (l *List) centerScrollState(width int, height int) (int, int, bool) {
 	remaining := desiredBefore
 	for remaining > 0 && top > 0 {
 		prevIndex := top - 1
-		prevItem := l.Builder(prevIndex, l.cursor)
+		prevItem := l.item(prevIndex)
 		if prevItem == nil {
 			break
 		}
@@ -727,7 +1116,7 @@ func (l *List) centerScrollState(width int, height int) (int, int, bool) {
 	// Verify there is enough content below to keep the viewport filled.
 	ah := -offset
 	for i := top; ; i++ {
-		item := l.Builder(i, l.cursor)
+		item := l.item(i)
 		if item == nil {
 			return 0, 0, false
 		}
@@ -751,7 +1140,7 @@ func (l *List) scrollByItems(delta int, count int, width int, height int) {
 	if delta > 0 {
 		// Step the top index downward without going past the end.
 		for i := 0; i < count; i++ {
-			if l.Builder(l.scroll.top+1, l.cursor) == nil {
+			if l.item(l.scroll.top+1) == nil {
 				break
 			}
 			l.scroll.top++
@@ -778,7 +1167,7 @@ func (l *List) visibleItemCount(width int, height int) int {
 	total := 0
 	count := 0
 	for idx := l.scroll.top; ; idx++ {
-		item := l.Builder(idx, l.cursor)
+		item := l.item(idx)
 		if item == nil {
 			break
 		}
@@ -805,16 +1194,16 @@ func (l *List) endScrollState(width int, height int) (int, int) {
 	}
 	start := max(l.scroll.top, 0)
 	// If the current top is past the end, restart from the beginning.
-	if l.Builder(start, l.cursor) == nil && start != 0 {
+	if l.item(start) == nil && start != 0 {
 		start = 0
 	}
-	last := start
-	for {
-		if l.Builder(last, l.cursor) == nil {
-			last--
-			break
+	last := l.knownItemCount() - 1
+	if last < -1 {
+		last = start
+		for l.item(last) != nil {
+			last++
 		}
-		last++
+		last--
 	}
 	if last < 0 {
 		return 0, 0
@@ -823,7 +1212,7 @@ func (l *List) endScrollState(width int, height int) (int, int) {
 	// Walk upward from the last item until we fill a viewport.
 	total := 0
 	for i := last; i >= 0; i-- {
-		item := l.Builder(i, l.cursor)
+		item := l.item(i)
 		if item == nil {
 			continue
 		}
@@ -847,38 +1236,110 @@ func (l *List) endScrollState(width int, height int) (int, int) {
 func (l *List) HandleEvent(event tcell.Event) Command {
 	switch event := event.(type) {
 	case *KeyEvent:
-		switch event.Key() {
+		resolved, ok := l.keyMap.resolve(event, listKeyActions)
+		if !ok {
+			// The default keys of rebound actions can still be typed as
+			// quick-jump labels or into the filter query.
+			if l.jumpLabels != nil {
+				return l.handleQuickJumpKey(event)
+			}
+			if l.filter != nil && l.handleFilterKey(event) {
+				return RedrawCommand{}
+			}
+			return nil
+		}
+		event = resolved
+		if l.jumpLabels != nil {
+			return l.handleQuickJumpKey(event)
+		}
+		if l.quickJump && isQuickJumpKey(event) && l.startQuickJump() {
+			return RedrawCommand{}
+		}
+		if l.filter != nil && l.handleFilterKey(event) {
+			return RedrawCommand{}
+		}
+		if event = l.orientKey(event); event == nil {
+			return nil
+		}
+		if l.browse {
+			return l.handleBrowseKey(event)
+		}
+		if l.multiSelect && l.handleSelectionKey(event) {
+			return RedrawCommand{}
+		}
+		if l.reorderable() && l.handleReorderKey(event) {
+			return RedrawCommand{}
+		}
+		if l.handleCountKey(event) {
+			return nil
+		}
+		count, counted := max(l.count, 1), l.count > 0
+		l.count = 0
+		switch key := event.Key(); key {
+		case tcell.KeyRune:
+			switch event.Str() {
+			case "j":
+				for range count {
+					l.NextItem()
+				}
+			case "k":
+				for range count {
+					l.PrevItem()
+				}
+			case "g":
+				if counted {
+					l.jumpToItem(count - 1)
+				} else {
+					l.FirstItem()
+				}
+			case "G":
+				if counted {
+					l.jumpToItem(count - 1)
+				} else {
+					l.LastItem()
+				}
+			}
 		case tcell.KeyDown:
-			l.NextItem()
+			for range count {
+				l.NextItem()
+			}
 		case tcell.KeyUp:
-			l.PrevItem()
-		case tcell.KeyPgDn:
-			_, _, width, height := l.GetInnerRect()
-			if l.snapToItems {
-				l.scrollByItems(1, l.visibleItemCount(width, height), width, height)
+			for range count {
+				l.PrevItem()
+			}
+		case tcell.KeyHome:
+			if counted {
+				l.jumpToItem(count - 1)
 			} else {
-				if height < 1 {
-					height = 1
-				}
-				l.scroll.pending += height
+				l.FirstItem()
 			}
-		case tcell.KeyPgUp:
-			_, _, width, height := l.GetInnerRect()
-			if l.snapToItems {
-				l.scrollByItems(-1, l.visibleItemCount(width, height), width, height)
+		case tcell.KeyEnd:
+			if counted {
+				l.jumpToItem(count - 1)
 			} else {
-				if height < 1 {
-					height = 1
-				}
-				l.scroll.pending -= height
+				l.LastItem()
+			}
+		case tcell.KeyEnter:
+			l.activate()
+		case tcell.KeyPgDn, tcell.KeyPgUp, tcell.KeyCtrlD, tcell.KeyCtrlU:
+			direction := 1
+			if key == tcell.KeyPgUp || key == tcell.KeyCtrlU {
+				direction = -1
 			}
+			l.scrollPages(direction*count, key == tcell.KeyCtrlD || key == tcell.KeyCtrlU)
 		}
 		return RedrawCommand{}
 	case *MouseEvent:
+		if l.jumpLabels != nil && event.Action != MouseMove {
+			l.stopQuickJump()
+			return RedrawCommand{}
+		}
 		var cmd Command
 		x, y := event.Position()
+		inRect := l.InRect(x, y)
+		x, y = l.transpose(x, y)
 		if l.scrollBarInteraction.dragDelta >= 0 {
-			_, innerY, innerWidth, innerHeight := l.GetInnerRect()
+			_, innerY, innerWidth, innerHeight := l.layoutRect()
 			contentWidth, _ := l.scrollBarLayout(0, innerWidth)
 			row := y - innerY
 			switch event.Action {
@@ -895,12 +1356,15 @@ func (l *List) HandleEvent(event tcell.Event) Command {
 				}
 			}
 		}
+		if cmd := l.handleDrag(event.Action, y); cmd != nil {
+			return cmd
+		}
 
-		if !l.InRect(x, y) {
+		if !inRect {
 			return nil
 		}
 
-		innerX, innerY, innerWidth, innerHeight := l.GetInnerRect()
+		innerX, innerY, innerWidth, innerHeight := l.layoutRect()
 		contentWidth, scrollBarX := l.scrollBarLayout(innerX, innerWidth)
 		drawScrollBar := l.shouldDrawScrollBar(innerWidth, innerHeight)
 		if drawScrollBar && x == scrollBarX && y >= innerY && y < innerY+innerHeight {
@@ -927,27 +1391,50 @@ func (l *List) HandleEvent(event tcell.Event) Command {
 		}
 
 		switch event.Action {
+		case MouseLeftDown:
+			if index := l.indexAtPoint(x, y); index >= 0 && l.reorderable() && event.Modifiers() == tcell.ModNone {
+				l.drag = listDragState{active: true, from: index, drop: -1}
+				return SetMouseCaptureCommand{Target: l}
+			}
 		case MouseLeftClick:
 			index := l.indexAtPoint(x, y)
-			if index >= 0 {
+			if l.browse {
+				l.activateAt(index)
+				return RedrawCommand{}
+			}
+			if index >= 0 && l.selectableAt(index) {
+				if l.multiSelect && l.handleSelectionClick(index, event.Modifiers()) {
+					return RedrawCommand{}
+				}
 				previous := l.cursor
 				l.cursor = index
 				l.ensureScroll()
-				if l.changed != nil && l.cursor != previous {
-					l.changed(l.cursor)
+				if l.cursor != previous {
+					l.notifyChanged()
 				}
 			}
 			return RedrawCommand{}
-		case MouseScrollUp:
-			_, _, width, height := l.GetInnerRect()
+		case MouseLeftDoubleClick:
+			if index := l.indexAtPoint(x, y); index >= 0 && index == l.cursor {
+				l.activate()
+			}
+			return RedrawCommand{}
+		case MouseScrollUp, MouseScrollLeft:
+			if event.Action == MouseScrollLeft && l.orientation != ListHorizontal {
+				return nil
+			}
+			_, _, width, height := l.layoutRect()
 			if l.snapToItems {
 				l.scrollByItems(-1, 1, width, height)
 			} else {
 				l.scroll.pending -= l.mouseScrollStep()
 			}
 			return RedrawCommand{}
-		case MouseScrollDown:
-			_, _, width, height := l.GetInnerRect()
+		case MouseScrollDown, MouseScrollRight:
+			if event.Action == MouseScrollRight && l.orientation != ListHorizontal {
+				return nil
+			}
+			_, _, width, height := l.layoutRect()
 			if l.snapToItems {
 				l.scrollByItems(1, 1, width, height)
 			} else {
@@ -1108,10 +1595,11 @@ func (l *List) handleScrollBarMouse(action MouseAction, row int, height int, con
 		targetOffset := (targetStart * maxOffset) / thumbTravel
 		l.scroll.pending += targetOffset - state.position
 	default:
+		page := l.scrollBar.pageLength(state.viewportLength)
 		if clickPos < state.metrics.thumbStart {
-			l.scroll.pending -= state.viewportLength
+			l.scroll.pending -= page
 		} else if clickPos >= state.metrics.thumbStart+state.metrics.thumbLen {
-			l.scroll.pending += state.viewportLength
+			l.scroll.pending += page
 		}
 	}
 	return true
@@ -1138,9 +1626,13 @@ func (l *List) scrollBarLayout(innerX int, innerWidth int) (contentWidth int, sc
 	contentWidth = innerWidth - 1
 	scrollBarX = innerX + contentWidth
 	// Reuse right padding for the scrollBar when available so we don't reduce content width by an extra column.
-	if l.paddingRight > 0 {
+	padding := l.paddingRight
+	if l.orientation == ListHorizontal {
+		padding = l.paddingBottom
+	}
+	if padding > 0 {
 		contentWidth = innerWidth
-		scrollBarX = innerX + innerWidth + l.paddingRight - 1
+		scrollBarX = innerX + innerWidth + padding - 1
 	}
 	return contentWidth, scrollBarX
 }
@@ -1174,6 +1666,17 @@ func (l *List) computeScrollBarState(contentWidth int, viewportHeight int, child
 	return state, true
 }
 
+// selectableAt returns whether the item last drawn at the given index may be
+// selected.
+func (l *List) selectableAt(index int) bool {
+	for _, child := range l.lastDraw {
+		if child.index == index {
+			return isSelectable(child.item)
+		}
+	}
+	return false
+}
+
 func (l *List) indexAtPoint(x, y int) int {
 	if len(l.lastDraw) == 0 {
 		return -1
@@ -1298,3 +1801,20 @@ func (l *List) trimToFullItems(children []listDrawnItem, height int) []listDrawn
 
 	return children
 }
+
+// Inspect describes this primitive in the inspection tree. See [Inspect]. The
+// items drawn last are reported as children with the [RoleListItem] role.
+func (l *List) Inspect(node *InspectNode) {
+	l.Box.Inspect(node)
+	node.Role = RoleList
+	for _, drawn := range l.lastDraw {
+		if child := node.AddPrimitive(drawn.item); child != nil {
+			child.Role = RoleListItem
+			if l.multiSelect {
+				child.Selected = l.IsSelected(l.itemIndex(drawn.index))
+			} else {
+				child.Selected = drawn.index == l.cursor
+			}
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package tview
+
+import "github.com/gdamore/tcell/v3"
+
+// SetBrowseMode sets whether the list has no cursor, e.g. for read-only feeds.
+// In browse mode, the cursor is always -1, so builders receive -1 as the
+// cursor, and methods which move the cursor, e.g. [List.SetCursor], have no
+// effect. The navigation keys scroll the list instead: Up and Down (or k and
+// j) by one row, or by one item if the list snaps to items (see
+// [List.SetSnapToItems]), Home and End to the start and the end. Enter
+// activates the item in the middle of the list and a click the item under the
+// mouse (see [List.SetSelectedFunc]). Items cannot be selected (see
+// [List.SetMultiSelect]) or reordered.
+//
+// Turning browse mode on removes the cursor.
+func (l *List) SetBrowseMode(browse bool) *List {
+	if l.browse == browse {
+		return l
+	}
+	if browse && l.cursor >= 0 {
+		l.cursor = -1
+		l.notifyChanged()
+	}
+	l.browse = browse
+	l.InvalidateRenderCache()
+	return l
+}
+
+// IsBrowseMode returns whether the list has no cursor, see
+// [List.SetBrowseMode].
+func (l *List) IsBrowseMode() bool {
+	return l.browse
+}
+
+// handleBrowseKey handles navigation keys in browse mode.
+func (l *List) handleBrowseKey(event *KeyEvent) Command {
+	if l.handleCountKey(event) {
+		return nil
+	}
+	count := max(l.count, 1)
+	l.count = 0
+	_, _, width, height := l.layoutRect()
+	scroll := func(direction int) {
+		if l.snapToItems {
+			l.scrollByItems(direction, count, width, height)
+		} else {
+			l.scroll.pending += direction * count
+		}
+	}
+	switch key := event.Key(); key {
+	case tcell.KeyRune:
+		switch event.Str() {
+		case "j":
+			scroll(1)
+		case "k":
+			scroll(-1)
+		case "g":
+			l.ScrollToStart()
+		case "G":
+			l.ScrollToEnd()
+		default:
+			return nil
+		}
+	case tcell.KeyDown:
+		scroll(1)
+	case tcell.KeyUp:
+		scroll(-1)
+	case tcell.KeyHome:
+		l.ScrollToStart()
+	case tcell.KeyEnd:
+		l.ScrollToEnd()
+	case tcell.KeyEnter:
+		l.activateAt(l.centerIndex())
+	case tcell.KeyPgDn, tcell.KeyPgUp, tcell.KeyCtrlD, tcell.KeyCtrlU:
+		direction := 1
+		if key == tcell.KeyPgUp || key == tcell.KeyCtrlU {
+			direction = -1
+		}
+		l.scrollPages(direction*count, key == tcell.KeyCtrlD || key == tcell.KeyCtrlU)
+	default:
+		return nil
+	}
+	return RedrawCommand{}
+}
+
+// centerIndex returns the position of the item last drawn in the middle of
+// the list, or of the drawn item closest to the middle, or -1 if no items
+// were drawn.
+func (l *List) centerIndex() int {
+	middle := l.lastRect.height / 2
+	index, distance := -1, 0
+	for _, child := range l.lastDraw {
+		d := 0
+		if middle < child.row {
+			d = child.row - middle
+		} else if middle >= child.row+child.height {
+			d = middle - (child.row + child.height - 1)
+		}
+		if index < 0 || d < distance {
+			index, distance = child.index, d
+		}
+	}
+	return index
+}
+
+// activateAt calls the selected handler for the item at the given position,
+// if it was drawn last and is selectable.
+func (l *List) activateAt(index int) {
+	if l.selected != nil && index >= 0 && l.selectableAt(index) {
+		l.selected(l.itemIndex(index))
+	}
+}
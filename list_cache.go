@@ -0,0 +1,143 @@
+package tview
+
+import (
+	"github.com/gdamore/tcell/v3"
+	"github.com/rivo/uniseg"
+)
+
+// listSnapshot is the recorded output of a list item drawn with a given size
+// and state.
+type listSnapshot struct {
+	width, height    int
+	cursor, selected bool
+	cells            []listSnapshotCell
+}
+
+// listSnapshotCell is a cell written by a list item, relative to the item's
+// top left corner.
+type listSnapshotCell struct {
+	x, y  int
+	str   string
+	style tcell.Style
+}
+
+// SetRenderCache sets the number of items whose drawn output is kept in a
+// cache. Cached items are not drawn again but copied to the screen as long as
+// their size and whether they are under the cursor or selected (see
+// [List.SetMultiSelect]) stay the same. This makes scrolling through items
+// which are expensive to draw, e.g. rendered Markdown, cheaper. The builder
+// and the items' Height functions are still called.
+//
+// Items whose appearance changes otherwise must be invalidated with
+// [List.InvalidateItem]. Items with focus are never cached. A size of 0 (the
+// default) disables the cache.
+func (l *List) SetRenderCache(size int) *List {
+	size = max(size, 0)
+	if l.renderCacheSize != size {
+		l.renderCacheSize = size
+		l.InvalidateRenderCache()
+	}
+	return l
+}
+
+// InvalidateItem removes the item with the given index from the render cache,
+// see [List.SetRenderCache].
+func (l *List) InvalidateItem(index int) *List {
+	delete(l.renderCache, index)
+	return l
+}
+
+// InvalidateRenderCache removes all items from the render cache, see
+// [List.SetRenderCache].
+func (l *List) InvalidateRenderCache() *List {
+	l.renderCache = nil
+	return l
+}
+
+// drawItem draws the given item to the screen, using or filling the render
+// cache if it is enabled. The item's rectangle must have been set.
+func (l *List) drawItem(screen tcell.Screen, drawn listDrawnItem) {
+	if l.renderCacheSize == 0 || drawn.item.HasFocus() {
+		drawn.item.Draw(screen)
+		return
+	}
+
+	x, y, width, height := drawn.item.GetRect()
+	index := l.itemIndex(drawn.index)
+	cursor, selected := drawn.index == l.cursor, l.IsSelected(index)
+	snapshot, ok := l.renderCache[index]
+	if !ok || snapshot.width != width || snapshot.height != height || snapshot.cursor != cursor || snapshot.selected != selected {
+		recorder := &recordingScreen{Screen: screen, x: x, y: y}
+		drawn.item.Draw(recorder)
+		snapshot = &listSnapshot{
+			width:    width,
+			height:   height,
+			cursor:   cursor,
+			selected: selected,
+			cells:    recorder.cells,
+		}
+		if l.renderCache == nil {
+			l.renderCache = make(map[int]*listSnapshot)
+		}
+		l.renderCache[index] = snapshot
+		l.trimRenderCache(index)
+	}
+
+	for _, cell := range snapshot.cells {
+		screen.Put(x+cell.x, y+cell.y, cell.str, cell.style)
+	}
+}
+
+// trimRenderCache removes the items farthest from the given index from the
+// render cache until it does not exceed its size.
+func (l *List) trimRenderCache(index int) {
+	for len(l.renderCache) > l.renderCacheSize {
+		farthest, distance := -1, -1
+		for cached := range l.renderCache {
+			if d := max(cached-index, index-cached); d > distance {
+				farthest, distance = cached, d
+			}
+		}
+		delete(l.renderCache, farthest)
+	}
+}
+
+// recordingScreen is a screen which records the cells written to it relative
+// to a given origin instead of writing them to the underlying screen.
+type recordingScreen struct {
+	tcell.Screen
+	x, y  int
+	cells []listSnapshotCell
+}
+
+// Put implements tcell.Screen.
+func (s *recordingScreen) Put(x, y int, str string, style tcell.Style) (string, int) {
+	if str == "" {
+		return "", 0
+	}
+	cluster, rest, width, _ := uniseg.FirstGraphemeClusterInString(str, -1)
+	s.cells = append(s.cells, listSnapshotCell{x: x - s.x, y: y - s.y, str: cluster, style: style})
+	return rest, max(width, 1)
+}
+
+// PutStr implements tcell.Screen.
+func (s *recordingScreen) PutStr(x, y int, str string) {
+	s.PutStrStyled(x, y, str, tcell.StyleDefault)
+}
+
+// PutStrStyled implements tcell.Screen.
+func (s *recordingScreen) PutStrStyled(x, y int, str string, style tcell.Style) {
+	for str != "" {
+		var width int
+		str, width = s.Put(x, y, str, style)
+		x += width
+	}
+}
+
+// SetContent implements tcell.Screen.
+func (s *recordingScreen) SetContent(x, y int, primary rune, combining []rune, style tcell.Style) {
+	s.Put(x, y, string(append([]rune{primary}, combining...)), style)
+}
+
+// ShowCursor implements tcell.Screen. Cursors are not recorded.
+func (s *recordingScreen) ShowCursor(x, y int) {}
@@ -0,0 +1,46 @@
+package tview
+
+// SetItemCount sets the number of items returned by the builder, so that the
+// list does not need to call the builder for every item to find the end of the
+// list, e.g. to size the scrollbar or in [List.ScrollToEnd]. Indices at or
+// beyond the count are treated as out of range even if the builder returns an
+// item for them. The count must be updated whenever items are added or
+// removed. A negative count (the default) means that the count is unknown.
+//
+// Determining the scrollbar's metrics also requires the heights of all items.
+// Use [List.SetUniformItemHeight] to avoid measuring them.
+func (l *List) SetItemCount(count int) *List {
+	l.knownCount = max(count, -1)
+	return l
+}
+
+// SetUniformItemHeight declares that all items have the given height, so that
+// the list can compute the content height and the scroll position without
+// measuring each item. Items' Height functions are not called while this is
+// set. In horizontal lists (see [List.SetOrientation]), this is the width of
+// the items. A height of 0 (the default) means that items are measured.
+func (l *List) SetUniformItemHeight(height int) *List {
+	l.uniformHeight = max(height, 0)
+	return l
+}
+
+// knownItemCount returns the number of items in the (possibly filtered) list
+// if it is known without calling the builder, or -1 otherwise.
+func (l *List) knownItemCount() int {
+	switch {
+	case l.Builder == nil:
+		return 0
+	case l.filtered != nil:
+		return len(l.filtered)
+	}
+	return l.knownCount
+}
+
+// uniformContentHeight returns the total height of the given number of items
+// of uniform height, including gaps.
+func (l *List) uniformContentHeight(count int) int {
+	if count <= 0 {
+		return 0
+	}
+	return count*l.uniformHeight + (count-1)*l.gap
+}
@@ -0,0 +1,160 @@
+package tview
+
+import (
+	"sort"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+// SetFilterFunc enables find-as-you-type filtering. While the list has focus,
+// typed characters are appended to a filter query, Backspace removes the last
+// character, and Escape clears the query. While the query is not empty, only
+// items for which the handler returns true are shown and reachable with the
+// cursor. The handler receives the query and the item's index, e.g.:
+//
+//	list.SetFilterFunc(func(query string, index int) bool {
+//		return tview.ContainsFold(names[index], query)
+//	})
+//
+// Item indices (e.g. in [List.Cursor], the builder, and the handlers) always
+// refer to all items, regardless of the filter. The cursor stays on its item if
+// it matches, otherwise it moves to the next matching item. The indices passed
+// to the viewport handler (see [List.SetViewportChangedFunc]) refer to the
+// filtered items.
+//
+// In multi-selection mode (see [List.SetMultiSelect]), Space toggles the
+// selection and cannot be part of the query. Set the handler to nil to disable
+// filtering.
+func (l *List) SetFilterFunc(handler func(query string, index int) bool) *List {
+	l.filter = handler
+	if handler == nil {
+		l.query = ""
+	}
+	l.applyFilter()
+	return l
+}
+
+// SetFilterQuery sets the filter query, see [List.SetFilterFunc]. The filter
+// is applied again even if the query did not change, so this function can also
+// be used to update the filtered items after the list's items have changed.
+func (l *List) SetFilterQuery(query string) *List {
+	if l.filter != nil {
+		l.query = query
+		l.applyFilter()
+	}
+	return l
+}
+
+// GetFilterQuery returns the current filter query, see [List.SetFilterFunc].
+func (l *List) GetFilterQuery() string {
+	return l.query
+}
+
+// item returns the item at the given position of the (possibly filtered)
+// list, or nil if there is no such item.
+func (l *List) item(position int) ListItem {
+	if l.Builder == nil || position < 0 {
+		return nil
+	}
+	cursor := l.itemIndex(l.cursor)
+	if l.filtered == nil {
+		if l.knownCount >= 0 && position >= l.knownCount {
+			return nil
+		}
+		return l.Builder(position, cursor)
+	}
+	if position >= len(l.filtered) {
+		return nil
+	}
+	return l.Builder(l.filtered[position], cursor)
+}
+
+// itemIndex returns the index of the item at the given position of the
+// (possibly filtered) list, or -1 if there is no such item.
+func (l *List) itemIndex(position int) int {
+	if l.filtered == nil || position < 0 {
+		return position
+	}
+	if position >= len(l.filtered) {
+		return -1
+	}
+	return l.filtered[position]
+}
+
+// visibleIndex returns the position of the item with the given index in the
+// (possibly filtered) list. For items which are filtered out, it returns the
+// position of the next visible item, or of the last one.
+func (l *List) visibleIndex(index int) int {
+	if l.filtered == nil || index < 0 {
+		return index
+	}
+	position := sort.SearchInts(l.filtered, index)
+	if position >= len(l.filtered) {
+		return len(l.filtered) - 1
+	}
+	return position
+}
+
+// applyFilter determines the items matching the current filter query and
+// moves the cursor to the nearest matching item.
+func (l *List) applyFilter() {
+	cursor := l.itemIndex(l.cursor)
+	l.filtered = nil
+	if l.filter != nil && l.query != "" && l.Builder != nil {
+		l.filtered = []int{}
+		for index := 0; (l.knownCount < 0 || index < l.knownCount) && l.Builder(index, cursor) != nil; index++ {
+			if l.filter(l.query, index) {
+				l.filtered = append(l.filtered, index)
+			}
+		}
+	}
+
+	// Keep the cursor on the nearest selectable item.
+	l.cursor = l.visibleIndex(cursor)
+	if l.cursor >= 0 && !l.selectableIndex(l.cursor) {
+		next := l.cursor + 1
+		for l.item(next) != nil && !l.selectableIndex(next) {
+			next++
+		}
+		if l.item(next) == nil {
+			next = l.cursor - 1
+			for next >= 0 && !l.selectableIndex(next) {
+				next--
+			}
+		}
+		l.cursor = next
+	}
+	l.scroll = listState{wantsCursor: true}
+	l.atEnd = false
+	if l.itemIndex(l.cursor) != cursor {
+		l.notifyChanged()
+	}
+}
+
+// handleFilterKey handles the keys editing the filter query. It returns
+// whether the key was handled.
+func (l *List) handleFilterKey(event *KeyEvent) bool {
+	switch event.Key() {
+	case tcell.KeyRune:
+		if event.Modifiers()&(tcell.ModCtrl|tcell.ModAlt|tcell.ModMeta) != 0 || l.multiSelect && event.Str() == " " {
+			return false
+		}
+		l.query += event.Str()
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if l.query == "" {
+			return false
+		}
+		_, size := utf8.DecodeLastRuneInString(l.query)
+		l.query = l.query[:len(l.query)-size]
+	case tcell.KeyEscape:
+		if l.query == "" {
+			return false
+		}
+		l.query = ""
+	default:
+		return false
+	}
+	l.applyFilter()
+	return true
+}
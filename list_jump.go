@@ -0,0 +1,167 @@
+package tview
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+// listJumpLabel is a quick-jump label shown on a visible item.
+type listJumpLabel struct {
+	label    string
+	position int
+}
+
+// SetQuickJump enables quick-jump mode (as known from the "avy" and
+// "easymotion" editor plugins). While the list has focus, pressing "f" (or
+// the keys bound to KeyActionQuickJump, see [List.SetKeyMap]) shows a label
+// of one or two letters on each visible selectable item. Typing an item's
+// label moves the cursor to the item, or activates it in browse mode (see
+// [List.SetBrowseMode]). Any other key, or a mouse button or wheel event,
+// leaves quick-jump mode without moving the cursor.
+//
+// As long as quick-jump mode is enabled, the trigger key cannot be typed into
+// a filter query (see [List.SetFilterFunc]). If the list is filtered, bind
+// KeyActionQuickJump to a key which is not typed into the query, e.g.
+// "ctrl+j". "f" can then be typed into the query like any other character.
+func (l *List) SetQuickJump(enabled bool) *List {
+	l.quickJump = enabled
+	if !enabled {
+		l.stopQuickJump()
+	}
+	return l
+}
+
+// SetQuickJumpActivates sets whether jumping to an item also activates it,
+// i.e. calls the handler set with [List.SetSelectedFunc], as if Enter had
+// been pressed. Items are always activated in browse mode.
+func (l *List) SetQuickJumpActivates(activates bool) *List {
+	l.quickJumpActivates = activates
+	return l
+}
+
+// SetQuickJumpAlphabet sets the characters from which quick-jump labels are
+// built (defaults to the letters ordered from the home row outwards).
+// Items closer to the top get the shorter labels. If there are more visible
+// items than the alphabet can label with two characters, the remaining items
+// are not labeled.
+func (l *List) SetQuickJumpAlphabet(alphabet string) *List {
+	l.quickJumpAlphabet = alphabet
+	return l
+}
+
+// SetQuickJumpStyle sets the style of quick-jump labels.
+func (l *List) SetQuickJumpStyle(style tcell.Style) *List {
+	l.quickJumpStyle = style
+	return l
+}
+
+// isQuickJumpKey returns whether the given (resolved) key event shows the
+// quick-jump labels.
+func isQuickJumpKey(event *KeyEvent) bool {
+	trigger := listKeyActions[KeyActionQuickJump][0]
+	return event.Key() == trigger.Key() && event.Modifiers() == trigger.Modifiers() && event.Str() == trigger.Str()
+}
+
+// startQuickJump shows the quick-jump labels on the items drawn last. It
+// returns false if there are no items to jump to.
+func (l *List) startQuickJump() bool {
+	var positions []int
+	for _, child := range l.lastDraw {
+		if isSelectable(child.item) {
+			positions = append(positions, child.index)
+		}
+	}
+	labels := quickJumpLabels(l.quickJumpAlphabet, len(positions))
+	if len(labels) == 0 {
+		return false
+	}
+	l.jumpLabels = make([]listJumpLabel, len(labels))
+	for index, label := range labels {
+		l.jumpLabels[index] = listJumpLabel{label: label, position: positions[index]}
+	}
+	l.jumpPrefix = ""
+	return true
+}
+
+// stopQuickJump leaves quick-jump mode.
+func (l *List) stopQuickJump() {
+	l.jumpLabels, l.jumpPrefix = nil, ""
+}
+
+// handleQuickJumpKey handles a key while the quick-jump labels are shown.
+func (l *List) handleQuickJumpKey(event *KeyEvent) Command {
+	if event.Key() != tcell.KeyRune || event.Modifiers()&(tcell.ModCtrl|tcell.ModAlt|tcell.ModMeta) != 0 {
+		l.stopQuickJump()
+		return RedrawCommand{}
+	}
+	prefix := l.jumpPrefix + event.Str()
+	var matched bool
+	for _, label := range l.jumpLabels {
+		if label.label == prefix {
+			l.stopQuickJump()
+			if l.browse {
+				l.activateAt(label.position)
+			} else {
+				l.moveCursor(label.position)
+				if l.quickJumpActivates {
+					l.activate()
+				}
+			}
+			return RedrawCommand{}
+		}
+		matched = matched || strings.HasPrefix(label.label, prefix)
+	}
+	if matched {
+		l.jumpPrefix = prefix
+	} else {
+		l.stopQuickJump()
+	}
+	return RedrawCommand{}
+}
+
+// drawQuickJumpLabels draws the remaining characters of the quick-jump labels
+// which start with the characters typed so far onto the given drawn items,
+// placed in the list's layout rectangle at (x,y).
+func (l *List) drawQuickJumpLabels(screen tcell.Screen, children []listDrawnItem, x, y int) {
+	for _, label := range l.jumpLabels {
+		if !strings.HasPrefix(label.label, l.jumpPrefix) {
+			continue
+		}
+		for _, child := range children {
+			if child.index == label.position {
+				labelX, labelY, _, _ := l.transposeRect(x, y+max(child.row, 0), 0, 0)
+				printWithStyle(screen, label.label[len(l.jumpPrefix):], labelX, labelY, 0, len(label.label), AlignmentLeft, l.quickJumpStyle, false)
+				break
+			}
+		}
+	}
+}
+
+// quickJumpLabels returns n prefix-free labels made of the characters of the
+// given alphabet, or fewer if the alphabet is too small. As many labels as
+// possible consist of a single character, the others of two characters.
+func quickJumpLabels(alphabet string, n int) []string {
+	characters := strings.Split(alphabet, "")
+	k := len(characters)
+	if k == 0 || n <= 0 {
+		return nil
+	}
+	if n <= k || k == 1 {
+		return characters[:min(n, k)]
+	}
+
+	// Use the first characters on their own and the rest as prefixes of
+	// two-character labels.
+	singles := max(min(k, (k*k-n)/(k-1)), 0)
+	labels := append(make([]string, 0, n), characters[:singles]...)
+	for _, prefix := range characters[singles:] {
+		for _, character := range characters {
+			if len(labels) == n {
+				return labels
+			}
+			labels = append(labels, prefix+character)
+		}
+	}
+	return labels
+}
@@ -0,0 +1,101 @@
+package tview
+
+import "github.com/gdamore/tcell/v3"
+
+// listMaxCount is the largest count which can be typed before a navigation
+// key.
+const listMaxCount = 99999
+
+// FirstItem moves the cursor to the first selectable item, if any, and scrolls
+// to the start of the list.
+func (l *List) FirstItem() bool {
+	for i := 0; ; i++ {
+		item := l.item(i)
+		if item == nil {
+			return false
+		}
+		if isSelectable(item) {
+			if l.cursor != i {
+				l.moveCursor(i)
+			}
+			l.ScrollToStart()
+			return true
+		}
+	}
+}
+
+// LastItem moves the cursor to the last selectable item, if any, and scrolls
+// to the end of the list. This iterates over all items.
+func (l *List) LastItem() bool {
+	for i := l.lastIndex(); i >= 0; i-- {
+		if item := l.item(i); item != nil && isSelectable(item) {
+			if l.cursor != i {
+				l.moveCursor(i)
+			}
+			l.ScrollToEnd()
+			return true
+		}
+	}
+	return false
+}
+
+// jumpToItem moves the cursor to the selectable item at the given position,
+// or to the nearest selectable item after it, or before it if there is none.
+// Positions beyond the end refer to the last item.
+func (l *List) jumpToItem(position int) {
+	if l.item(position) == nil {
+		position = l.lastIndex()
+	}
+	for i := position; l.item(i) != nil; i++ {
+		if l.selectableIndex(i) {
+			if l.cursor != i {
+				l.moveCursor(i)
+			}
+			return
+		}
+	}
+	for i := position - 1; i >= 0; i-- {
+		if l.selectableIndex(i) {
+			if l.cursor != i {
+				l.moveCursor(i)
+			}
+			return
+		}
+	}
+}
+
+// scrollPages scrolls the list by the given number of pages, or half pages if
+// half is true. Negative numbers scroll up.
+func (l *List) scrollPages(pages int, half bool) {
+	_, _, width, height := l.layoutRect()
+	if l.snapToItems {
+		items := l.visibleItemCount(width, height)
+		if half {
+			items = max(items/2, 1)
+		}
+		direction := 1
+		if pages < 0 {
+			direction, pages = -1, -pages
+		}
+		l.scrollByItems(direction, items*pages, width, height)
+		return
+	}
+	if half {
+		height /= 2
+	}
+	l.scroll.pending += max(height, 1) * pages
+}
+
+// handleCountKey handles the digits of a count typed before a navigation key.
+// It returns whether the key was handled.
+func (l *List) handleCountKey(event *KeyEvent) bool {
+	if event.Key() != tcell.KeyRune || event.Modifiers() != tcell.ModNone {
+		return false
+	}
+	str := event.Str()
+	if len(str) != 1 || str[0] < '0' || str[0] > '9' || str == "0" && l.count == 0 {
+		return false
+	}
+	l.count = min(l.count*10+int(str[0]-'0'), listMaxCount)
+	return true
+}
@@ -0,0 +1,108 @@
+package tview
+
+import "github.com/gdamore/tcell/v3"
+
+// ListOrientation determines how the items of a [List] are laid out.
+type ListOrientation int
+
+// The available list orientations.
+const (
+	ListVertical   ListOrientation = iota // Items are laid out top to bottom.
+	ListHorizontal                        // Items are laid out left to right.
+)
+
+// HorizontalListItem is an optional interface for items of horizontal lists
+// (see [List.SetOrientation]). Items report their width for the given height.
+// Items which don't implement it are as wide as the list.
+type HorizontalListItem interface {
+	ListItem
+	Width(height int) int
+}
+
+// SetOrientation sets whether the items are laid out top to bottom (the
+// default) or left to right, e.g. for tab strips or carousels. In horizontal
+// lists, items span the list's height and their widths are determined by
+// [HorizontalListItem]. The scroll bar is drawn below the items, and the Left
+// and Right arrow keys move the cursor.
+func (l *List) SetOrientation(orientation ListOrientation) *List {
+	if l.orientation != orientation {
+		l.orientation = orientation
+		l.scroll = listState{wantsCursor: l.cursor >= 0}
+		l.atEnd = false
+		l.setLastDraw(nil)
+		l.InvalidateRenderCache()
+	}
+	return l
+}
+
+// GetOrientation returns the orientation set with [List.SetOrientation].
+func (l *List) GetOrientation() ListOrientation {
+	return l.orientation
+}
+
+// itemWidth returns the width of the given item in a horizontal list with the
+// given height.
+func (l *List) itemWidth(item ListItem, height int) int {
+	if horizontal, ok := item.(HorizontalListItem); ok {
+		return max(horizontal.Width(height), 1)
+	}
+	_, _, width, _ := l.GetInnerRect()
+	return max(width, 1)
+}
+
+// transpose swaps the given coordinates in horizontal lists. The list's
+// layout works with coordinates along its orientation ("y") and across it
+// ("x"), which this function converts to and from screen coordinates.
+func (l *List) transpose(x, y int) (int, int) {
+	if l.orientation == ListHorizontal {
+		return y, x
+	}
+	return x, y
+}
+
+// transposeRect swaps the coordinates and the size of the given rectangle in
+// horizontal lists, see [List.transpose].
+func (l *List) transposeRect(x, y, width, height int) (int, int, int, int) {
+	if l.orientation == ListHorizontal {
+		return y, x, height, width
+	}
+	return x, y, width, height
+}
+
+// layoutRect returns the list's inner rectangle in layout coordinates, see
+// [List.transpose].
+func (l *List) layoutRect() (x, y, width, height int) {
+	return l.transposeRect(l.GetInnerRect())
+}
+
+// orientKey translates the navigation keys of horizontal lists to those of
+// vertical lists: Left arrow and h act like Up arrow and k, Right arrow and l
+// like Down arrow and j. It returns nil for keys which the list must ignore,
+// e.g. Up arrow in horizontal lists.
+func (l *List) orientKey(event *KeyEvent) *KeyEvent {
+	modifiers := event.Modifiers()
+	if l.orientation != ListHorizontal {
+		return event
+	}
+	switch event.Key() {
+	case tcell.KeyLeft:
+		return tcell.NewEventKey(tcell.KeyUp, "", modifiers)
+	case tcell.KeyRight:
+		return tcell.NewEventKey(tcell.KeyDown, "", modifiers)
+	case tcell.KeyUp, tcell.KeyDown:
+		return nil
+	case tcell.KeyRune:
+		if modifiers != tcell.ModNone {
+			break
+		}
+		switch event.Str() {
+		case "h":
+			return tcell.NewEventKey(tcell.KeyRune, "k", modifiers)
+		case "l":
+			return tcell.NewEventKey(tcell.KeyRune, "j", modifiers)
+		case "k", "j":
+			return nil
+		}
+	}
+	return event
+}
@@ -0,0 +1,188 @@
+package tview
+
+import (
+	"maps"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+// listDragState is the state of an item being dragged to a new position.
+type listDragState struct {
+	// Set to true while the left mouse button is held down on an item.
+	active bool
+
+	// Set to true once the mouse has moved to a drop position.
+	moved bool
+
+	// The position of the dragged item and the position before which it will
+	// be dropped.
+	from, drop int
+}
+
+// SetMovedFunc enables reordering items. The user can drag items to a new
+// position with the mouse, which is indicated by a line while dragging, or
+// move the item under the cursor with Alt+Up arrow and Alt+Down arrow. The
+// list does not own its items, so the handler must update the backing data:
+// it receives the index of the moved item and the index it has after the
+// move. The cursor, the selection, and the render cache follow the move.
+//
+// Items cannot be reordered while a filter query is active (see
+// [List.SetFilterFunc]). Set the handler to nil to disable reordering.
+func (l *List) SetMovedFunc(handler func(from, to int)) *List {
+	l.moved = handler
+	l.drag = listDragState{}
+	return l
+}
+
+// SetDropIndicatorStyle sets the style of the line indicating where a dragged
+// item will be dropped, see [List.SetMovedFunc].
+func (l *List) SetDropIndicatorStyle(style tcell.Style) *List {
+	l.dropIndicatorStyle = style
+	return l
+}
+
+// reorderable returns whether items can currently be reordered.
+func (l *List) reorderable() bool {
+	return l.moved != nil && l.filtered == nil && !l.browse
+}
+
+// moveItem moves the item at the given position to the other position and
+// updates the cursor, the selection, and the render cache accordingly.
+func (l *List) moveItem(from, to int) {
+	if from == to || from < 0 || to < 0 || l.item(from) == nil || l.item(to) == nil {
+		return
+	}
+	l.moved(from, to)
+
+	// shift returns the index an item has after the move.
+	shift := func(index int) int {
+		switch {
+		case index == from:
+			return to
+		case from < index && index <= to:
+			return index - 1
+		case to <= index && index < from:
+			return index + 1
+		}
+		return index
+	}
+	if len(l.selection) > 0 {
+		selection := make(map[int]struct{}, len(l.selection))
+		for index := range maps.Keys(l.selection) {
+			selection[shift(index)] = struct{}{}
+		}
+		l.selection = selection
+		l.rangeBase = nil
+		l.notifySelection()
+	}
+	l.InvalidateRenderCache()
+	if cursor := shift(l.cursor); cursor != l.cursor {
+		l.moveCursor(cursor)
+	}
+}
+
+// handleReorderKey handles the keys moving the item under the cursor. It
+// returns whether the key was handled.
+func (l *List) handleReorderKey(event *KeyEvent) bool {
+	if event.Modifiers() != tcell.ModAlt || l.cursor < 0 {
+		return false
+	}
+	switch event.Key() {
+	case tcell.KeyUp:
+		l.moveItem(l.cursor, l.cursor-1)
+	case tcell.KeyDown:
+		l.moveItem(l.cursor, l.cursor+1)
+	default:
+		return false
+	}
+	return true
+}
+
+// dropPosition returns the position before which an item dropped at the
+// given screen row would be inserted, based on the last drawn items. It is the
+// number of items if the item would be dropped after the last one, and -1 if
+// the row cannot be mapped.
+func (l *List) dropPosition(y int) int {
+	if len(l.lastDraw) == 0 {
+		return -1
+	}
+	row := y - l.lastRect.y
+	first, last := l.lastDraw[0], l.lastDraw[len(l.lastDraw)-1]
+	if row < first.row {
+		return first.index
+	}
+	for _, child := range l.lastDraw {
+		if row < child.row+child.height+l.gap {
+			if row < child.row+child.height/2 {
+				return child.index
+			}
+			return child.index + 1
+		}
+	}
+	return last.index + 1
+}
+
+// handleDrag handles mouse events while an item is dragged. The y coordinate
+// is the mouse position along the list's orientation. It returns nil if the
+// event is not part of a drag.
+func (l *List) handleDrag(action MouseAction, y int) Command {
+	if !l.drag.active {
+		return nil
+	}
+	switch action {
+	case MouseMove:
+		if y < l.lastRect.y {
+			l.scroll.pending--
+		} else if y >= l.lastRect.y+l.lastRect.height {
+			l.scroll.pending++
+		}
+		l.drag.drop = l.dropPosition(y)
+		l.drag.moved = true
+		return BatchCommand{SetMouseCaptureCommand{Target: l}, RedrawCommand{}}
+	case MouseLeftUp:
+		drag := l.drag
+		l.drag = listDragState{}
+		if drag.moved && drag.drop >= 0 {
+			to := drag.drop
+			if to > drag.from {
+				to--
+			}
+			l.moveItem(drag.from, to)
+		}
+		return BatchCommand{SetMouseCaptureCommand{Target: nil}, RedrawCommand{}}
+	}
+	return nil
+}
+
+// drawDropIndicator draws the line indicating where the dragged item will be
+// dropped. The list's inner area starts at the given position. Coordinates
+// are measured along and across the list's orientation.
+func (l *List) drawDropIndicator(screen tcell.Screen, children []listDrawnItem, x, y, width, height int) {
+	if !l.drag.moved || l.drag.drop < 0 {
+		return
+	}
+	row := -1
+	for _, child := range children {
+		if child.index == l.drag.drop {
+			row = child.row
+			if l.gap > 0 {
+				row--
+			}
+			break
+		}
+		if child.index == l.drag.drop-1 {
+			row = min(child.row+child.height, height-1)
+		}
+	}
+	if row < 0 || row >= height {
+		return
+	}
+	line := BoxDrawingsHeavyHorizontal
+	if l.orientation == ListHorizontal {
+		line = BoxDrawingsHeavyVertical
+	}
+	for column := range width {
+		cellX, cellY := l.transpose(x+column, y+row)
+		screen.Put(cellX, cellY, line, l.dropIndicatorStyle)
+	}
+}
@@ -0,0 +1,194 @@
+package tview
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+// ListSelectionBuilder returns a list item for the given index and cursor
+// position, like a [ListBuilder], and additionally receives whether the item
+// is selected in multi-selection mode, e.g. to render a checkmark. It must
+// return nil when the index is out of range.
+type ListSelectionBuilder func(index int, cursor int, selected bool) ListItem
+
+// SetMultiSelect sets whether the user can select multiple items. In
+// multi-selection mode, the following keys are available in addition to the
+// navigation keys:
+//
+//   - Space: Toggle the selection of the item under the cursor.
+//   - Shift+Up arrow, Shift+Down arrow: Move the cursor, selecting the range of
+//     items between the item where the movement started and the cursor.
+//
+// Clicking an item with Ctrl toggles its selection, clicking with Shift
+// selects the range between the cursor and the item. Only selectable items
+// (see [SelectableListItem]) can be selected. Disabling multi-selection mode
+// clears the selection.
+func (l *List) SetMultiSelect(multiSelect bool) *List {
+	if l.multiSelect != multiSelect {
+		l.multiSelect = multiSelect
+		if !multiSelect {
+			l.ClearSelection()
+		}
+	}
+	return l
+}
+
+// SetSelectionBuilder sets a builder which receives the selection state of
+// each item, see [ListSelectionBuilder]. It replaces the builder set with
+// [List.SetBuilder].
+func (l *List) SetSelectionBuilder(builder ListSelectionBuilder) *List {
+	if builder == nil {
+		return l.SetBuilder(nil)
+	}
+	return l.SetBuilder(func(index int, cursor int) ListItem {
+		return builder(index, cursor, l.IsSelected(index))
+	})
+}
+
+// SetSelectionChangedFunc sets a handler which is called when the set of
+// selected items changes. It receives the sorted indices of the selected
+// items.
+func (l *List) SetSelectionChangedFunc(handler func(indices []int)) *List {
+	l.selectionChanged = handler
+	return l
+}
+
+// IsSelected returns whether the item with the given index is selected.
+func (l *List) IsSelected(index int) bool {
+	_, ok := l.selection[index]
+	return ok
+}
+
+// GetSelectedIndices returns the sorted indices of the selected items.
+func (l *List) GetSelectedIndices() []int {
+	return slices.Sorted(maps.Keys(l.selection))
+}
+
+// SetItemSelected selects or deselects the item with the given index. This
+// has no effect unless multi-selection mode is enabled, see
+// [List.SetMultiSelect].
+func (l *List) SetItemSelected(index int, selected bool) *List {
+	if !l.multiSelect || index < 0 || l.IsSelected(index) == selected {
+		return l
+	}
+	l.setSelected(index, selected)
+	l.rangeBase = nil
+	l.notifySelection()
+	return l
+}
+
+// ClearSelection deselects all items.
+func (l *List) ClearSelection() *List {
+	l.rangeBase = nil
+	if len(l.selection) > 0 {
+		l.selection = nil
+		l.notifySelection()
+	}
+	return l
+}
+
+// setSelected selects or deselects the item with the given index without
+// notifying the handler.
+func (l *List) setSelected(index int, selected bool) {
+	if !selected {
+		delete(l.selection, index)
+		return
+	}
+	if l.selection == nil {
+		l.selection = make(map[int]struct{})
+	}
+	l.selection[index] = struct{}{}
+}
+
+// notifySelection calls the selection changed handler, if any.
+func (l *List) notifySelection() {
+	if l.selectionChanged != nil {
+		l.selectionChanged(l.GetSelectedIndices())
+	}
+}
+
+// toggleSelection toggles the selection of the item with the given index, if
+// it is selectable.
+func (l *List) toggleSelection(index int) {
+	if !l.selectableIndex(index) {
+		return
+	}
+	index = l.itemIndex(index)
+	l.setSelected(index, !l.IsSelected(index))
+	l.rangeBase = nil
+	l.notifySelection()
+}
+
+// selectRange selects the selectable items between the anchor and the given
+// index, in addition to the items which were selected when the range was
+// started. A range is started if there is none.
+func (l *List) selectRange(anchor, index int) {
+	if l.rangeBase == nil {
+		l.rangeBase = maps.Clone(l.selection)
+		if l.rangeBase == nil {
+			l.rangeBase = make(map[int]struct{})
+		}
+		l.rangeAnchor = anchor
+	}
+	l.selection = maps.Clone(l.rangeBase)
+	from, to := min(l.rangeAnchor, index), max(l.rangeAnchor, index)
+	for i := max(from, 0); i <= to; i++ {
+		if l.selectableIndex(i) {
+			l.setSelected(l.itemIndex(i), true)
+		}
+	}
+	l.notifySelection()
+}
+
+// selectableIndex returns whether the item with the given index exists and is
+// selectable.
+func (l *List) selectableIndex(index int) bool {
+	if l.Builder == nil || index < 0 {
+		return false
+	}
+	item := l.item(index)
+	return item != nil && isSelectable(item)
+}
+
+// handleSelectionKey handles the keys of multi-selection mode. It returns
+// whether the key was handled.
+func (l *List) handleSelectionKey(event *KeyEvent) bool {
+	key := event.Key()
+	switch {
+	case key == tcell.KeyRune && event.Str() == " " && event.Modifiers() == tcell.ModNone:
+		l.toggleSelection(l.cursor)
+		return true
+	case (key == tcell.KeyUp || key == tcell.KeyDown) && event.Modifiers() == tcell.ModShift:
+		anchor := l.cursor
+		if key == tcell.KeyUp {
+			l.PrevItem()
+		} else {
+			l.NextItem()
+		}
+		l.selectRange(anchor, l.cursor)
+		return true
+	}
+	l.rangeBase = nil
+	return false
+}
+
+// handleSelectionClick handles clicks with modifiers on the item with the
+// given index in multi-selection mode. It returns whether the click was
+// handled.
+func (l *List) handleSelectionClick(index int, modifiers tcell.ModMask) bool {
+	switch modifiers {
+	case tcell.ModCtrl:
+		l.toggleSelection(index)
+	case tcell.ModShift:
+		l.selectRange(max(l.cursor, 0), index)
+	default:
+		l.rangeBase = nil
+		return false
+	}
+	if l.cursor != index {
+		l.moveCursor(index)
+	}
+	return true
+}
@@ -0,0 +1,188 @@
+package tview
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Identifiers of the built-in user interface strings, see [SetTranslator].
+const (
+	MessageLoading            = "loading"             // "Loading..."
+	MessageHelpShortSeparator = "help.shortSeparator" // " • "
+	MessageHelpFullSeparator  = "help.fullSeparator"  // "    "
+	MessageHelpKey            = "help.key"            // "Key"
+	MessageHelpDescription    = "help.description"    // "Description"
+	MessageInvalidNumber      = "invalid.number"      // "Invalid number"
+	MessageInvalidDate        = "invalid.date"        // "Invalid date"
+	MessageInvalidDuration    = "invalid.duration"    // "Invalid duration"
+	MessageOutOfRange         = "invalid.range"       // "Value out of range"
+	MessageRequired           = "invalid.required"    // "Required"
+)
+
+// defaultMessages contains the English texts of the built-in user interface
+// strings.
+var defaultMessages = map[string]string{
+	MessageLoading:            "Loading...",
+	MessageHelpShortSeparator: " • ",
+	MessageHelpFullSeparator:  "    ",
+	MessageHelpKey:            "Key",
+	MessageHelpDescription:    "Description",
+	MessageInvalidNumber:      "Invalid number",
+	MessageInvalidDate:        "Invalid date",
+	MessageInvalidDuration:    "Invalid duration",
+	MessageOutOfRange:         "Value out of range",
+	MessageRequired:           "Required",
+}
+
+// Locale describes how numbers, dates, and times are formatted by
+// [FormatInt], [FormatFloat], [FormatDate], and [FormatTime].
+type Locale struct {
+	// The separator between the integer and the fractional part of a number.
+	DecimalSeparator string
+
+	// The separator between groups of three digits of the integer part of a
+	// number. It may be empty.
+	GroupSeparator string
+
+	// The layouts of dates and times, as used by [time.Time.Format]. Note that
+	// names of months and weekdays are always English.
+	DateLayout string
+	TimeLayout string
+}
+
+var (
+	// Guards translator and locale.
+	localizationMutex sync.RWMutex
+
+	// The function set with SetTranslator, or nil.
+	translator func(id, text string) string
+
+	// The locale set with SetLocale.
+	locale = Locale{
+		DecimalSeparator: ".",
+		GroupSeparator:   ",",
+		DateLayout:       "2006-01-02",
+		TimeLayout:       "15:04",
+	}
+)
+
+// SetTranslator sets a function which translates the built-in user interface
+// strings, e.g. the loading indicator of autocomplete lists or the separators
+// of help views. The function receives the string's identifier (one of the
+// Message constants, e.g. [MessageLoading]) and its English text and returns
+// the text to use. Returning the English text keeps it. A nil function
+// restores the English texts.
+//
+// Primitives translate their default strings when they are created, so the
+// translator should be set before any primitives are created. Strings set
+// explicitly by the application are never translated.
+func SetTranslator(translate func(id, text string) string) {
+	localizationMutex.Lock()
+	defer localizationMutex.Unlock()
+	translator = translate
+}
+
+// Translate returns the translation of the string with the given identifier
+// using the function set with [SetTranslator]. For the identifiers of built-in
+// strings, the text may be empty, in which case the English text is used.
+// Custom primitives may use this function with their own identifiers to share
+// the application's translator.
+func Translate(id, text string) string {
+	if text == "" {
+		text = defaultMessages[id]
+	}
+	localizationMutex.RLock()
+	translate := translator
+	localizationMutex.RUnlock()
+	if translate == nil {
+		return text
+	}
+	return translate(id, text)
+}
+
+// SetLocale sets the locale used by the formatting functions [FormatInt],
+// [FormatFloat], [FormatDate], and [FormatTime]. Empty fields of the given
+// locale keep their current values, except for the group separator.
+func SetLocale(l Locale) {
+	localizationMutex.Lock()
+	defer localizationMutex.Unlock()
+	if l.DecimalSeparator != "" {
+		locale.DecimalSeparator = l.DecimalSeparator
+	}
+	locale.GroupSeparator = l.GroupSeparator
+	if l.DateLayout != "" {
+		locale.DateLayout = l.DateLayout
+	}
+	if l.TimeLayout != "" {
+		locale.TimeLayout = l.TimeLayout
+	}
+}
+
+// GetLocale returns the locale set with [SetLocale].
+func GetLocale() Locale {
+	localizationMutex.RLock()
+	defer localizationMutex.RUnlock()
+	return locale
+}
+
+// FormatInt formats an integer with the group separator of the current
+// locale, e.g. "1,234,567".
+func FormatInt(n int64) string {
+	return groupDigits(strconv.FormatInt(n, 10), GetLocale().GroupSeparator)
+}
+
+// FormatFloat formats a number with the given number of decimals, using the
+// separators of the current locale, e.g. "1,234.50". A negative number of
+// decimals uses as many as necessary to represent the number exactly.
+func FormatFloat(f float64, decimals int) string {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	l := GetLocale()
+	str := strconv.FormatFloat(f, 'f', max(decimals, -1), 64)
+	integer, fraction, hasFraction := strings.Cut(str, ".")
+	str = groupDigits(integer, l.GroupSeparator)
+	if hasFraction {
+		str += l.DecimalSeparator + fraction
+	}
+	return str
+}
+
+// FormatDate formats the date of the given time with the date layout of the
+// current locale.
+func FormatDate(t time.Time) string {
+	return t.Format(GetLocale().DateLayout)
+}
+
+// FormatTime formats the time of day of the given time with the time layout of
+// the current locale.
+func FormatTime(t time.Time) string {
+	return t.Format(GetLocale().TimeLayout)
+}
+
+// groupDigits inserts the separator between groups of three digits of the
+// given integer string, which may start with a minus sign.
+func groupDigits(integer, separator string) string {
+	sign := ""
+	if strings.HasPrefix(integer, "-") {
+		sign, integer = "-", integer[1:]
+	}
+	if separator == "" || len(integer) <= 3 {
+		return sign + integer
+	}
+	var b strings.Builder
+	b.WriteString(sign)
+	first := len(integer) % 3
+	if first == 0 {
+		first = 3
+	}
+	b.WriteString(integer[:first])
+	for index := first; index < len(integer); index += 3 {
+		b.WriteString(separator)
+		b.WriteString(integer[index : index+3])
+	}
+	return b.String()
+}
@@ -0,0 +1,542 @@
+package tview
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v3"
+	"github.com/rivo/uniseg"
+)
+
+// MarkdownStyles defines the styles used by [Markdown]. Bold, italic, and
+// strikethrough text is drawn with the corresponding attributes added to the
+// surrounding style.
+type MarkdownStyles struct {
+	// The style of regular text.
+	Text tcell.Style
+
+	// The styles of headings of levels 1 to 6.
+	Headings [6]tcell.Style
+
+	// The style of inline code spans.
+	Code tcell.Style
+
+	// The style of fenced code blocks.
+	CodeBlock tcell.Style
+
+	// The style of block quotes, including their markers.
+	Quote tcell.Style
+
+	// The style of link texts. Links also carry their URL, see
+	// [TextView.SetLinkClickedFunc].
+	Link tcell.Style
+
+	// The style of list bullets and numbers.
+	ListMarker tcell.Style
+}
+
+// DefaultMarkdownStyles returns Markdown styles based on the given text style
+// and the global [Styles].
+func DefaultMarkdownStyles(text tcell.Style) MarkdownStyles {
+	heading := text.Foreground(Styles.TitleColor).Bold(true)
+	return MarkdownStyles{
+		Text:       text,
+		Headings:   [6]tcell.Style{heading.Underline(true), heading, heading, heading, heading, heading},
+		Code:       text.Foreground(Styles.SecondaryTextColor),
+		CodeBlock:  text.Foreground(Styles.SecondaryTextColor),
+		Quote:      text.Foreground(Styles.TertiaryTextColor),
+		Link:       text.Underline(true),
+		ListMarker: text.Foreground(Styles.SecondaryTextColor),
+	}
+}
+
+// Markdown renders a subset of Markdown into styled lines, e.g. for
+// [TextView.SetLines]. The following elements are supported:
+//
+//   - ATX headings ("# Heading").
+//   - Paragraphs. Consecutive lines are joined into one line which is wrapped
+//     by the text view. Lines ending in two spaces or a backslash end with a
+//     hard line break.
+//   - Bold ("**bold**", "__bold__"), italic ("*italic*", "_italic_"), and
+//     strikethrough ("~~strikethrough~~") text, and inline code ("`code`").
+//   - Links ("[text](url)", "<url>").
+//   - Unordered ("-", "*", "+") and ordered ("1.", "1)") lists, which may be
+//     nested. Wrapped list items are indented to the start of their text.
+//   - Fenced code blocks ("```" or "~~~"), shown verbatim.
+//   - Block quotes ("> quote"), which may contain other elements.
+//
+// Any other text is shown as is.
+func Markdown(text string, styles MarkdownStyles) []Line {
+	p := markdownParser{styles: styles}
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	for line := range strings.SplitSeq(text, "\n") {
+		p.parseLine(line)
+	}
+	p.flush()
+	if p.blank {
+		p.lines = p.lines[:len(p.lines)-1]
+	}
+	return p.lines
+}
+
+// markdownList is an open (possibly nested) list.
+type markdownList struct {
+	// The column of the list item markers in the source.
+	column int
+
+	// The screen width of the rendered indentation of the list item text.
+	indent int
+}
+
+// markdownParser converts Markdown into lines, one source line at a time.
+type markdownParser struct {
+	styles MarkdownStyles
+
+	// The rendered lines.
+	lines []Line
+
+	// The source lines of the current paragraph, ending with "\n" if followed
+	// by a hard line break.
+	paragraph []string
+
+	// The segments drawn before the first line of the current paragraph and
+	// before all following lines (including wrapped ones).
+	prefix, indent []Segment
+
+	// The base style of the current paragraph.
+	style tcell.Style
+
+	// The number of nested block quotes of the current paragraph.
+	quote int
+
+	// The open lists, outermost first.
+	lists []markdownList
+
+	// The opening fence of the current code block, or an empty string.
+	fence string
+
+	// The column of the opening fence of the current code block.
+	fenceColumn int
+
+	// Set to true if the last rendered line is an empty line.
+	blank bool
+}
+
+// parseLine processes one line of Markdown source.
+func (p *markdownParser) parseLine(line string) {
+	line = expandLeadingTabs(line)
+
+	// Code blocks.
+	if p.fence != "" {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, p.fence) && strings.Trim(trimmed, p.fence[:1]) == "" {
+			p.fence = ""
+			return
+		}
+		line = line[min(p.fenceColumn, len(line)-len(strings.TrimLeft(line, " "))):]
+		p.addLine(Line{
+			Segments: append(p.containerIndent(), Segment{Text: line, Style: p.styles.CodeBlock}),
+			Indent:   p.containerIndent(),
+		})
+		return
+	}
+
+	// Block quotes.
+	quote := 0
+	for {
+		trimmed := strings.TrimLeft(line, " ")
+		if !strings.HasPrefix(trimmed, ">") {
+			break
+		}
+		quote++
+		line = strings.TrimPrefix(trimmed[1:], " ")
+	}
+	if quote != p.quote {
+		p.flush()
+		p.lists = nil
+		p.quote = quote
+	}
+
+	trimmed := strings.TrimLeft(line, " ")
+	column := len(line) - len(trimmed)
+
+	// Blank lines end paragraphs.
+	if trimmed == "" {
+		p.flush()
+		p.addBlank()
+		return
+	}
+
+	// Code fences.
+	if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+		p.flush()
+		p.fence = trimmed[:3]
+		for len(p.fence) < len(trimmed) && trimmed[len(p.fence)] == p.fence[0] {
+			p.fence += p.fence[:1]
+		}
+		p.fenceColumn = column
+		p.unindentLists(column)
+		return
+	}
+
+	// Headings.
+	if level := headingLevel(trimmed); level > 0 {
+		p.flush()
+		p.lists = nil
+		text := strings.TrimSpace(trimmed[level:])
+		if stripped := strings.TrimRight(text, "#"); stripped == "" || strings.HasSuffix(stripped, " ") {
+			text = strings.TrimSpace(stripped)
+		}
+		style := p.styles.Headings[level-1]
+		quotePrefix := p.quotePrefix(p.quote)
+		p.addLine(Line{
+			Segments: append(quotePrefix, p.inline(text, style)...),
+			Indent:   quotePrefix,
+		})
+		return
+	}
+
+	// List items.
+	if marker, text, ok := listItem(trimmed); ok {
+		p.flush()
+
+		// Close lists which are not parents of this item.
+		for len(p.lists) > 0 && p.lists[len(p.lists)-1].column >= column {
+			p.lists = p.lists[:len(p.lists)-1]
+		}
+		level := len(p.lists)
+		if marker == "-" || marker == "*" || marker == "+" {
+			marker = []string{"•", "◦", "▪"}[level%3]
+		}
+		parentIndent := 0
+		if level > 0 {
+			parentIndent = p.lists[level-1].indent
+		}
+		marker += " "
+		indent := parentIndent + uniseg.StringWidth(marker)
+		p.lists = append(p.lists, markdownList{column: column, indent: indent})
+
+		p.startParagraph(p.styles.Text)
+		p.prefix = append(p.quotePrefix(p.quote), Segment{Text: strings.Repeat(" ", parentIndent), Style: p.styles.Text}, Segment{Text: marker, Style: p.styles.ListMarker})
+		p.addParagraphLine(text)
+		return
+	}
+
+	// Paragraph text.
+	if len(p.paragraph) == 0 {
+		p.unindentLists(column)
+		style := p.styles.Text
+		if p.quote > 0 {
+			style = p.styles.Quote
+		}
+		p.startParagraph(style)
+	}
+	p.addParagraphLine(trimmed)
+}
+
+// unindentLists closes all lists whose items cannot contain text starting at
+// the given source column.
+func (p *markdownParser) unindentLists(column int) {
+	for len(p.lists) > 0 && p.lists[len(p.lists)-1].column >= column {
+		p.lists = p.lists[:len(p.lists)-1]
+	}
+}
+
+// quotePrefix returns the segments drawn in front of every line nested in the
+// given number of block quotes.
+func (p *markdownParser) quotePrefix(quote int) []Segment {
+	if quote == 0 {
+		return nil
+	}
+	return []Segment{{Text: strings.Repeat("│ ", quote), Style: p.styles.Quote}}
+}
+
+// containerIndent returns the segments drawn in front of lines of the current
+// block quote and list item.
+func (p *markdownParser) containerIndent() []Segment {
+	indent := p.quotePrefix(p.quote)
+	if len(p.lists) > 0 {
+		indent = append(indent, Segment{Text: strings.Repeat(" ", p.lists[len(p.lists)-1].indent), Style: p.styles.Text})
+	}
+	return indent
+}
+
+// startParagraph starts a new paragraph with the given base style.
+func (p *markdownParser) startParagraph(style tcell.Style) {
+	p.style = style
+	p.indent = p.containerIndent()
+	p.prefix = p.indent
+}
+
+// addParagraphLine adds one source line to the current paragraph.
+func (p *markdownParser) addParagraphLine(text string) {
+	switch {
+	case strings.HasSuffix(text, "  "):
+		text = strings.TrimRight(text, " ") + "\n"
+	case strings.HasSuffix(text, "\\") && !strings.HasSuffix(text, "\\\\"):
+		text = text[:len(text)-1] + "\n"
+	default:
+		text = strings.TrimRight(text, " ")
+	}
+	p.paragraph = append(p.paragraph, text)
+}
+
+// flush renders the current paragraph, if any.
+func (p *markdownParser) flush() {
+	if len(p.paragraph) == 0 {
+		return
+	}
+	var b strings.Builder
+	for index, text := range p.paragraph {
+		if index > 0 && !strings.HasSuffix(p.paragraph[index-1], "\n") {
+			b.WriteByte(' ')
+		}
+		b.WriteString(text)
+	}
+	for index, text := range strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n") {
+		prefix := p.indent
+		if index == 0 {
+			prefix = p.prefix
+		}
+		p.addLine(Line{
+			Segments: append(append([]Segment(nil), prefix...), p.inline(text, p.style)...),
+			Indent:   p.indent,
+		})
+	}
+	p.paragraph = nil
+}
+
+// addLine adds a rendered line.
+func (p *markdownParser) addLine(line Line) {
+	p.lines = append(p.lines, line)
+	p.blank = false
+}
+
+// addBlank adds an empty line (apart from block quote markers) unless the
+// previous line is empty or there is none.
+func (p *markdownParser) addBlank() {
+	if len(p.lines) == 0 || p.blank {
+		return
+	}
+	prefix := p.quotePrefix(p.quote)
+	p.addLine(Line{Segments: prefix, Indent: prefix})
+	p.blank = true
+}
+
+// inline renders the inline elements of the given text, based on the given
+// style.
+func (p *markdownParser) inline(text string, base tcell.Style) []Segment {
+	var (
+		segments             []Segment
+		current              strings.Builder
+		bold, italic, strike bool
+		boldDelim, italDelim string
+		previous             rune // The previous character, 0 at the start.
+		style                = base
+	)
+	next := func(index int) rune {
+		r, _ := utf8.DecodeRuneInString(text[index:])
+		return r
+	}
+	isWord := func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r)
+	}
+	flush := func() {
+		if current.Len() > 0 {
+			segments = append(segments, Segment{Text: current.String(), Style: style})
+			current.Reset()
+		}
+	}
+	updateStyle := func() {
+		flush()
+		style = base
+		if bold {
+			style = style.Bold(true)
+		}
+		if italic {
+			style = style.Italic(true)
+		}
+		if strike {
+			style = style.StrikeThrough(true)
+		}
+	}
+
+	// Emphasis delimiters open when followed by a non-space character and
+	// close when preceded by one. Underscores must not be inside words.
+	canOpen := func(index, length int) bool {
+		if index+length >= len(text) || unicode.IsSpace(next(index+length)) {
+			return false
+		}
+		return text[index] != '_' || !isWord(previous)
+	}
+	canClose := func(index, length int) bool {
+		if previous == 0 || unicode.IsSpace(previous) {
+			return false
+		}
+		return text[index] != '_' || index+length >= len(text) || !isWord(next(index+length))
+	}
+	hasCloser := func(index int, delim string) bool {
+		return strings.Contains(text[index+len(delim):], delim)
+	}
+
+	for index := 0; index < len(text); {
+		ch, size := utf8.DecodeRuneInString(text[index:])
+		rest := text[index:]
+		switch {
+		case ch == '\\' && index+1 < len(text) && (unicode.IsPunct(next(index+1)) || unicode.IsSymbol(next(index+1))):
+			// Escaped character.
+			escaped, escapedSize := utf8.DecodeRuneInString(text[index+1:])
+			current.WriteRune(escaped)
+			index += 1 + escapedSize
+			previous = escaped
+			continue
+		case ch == '`':
+			// Code spans.
+			run := rest[:len(rest)-len(strings.TrimLeft(rest, "`"))]
+			end := strings.Index(rest[len(run):], run)
+			if end < 0 {
+				current.WriteString(run)
+				index += len(run)
+				previous = '`'
+				continue
+			}
+			code := rest[len(run) : len(run)+end]
+			if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' {
+				code = code[1 : len(code)-1]
+			}
+			flush()
+			segments = append(segments, Segment{Text: code, Style: mergeStyle(style, p.styles.Code)})
+			index += 2*len(run) + end
+			previous = '`'
+			continue
+		case strings.HasPrefix(rest, "**") || strings.HasPrefix(rest, "__"):
+			delim := rest[:2]
+			if bold && delim == boldDelim && canClose(index, 2) {
+				bold = false
+				updateStyle()
+				index += 2
+				previous = ch
+				continue
+			} else if !bold && canOpen(index, 2) && hasCloser(index, delim) {
+				bold, boldDelim = true, delim
+				updateStyle()
+				index += 2
+				previous = ch
+				continue
+			}
+		case strings.HasPrefix(rest, "~~"):
+			if strike && canClose(index, 2) || !strike && canOpen(index, 2) && hasCloser(index, "~~") {
+				strike = !strike
+				updateStyle()
+				index += 2
+				previous = ch
+				continue
+			}
+		case ch == '*' || ch == '_':
+			delim := rest[:1]
+			if italic && delim == italDelim && canClose(index, 1) {
+				italic = false
+				updateStyle()
+				index++
+				previous = ch
+				continue
+			} else if !italic && canOpen(index, 1) && hasCloser(index, delim) {
+				italic, italDelim = true, delim
+				updateStyle()
+				index++
+				previous = ch
+				continue
+			}
+		case ch == '[':
+			// Links.
+			labelEnd := strings.Index(rest, "](")
+			if labelEnd < 0 {
+				break
+			}
+			urlEnd := strings.IndexByte(rest[labelEnd+2:], ')')
+			if urlEnd < 0 {
+				break
+			}
+			label, url := rest[1:labelEnd], strings.TrimSpace(rest[labelEnd+2:labelEnd+2+urlEnd])
+			if label == "" {
+				label = url
+			}
+			flush()
+			segments = append(segments, p.inline(label, mergeStyle(style, p.styles.Link).Url(url))...)
+			index += labelEnd + 2 + urlEnd + 1
+			previous = ')'
+			continue
+		case ch == '<':
+			// Autolinks.
+			end := strings.IndexByte(rest, '>')
+			if end < 0 {
+				break
+			}
+			url := rest[1:end]
+			if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") && !strings.HasPrefix(url, "mailto:") || strings.ContainsAny(url, " <") {
+				break
+			}
+			flush()
+			segments = append(segments, Segment{Text: strings.TrimPrefix(url, "mailto:"), Style: mergeStyle(style, p.styles.Link).Url(url)})
+			index += end + 1
+			previous = '>'
+			continue
+		}
+		current.WriteRune(ch)
+		index += size
+		previous = ch
+	}
+	flush()
+	return segments
+}
+
+// headingLevel returns the level of the ATX heading on the given line (without
+// leading spaces), or 0 if it is not a heading.
+func headingLevel(line string) int {
+	level := len(line) - len(strings.TrimLeft(line, "#"))
+	if level < 1 || level > 6 || level < len(line) && line[level] != ' ' {
+		return 0
+	}
+	return level
+}
+
+// listItem returns the marker and the text of the list item on the given line
+// (without leading spaces). It returns false if the line is not a list item.
+func listItem(line string) (marker, text string, ok bool) {
+	if len(line) >= 2 && strings.ContainsRune("-*+", rune(line[0])) && line[1] == ' ' {
+		return line[:1], strings.TrimLeft(line[2:], " "), true
+	}
+	digits := len(line) - len(strings.TrimLeft(line, "0123456789"))
+	if digits < 1 || digits > 9 || digits+1 >= len(line) || line[digits] != '.' && line[digits] != ')' || line[digits+1] != ' ' {
+		return "", "", false
+	}
+	return line[:digits+1], strings.TrimLeft(line[digits+2:], " "), true
+}
+
+// expandLeadingTabs replaces tabs in the indentation of the given line with
+// spaces, assuming tab stops every four columns.
+func expandLeadingTabs(line string) string {
+	if !strings.HasPrefix(line, "\t") && !strings.Contains(line[:len(line)-len(strings.TrimLeft(line, " \t"))], "\t") {
+		return line
+	}
+	var (
+		b      strings.Builder
+		column int
+	)
+	for index, ch := range line {
+		switch ch {
+		case ' ':
+			b.WriteByte(' ')
+			column++
+		case '\t':
+			spaces := 4 - column%4
+			b.WriteString(strings.Repeat(" ", spaces))
+			column += spaces
+		default:
+			b.WriteString(line[index:])
+			return b.String()
+		}
+	}
+	return b.String()
+}
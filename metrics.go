@@ -0,0 +1,231 @@
+package tview
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
+
+	"github.com/gdamore/tcell/v3"
+	"github.com/rivo/uniseg"
+)
+
+// Metrics contains performance measurements of an application, see
+// [Application.EnableMetrics]. It can be published with the expvar package,
+// e.g.:
+//
+//	expvar.Publish("tui", expvar.Func(func() any { return app.Metrics() }))
+//
+// or exported in the Prometheus text format with [Metrics.WritePrometheus].
+type Metrics struct {
+	// The number of frames drawn.
+	Frames uint64 `json:"frames"`
+
+	// The number of frames drawn during the last second.
+	FramesPerSecond int `json:"framesPerSecond"`
+
+	// The number of events received from the screen.
+	Events uint64 `json:"events"`
+
+	// The time between receiving the oldest event handled before the last
+	// frame and the end of that frame, i.e. the input latency.
+	Latency time.Duration `json:"latency"`
+
+	// The highest latency measured.
+	MaxLatency time.Duration `json:"maxLatency"`
+
+	// The time it took to draw the last frame, including writing it to the
+	// terminal.
+	DrawDuration time.Duration `json:"drawDuration"`
+
+	// The number of events waiting to be handled.
+	EventQueueDepth int `json:"eventQueueDepth"`
+
+	// The number of functions waiting to be executed, see
+	// [Application.QueueUpdate].
+	UpdateQueueDepth int `json:"updateQueueDepth"`
+
+	// The number of cells written by primitives during the last frame.
+	Cells int `json:"cells"`
+
+	// The number of cells written by primitives during all frames.
+	TotalCells uint64 `json:"totalCells"`
+}
+
+// String returns the metrics as JSON.
+func (m Metrics) String() string {
+	data, _ := json.Marshal(m)
+	return string(data)
+}
+
+// WritePrometheus writes the metrics in the Prometheus text exposition format
+// to the given writer. Metric names are prefixed with the given namespace
+// followed by an underscore, unless it is empty. Durations are written in
+// seconds.
+func (m Metrics) WritePrometheus(w io.Writer, namespace string) error {
+	if namespace != "" {
+		namespace += "_"
+	}
+	for _, metric := range []struct {
+		name, kind, help string
+		value            any
+	}{
+		{"frames_total", "counter", "Number of frames drawn.", m.Frames},
+		{"frames_per_second", "gauge", "Number of frames drawn during the last second.", m.FramesPerSecond},
+		{"events_total", "counter", "Number of events received from the screen.", m.Events},
+		{"latency_seconds", "gauge", "Time from receiving an event to the end of the following frame.", m.Latency.Seconds()},
+		{"max_latency_seconds", "gauge", "Highest event-to-frame latency.", m.MaxLatency.Seconds()},
+		{"draw_duration_seconds", "gauge", "Time it took to draw the last frame.", m.DrawDuration.Seconds()},
+		{"event_queue_depth", "gauge", "Number of events waiting to be handled.", m.EventQueueDepth},
+		{"update_queue_depth", "gauge", "Number of queued updates waiting to be executed.", m.UpdateQueueDepth},
+		{"cells", "gauge", "Number of cells written during the last frame.", m.Cells},
+		{"cells_total", "counter", "Number of cells written during all frames.", m.TotalCells},
+	} {
+		name := namespace + metric.name
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n%s %v\n", name, metric.help, name, metric.kind, name, metric.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appMetrics holds the state of an application's metrics collection.
+type appMetrics struct {
+	// Set to true if metrics are collected. This and the other atomic fields
+	// are accessed without the application's lock as they are updated for
+	// every event.
+	enabled atomic.Bool
+
+	// An optional function which receives the metrics after each frame.
+	handler func(metrics Metrics)
+
+	// The metrics collected so far, except for the number of events.
+	current Metrics
+
+	// The number of events received.
+	events atomic.Uint64
+
+	// The time (in Unix nanoseconds) the oldest event since the last frame was
+	// received, or 0 if there was no event.
+	pendingSince atomic.Int64
+
+	// The times at which the frames of the last second ended.
+	frameTimes []time.Time
+}
+
+// countingScreen is a screen which counts the cells written to it.
+type countingScreen struct {
+	tcell.Screen
+	cells int
+}
+
+// Put implements tcell.Screen.
+func (s *countingScreen) Put(x, y int, str string, style tcell.Style) (string, int) {
+	s.cells++
+	return s.Screen.Put(x, y, str, style)
+}
+
+// PutStr implements tcell.Screen.
+func (s *countingScreen) PutStr(x, y int, str string) {
+	s.cells += uniseg.GraphemeClusterCount(str)
+	s.Screen.PutStr(x, y, str)
+}
+
+// PutStrStyled implements tcell.Screen.
+func (s *countingScreen) PutStrStyled(x, y int, str string, style tcell.Style) {
+	s.cells += uniseg.GraphemeClusterCount(str)
+	s.Screen.PutStrStyled(x, y, str, style)
+}
+
+// SetContent implements tcell.Screen.
+func (s *countingScreen) SetContent(x, y int, primary rune, combining []rune, style tcell.Style) {
+	s.cells++
+	s.Screen.SetContent(x, y, primary, combining, style)
+}
+
+// EnableMetrics enables or disables the collection of performance metrics
+// such as input latency, frame rate, and queue depths. See
+// [Application.Metrics] and [Application.SetMetricsFunc].
+func (a *Application) EnableMetrics(enable bool) *Application {
+	a.Lock()
+	defer a.Unlock()
+	a.metrics.enabled.Store(enable)
+	a.metrics.pendingSince.Store(0)
+	a.metrics.frameTimes = nil
+	return a
+}
+
+// SetMetricsFunc sets a handler which receives the application's metrics
+// after each frame while metrics are enabled (see
+// [Application.EnableMetrics]). It is called from the event loop so it should
+// return quickly.
+func (a *Application) SetMetricsFunc(handler func(metrics Metrics)) *Application {
+	a.Lock()
+	defer a.Unlock()
+	a.metrics.handler = handler
+	return a
+}
+
+// Metrics returns the application's current metrics. Metrics are only
+// collected while enabled, see [Application.EnableMetrics].
+func (a *Application) Metrics() Metrics {
+	a.RLock()
+	defer a.RUnlock()
+	return a.currentMetrics(time.Now())
+}
+
+// currentMetrics returns a copy of the collected metrics with the values
+// which are sampled at the given time. The caller must hold the lock.
+func (a *Application) currentMetrics(now time.Time) Metrics {
+	metrics := a.metrics.current
+	metrics.Events = a.metrics.events.Load()
+	metrics.EventQueueDepth = len(a.events)
+	metrics.UpdateQueueDepth = len(a.updates)
+	metrics.FramesPerSecond = 0
+	for _, frameTime := range a.metrics.frameTimes {
+		if now.Sub(frameTime) < time.Second {
+			metrics.FramesPerSecond++
+		}
+	}
+	return metrics
+}
+
+// eventReceived records the receipt of the given event.
+func (a *Application) eventReceived(event tcell.Event) {
+	if !a.metrics.enabled.Load() {
+		return
+	}
+	a.metrics.events.Add(1)
+	a.metrics.pendingSince.CompareAndSwap(0, event.When().UnixNano())
+}
+
+// frameDrawn records a frame which started drawing at the given time and for
+// which the given number of cells were written. It returns the handler which
+// must be called with the returned metrics, if any.
+func (a *Application) frameDrawn(start time.Time, cells int) (func(metrics Metrics), Metrics) {
+	a.Lock()
+	defer a.Unlock()
+	if !a.metrics.enabled.Load() {
+		return nil, Metrics{}
+	}
+	now := time.Now()
+	m := &a.metrics
+	m.current.Frames++
+	m.current.DrawDuration = now.Sub(start)
+	m.current.Cells = cells
+	m.current.TotalCells += uint64(cells)
+	if pendingSince := m.pendingSince.Swap(0); pendingSince != 0 {
+		m.current.Latency = now.Sub(time.Unix(0, pendingSince))
+		m.current.MaxLatency = max(m.current.MaxLatency, m.current.Latency)
+	}
+
+	// Keep only the frames of the last second.
+	index := 0
+	for index < len(m.frameTimes) && now.Sub(m.frameTimes[index]) >= time.Second {
+		index++
+	}
+	m.frameTimes = append(m.frameTimes[index:], now)
+
+	return m.handler, a.currentMetrics(now)
+}
@@ -6,7 +6,13 @@ import (
 
 // Modal is a centered message window used to inform the user or prompt them
 // for an immediate decision. It needs to have at least one button (added via
-// [Modal.AddButtons]) or it will never disappear.
+// [Modal.AddButtons]) or a dismissal handler (see [Modal.SetEscapeFunc] and
+// [Modal.SetClickOutsideFunc]) or it will never disappear.
+//
+// Besides the message text, the window may contain an arbitrary primitive,
+// e.g. an input field or a list, between the text and the buttons (see
+// [Modal.SetContent]). If the buttons don't fit next to each other, they are
+// stacked vertically.
 //
 // See https://github.com/ayn2op/tview/wiki/Modal for an example.
 type Modal struct {
@@ -18,6 +24,16 @@ type Modal struct {
 	// The form embedded in the modal's frame.
 	form *Form
 
+	// The layout of the content and the form, embedded in the frame.
+	layout *Flex
+
+	// The optional primitive shown between the text and the buttons.
+	content Primitive
+
+	// The requested size of the modal, including its border. A value of 0
+	// means that the size is calculated from the modal's contents.
+	width, height int
+
 	// The message text (original, not word-wrapped).
 	text string
 
@@ -27,6 +43,10 @@ type Modal struct {
 	// The optional callback for when the user clicked one of the buttons. It
 	// receives the index of the clicked button and the button's label.
 	done func(buttonIndex int, buttonLabel string)
+
+	// Optional callbacks for when the user pressed Escape or clicked outside
+	// the modal.
+	escape, clickOutside func()
 }
 
 // NewModal returns a new modal message window.
@@ -42,12 +62,22 @@ func NewModal() *Modal {
 			m.done(-1, "")
 		}
 	})
-	m.frame = NewFrame(m.form).SetBorders(0, 0, 1, 0, 0, 0)
+	m.layout = NewFlex().SetDirection(FlexRow).AddItem(m.form, 0, 1, true)
+	m.frame = NewFrame(m.layout).SetBorders(0, 0, 1, 0, 0, 0)
 	m.frame.SetBackgroundColor(Styles.ContrastBackgroundColor).
 		SetBorderPadding(1, 1, 1, 1)
 	return m
 }
 
+// applyTheme updates the modal's default styles after a theme change.
+func (m *Modal) applyTheme(change themeChange) {
+	m.Box.applyTheme(change)
+	m.backgroundColor = change.color(m.backgroundColor, themeContrastBackground)
+	m.form.backgroundColor = change.color(m.form.backgroundColor, themeContrastBackground)
+	m.frame.backgroundColor = change.color(m.frame.backgroundColor, themeContrastBackground)
+	m.textColor = change.color(m.textColor, themePrimaryText)
+}
+
 // SetBackgroundColor sets the color of the modal frame background.
 func (m *Modal) SetBackgroundColor(color tcell.Color) *Modal {
 	if m.form.GetBackgroundColor() != color || m.frame.GetBackgroundColor() != color {
@@ -96,6 +126,57 @@ func (m *Modal) SetText(text string) *Modal {
 	return m
 }
 
+// SetContent sets a primitive to be shown between the message text and the
+// buttons, e.g. an input field to prompt the user for a value. It receives the
+// focus when the modal receives focus. Tab and Backtab move the focus between
+// the content and the buttons. Set it to nil to remove the content.
+//
+// The content's height is determined by the height set with [Modal.SetSize],
+// by the content's Height(width int) int function if it has one (e.g.
+// [TextView]), by the field height of form items (e.g. [InputField]), or it
+// is a third of the screen's height.
+func (m *Modal) SetContent(content Primitive) *Modal {
+	m.content = content
+	m.layout.Clear()
+	if content != nil {
+		m.layout.AddItem(content, 1, 0, true).
+			AddItem(nil, 1, 0, false)
+	}
+	m.layout.AddItem(m.form, 0, 1, content == nil)
+	return m
+}
+
+// GetContent returns the primitive set with [Modal.SetContent].
+func (m *Modal) GetContent() Primitive {
+	return m.content
+}
+
+// SetSize sets the size of the modal, including its border. A width or height
+// of 0 (the default) calculates the respective dimension from the modal's
+// contents. The modal never exceeds the screen.
+func (m *Modal) SetSize(width, height int) *Modal {
+	m.width, m.height = max(width, 0), max(height, 0)
+	return m
+}
+
+// SetEscapeFunc sets a handler which is called when the user presses the
+// Escape key anywhere in the modal, e.g. to dismiss it. If set, it replaces
+// the default Escape handling, i.e. the "done" handler (see
+// [Modal.SetDoneFunc]) is not called and the content does not receive the
+// Escape key.
+func (m *Modal) SetEscapeFunc(handler func()) *Modal {
+	m.escape = handler
+	return m
+}
+
+// SetClickOutsideFunc sets a handler which is called when the user clicks
+// outside the modal, e.g. to dismiss it. This requires the modal to receive
+// mouse events outside its area, e.g. when it is the topmost page.
+func (m *Modal) SetClickOutsideFunc(handler func()) *Modal {
+	m.clickOutside = handler
+	return m
+}
+
 // AddButtons adds buttons to the window. There must be at least one button and
 // a "done" handler so the window can be closed again.
 func (m *Modal) AddButtons(labels []string) *Modal {
@@ -128,26 +209,43 @@ func (m *Modal) SetFocus(index int) *Modal {
 
 // Focus is called when this primitive receives focus.
 func (m *Modal) Focus(delegate func(p Primitive)) {
+	if m.content != nil {
+		delegate(m.content)
+		return
+	}
 	delegate(m.form)
 }
 
 // HasFocus returns whether or not this primitive has focus.
 func (m *Modal) HasFocus() bool {
-	return m.form.HasFocus()
+	return m.form.HasFocus() || m.content != nil && m.content.HasFocus()
 }
 
 // Draw draws this primitive onto the screen.
 func (m *Modal) Draw(screen tcell.Screen) {
+	m.updateTheme(m)
+
 	// Calculate the width of this modal.
 	buttonsWidth := 0
 	for _, button := range m.form.buttons {
-		buttonsWidth += TaggedStringWidth(button.text) + 4 + 2
+		buttonsWidth += button.contentWidth() + 4 + 2
 	}
 	buttonsWidth -= 2
 	screenWidth, screenHeight := screen.Size()
 	width := max(screenWidth/3, buttonsWidth)
+	if m.width > 0 {
+		width = m.width - 4
+	}
+	width = max(min(width, screenWidth-4), 1)
 	// width is now without the box border.
 
+	// Stack the buttons if they don't fit next to each other.
+	m.form.stackButtons = buttonsWidth > width
+	buttonsHeight := 1
+	if m.form.stackButtons {
+		buttonsHeight = len(m.form.buttons)
+	}
+
 	// Reset the text and find out how wide it is.
 	m.frame.Clear()
 	lines := WordWrap(m.text, width)
@@ -156,7 +254,24 @@ func (m *Modal) Draw(screen tcell.Screen) {
 	}
 
 	// Set the modal's position and size.
-	height := len(lines) + 6
+	height := len(lines) + 5 + buttonsHeight
+	if m.content != nil {
+		contentHeight := screenHeight / 3
+		if m.height > 0 {
+			contentHeight = m.height - height - 1
+		} else if content, ok := m.content.(interface{ Height(width int) int }); ok {
+			contentHeight = content.Height(width)
+		} else if content, ok := m.content.(FormItem); ok && content.GetFieldHeight() > 0 {
+			contentHeight = content.GetFieldHeight()
+		}
+		contentHeight = max(contentHeight, 1)
+		m.layout.ResizeItem(m.content, contentHeight, 0)
+		height += contentHeight + 1
+	}
+	if m.height > 0 {
+		height = m.height
+	}
+	height = min(height, screenHeight)
 	width += 4
 	x := (screenWidth - width) / 2
 	y := (screenHeight - height) / 2
@@ -173,13 +288,36 @@ func (m *Modal) Draw(screen tcell.Screen) {
 func (m *Modal) HandleEvent(event tcell.Event) Command {
 	switch event := event.(type) {
 	case *MouseEvent:
-		// Pass mouse events on to the form.
-		cmd := m.form.HandleEvent(event)
-		if cmd == nil && event.Action == MouseLeftDown && m.InRect(event.Position()) {
+		if !m.InRect(event.Position()) {
+			if event.Action == MouseLeftClick && m.clickOutside != nil {
+				m.clickOutside()
+				return RedrawCommand{}
+			}
+			return nil
+		}
+
+		// Pass mouse events on to the form and the content.
+		cmd := m.frame.HandleEvent(event)
+		if cmd == nil && event.Action == MouseLeftDown {
 			cmd = SetFocusCommand{Target: m}
 		}
 		return cmd
 	case *KeyEvent:
+		if event.Key() == tcell.KeyEscape && m.escape != nil && m.HasFocus() {
+			m.escape()
+			return RedrawCommand{}
+		}
+
+		// Tab and Backtab move between the content and the buttons.
+		if m.content != nil {
+			if cmd := m.handleContentTab(event); cmd != nil {
+				return cmd
+			}
+			if m.content.HasFocus() {
+				return m.content.HandleEvent(event)
+			}
+		}
+
 		// Keep arrow-key navigation between modal buttons.
 		switch event.Key() {
 		case tcell.KeyDown, tcell.KeyRight:
@@ -198,3 +336,34 @@ func (m *Modal) HandleEvent(event tcell.Event) Command {
 	}
 	return nil
 }
+
+// handleContentTab moves the focus from the content to the buttons when Tab or
+// Backtab is pressed, and back from the last (or first) button to the
+// content. It returns nil if the focus does not change.
+func (m *Modal) handleContentTab(event *KeyEvent) Command {
+	key := event.Key()
+	if key != tcell.KeyTab && key != tcell.KeyBacktab || len(m.form.buttons) == 0 {
+		return nil
+	}
+	if m.content.HasFocus() {
+		button := 0
+		if key == tcell.KeyBacktab {
+			button = len(m.form.buttons) - 1
+		}
+		m.form.SetFocus(len(m.form.items) + button)
+		return SetFocusCommand{Target: m.form}
+	}
+	_, button := m.form.GetFocusedItemIndex()
+	if key == tcell.KeyTab && button == len(m.form.buttons)-1 || key == tcell.KeyBacktab && button == 0 {
+		return SetFocusCommand{Target: m.content}
+	}
+	return nil
+}
+
+// Inspect describes this primitive in the inspection tree. See [Inspect].
+func (m *Modal) Inspect(node *InspectNode) {
+	m.Box.Inspect(node)
+	node.Role = RoleDialog
+	node.Value = m.text
+	node.AddPrimitive(m.frame)
+}
@@ -0,0 +1,85 @@
+package tview
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+)
+
+// PanicReport describes a panic which occurred in the application's event
+// loop, e.g. while drawing or handling an event. See
+// [Application.SetPanicReportFunc].
+type PanicReport struct {
+	// The value passed to panic.
+	Value any
+
+	// The stack trace of the goroutine which panicked.
+	Stack []byte
+
+	// The time the panic was recovered.
+	Time time.Time
+
+	// The path and version of the main module, if available.
+	Module, Version string
+
+	// The version of the Go runtime.
+	GoVersion string
+
+	// The file the report was written to, or an empty string if it was not
+	// written to a file. See [Application.SetPanicLogPath].
+	LogPath string
+}
+
+// newPanicReport returns a report for the given recovered panic value. It must
+// be called from the deferred function which recovered the panic so the stack
+// trace includes the panicking code.
+func newPanicReport(value any) *PanicReport {
+	report := &PanicReport{
+		Value:     value,
+		Stack:     debug.Stack(),
+		Time:      time.Now(),
+		GoVersion: runtime.Version(),
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		report.Module, report.Version = info.Main.Path, info.Main.Version
+	}
+	return report
+}
+
+// String returns the report as plain text, including the panic value and the
+// stack trace.
+func (r *PanicReport) String() string {
+	return fmt.Sprintf("panic: %v\n\n%s\n%s", r.Value, r.Summary(), r.Stack)
+}
+
+// Summary returns the version information and the log path of the report as
+// plain text, without the panic value and the stack trace, which the Go
+// runtime prints when the panic continues.
+func (r *PanicReport) Summary() string {
+	var b strings.Builder
+	if r.Module != "" {
+		fmt.Fprintf(&b, "Module:  %s %s\n", r.Module, r.Version)
+	}
+	fmt.Fprintf(&b, "Go:      %s %s/%s\n", r.GoVersion, runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&b, "Time:    %s\n", r.Time.Format(time.RFC3339))
+	if r.LogPath != "" {
+		fmt.Fprintf(&b, "Log:     %s\n", r.LogPath)
+	}
+	return b.String()
+}
+
+// writeLog appends the report to the file at the given path and records the
+// path in the report if successful.
+func (r *PanicReport) writeLog(path string) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+	if _, err := file.WriteString(r.String() + "\n"); err == nil {
+		r.LogPath = path
+	}
+}
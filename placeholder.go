@@ -0,0 +1,56 @@
+package tview
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+// placeholder is the content which primitives like [List] and [TreeView] show
+// in place of their data while they have none.
+type placeholder struct {
+	// The primitive drawn into the inner area, or nil to draw the text.
+	primitive Primitive
+
+	// The text drawn in the middle of the inner area if there is no
+	// primitive. It may span several lines.
+	text string
+
+	// The style of the text.
+	style tcell.Style
+}
+
+// newPlaceholder returns an empty placeholder with the default style.
+func newPlaceholder() placeholder {
+	return placeholder{style: tcell.StyleDefault.Foreground(Styles.SecondaryTextColor)}
+}
+
+// applyTheme updates the placeholder's default style after a theme change.
+func (p *placeholder) applyTheme(change themeChange) {
+	p.style = change.style(p.style, themeSecondaryText, 0)
+}
+
+// draw draws the placeholder into the inner area of the given box.
+func (p *placeholder) draw(screen tcell.Screen, box *Box) {
+	x, y, width, height := box.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+	if p.primitive != nil {
+		p.primitive.SetRect(x, y, width, height)
+		p.primitive.Draw(screen)
+		return
+	}
+	if p.text == "" {
+		return
+	}
+	lines := strings.Split(p.text, "\n")
+	top := y + max(height-len(lines), 0)/2
+	style := p.style.Background(box.backgroundColor)
+	for index, line := range lines {
+		if index >= height {
+			break
+		}
+		printWithStyle(screen, line, x, top+index, 0, width, AlignmentCenter, style, false)
+	}
+}
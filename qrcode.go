@@ -0,0 +1,194 @@
+package tview
+
+import (
+	"errors"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+// QRErrorCorrection is the error correction level of a [QRCode]. Higher
+// levels make the code readable even if parts of it are damaged or covered,
+// at the cost of a larger code.
+type QRErrorCorrection int
+
+// The available error correction levels, with the approximate share of the
+// code which may be damaged.
+const (
+	QRErrorCorrectionLow      QRErrorCorrection = iota // 7%
+	QRErrorCorrectionMedium                            // 15%
+	QRErrorCorrectionQuartile                          // 25%
+	QRErrorCorrectionHigh                              // 30%
+)
+
+// ErrQRCodeTooLong is returned by [QRCode.GetError] if the text does not fit
+// into a QR code.
+var ErrQRCodeTooLong = errors.New("text too long for a QR code")
+
+// QRCode displays a string as a QR code, e.g. to share URLs or to pair devices
+// with a terminal application. Each module (the code's "pixel") is drawn as
+// half of a cell using half-block characters, so modules are roughly square.
+// The code is scaled up to fill the box as far as possible and surrounded by a
+// quiet zone of light modules. If the code does not fit into the box, the
+// quiet zone is reduced. If it still does not fit, nothing is drawn.
+//
+// Dark and light modules use fixed colors (black and white by default), not
+// the theme's colors, because scanners expect dark modules on a light
+// background.
+type QRCode struct {
+	*Box
+
+	// The encoded text.
+	text string
+
+	// The error correction level.
+	level QRErrorCorrection
+
+	// The width of the quiet zone in modules.
+	quietZone int
+
+	// The colors of dark and light modules.
+	darkColor, lightColor tcell.Color
+
+	// The encoded code or nil if it has not been encoded yet or the text is
+	// too long.
+	matrix *qrMatrix
+
+	// Set to true if the matrix reflects the current text and level.
+	encoded bool
+}
+
+// NewQRCode returns a new QR code for the given text with medium error
+// correction and a quiet zone of four modules.
+func NewQRCode(text string) *QRCode {
+	return &QRCode{
+		Box:        NewBox(),
+		text:       text,
+		level:      QRErrorCorrectionMedium,
+		quietZone:  4,
+		darkColor:  tcell.ColorBlack,
+		lightColor: tcell.ColorWhite,
+	}
+}
+
+// SetText sets the text encoded in the QR code.
+func (q *QRCode) SetText(text string) *QRCode {
+	if q.text != text {
+		q.text = text
+		q.encoded = false
+	}
+	return q
+}
+
+// GetText returns the text encoded in the QR code.
+func (q *QRCode) GetText() string {
+	return q.text
+}
+
+// SetErrorCorrection sets the error correction level.
+func (q *QRCode) SetErrorCorrection(level QRErrorCorrection) *QRCode {
+	if level < QRErrorCorrectionLow || level > QRErrorCorrectionHigh {
+		level = QRErrorCorrectionMedium
+	}
+	if q.level != level {
+		q.level = level
+		q.encoded = false
+	}
+	return q
+}
+
+// SetQuietZone sets the width of the quiet zone around the code, in modules.
+// The QR code standard requires four modules but most scanners also accept
+// smaller quiet zones.
+func (q *QRCode) SetQuietZone(modules int) *QRCode {
+	q.quietZone = max(modules, 0)
+	return q
+}
+
+// SetColors sets the colors of dark and light modules, including the quiet
+// zone.
+func (q *QRCode) SetColors(dark, light tcell.Color) *QRCode {
+	q.darkColor, q.lightColor = dark, light
+	return q
+}
+
+// GetError returns [ErrQRCodeTooLong] if the text does not fit into a QR code,
+// or nil otherwise.
+func (q *QRCode) GetError() error {
+	if q.encode() == nil {
+		return ErrQRCodeTooLong
+	}
+	return nil
+}
+
+// GetSize returns the number of modules per side of the code, without the
+// quiet zone, or 0 if the text is too long. The code needs at least this
+// many columns and half as many rows (rounded up).
+func (q *QRCode) GetSize() int {
+	if matrix := q.encode(); matrix != nil {
+		return matrix.size
+	}
+	return 0
+}
+
+// encode encodes the text if needed and returns the encoded code.
+func (q *QRCode) encode() *qrMatrix {
+	if !q.encoded {
+		q.matrix = encodeQR([]byte(q.text), q.level)
+		q.encoded = true
+	}
+	return q.matrix
+}
+
+// Draw draws this primitive onto the screen.
+func (q *QRCode) Draw(screen tcell.Screen) {
+	q.DrawForSubclass(screen, q)
+	matrix := q.encode()
+	x, y, width, height := q.GetInnerRect()
+	if matrix == nil || width <= 0 || height <= 0 {
+		return
+	}
+
+	// Determine the quiet zone and the scale. Each cell holds two modules
+	// vertically.
+	quietZone := q.quietZone
+	for quietZone > 0 && (matrix.size+2*quietZone > width || matrix.size+2*quietZone > 2*height) {
+		quietZone--
+	}
+	total := matrix.size + 2*quietZone
+	scale := min(width/total, 2*height/total)
+	if scale < 1 {
+		return
+	}
+
+	// dark returns whether the module at the given position, including the
+	// quiet zone, is dark.
+	dark := func(column, row int) bool {
+		column, row = column/scale-quietZone, row/scale-quietZone
+		return column >= 0 && column < matrix.size && row >= 0 && row < matrix.size && matrix.modules[row][column]
+	}
+	color := func(dark bool) tcell.Color {
+		if dark {
+			return q.darkColor
+		}
+		return q.lightColor
+	}
+
+	// Center the code.
+	size := total * scale
+	x += (width - size) / 2
+	y += (height - (size+1)/2) / 2
+	for row := 0; row < size; row += 2 {
+		for column := range size {
+			bottom := row+1 < size && dark(column, row+1)
+			style := tcell.StyleDefault.Foreground(color(dark(column, row))).Background(color(bottom))
+			screen.Put(x+column, y+row/2, BlockUpperHalfBlock, style)
+		}
+	}
+}
+
+// Inspect describes this primitive in the inspection tree. See [Inspect].
+func (q *QRCode) Inspect(node *InspectNode) {
+	q.Box.Inspect(node)
+	node.Role = RoleImage
+	node.Value = q.text
+}
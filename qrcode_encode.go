@@ -0,0 +1,470 @@
+package tview
+
+// This file implements a QR code encoder (ISO/IEC 18004) for byte mode
+// segments, following the structure of Project Nayuki's QR code generator.
+
+// qrECCCodewordsPerBlock contains the number of error correction codewords per
+// block, indexed by error correction level and version.
+var qrECCCodewordsPerBlock = [4][41]int{
+	{-1, 7, 10, 15, 20, 26, 18, 20, 24, 30, 18, 20, 24, 26, 30, 22, 24, 28, 30, 28, 28, 28, 28, 30, 30, 26, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26, 26, 26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28},
+	{-1, 13, 22, 18, 26, 18, 24, 18, 22, 20, 24, 28, 26, 24, 20, 30, 24, 28, 28, 26, 30, 28, 30, 30, 30, 30, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 17, 28, 22, 16, 22, 28, 26, 26, 24, 28, 24, 28, 22, 24, 24, 30, 28, 28, 26, 28, 30, 24, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+}
+
+// qrErrorCorrectionBlocks contains the number of error correction blocks,
+// indexed by error correction level and version.
+var qrErrorCorrectionBlocks = [4][41]int{
+	{-1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 4, 4, 4, 4, 4, 6, 6, 6, 6, 7, 8, 8, 9, 9, 10, 12, 12, 12, 13, 14, 15, 16, 17, 18, 19, 19, 20, 21, 22, 24, 25},
+	{-1, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14, 16, 17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49},
+	{-1, 1, 1, 2, 2, 4, 4, 6, 6, 8, 8, 8, 10, 12, 16, 12, 17, 16, 18, 21, 20, 23, 23, 25, 27, 29, 34, 34, 35, 38, 40, 43, 45, 48, 51, 53, 56, 59, 62, 65, 68},
+	{-1, 1, 1, 2, 4, 4, 4, 5, 6, 8, 8, 11, 11, 16, 16, 18, 16, 19, 21, 25, 25, 25, 34, 30, 32, 35, 37, 40, 42, 45, 48, 51, 54, 57, 60, 63, 66, 70, 74, 77, 81},
+}
+
+// qrFormatBits maps error correction levels to the bits used in the format
+// information.
+var qrFormatBits = [4]int{1, 0, 3, 2}
+
+// qrMatrix is an encoded QR code. Dark modules are true.
+type qrMatrix struct {
+	size       int
+	modules    [][]bool
+	isFunction [][]bool
+}
+
+// encodeQR encodes the given data as a QR code with the given error
+// correction level, using the smallest possible version. It returns nil if
+// the data is too long.
+func encodeQR(data []byte, level QRErrorCorrection) *qrMatrix {
+	// Find the smallest version which fits the data.
+	version := 1
+	for ; ; version++ {
+		if version > 40 {
+			return nil
+		}
+		countBits := 8
+		if version > 9 {
+			countBits = 16
+		}
+		if len(data) < 1<<countBits && 4+countBits+len(data)*8 <= qrDataCodewords(version, level)*8 {
+			break
+		}
+	}
+	countBits := 8
+	if version > 9 {
+		countBits = 16
+	}
+
+	// Build the data codewords: byte mode indicator, character count, data,
+	// terminator, and padding.
+	var bits qrBitBuffer
+	bits.append(0x4, 4)
+	bits.append(len(data), countBits)
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+	capacity := qrDataCodewords(version, level) * 8
+	bits.append(0, min(4, capacity-bits.length))
+	bits.append(0, (8-bits.length%8)%8)
+	for pad := 0xec; bits.length < capacity; pad ^= 0xec ^ 0x11 {
+		bits.append(pad, 8)
+	}
+
+	// Draw the code.
+	size := version*4 + 17
+	q := &qrMatrix{size: size, modules: make([][]bool, size), isFunction: make([][]bool, size)}
+	for row := range size {
+		q.modules[row] = make([]bool, size)
+		q.isFunction[row] = make([]bool, size)
+	}
+	q.drawFunctionPatterns(version, level)
+	q.drawCodewords(qrAddECCAndInterleave(bits.bytes, version, level))
+
+	// Choose the mask with the lowest penalty.
+	mask, lowest := 0, -1
+	for candidate := range 8 {
+		q.applyMask(candidate)
+		q.drawFormatBits(level, candidate)
+		if penalty := q.penalty(); lowest < 0 || penalty < lowest {
+			mask, lowest = candidate, penalty
+		}
+		q.applyMask(candidate) // Undo.
+	}
+	q.applyMask(mask)
+	q.drawFormatBits(level, mask)
+	return q
+}
+
+// qrRawDataModules returns the number of data modules of the given version,
+// including error correction and remainder bits.
+func qrRawDataModules(version int) int {
+	result := (16*version+128)*version + 64
+	if version >= 2 {
+		alignments := version/7 + 2
+		result -= (25*alignments-10)*alignments - 55
+		if version >= 7 {
+			result -= 36
+		}
+	}
+	return result
+}
+
+// qrDataCodewords returns the number of data codewords of the given version
+// and error correction level.
+func qrDataCodewords(version int, level QRErrorCorrection) int {
+	return qrRawDataModules(version)/8 - qrECCCodewordsPerBlock[level][version]*qrErrorCorrectionBlocks[level][version]
+}
+
+// qrBitBuffer is a sequence of bits, packed into bytes, most significant bit
+// first.
+type qrBitBuffer struct {
+	bytes  []byte
+	length int
+}
+
+// append appends the given number of low bits of the value.
+func (b *qrBitBuffer) append(value, bits int) {
+	for bit := bits - 1; bit >= 0; bit-- {
+		if b.length%8 == 0 {
+			b.bytes = append(b.bytes, 0)
+		}
+		if value>>bit&1 != 0 {
+			b.bytes[b.length/8] |= 0x80 >> (b.length % 8)
+		}
+		b.length++
+	}
+}
+
+// qrAddECCAndInterleave splits the data codewords into blocks, adds error
+// correction codewords to each block, and interleaves the blocks.
+func qrAddECCAndInterleave(data []byte, version int, level QRErrorCorrection) []byte {
+	blocks := qrErrorCorrectionBlocks[level][version]
+	eccLength := qrECCCodewordsPerBlock[level][version]
+	raw := qrRawDataModules(version) / 8
+	shortBlocks := blocks - raw%blocks
+	shortLength := raw / blocks
+
+	divisor := qrReedSolomonDivisor(eccLength)
+	result := make([][]byte, blocks)
+	for index, offset := 0, 0; index < blocks; index++ {
+		length := shortLength - eccLength
+		if index >= shortBlocks {
+			length++
+		}
+		block := append([]byte(nil), data[offset:offset+length]...)
+		offset += length
+		ecc := qrReedSolomonRemainder(block, divisor)
+		if index < shortBlocks {
+			block = append(block, 0) // Placeholder, skipped below.
+		}
+		result[index] = append(block, ecc...)
+	}
+
+	interleaved := make([]byte, 0, raw)
+	for position := range result[0] {
+		for index, block := range result {
+			if position != shortLength-eccLength || index >= shortBlocks {
+				interleaved = append(interleaved, block[position])
+			}
+		}
+	}
+	return interleaved
+}
+
+// qrMultiply multiplies two elements of GF(2^8) modulo x^8+x^4+x^3+x^2+1.
+func qrMultiply(x, y byte) byte {
+	var z int
+	for bit := 7; bit >= 0; bit-- {
+		z = z<<1 ^ (z>>7)*0x11d
+		z ^= int(y>>bit&1) * int(x)
+	}
+	return byte(z)
+}
+
+// qrReedSolomonDivisor returns the coefficients of the Reed-Solomon generator
+// polynomial of the given degree, highest to lowest power, without the
+// leading 1.
+func qrReedSolomonDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for range degree {
+		for index := range result {
+			result[index] = qrMultiply(result[index], root)
+			if index+1 < len(result) {
+				result[index] ^= result[index+1]
+			}
+		}
+		root = qrMultiply(root, 2)
+	}
+	return result
+}
+
+// qrReedSolomonRemainder returns the error correction codewords of the given
+// data.
+func qrReedSolomonRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for index := range result {
+			result[index] ^= qrMultiply(divisor[index], factor)
+		}
+	}
+	return result
+}
+
+// setFunction sets a module of a function pattern.
+func (q *qrMatrix) setFunction(x, y int, dark bool) {
+	q.modules[y][x] = dark
+	q.isFunction[y][x] = true
+}
+
+// drawFunctionPatterns draws the timing, finder, and alignment patterns, and
+// the version information. The format information is reserved.
+func (q *qrMatrix) drawFunctionPatterns(version int, level QRErrorCorrection) {
+	for index := range q.size {
+		q.setFunction(6, index, index%2 == 0)
+		q.setFunction(index, 6, index%2 == 0)
+	}
+
+	// Finder patterns.
+	for _, center := range [][2]int{{3, 3}, {q.size - 4, 3}, {3, q.size - 4}} {
+		for dy := -4; dy <= 4; dy++ {
+			for dx := -4; dx <= 4; dx++ {
+				x, y := center[0]+dx, center[1]+dy
+				if x >= 0 && x < q.size && y >= 0 && y < q.size {
+					distance := max(dx, -dx, dy, -dy)
+					q.setFunction(x, y, distance != 2 && distance != 4)
+				}
+			}
+		}
+	}
+
+	// Alignment patterns, except where they overlap finder patterns.
+	positions := qrAlignmentPositions(version, q.size)
+	for i, y := range positions {
+		for j, x := range positions {
+			last := len(positions) - 1
+			if i == 0 && j == 0 || i == 0 && j == last || i == last && j == 0 {
+				continue
+			}
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					q.setFunction(x+dx, y+dy, max(dx, -dx, dy, -dy) != 1)
+				}
+			}
+		}
+	}
+
+	q.drawFormatBits(level, 0)
+
+	// Version information.
+	if version >= 7 {
+		remainder := version
+		for range 12 {
+			remainder = remainder<<1 ^ (remainder>>11)*0x1f25
+		}
+		bits := version<<12 | remainder
+		for index := range 18 {
+			dark := bits>>index&1 != 0
+			a, b := q.size-11+index%3, index/3
+			q.setFunction(a, b, dark)
+			q.setFunction(b, a, dark)
+		}
+	}
+}
+
+// qrAlignmentPositions returns the center coordinates of the alignment
+// patterns of the given version.
+func qrAlignmentPositions(version, size int) []int {
+	if version == 1 {
+		return nil
+	}
+	count := version/7 + 2
+	step := (version*8 + count*3 + 5) / (count*4 - 4) * 2
+	positions := make([]int, count)
+	positions[0] = 6
+	for index, position := count-1, size-7; index >= 1; index, position = index-1, position-step {
+		positions[index] = position
+	}
+	return positions
+}
+
+// drawFormatBits draws both copies of the format information for the given
+// error correction level and mask.
+func (q *qrMatrix) drawFormatBits(level QRErrorCorrection, mask int) {
+	data := qrFormatBits[level]<<3 | mask
+	remainder := data
+	for range 10 {
+		remainder = remainder<<1 ^ (remainder>>9)*0x537
+	}
+	bits := (data<<10 | remainder) ^ 0x5412
+	bit := func(index int) bool {
+		return bits>>index&1 != 0
+	}
+
+	// First copy, around the top left finder pattern.
+	for index := range 6 {
+		q.setFunction(8, index, bit(index))
+	}
+	q.setFunction(8, 7, bit(6))
+	q.setFunction(8, 8, bit(7))
+	q.setFunction(7, 8, bit(8))
+	for index := 9; index < 15; index++ {
+		q.setFunction(14-index, 8, bit(index))
+	}
+
+	// Second copy, split between the other finder patterns.
+	for index := range 8 {
+		q.setFunction(q.size-1-index, 8, bit(index))
+	}
+	for index := 8; index < 15; index++ {
+		q.setFunction(8, q.size-15+index, bit(index))
+	}
+	q.setFunction(8, q.size-8, true)
+}
+
+// drawCodewords draws the codewords in the zigzag pattern into the modules
+// which are not part of function patterns.
+func (q *qrMatrix) drawCodewords(data []byte) {
+	var index int
+	for right := q.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5 // Skip the vertical timing pattern.
+		}
+		upward := (right+1)&2 == 0
+		for vertical := range q.size {
+			for column := range 2 {
+				x, y := right-column, vertical
+				if upward {
+					y = q.size - 1 - vertical
+				}
+				if !q.isFunction[y][x] && index < len(data)*8 {
+					q.modules[y][x] = data[index/8]>>(7-index%8)&1 != 0
+					index++
+				}
+			}
+		}
+	}
+}
+
+// applyMask inverts the data modules selected by the given mask. Applying
+// the same mask twice restores the modules.
+func (q *qrMatrix) applyMask(mask int) {
+	for y := range q.size {
+		for x := range q.size {
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert && !q.isFunction[y][x] {
+				q.modules[y][x] = !q.modules[y][x]
+			}
+		}
+	}
+}
+
+// penalty returns the penalty score of the current modules, which is used to
+// choose the mask which is easiest to scan.
+func (q *qrMatrix) penalty() int {
+	var result, dark int
+	at := func(x, y int, transposed bool) bool {
+		if transposed {
+			return q.modules[x][y]
+		}
+		return q.modules[y][x]
+	}
+
+	// Runs of five or more modules of the same color, and patterns similar to
+	// finder patterns, in rows and columns.
+	finder := []bool{true, false, true, true, true, false, true}
+	for _, transposed := range []bool{false, true} {
+		for y := range q.size {
+			run := 0
+			for x := range q.size {
+				if x > 0 && at(x, y, transposed) == at(x-1, y, transposed) {
+					run++
+					if run == 5 {
+						result += 3
+					} else if run > 5 {
+						result++
+					}
+				} else {
+					run = 1
+				}
+
+				// Check for a finder-like pattern starting at x with four light
+				// modules (or the border) before or after it.
+				if x+len(finder) > q.size {
+					continue
+				}
+				matches := true
+				for offset, want := range finder {
+					if at(x+offset, y, transposed) != want {
+						matches = false
+						break
+					}
+				}
+				if matches && (q.lightRun(x-4, x, y, transposed) || q.lightRun(x+7, x+11, y, transposed)) {
+					result += 40
+				}
+			}
+		}
+	}
+
+	// 2x2 blocks of the same color.
+	for y := range q.size - 1 {
+		for x := range q.size - 1 {
+			color := q.modules[y][x]
+			if color == q.modules[y][x+1] && color == q.modules[y+1][x] && color == q.modules[y+1][x+1] {
+				result += 3
+			}
+		}
+	}
+
+	// Balance of dark and light modules.
+	for y := range q.size {
+		for x := range q.size {
+			if q.modules[y][x] {
+				dark++
+			}
+		}
+	}
+	total := q.size * q.size
+	k := (max(dark*20-total*10, total*10-dark*20)+total-1)/total - 1
+	result += max(k, 0) * 10
+
+	return result
+}
+
+// lightRun returns whether the modules from "from" (inclusive) to "to"
+// (exclusive) in the given row (or column, if transposed) are light. Modules
+// outside the code count as light.
+func (q *qrMatrix) lightRun(from, to, y int, transposed bool) bool {
+	for x := from; x < to; x++ {
+		if x < 0 || x >= q.size {
+			continue
+		}
+		if transposed && q.modules[x][y] || !transposed && q.modules[y][x] {
+			return false
+		}
+	}
+	return true
+}
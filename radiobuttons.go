@@ -0,0 +1,344 @@
+package tview
+
+import "github.com/gdamore/tcell/v3"
+
+// RadioButtons implements a group of mutually exclusive options, one per row,
+// of which the user can select one. It is a single form item with one label,
+// see [Form.AddRadioButtons]. Use a [RadioGroup] instead if the options are
+// checkboxes which need their own labels, styles, or positions in a layout.
+//
+// The following keys are available:
+//
+//   - Up arrow / Down arrow: Move the cursor to the previous/next option.
+//   - Home / End: Move the cursor to the first/last option.
+//   - Enter, Space: Select the option under the cursor.
+//   - Tab, Backtab, Escape: Finish, e.g. to move to the next form item.
+//
+// Clicking an option selects it.
+type RadioButtons struct {
+	*Box
+
+	// Whether or not the radio buttons are disabled/read-only.
+	disabled bool
+
+	// The options from which the user can choose.
+	options []string
+
+	// The index of the selected option, or -1 if no option is selected.
+	current int
+
+	// The index of the option under the cursor.
+	cursor int
+
+	// The text to be displayed before the options.
+	label string
+
+	// The screen width of the label area. A value of 0 means use the width of
+	// the label text.
+	labelWidth int
+
+	// The label style.
+	labelStyle tcell.Style
+
+	// The style of the options.
+	fieldStyle tcell.Style
+
+	// The style of the option under the cursor while the primitive has focus.
+	focusStyle tcell.Style
+
+	// The strings used to display a selected and an unselected option.
+	checkedString, uncheckedString string
+
+	// An optional function which is called when the user selects an option.
+	changed func(option string, index int)
+
+	// An optional function which is called when the user indicated that they
+	// are done selecting options. The key which was pressed is provided (tab,
+	// shift-tab, or escape).
+	done func(tcell.Key)
+
+	// A callback function set by the Form class and called when the user leaves
+	// this form item.
+	finished func(tcell.Key)
+}
+
+// NewRadioButtons returns a new group of radio buttons with the given options.
+// No option is selected.
+func NewRadioButtons(options ...string) *RadioButtons {
+	return &RadioButtons{
+		Box:             NewBox(),
+		options:         options,
+		current:         -1,
+		labelStyle:      tcell.StyleDefault.Foreground(Styles.SecondaryTextColor),
+		fieldStyle:      tcell.StyleDefault.Background(Styles.ContrastBackgroundColor).Foreground(Styles.PrimaryTextColor),
+		focusStyle:      tcell.StyleDefault.Background(Styles.PrimaryTextColor).Foreground(Styles.ContrastBackgroundColor),
+		checkedString:   "(•)",
+		uncheckedString: "( )",
+	}
+}
+
+// applyTheme updates the radio buttons' default styles after a theme change.
+func (r *RadioButtons) applyTheme(change themeChange) {
+	r.Box.applyTheme(change)
+	r.labelStyle = change.style(r.labelStyle, themeSecondaryText, 0)
+	r.fieldStyle = change.style(r.fieldStyle, themePrimaryText, themeContrastBackground)
+	r.focusStyle = change.style(r.focusStyle, themeContrastBackground, themePrimaryText)
+}
+
+// SetOptions replaces all options. The selection is cleared.
+func (r *RadioButtons) SetOptions(options ...string) *RadioButtons {
+	r.options = options
+	r.current, r.cursor = -1, 0
+	return r
+}
+
+// GetOptionCount returns the number of options.
+func (r *RadioButtons) GetOptionCount() int {
+	return len(r.options)
+}
+
+// SetCurrentOption selects the option at the given index and moves the cursor
+// to it. A negative index clears the selection. This triggers the "changed"
+// callback if the selection changes.
+func (r *RadioButtons) SetCurrentOption(index int) *RadioButtons {
+	if index < 0 || index >= len(r.options) {
+		index = -1
+	} else {
+		r.cursor = index
+	}
+	if index == r.current {
+		return r
+	}
+	r.current = index
+	if r.changed != nil && index >= 0 {
+		r.changed(r.options[index], index)
+	}
+	return r
+}
+
+// GetCurrentOption returns the index and the text of the selected option, or
+// -1 and an empty string if no option is selected.
+func (r *RadioButtons) GetCurrentOption() (int, string) {
+	if r.current < 0 || r.current >= len(r.options) {
+		return -1, ""
+	}
+	return r.current, r.options[r.current]
+}
+
+// GetText returns the text of the selected option, or an empty string if no
+// option is selected.
+func (r *RadioButtons) GetText() string {
+	_, text := r.GetCurrentOption()
+	return text
+}
+
+// SetLabel sets the text to be displayed before the options.
+func (r *RadioButtons) SetLabel(label string) *RadioButtons {
+	r.label = label
+	return r
+}
+
+// GetLabel returns the text to be displayed before the options.
+func (r *RadioButtons) GetLabel() string {
+	return r.label
+}
+
+// SetLabelWidth sets the screen width of the label. A value of 0 will cause the
+// primitive to use the width of the label string.
+func (r *RadioButtons) SetLabelWidth(width int) *RadioButtons {
+	r.labelWidth = width
+	return r
+}
+
+// SetLabelStyle sets the style of the label.
+func (r *RadioButtons) SetLabelStyle(style tcell.Style) *RadioButtons {
+	r.labelStyle = style
+	return r
+}
+
+// SetFieldStyle sets the style of the options.
+func (r *RadioButtons) SetFieldStyle(style tcell.Style) *RadioButtons {
+	r.fieldStyle = style
+	return r
+}
+
+// SetActivatedStyle sets the style of the option under the cursor while the
+// primitive has focus.
+func (r *RadioButtons) SetActivatedStyle(style tcell.Style) *RadioButtons {
+	r.focusStyle = style
+	return r
+}
+
+// SetCheckedString sets the strings which mark a selected and an unselected
+// option. They should have the same width. The defaults are "(•)" and "( )".
+func (r *RadioButtons) SetCheckedString(checked, unchecked string) *RadioButtons {
+	r.checkedString, r.uncheckedString = checked, unchecked
+	return r
+}
+
+// SetChangedFunc sets a handler which is called when the user selects an
+// option. It receives the option's text and index.
+func (r *RadioButtons) SetChangedFunc(handler func(option string, index int)) *RadioButtons {
+	r.changed = handler
+	return r
+}
+
+// SetDoneFunc sets a handler which is called when the user is done using the
+// radio buttons. The callback function is provided with the key that was
+// pressed, which is one of the following:
+//
+//   - KeyEscape: Abort selection.
+//   - KeyTab: Move to the next field.
+//   - KeyBacktab: Move to the previous field.
+func (r *RadioButtons) SetDoneFunc(handler func(key tcell.Key)) *RadioButtons {
+	r.done = handler
+	return r
+}
+
+// SetFinishedFunc sets a callback invoked when the user leaves this form item.
+func (r *RadioButtons) SetFinishedFunc(handler func(key tcell.Key)) FormItem {
+	r.finished = handler
+	return r
+}
+
+// SetFormAttributes sets attributes shared by all form items.
+func (r *RadioButtons) SetFormAttributes(labelWidth int, labelColor, bgColor, fieldTextColor, fieldBgColor tcell.Color) FormItem {
+	r.labelWidth = labelWidth
+	r.labelStyle = r.labelStyle.Foreground(labelColor)
+	r.backgroundColor = bgColor
+	r.fieldStyle = r.fieldStyle.Foreground(fieldTextColor).Background(fieldBgColor)
+	r.focusStyle = r.focusStyle.Foreground(fieldBgColor).Background(fieldTextColor)
+	return r
+}
+
+// GetFieldWidth returns this primitive's field width.
+func (r *RadioButtons) GetFieldWidth() int {
+	var width int
+	for _, option := range r.options {
+		width = max(width, TaggedStringWidth(option))
+	}
+	return width + TaggedStringWidth(r.checkedString) + 1
+}
+
+// GetFieldHeight returns this primitive's field height.
+func (r *RadioButtons) GetFieldHeight() int {
+	return max(len(r.options), 1)
+}
+
+// SetDisabled sets whether or not the item is disabled / read-only.
+func (r *RadioButtons) SetDisabled(disabled bool) FormItem {
+	r.disabled = disabled
+	if r.finished != nil {
+		r.finished(-1)
+	}
+	return r
+}
+
+// GetDisabled returns whether or not the item is disabled / read-only.
+func (r *RadioButtons) GetDisabled() bool {
+	return r.disabled
+}
+
+// Focus is called when this primitive receives focus.
+func (r *RadioButtons) Focus(delegate func(p Primitive)) {
+	// If we're part of a form and this item is disabled, there's nothing the
+	// user can do here so we're finished.
+	if r.finished != nil && r.disabled {
+		r.finished(-1)
+		return
+	}
+	r.Box.Focus(delegate)
+}
+
+// Draw draws this primitive onto the screen.
+func (r *RadioButtons) Draw(screen tcell.Screen) {
+	r.DrawForSubclass(screen, r)
+	x, y, width, height := r.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	// Draw the label and the options.
+	x, width = printLabel(screen, r.label, r.labelWidth, r.labelStyle, x, y, width)
+	if width <= 0 {
+		return
+	}
+	for index, option := range r.options {
+		if index >= height {
+			break
+		}
+		style := r.fieldStyle
+		if r.disabled {
+			style = style.Background(r.backgroundColor)
+		}
+		if r.HasFocus() && index == r.cursor {
+			style = r.focusStyle
+		}
+		mark := r.uncheckedString
+		if index == r.current {
+			mark = r.checkedString
+		}
+		_, _, markWidth := printWithStyle(screen, mark, x, y+index, 0, width, AlignmentLeft, style, r.disabled)
+		printWithStyle(screen, " "+option, x+markWidth, y+index, 0, width-markWidth, AlignmentLeft, r.fieldStyle, true)
+	}
+}
+
+// HandleEvent handles input events for this primitive.
+func (r *RadioButtons) HandleEvent(event tcell.Event) Command {
+	if r.disabled {
+		return nil
+	}
+
+	switch event := event.(type) {
+	case *KeyEvent:
+		switch key := event.Key(); key {
+		case tcell.KeyUp:
+			r.cursor = max(r.cursor-1, 0)
+		case tcell.KeyDown:
+			r.cursor = max(min(r.cursor+1, len(r.options)-1), 0)
+		case tcell.KeyHome:
+			r.cursor = 0
+		case tcell.KeyEnd:
+			r.cursor = max(len(r.options)-1, 0)
+		case tcell.KeyRune, tcell.KeyEnter:
+			if key == tcell.KeyRune && event.Str() != " " {
+				return nil
+			}
+			r.SetCurrentOption(r.cursor)
+		case tcell.KeyTab, tcell.KeyBacktab, tcell.KeyEscape: // We're done.
+			if r.done != nil {
+				r.done(key)
+			}
+			if r.finished != nil {
+				r.finished(key)
+			}
+		default:
+			return nil
+		}
+		return RedrawCommand{}
+	case *MouseEvent:
+		x, y := event.Position()
+		if !r.InRect(x, y) {
+			return nil
+		}
+		_, rectY, _, _ := r.GetInnerRect()
+		switch event.Action {
+		case MouseLeftDown:
+			return SetFocusCommand{Target: r}
+		case MouseLeftClick:
+			if index := y - rectY; index >= 0 && index < len(r.options) {
+				r.SetCurrentOption(index)
+				return RedrawCommand{}
+			}
+		}
+	}
+	return nil
+}
+
+// Inspect describes this primitive in the inspection tree. See [Inspect].
+func (r *RadioButtons) Inspect(node *InspectNode) {
+	node.Role = RoleRadioGroup
+	node.Label = r.label
+	node.Value = r.GetText()
+	node.Disabled = r.disabled
+}
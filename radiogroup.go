@@ -0,0 +1,261 @@
+package tview
+
+import (
+	"slices"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+// RadioGroup arranges a set of checkboxes, one per row, of which at most one
+// can be checked at a time. Checking one of the checkboxes (by the user or
+// with [Checkbox.SetChecked]) unchecks all others, and the user cannot
+// uncheck the checked box other than by checking another one. Unlike
+// [RadioButtons], each option is a full [Checkbox] with its own label,
+// styles, and glyphs, and the checkboxes may be placed anywhere, e.g. as
+// separate items of a [Form]. For a simple list of options in a form, use
+// [Form.AddRadioButtons] instead.
+//
+// The following keys are available when one of the checkboxes has focus:
+//
+//   - Up arrow / Left arrow: Move the focus to the previous checkbox.
+//   - Down arrow / Right arrow: Move the focus to the next checkbox.
+//   - Home / End: Move the focus to the first/last checkbox.
+//   - Enter, Space: Check the focused checkbox.
+//
+// Disabled checkboxes are skipped.
+type RadioGroup struct {
+	*Box
+
+	// The checkboxes of this group.
+	checkboxes []*Checkbox
+
+	// The index of the checkbox which receives the focus.
+	current int
+
+	// An optional function which is called when a checkbox becomes checked.
+	changed func(index int, checkbox *Checkbox)
+}
+
+// NewRadioGroup returns a new radio group with the given checkboxes. If more
+// than one of them is checked, only the last one stays checked.
+func NewRadioGroup(checkboxes ...*Checkbox) *RadioGroup {
+	g := &RadioGroup{
+		Box: NewBox(),
+	}
+	for _, checkbox := range checkboxes {
+		g.AddCheckbox(checkbox)
+	}
+	return g
+}
+
+// AddCheckbox adds a checkbox to the end of the group. If it is checked, all
+// other checkboxes of the group are unchecked. A checkbox cannot be part of
+// more than one group.
+func (g *RadioGroup) AddCheckbox(checkbox *Checkbox) *RadioGroup {
+	if checkbox.group != nil {
+		checkbox.group.RemoveCheckbox(checkbox)
+	}
+	checkbox.group = g
+	g.checkboxes = append(g.checkboxes, checkbox)
+	if checkbox.IsChecked() {
+		g.checked(checkbox)
+	}
+	return g
+}
+
+// RemoveCheckbox removes the given checkbox from the group. Nothing happens if
+// it is not part of the group.
+func (g *RadioGroup) RemoveCheckbox(checkbox *Checkbox) *RadioGroup {
+	for index, c := range g.checkboxes {
+		if c == checkbox {
+			checkbox.group = nil
+			g.checkboxes = slices.Delete(g.checkboxes, index, index+1)
+			if g.current > index || g.current >= len(g.checkboxes) {
+				g.current = max(g.current-1, 0)
+			}
+			break
+		}
+	}
+	return g
+}
+
+// Clear removes all checkboxes from the group.
+func (g *RadioGroup) Clear() *RadioGroup {
+	for _, checkbox := range g.checkboxes {
+		checkbox.group = nil
+	}
+	g.checkboxes = nil
+	g.current = 0
+	return g
+}
+
+// GetCheckboxCount returns the number of checkboxes in the group.
+func (g *RadioGroup) GetCheckboxCount() int {
+	return len(g.checkboxes)
+}
+
+// GetCheckbox returns the checkbox at the given index or nil if there is no
+// such checkbox.
+func (g *RadioGroup) GetCheckbox(index int) *Checkbox {
+	if index < 0 || index >= len(g.checkboxes) {
+		return nil
+	}
+	return g.checkboxes[index]
+}
+
+// SetChecked checks the checkbox at the given index and unchecks all others.
+// An index outside the range of checkboxes unchecks all checkboxes.
+func (g *RadioGroup) SetChecked(index int) *RadioGroup {
+	if index >= 0 && index < len(g.checkboxes) {
+		g.checkboxes[index].SetChecked(true)
+		return g
+	}
+	for _, checkbox := range g.checkboxes {
+		checkbox.SetChecked(false)
+	}
+	return g
+}
+
+// GetChecked returns the index of the checked checkbox and the checkbox
+// itself. If no checkbox is checked, -1 and nil are returned.
+func (g *RadioGroup) GetChecked() (index int, checkbox *Checkbox) {
+	for index, checkbox := range g.checkboxes {
+		if checkbox.IsChecked() {
+			return index, checkbox
+		}
+	}
+	return -1, nil
+}
+
+// SetChangedFunc sets a handler which is called when a checkbox of the group
+// becomes checked. The handler receives the checkbox and its index.
+func (g *RadioGroup) SetChangedFunc(handler func(index int, checkbox *Checkbox)) *RadioGroup {
+	g.changed = handler
+	return g
+}
+
+// checked is called by a checkbox of the group which became checked. It
+// unchecks all other checkboxes.
+func (g *RadioGroup) checked(checkbox *Checkbox) {
+	checkedIndex := -1
+	for index, c := range g.checkboxes {
+		if c == checkbox {
+			checkedIndex = index
+		} else if c.state == CheckboxChecked {
+			c.SetState(CheckboxUnchecked)
+		}
+	}
+	if checkedIndex >= 0 && g.changed != nil {
+		g.changed(checkedIndex, checkbox)
+	}
+}
+
+// focusedIndex returns the index of the checkbox which has focus or -1 if
+// none has.
+func (g *RadioGroup) focusedIndex() int {
+	for index, checkbox := range g.checkboxes {
+		if checkbox.HasFocus() {
+			return index
+		}
+	}
+	return -1
+}
+
+// move returns the index of the first enabled checkbox starting at the given
+// index and going in the given direction (1 or -1), or -1 if there is none.
+func (g *RadioGroup) move(index, direction int) int {
+	for ; index >= 0 && index < len(g.checkboxes); index += direction {
+		if !g.checkboxes[index].GetDisabled() {
+			return index
+		}
+	}
+	return -1
+}
+
+// Draw draws this primitive onto the screen.
+func (g *RadioGroup) Draw(screen tcell.Screen) {
+	g.DrawForSubclass(screen, g)
+
+	x, y, width, height := g.GetInnerRect()
+	for index, checkbox := range g.checkboxes {
+		if index >= height {
+			checkbox.SetRect(x, y+height, width, 0)
+			continue
+		}
+		checkbox.SetRect(x, y+index, width, 1)
+		checkbox.Draw(screen)
+	}
+}
+
+// Focus is called when this primitive receives focus.
+func (g *RadioGroup) Focus(delegate func(p Primitive)) {
+	index := g.move(min(g.current, len(g.checkboxes)-1), 1)
+	if index < 0 {
+		index = g.move(len(g.checkboxes)-1, -1)
+	}
+	if index < 0 {
+		g.Box.Focus(delegate)
+		return
+	}
+	g.current = index
+	delegate(g.checkboxes[index])
+}
+
+// HasFocus returns whether or not this primitive has focus.
+func (g *RadioGroup) HasFocus() bool {
+	return g.focusedIndex() >= 0 || g.Box.HasFocus()
+}
+
+// HandleEvent handles input events for this primitive.
+func (g *RadioGroup) HandleEvent(event tcell.Event) Command {
+	switch event := event.(type) {
+	case *KeyEvent:
+		focused := g.focusedIndex()
+		if focused < 0 {
+			return nil
+		}
+		g.current = focused
+
+		// Arrow keys move the focus within the group.
+		next := -1
+		switch event.Key() {
+		case tcell.KeyUp, tcell.KeyLeft:
+			next = g.move(focused-1, -1)
+		case tcell.KeyDown, tcell.KeyRight:
+			next = g.move(focused+1, 1)
+		case tcell.KeyHome:
+			next = g.move(0, 1)
+		case tcell.KeyEnd:
+			next = g.move(len(g.checkboxes)-1, -1)
+		default:
+			return g.checkboxes[focused].HandleEvent(event)
+		}
+		if next < 0 || next == focused {
+			return nil
+		}
+		g.current = next
+		return SetFocusCommand{Target: g.checkboxes[next]}
+	case *MouseEvent:
+		if !g.InRect(event.Position()) {
+			return nil
+		}
+		for index, checkbox := range g.checkboxes {
+			if checkbox.InRect(event.Position()) {
+				if event.Action == MouseLeftDown && !checkbox.GetDisabled() {
+					g.current = index
+				}
+				return checkbox.HandleEvent(event)
+			}
+		}
+	}
+	return nil
+}
+
+// Inspect describes this primitive in the inspection tree. See [Inspect].
+func (g *RadioGroup) Inspect(node *InspectNode) {
+	g.Box.Inspect(node)
+	node.Role = RoleRadioGroup
+	for _, checkbox := range g.checkboxes {
+		node.AddPrimitive(checkbox)
+	}
+}
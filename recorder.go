@@ -0,0 +1,366 @@
+package tview
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v3"
+	"github.com/gdamore/tcell/v3/color"
+)
+
+// Recorder records the screen contents of an application as an asciicast v2
+// file (see https://docs.asciinema.org/manual/asciicast/v2/), e.g. for
+// documentation or bug reports. Each draw is recorded as an output event which
+// updates only the cells that changed since the previous draw. Rows which
+// scrolled vertically, e.g. in a tailed log spanning the screen's width, are
+// moved with the terminal's scroll region instead of being drawn again. This
+// only applies to the recorded output. The application's own screen is
+// updated by tcell, which does not use scroll regions.
+//
+// Frames can also be passed to a custom encoder, e.g. to render a GIF, see
+// [Recorder.SetFrameFunc].
+//
+// Use [Application.StartRecording] to start recording.
+type Recorder struct {
+	sync.Mutex
+
+	// The writer the asciicast file is written to. May be nil if only the
+	// frame function is used.
+	writer io.Writer
+
+	// The optional title written to the header.
+	title string
+
+	// An optional function which receives every recorded frame.
+	frame func(snapshot *Snapshot, elapsed time.Duration)
+
+	// The time the first frame was recorded.
+	start time.Time
+
+	// Set to true while recording is paused.
+	paused bool
+
+	// The time recording was paused.
+	pausedAt time.Time
+
+	// The total time recording was paused, excluded from event timestamps.
+	pausedTotal time.Duration
+
+	// The previously recorded frame, nil before the first frame.
+	last *Snapshot
+
+	// The first error which occurred while writing.
+	err error
+}
+
+// NewRecorder returns a new recorder which writes an asciicast v2 file to the
+// given writer. The writer may be nil if only a frame function is used.
+func NewRecorder(writer io.Writer) *Recorder {
+	return &Recorder{writer: writer}
+}
+
+// SetTitle sets the title written to the asciicast header.
+func (r *Recorder) SetTitle(title string) *Recorder {
+	r.Lock()
+	defer r.Unlock()
+	r.title = title
+	return r
+}
+
+// SetFrameFunc sets a function which receives every recorded frame along with
+// the time elapsed since the first frame (excluding pauses). This can be used
+// to hook up external encoders, e.g. to render a GIF. The function is called
+// from the application's event loop and owns the snapshot.
+func (r *Recorder) SetFrameFunc(handler func(snapshot *Snapshot, elapsed time.Duration)) *Recorder {
+	r.Lock()
+	defer r.Unlock()
+	r.frame = handler
+	return r
+}
+
+// SetPaused pauses or resumes recording. Draws are ignored while paused and the
+// time spent paused is removed from the recording.
+func (r *Recorder) SetPaused(paused bool) *Recorder {
+	r.Lock()
+	defer r.Unlock()
+	if r.paused == paused {
+		return r
+	}
+	r.paused = paused
+	if paused {
+		r.pausedAt = time.Now()
+	} else if !r.pausedAt.IsZero() {
+		r.pausedTotal += time.Since(r.pausedAt)
+	}
+	return r
+}
+
+// IsPaused returns whether recording is paused.
+func (r *Recorder) IsPaused() bool {
+	r.Lock()
+	defer r.Unlock()
+	return r.paused
+}
+
+// Err returns the first error which occurred while writing the recording.
+func (r *Recorder) Err() error {
+	r.Lock()
+	defer r.Unlock()
+	return r.err
+}
+
+// record records the given frame.
+func (r *Recorder) record(snapshot *Snapshot) {
+	r.Lock()
+	defer r.Unlock()
+	if r.paused {
+		return
+	}
+
+	now := time.Now()
+	if r.start.IsZero() {
+		r.start = now
+		r.writeHeader(snapshot)
+	}
+	elapsed := now.Sub(r.start) - r.pausedTotal
+	seconds := elapsed.Seconds()
+
+	if r.last != nil && (r.last.Width != snapshot.Width || r.last.Height != snapshot.Height) {
+		r.writeEvent(seconds, "r", fmt.Sprintf("%dx%d", snapshot.Width, snapshot.Height))
+		r.last = nil
+	}
+	if output := diffSnapshots(r.last, snapshot); output != "" {
+		r.writeEvent(seconds, "o", output)
+	}
+	r.last = snapshot
+
+	if r.frame != nil {
+		r.frame(snapshot, elapsed)
+	}
+}
+
+// writeHeader writes the asciicast header for the given first frame.
+func (r *Recorder) writeHeader(snapshot *Snapshot) {
+	header := struct {
+		Version   int               `json:"version"`
+		Width     int               `json:"width"`
+		Height    int               `json:"height"`
+		Timestamp int64             `json:"timestamp"`
+		Title     string            `json:"title,omitempty"`
+		Env       map[string]string `json:"env,omitempty"`
+	}{
+		Version:   2,
+		Width:     snapshot.Width,
+		Height:    snapshot.Height,
+		Timestamp: r.start.Unix(),
+		Title:     r.title,
+	}
+	if term := os.Getenv("TERM"); term != "" {
+		header.Env = map[string]string{"TERM": term}
+	}
+	r.writeJSON(header)
+}
+
+// writeEvent writes an asciicast event of the given type.
+func (r *Recorder) writeEvent(seconds float64, code, data string) {
+	r.writeJSON([]any{float64(int64(seconds*1e6)) / 1e6, code, data})
+}
+
+// writeJSON writes the given value as one line of JSON.
+func (r *Recorder) writeJSON(value any) {
+	if r.writer == nil || r.err != nil {
+		return
+	}
+	line, err := json.Marshal(value)
+	if err != nil {
+		r.err = err
+		return
+	}
+	_, r.err = r.writer.Write(append(line, '\n'))
+}
+
+// diffSnapshots returns the terminal output which turns the screen shown in
+// the previous snapshot into the next one. If previous is nil, the entire
+// screen is drawn. It is used for recordings only, not for drawing the
+// application's screen.
+func diffSnapshots(previous, next *Snapshot) string {
+	var (
+		b                strings.Builder
+		style            tcell.Style
+		cursorX, cursorY = -1, -1
+		styleSet         bool
+		forceX, forceY   = -1, -1 // Cells up to forceX are redrawn because they were covered by a replaced wide character.
+	)
+	if previous == nil {
+		b.WriteString("\x1b[0m\x1b[2J")
+		styleSet = true
+	} else if top, bottom, shift, ok := detectScroll(previous, next); ok {
+		// Scroll the region with the terminal so that only the rows which
+		// scrolled in need to be drawn.
+		b.WriteString("\x1b[0m")
+		if shift > 0 {
+			fmt.Fprintf(&b, "\x1b[%d;%dr\x1b[%dS", top+1, bottom+shift+1, shift)
+		} else {
+			fmt.Fprintf(&b, "\x1b[%d;%dr\x1b[%dT", top+shift+1, bottom+1, -shift)
+		}
+		b.WriteString("\x1b[r")
+		previous = scrollSnapshot(previous, top, bottom, shift)
+	}
+	next.Range(func(x, y int, cell SnapshotCell) bool {
+		if previous != nil {
+			old := previous.Cell(x, y)
+			if old == cell && (y != forceY || x >= forceX) {
+				return true
+			}
+			if old.Width > 1 {
+				forceX, forceY = x+old.Width, y
+			}
+		}
+		if x != cursorX || y != cursorY {
+			fmt.Fprintf(&b, "\x1b[%d;%dH", y+1, x+1)
+		}
+		if !styleSet || cell.Style != style {
+			b.WriteString(sgr(cell.Style))
+			style, styleSet = cell.Style, true
+		}
+		b.WriteString(cell.Text)
+		cursorX, cursorY = x+max(cell.Width, 1), y
+		return true
+	})
+	if b.Len() > 0 {
+		b.WriteString("\x1b[0m")
+	}
+	return b.String()
+}
+
+// detectScroll finds the band of rows which, compared to the previous
+// snapshot, moved up (positive shift) or down (negative shift) the furthest
+// in terms of rows that would otherwise need to be redrawn. The rows from top
+// to bottom (inclusive) of the next snapshot are equal to the previous
+// snapshot's rows shifted by shift. Only entire rows are considered because
+// terminals scroll entire rows. It returns false if scrolling does not save
+// enough output.
+func detectScroll(previous, next *Snapshot) (top, bottom, shift int, ok bool) {
+	const minSavedRows = 2
+	height := next.Height
+	bestSaved := minSavedRows - 1
+	for d := 1 - height; d < height; d++ {
+		if d == 0 {
+			continue
+		}
+		start, saved := -1, 0
+		for y := max(0, -d); y <= min(height, height-d); y++ {
+			if y < min(height, height-d) && snapshotRowsEqual(next, y, previous, y+d) {
+				if start < 0 {
+					start, saved = y, 0
+				}
+				if !snapshotRowsEqual(next, y, previous, y) {
+					saved++
+				}
+				continue
+			}
+			if start >= 0 && saved > bestSaved {
+				top, bottom, shift, bestSaved, ok = start, y-1, d, saved, true
+			}
+			start = -1
+		}
+	}
+	return
+}
+
+// snapshotRowsEqual returns whether row ay of snapshot a equals row by of
+// snapshot b. Both snapshots must have the same width.
+func snapshotRowsEqual(a *Snapshot, ay int, b *Snapshot, by int) bool {
+	width := a.Width
+	return slices.Equal(a.cells[ay*width:(ay+1)*width], b.cells[by*width:(by+1)*width])
+}
+
+// scrollSnapshot returns a copy of the given snapshot as it looks after the
+// terminal scrolled the region found by detectScroll. Rows which scrolled in
+// are empty.
+func scrollSnapshot(snapshot *Snapshot, top, bottom, shift int) *Snapshot {
+	width := snapshot.Width
+	scrolled := &Snapshot{
+		Width:  width,
+		Height: snapshot.Height,
+		cells:  slices.Clone(snapshot.cells),
+	}
+	regionTop, regionBottom := top, bottom+shift
+	if shift < 0 {
+		regionTop, regionBottom = top+shift, bottom
+	}
+	for y := regionTop; y <= regionBottom; y++ {
+		row := scrolled.cells[y*width : (y+1)*width]
+		if source := y + shift; source >= regionTop && source <= regionBottom {
+			copy(row, snapshot.cells[source*width:(source+1)*width])
+			continue
+		}
+		for x := range row {
+			row[x] = SnapshotCell{Text: " ", Width: 1}
+		}
+	}
+	return scrolled
+}
+
+// sgr returns the Select Graphic Rendition sequence which sets the given style.
+func sgr(style tcell.Style) string {
+	codes := []string{"0"}
+	if style.HasBold() {
+		codes = append(codes, "1")
+	}
+	if style.HasDim() {
+		codes = append(codes, "2")
+	}
+	if style.HasItalic() {
+		codes = append(codes, "3")
+	}
+	if style.HasUnderline() {
+		codes = append(codes, "4")
+	}
+	if style.HasBlink() {
+		codes = append(codes, "5")
+	}
+	if style.HasReverse() {
+		codes = append(codes, "7")
+	}
+	if style.HasStrikeThrough() {
+		codes = append(codes, "9")
+	}
+	if code := sgrColor(style.GetForeground(), 30); code != "" {
+		codes = append(codes, code)
+	}
+	if code := sgrColor(style.GetBackground(), 40); code != "" {
+		codes = append(codes, code)
+	}
+	return "\x1b[" + strings.Join(codes, ";") + "m"
+}
+
+// sgrColor returns the SGR parameters for the given color, where base is 30
+// for foreground and 40 for background colors. It returns an empty string for
+// the default color.
+func sgrColor(c tcell.Color, base int) string {
+	switch {
+	case !c.Valid() || c&color.IsSpecial != 0:
+		return ""
+	case c.IsRGB():
+		r, g, b := c.RGB()
+		return fmt.Sprintf("%d;2;%d;%d;%d", base+8, r, g, b)
+	}
+	index := int(c - color.IsValid)
+	switch {
+	case index < 8:
+		return fmt.Sprint(base + index)
+	case index < 16:
+		return fmt.Sprint(base + 60 + index - 8)
+	case index < 256:
+		return fmt.Sprintf("%d;5;%d", base+8, index)
+	}
+	r, g, b := c.RGB()
+	return fmt.Sprintf("%d;2;%d;%d;%d", base+8, r, g, b)
+}
@@ -0,0 +1,410 @@
+package tview
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gdamore/tcell/v3"
+	"github.com/gdamore/tcell/v3/tty"
+)
+
+// The message types of the remote rendering protocol. Each message consists of
+// the type byte, the payload length as a 32-bit big-endian integer, and the
+// payload.
+const (
+	remoteHello  byte = iota + 1 // Client to server: $TERM and $COLORTERM, separated by a NUL byte.
+	remoteResize                 // Client to server: width and height, 16 bits each.
+	remoteInput                  // Client to server: terminal input.
+	remoteOutput                 // Server to client: terminal output.
+)
+
+// remoteMaxPayload is the maximum accepted payload length of a remote message.
+const remoteMaxPayload = 1 << 20
+
+// remoteConn sends and receives remote rendering messages over a connection.
+type remoteConn struct {
+	net.Conn
+
+	// Serializes sending messages.
+	writeMutex sync.Mutex
+}
+
+// send sends a message of the given type.
+func (c *remoteConn) send(kind byte, payload []byte) error {
+	message := make([]byte, 5, 5+len(payload))
+	message[0] = kind
+	binary.BigEndian.PutUint32(message[1:], uint32(len(payload)))
+	message = append(message, payload...)
+	c.writeMutex.Lock()
+	defer c.writeMutex.Unlock()
+	_, err := c.Write(message)
+	return err
+}
+
+// receive waits for the next message. It must not be called concurrently.
+func (c *remoteConn) receive() (kind byte, payload []byte, err error) {
+	var header [5]byte
+	if _, err = io.ReadFull(c, header[:]); err != nil {
+		return
+	}
+	length := binary.BigEndian.Uint32(header[1:])
+	if length > remoteMaxPayload {
+		return 0, nil, fmt.Errorf("remote message of %d bytes exceeds the limit", length)
+	}
+	payload = make([]byte, length)
+	if _, err = io.ReadFull(c, payload); err != nil {
+		return
+	}
+	return header[0], payload, nil
+}
+
+// remoteTty is the server side of a remote terminal. It implements [tty.Tty]
+// for tcell, writing terminal output to the client and reading the input
+// received from the client.
+type remoteTty struct {
+	sync.Mutex
+
+	conn *remoteConn
+
+	// The input payloads received from the client. Closed when the connection
+	// ends.
+	input chan []byte
+
+	// The rest of an input payload which has not been read yet.
+	pending []byte
+
+	// Closed by Drain to wake up a blocked Read.
+	wake chan struct{}
+
+	// The client's terminal size and the channel which is notified when it
+	// changes.
+	size   tty.WindowSize
+	resize chan<- bool
+
+	// Closed by Close to end the receive loop.
+	done      chan struct{}
+	closeOnce sync.Once
+
+	// The error which ended the connection.
+	err error
+}
+
+// NewRemoteScreen returns an initialized screen which displays its contents in
+// the terminal of a thin client connected over the given connection, see
+// [RunRemoteTerminal]. This allows a tview application to run on a server,
+// e.g. to show the same dashboard to several users, with one [Application]
+// per connection:
+//
+//	screen, err := tview.NewRemoteScreen(conn)
+//	if err != nil {
+//		return err
+//	}
+//	return tview.NewApplication().SetScreen(screen).SetRoot(root).Run()
+//
+// The screen transmits the terminal output generated by tcell, i.e. only the
+// cells which changed since the previous draw, and receives the client's
+// terminal input and size. The client's $TERM and $COLORTERM variables are
+// used in place of the server's. When the connection ends, the application
+// stops with an error. When the screen is finalized, the connection is closed.
+// See also [ServeRemote].
+//
+// This function waits for the client to send its terminal properties. Use
+// [net.Conn.SetDeadline] to limit the wait. The transport is experimental and
+// its protocol may change. It is neither authenticated nor encrypted. Use it
+// over a trusted channel, e.g. a [crypto/tls] connection.
+func NewRemoteScreen(conn net.Conn) (tcell.Screen, error) {
+	t := &remoteTty{
+		conn:  &remoteConn{Conn: conn},
+		input: make(chan []byte),
+		wake:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+
+	// The client starts with its terminal properties and size.
+	kind, payload, err := t.conn.receive()
+	if err == nil && kind != remoteHello {
+		err = errors.New("remote client did not send its terminal properties")
+	}
+	if err != nil {
+		return nil, err
+	}
+	term, colorTerm, _ := strings.Cut(string(payload), "\x00")
+	kind, payload, err = t.conn.receive()
+	if err == nil && (kind != remoteResize || len(payload) != 4) {
+		err = errors.New("remote client did not send its terminal size")
+	}
+	if err != nil {
+		return nil, err
+	}
+	t.size = decodeRemoteSize(payload)
+	go t.receiveLoop()
+
+	options := []tcell.TerminfoScreenOption{tcell.OptTerm(term)}
+	if slices.Contains([]string{"truecolor", "direct", "24bit"}, colorTerm) {
+		options = append(options, tcell.OptColors(1<<24))
+	}
+	screen, err := tcell.NewTerminfoScreenFromTty(t, options...)
+	if err == nil {
+		err = screen.Init()
+	}
+	if err != nil {
+		t.Close()
+		return nil, err
+	}
+	return screen, nil
+}
+
+// ServeRemote accepts connections from remote clients (see
+// [RunRemoteTerminal]) on the given listener and runs a separate application
+// for each of them, using [NewRemoteScreen]. The application is created by
+// the given function, which is called for every client. This function returns
+// when the listener fails, e.g. because it was closed. Running applications
+// are not stopped.
+func ServeRemote(listener net.Listener, newApplication func() *Application) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+		go func() {
+			screen, err := NewRemoteScreen(conn)
+			if err != nil {
+				conn.Close()
+				return
+			}
+			newApplication().SetScreen(screen).Run()
+		}()
+	}
+}
+
+// receiveLoop receives the client's messages until the connection ends.
+func (t *remoteTty) receiveLoop() {
+	defer close(t.input)
+	for {
+		kind, payload, err := t.conn.receive()
+		if err != nil {
+			t.Lock()
+			t.err = err
+			t.Unlock()
+			return
+		}
+		switch kind {
+		case remoteResize:
+			if len(payload) != 4 {
+				continue
+			}
+			t.Lock()
+			t.size = decodeRemoteSize(payload)
+			if t.resize != nil {
+				select {
+				case t.resize <- true:
+				default:
+				}
+			}
+			t.Unlock()
+		case remoteInput:
+			select {
+			case t.input <- payload:
+			case <-t.done:
+				return
+			}
+		}
+	}
+}
+
+// Start is called when tcell starts using the terminal.
+func (t *remoteTty) Start() error {
+	t.Lock()
+	defer t.Unlock()
+	select {
+	case <-t.wake:
+		t.wake = make(chan struct{}) // Drained before.
+	default:
+	}
+	return nil
+}
+
+// Stop is called when tcell stops using the terminal.
+func (t *remoteTty) Stop() error {
+	return nil
+}
+
+// Drain wakes up a blocked Read.
+func (t *remoteTty) Drain() error {
+	t.Lock()
+	defer t.Unlock()
+	select {
+	case <-t.wake:
+	default:
+		close(t.wake)
+	}
+	return nil
+}
+
+// NotifyResize sets the channel which is notified when the client's terminal
+// size changes.
+func (t *remoteTty) NotifyResize(resize chan<- bool) {
+	t.Lock()
+	defer t.Unlock()
+	t.resize = resize
+}
+
+// WindowSize returns the client's terminal size.
+func (t *remoteTty) WindowSize() (tty.WindowSize, error) {
+	t.Lock()
+	defer t.Unlock()
+	return t.size, nil
+}
+
+// Read reads input received from the client. It returns no data after Drain
+// and an error when the connection has ended.
+func (t *remoteTty) Read(b []byte) (int, error) {
+	if len(t.pending) == 0 {
+		t.Lock()
+		wake := t.wake
+		t.Unlock()
+		select {
+		case payload, ok := <-t.input:
+			if !ok {
+				t.Lock()
+				defer t.Unlock()
+				return 0, fmt.Errorf("remote client disconnected: %w", t.err)
+			}
+			t.pending = payload
+		case <-wake:
+			return 0, nil
+		}
+	}
+	n := copy(b, t.pending)
+	t.pending = t.pending[n:]
+	return n, nil
+}
+
+// Write sends terminal output to the client.
+func (t *remoteTty) Write(b []byte) (int, error) {
+	if err := t.conn.send(remoteOutput, b); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// Close closes the connection.
+func (t *remoteTty) Close() error {
+	var err error
+	t.closeOnce.Do(func() {
+		close(t.done)
+		err = t.conn.Close()
+	})
+	return err
+}
+
+// RunRemoteTerminal connects the current terminal to an application on a
+// server (see [NewRemoteScreen]) over the given connection. It displays the
+// application's output and sends the terminal's input and size changes to
+// the server. The terminal is put into raw mode until the function returns,
+// which happens when the connection ends. The connection is closed on
+// return. A connection closed by the server is not an error.
+func RunRemoteTerminal(conn net.Conn) error {
+	c := &remoteConn{Conn: conn}
+	defer conn.Close()
+	terminal, err := tty.NewDevTty()
+	if err != nil {
+		return err
+	}
+	defer terminal.Close()
+	if err := terminal.Start(); err != nil {
+		return err
+	}
+	defer func() {
+		terminal.Drain()
+		terminal.Stop()
+	}()
+
+	// Send the terminal's properties and size.
+	hello := os.Getenv("TERM") + "\x00" + os.Getenv("COLORTERM")
+	if err := c.send(remoteHello, []byte(hello)); err != nil {
+		return err
+	}
+	sendSize := func() error {
+		size, err := terminal.WindowSize()
+		if err != nil {
+			return err
+		}
+		return c.send(remoteResize, encodeRemoteSize(size))
+	}
+	if err := sendSize(); err != nil {
+		return err
+	}
+
+	// Forward size changes and input.
+	done := make(chan struct{})
+	defer close(done)
+	resized := make(chan bool, 1)
+	terminal.NotifyResize(resized)
+	defer terminal.NotifyResize(nil)
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-resized:
+				if sendSize() != nil {
+					return
+				}
+			}
+		}
+	}()
+	go func() {
+		buffer := make([]byte, 4096)
+		for {
+			n, err := terminal.Read(buffer)
+			if n > 0 && c.send(remoteInput, buffer[:n]) != nil || err != nil {
+				return
+			}
+			select {
+			case <-done:
+				return
+			default:
+			}
+		}
+	}()
+
+	// Display the output.
+	for {
+		kind, payload, err := c.receive()
+		if errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if kind != remoteOutput {
+			continue
+		}
+		if _, err := terminal.Write(payload); err != nil {
+			return err
+		}
+	}
+}
+
+// encodeRemoteSize returns the payload of a resize message.
+func encodeRemoteSize(size tty.WindowSize) []byte {
+	payload := make([]byte, 4)
+	binary.BigEndian.PutUint16(payload, uint16(min(max(size.Width, 0), 0xffff)))
+	binary.BigEndian.PutUint16(payload[2:], uint16(min(max(size.Height, 0), 0xffff)))
+	return payload
+}
+
+// decodeRemoteSize returns the terminal size of a resize message's payload.
+func decodeRemoteSize(payload []byte) tty.WindowSize {
+	return tty.WindowSize{
+		Width:  int(binary.BigEndian.Uint16(payload)),
+		Height: int(binary.BigEndian.Uint16(payload[2:])),
+	}
+}
@@ -20,6 +20,14 @@ func (a ScrollBarArrows) hasEnd() bool {
 	return a == ScrollBarArrowsEnd || a == ScrollBarArrowsBoth
 }
 
+// ScrollBarOrientation controls whether a scrollBar is vertical or horizontal.
+type ScrollBarOrientation uint8
+
+const (
+	ScrollBarVertical ScrollBarOrientation = iota
+	ScrollBarHorizontal
+)
+
 // TrackClickBehavior configures behavior when clicking scrollBar track cells
 // outside the thumb.
 type TrackClickBehavior uint8
@@ -37,21 +45,30 @@ type ScrollLengths struct {
 
 const subcell = 8
 
-// GlyphSet defines vertical track, arrow, and fractional thumb glyphs.
+// GlyphSet defines track, arrow, and fractional thumb glyphs for vertical and
+// horizontal scrollBars.
 type GlyphSet struct {
-	TrackVertical string
+	TrackVertical   string
+	TrackHorizontal string
 
-	ArrowVerticalStart string
-	ArrowVerticalEnd   string
+	ArrowVerticalStart   string
+	ArrowVerticalEnd     string
+	ArrowHorizontalStart string
+	ArrowHorizontalEnd   string
 
 	ThumbVerticalLower [8]string
 	ThumbVerticalUpper [8]string
+
+	// Horizontal thumb glyphs filled from the left and from the right.
+	ThumbHorizontalLeft  [8]string
+	ThumbHorizontalRight [8]string
 }
 
 // MinimalGlyphSet returns the minimal glyph set (space track, fractional thumbs).
 func MinimalGlyphSet() GlyphSet {
 	g := LegacyComputingGlyphSet()
 	g.TrackVertical = " "
+	g.TrackHorizontal = " "
 	return g
 }
 
@@ -63,33 +80,46 @@ func BoxDrawingGlyphSet() GlyphSet {
 // LegacyComputingGlyphSet returns legacy-computing symbols for full 1/8 fractional fidelity.
 func LegacyComputingGlyphSet() GlyphSet {
 	return GlyphSet{
-		TrackVertical: "│",
+		TrackVertical:   "│",
+		TrackHorizontal: "─",
 
-		ArrowVerticalStart: "▲",
-		ArrowVerticalEnd:   "▼",
+		ArrowVerticalStart:   "▲",
+		ArrowVerticalEnd:     "▼",
+		ArrowHorizontalStart: "◀",
+		ArrowHorizontalEnd:   "▶",
 
-		ThumbVerticalLower: [8]string{"▁", "▂", "▃", "▄", "▅", "▆", "▇", "█"},
+		ThumbVerticalLower: fractionGlyphsVertical,
 		ThumbVerticalUpper: [8]string{"▔", "🮂", "🮃", "▀", "🮄", "🮅", "🮆", "█"},
+
+		ThumbHorizontalLeft:  fractionGlyphsHorizontal,
+		ThumbHorizontalRight: [8]string{"▕", "🮇", "🮈", "▐", "🮉", "🮊", "🮋", "█"},
 	}
 }
 
 // UnicodeGlyphSet returns a standard-unicode-only approximation set.
 func UnicodeGlyphSet() GlyphSet {
 	return GlyphSet{
-		TrackVertical: "│",
+		TrackVertical:   "│",
+		TrackHorizontal: "─",
 
-		ArrowVerticalStart: "▲",
-		ArrowVerticalEnd:   "▼",
+		ArrowVerticalStart:   "▲",
+		ArrowVerticalEnd:     "▼",
+		ArrowHorizontalStart: "◀",
+		ArrowHorizontalEnd:   "▶",
 
-		ThumbVerticalLower: [8]string{"▁", "▂", "▃", "▄", "▅", "▆", "▇", "█"},
+		ThumbVerticalLower: fractionGlyphsVertical,
 		ThumbVerticalUpper: [8]string{"▔", "▔", "▀", "▀", "▀", "▀", "█", "█"},
+
+		ThumbHorizontalLeft:  fractionGlyphsHorizontal,
+		ThumbHorizontalRight: [8]string{"▕", "▕", "▐", "▐", "▐", "▐", "█", "█"},
 	}
 }
 
-// ScrollBar renders a vertical customizable scrollBar widget.
+// ScrollBar renders a vertical or horizontal customizable scrollBar widget.
 type ScrollBar struct {
 	*Box
 
+	orientation ScrollBarOrientation
 	autoHide    bool
 	contentLen  int
 	viewportLen int
@@ -105,6 +135,11 @@ type ScrollBar struct {
 	trackClickBehavior TrackClickBehavior
 	scrollStep         int
 
+	// The page size for track clicks as a fraction of the viewport, and the
+	// number of logical units by which consecutive pages overlap.
+	pageFraction float64
+	pageOverlap  int
+
 	showTrack bool
 }
 
@@ -120,6 +155,7 @@ func NewScrollBar() *ScrollBar {
 		arrows:             ScrollBarArrowsNone,
 		trackClickBehavior: TrackClickBehaviorPage,
 		scrollStep:         1,
+		pageFraction:       1,
 		showTrack:          true,
 	}
 }
@@ -129,6 +165,25 @@ func NewVerticalScrollBar(lengths ScrollLengths) *ScrollBar {
 	return NewScrollBar().SetLengths(lengths)
 }
 
+// NewHorizontalScrollBar creates a horizontal scrollBar from lengths.
+func NewHorizontalScrollBar(lengths ScrollLengths) *ScrollBar {
+	return NewScrollBar().SetOrientation(ScrollBarHorizontal).SetLengths(lengths)
+}
+
+// SetOrientation sets whether the scrollBar is vertical (the default) or
+// horizontal.
+func (s *ScrollBar) SetOrientation(orientation ScrollBarOrientation) *ScrollBar {
+	if s.orientation != orientation {
+		s.orientation = orientation
+	}
+	return s
+}
+
+// GetOrientation returns whether the scrollBar is vertical or horizontal.
+func (s *ScrollBar) GetOrientation() ScrollBarOrientation {
+	return s.orientation
+}
+
 // SetLengths sets content and viewport lengths.
 func (s *ScrollBar) SetLengths(lengths ScrollLengths) *ScrollBar {
 	s.contentLen = max(lengths.ContentLen, 0)
@@ -175,6 +230,21 @@ func (s *ScrollBar) SetScrollStep(step int) *ScrollBar {
 	return s
 }
 
+// SetPageSize sets the distance scrolled by clicking the track with
+// [TrackClickBehaviorPage] as a fraction of the viewport length, minus an
+// overlap in logical units which remains visible after paging. For example, a
+// fraction of 0.9 keeps a tenth of the viewport visible and a fraction of 1
+// with an overlap of 2 keeps two lines visible. Pages are at least one unit
+// long. The default is exactly one viewport.
+func (s *ScrollBar) SetPageSize(fraction float64, overlap int) *ScrollBar {
+	if fraction <= 0 || fraction > 1 {
+		fraction = 1
+	}
+	s.pageFraction = fraction
+	s.pageOverlap = max(overlap, 0)
+	return s
+}
+
 // SetAutoHide controls whether the scrollBar is hidden when there is nothing to scroll.
 func (s *ScrollBar) SetAutoHide(autoHide bool) *ScrollBar {
 	if s.autoHide != autoHide {
@@ -188,6 +258,8 @@ func (s *ScrollBar) SetThumbGlyph(glyph string) *ScrollBar {
 	for i := range len(s.glyphSet.ThumbVerticalLower) {
 		s.glyphSet.ThumbVerticalLower[i] = glyph
 		s.glyphSet.ThumbVerticalUpper[i] = glyph
+		s.glyphSet.ThumbHorizontalLeft[i] = glyph
+		s.glyphSet.ThumbHorizontalRight[i] = glyph
 	}
 	return s
 }
@@ -200,9 +272,14 @@ func (s *ScrollBar) SetThumbStyle(style tcell.Style) *ScrollBar {
 	return s
 }
 
-// SetTrackGlyph sets the track symbol and visibility.
+// SetTrackGlyph sets the track symbol for the scrollBar's current orientation
+// and the track visibility.
 func (s *ScrollBar) SetTrackGlyph(glyph string, visible bool) *ScrollBar {
-	s.glyphSet.TrackVertical = glyph
+	if s.orientation == ScrollBarHorizontal {
+		s.glyphSet.TrackHorizontal = glyph
+	} else {
+		s.glyphSet.TrackVertical = glyph
+	}
 	s.showTrack = visible
 	return s
 }
@@ -311,6 +388,23 @@ func cellFill(m scrollMetrics, cellIndex int) (start int, fillLen int) {
 	return start, fillLen
 }
 
+func (s *ScrollBar) glyphForHorizontal(start, fillLen int) (string, tcell.Style) {
+	if fillLen <= 0 {
+		if !s.showTrack {
+			return " ", s.trackStyle
+		}
+		return s.glyphSet.TrackHorizontal, s.trackStyle
+	}
+	if fillLen >= subcell {
+		return s.glyphSet.ThumbHorizontalLeft[7], s.thumbStyle
+	}
+	ix := fillLen - 1
+	if start == 0 {
+		return s.glyphSet.ThumbHorizontalLeft[ix], s.thumbStyle
+	}
+	return s.glyphSet.ThumbHorizontalRight[ix], s.thumbStyle
+}
+
 func (s *ScrollBar) glyphForVertical(start, fillLen int) (string, tcell.Style) {
 	if fillLen <= 0 {
 		if !s.showTrack {
@@ -329,39 +423,122 @@ func (s *ScrollBar) glyphForVertical(start, fillLen int) (string, tcell.Style) {
 }
 
 func (s *ScrollBar) put(screen tcell.Screen, x, y, index int, glyph string, style tcell.Style) {
+	if s.orientation == ScrollBarHorizontal {
+		screen.Put(x+index, y, glyph, style)
+		return
+	}
 	screen.Put(x, y+index, glyph, style)
 }
 
+// pageLength returns the distance scrolled by one page, given the number of
+// cells along the scrollBar. See [ScrollBar.SetPageSize].
+func (s *ScrollBar) pageLength(length int) int {
+	page := int(float64(s.viewportLength(length)) * s.pageFraction)
+	return max(page-s.pageOverlap, 1)
+}
+
+// length returns the number of cells along the scrollBar.
+func (s *ScrollBar) length() int {
+	_, _, width, height := s.GetInnerRect()
+	if s.orientation == ScrollBarHorizontal {
+		return width
+	}
+	return height
+}
+
 // Draw draws the scrollBar.
 func (s *ScrollBar) Draw(screen tcell.Screen) {
 	s.DrawForSubclass(screen, s)
 
-	x, y, _, height := s.GetInnerRect()
-	if height <= 0 {
+	x, y, _, _ := s.GetInnerRect()
+	length := s.length()
+	if length <= 0 {
 		return
 	}
-	length := height
 	m := s.metrics(length)
 	if !s.shouldDraw(length, m) {
 		return
 	}
 
+	arrowStart, arrowEnd := s.glyphSet.ArrowVerticalStart, s.glyphSet.ArrowVerticalEnd
+	glyphFor := s.glyphForVertical
+	if s.orientation == ScrollBarHorizontal {
+		arrowStart, arrowEnd = s.glyphSet.ArrowHorizontalStart, s.glyphSet.ArrowHorizontalEnd
+		glyphFor = s.glyphForHorizontal
+	}
+
 	idx := 0
 	if s.arrows.hasStart() {
-		s.put(screen, x, y, idx, s.glyphSet.ArrowVerticalStart, s.arrowStyle)
+		s.put(screen, x, y, idx, arrowStart, s.arrowStyle)
 		idx++
 	}
 
 	for cell := 0; cell < m.trackCells; cell++ {
 		start, fillLen := cellFill(m, cell)
-		glyph, style := s.glyphForVertical(start, fillLen)
+		glyph, style := glyphFor(start, fillLen)
 		s.put(screen, x, y, idx, glyph, style)
 		idx++
 	}
 
 	if s.arrows.hasEnd() {
-		s.put(screen, x, y, idx, s.glyphSet.ArrowVerticalEnd, s.arrowStyle)
+		s.put(screen, x, y, idx, arrowEnd, s.arrowStyle)
+	}
+}
+
+// The parts of a scrollBar, see [ScrollBar.partAt].
+const (
+	scrollBarPartNone = iota
+	scrollBarPartStartArrow
+	scrollBarPartEndArrow
+	scrollBarPartTrackBefore // The track before the thumb.
+	scrollBarPartThumb
+	scrollBarPartTrackAfter // The track after the thumb.
+)
+
+// partAt returns the part of the scrollBar at the given cell index along the
+// bar, based on its current rect, lengths, and offset. It also returns the
+// position on the track in subcells (the center of the cell), clamped to the
+// track.
+func (s *ScrollBar) partAt(index int) (part, trackPos int) {
+	length := s.length()
+	m := s.metrics(length)
+	if m.trackCells == 0 || index < 0 || index >= length {
+		return scrollBarPartNone, 0
+	}
+	trackIndex := index
+	if s.arrows.hasStart() {
+		trackIndex--
+	}
+	trackPos = min(max(trackIndex, 0), m.trackCells-1)*subcell + subcell/2
+	switch {
+	case trackIndex < 0:
+		return scrollBarPartStartArrow, trackPos
+	case trackIndex >= m.trackCells:
+		if s.arrows.hasEnd() && trackIndex == m.trackCells {
+			return scrollBarPartEndArrow, trackPos
+		}
+		return scrollBarPartNone, trackPos
+	case trackPos < m.thumbStart:
+		return scrollBarPartTrackBefore, trackPos
+	case trackPos >= m.thumbStart+m.thumbLen:
+		return scrollBarPartTrackAfter, trackPos
+	}
+	return scrollBarPartThumb, trackPos
+}
+
+// offsetForThumb returns the content offset at which the thumb starts at the
+// given track position in subcells, based on the scrollBar's current rect and
+// lengths.
+func (s *ScrollBar) offsetForThumb(thumbStart int) int {
+	length := s.length()
+	m := s.metrics(length)
+	maxOffset := max(s.contentLen-min(s.viewportLength(length), s.contentLen), 0)
+	thumbTravel := max(m.trackLen-m.thumbLen, 0)
+	if maxOffset == 0 || thumbTravel == 0 {
+		return 0
 	}
+	thumbStart = min(max(thumbStart, 0), thumbTravel)
+	return (thumbStart*maxOffset + thumbTravel/2) / thumbTravel
 }
 
 var _ Primitive = &ScrollBar{}
@@ -0,0 +1,250 @@
+package tview
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// The scores of [FuzzyMatch].
+const (
+	// The score of each matched character.
+	fuzzyScoreMatch = 16
+
+	// The bonus for a matched character which directly follows the previous
+	// matched character.
+	fuzzyBonusConsecutive = 8
+
+	// The bonus for a matched character at the start of a word, e.g. after a
+	// space or an underscore, or at a lower-to-upper case change.
+	fuzzyBonusBoundary = 8
+
+	// The additional bonus for a match of the first character of the text.
+	fuzzyBonusFirst = 8
+
+	// The penalty for each unmatched character between the first and the last
+	// matched character.
+	fuzzyPenaltyGap = 1
+)
+
+// strippedLetters maps letters which have no Unicode decomposition to the base
+// letters they are commonly searched for with.
+var strippedLetters = map[rune]rune{
+	'ø': 'o', 'Ø': 'O',
+	'ł': 'l', 'Ł': 'L',
+	'đ': 'd', 'Đ': 'D',
+	'ħ': 'h', 'Ħ': 'H',
+}
+
+// diacriticVariants maps base letters to the Latin letters with diacritics
+// which are stripped to them, e.g. 'e' to "éèêë...".
+var diacriticVariants = sync.OnceValue(func() map[rune][]rune {
+	variants := make(map[rune][]rune)
+	for _, bounds := range [][2]rune{{0xc0, 0x24f}, {0x1e00, 0x1eff}} {
+		for r := bounds[0]; r <= bounds[1]; r++ {
+			if base := stripRune(r); base != r {
+				variants[base] = append(variants[base], r)
+			}
+		}
+	}
+	return variants
+})
+
+// stripRune returns the given rune without diacritics.
+func stripRune(r rune) rune {
+	if r < 0xc0 {
+		return r
+	}
+	if base, ok := strippedLetters[r]; ok {
+		return base
+	}
+	decomposed := norm.NFD.String(string(r))
+	base, size := utf8.DecodeRuneInString(decomposed)
+	if size == len(decomposed) {
+		return r
+	}
+	for _, mark := range decomposed[size:] {
+		if !unicode.Is(unicode.Mn, mark) {
+			return r
+		}
+	}
+	return base
+}
+
+// foldRune returns the given rune without diacritics and case-folded.
+func foldRune(r rune) rune {
+	return unicode.ToLower(unicode.ToUpper(stripRune(r)))
+}
+
+// FoldCase returns the text with each character case-folded, such that two
+// texts which only differ in letter case are equal after folding. Unlike
+// [strings.ToLower], this also folds characters like the Kelvin sign. Each
+// character is folded individually, so folding never changes the number of
+// characters.
+func FoldCase(text string) string {
+	return strings.Map(func(r rune) rune {
+		return unicode.ToLower(unicode.ToUpper(r))
+	}, text)
+}
+
+// StripDiacritics returns the text with diacritics removed from its letters,
+// e.g. "Crème brûlée" becomes "Creme brulee". Some letters without a Unicode
+// decomposition, such as "ø" and "ł", are also mapped to their base letters.
+// Each character is mapped individually, so the number of characters never
+// changes.
+func StripDiacritics(text string) string {
+	return strings.Map(stripRune, text)
+}
+
+// Fold returns the text with diacritics removed and case-folded. Combining
+// marks are removed, too, so decomposed text like "cre\u0301me" folds to
+// "creme". Texts which are equal after folding are considered equal by the
+// package's search features, e.g. [ContainsFold], [FuzzyMatch], the filter of
+// [DropDown], and [TextView.Search] with both [SearchOptions.CaseInsensitive]
+// and [SearchOptions.IgnoreDiacritics].
+func Fold(text string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.Is(unicode.Mn, r) {
+			return -1
+		}
+		return foldRune(r)
+	}, text)
+}
+
+// ContainsFold returns whether the text contains the query, ignoring letter
+// case and diacritics. An empty query is contained in every text.
+func ContainsFold(text, query string) bool {
+	return strings.Contains(Fold(text), Fold(query))
+}
+
+// HasPrefixFold returns whether the text begins with the query, ignoring
+// letter case and diacritics.
+func HasPrefixFold(text, query string) bool {
+	return strings.HasPrefix(Fold(text), Fold(query))
+}
+
+// FuzzyMatch matches the query as a subsequence of the text, ignoring letter
+// case and diacritics, e.g. "fb" matches "FooBar". It returns whether the
+// query matches, a score which ranks better matches higher, and the byte
+// offsets of the matched characters in the text, e.g. to highlight them.
+// Matches score higher if their characters are consecutive, at the start of
+// words, or at the start of the text, and lower if they are spread out. An
+// empty query matches every text with a score of 0.
+func FuzzyMatch(text, query string) (score int, positions []int, ok bool) {
+	if query == "" {
+		return 0, nil, true
+	}
+	type character struct {
+		rune, folded rune
+		offset       int
+	}
+	var characters []character
+	for offset, r := range text {
+		characters = append(characters, character{rune: r, folded: foldRune(r), offset: offset})
+	}
+	var pattern []rune
+	for _, r := range query {
+		pattern = append(pattern, foldRune(r))
+	}
+
+	// Find the first end of a match, then the latest start of a match ending
+	// there, to keep the matched characters close together.
+	end, p := -1, 0
+	for index, c := range characters {
+		if c.folded == pattern[p] {
+			p++
+			if p == len(pattern) {
+				end = index
+				break
+			}
+		}
+	}
+	if end < 0 {
+		return 0, nil, false
+	}
+	start := end
+	for p = len(pattern) - 1; ; start-- {
+		if characters[start].folded == pattern[p] {
+			if p == 0 {
+				break
+			}
+			p--
+		}
+	}
+
+	// Score the match.
+	p, previous := 0, -1
+	for index := start; index <= end && p < len(pattern); index++ {
+		c := characters[index]
+		if c.folded != pattern[p] {
+			continue
+		}
+		score += fuzzyScoreMatch
+		if index == 0 {
+			score += fuzzyBonusFirst + fuzzyBonusBoundary
+		} else if before := characters[index-1].rune; !unicode.IsLetter(before) && !unicode.IsDigit(before) || unicode.IsLower(before) && unicode.IsUpper(c.rune) {
+			score += fuzzyBonusBoundary
+		}
+		if previous >= 0 {
+			if index == previous+1 {
+				score += fuzzyBonusConsecutive
+			} else {
+				score -= (index - previous - 1) * fuzzyPenaltyGap
+			}
+		}
+		positions = append(positions, c.offset)
+		previous = index
+		p++
+	}
+	return score, positions, true
+}
+
+// foldPattern returns a regular expression which matches the given literal
+// text. If caseInsensitive is true, each letter also matches its other cases,
+// as folded by [FoldCase]. If diacritics is true, each letter also matches its
+// variants with diacritics and may be followed by combining marks, while
+// combining marks in the text are ignored, as folded by [Fold].
+func foldPattern(text string, caseInsensitive, diacritics bool) string {
+	variants := diacriticVariants()
+	var pattern strings.Builder
+	for _, r := range text {
+		if diacritics && unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		base := r
+		if diacritics {
+			base = stripRune(r)
+		}
+		cases := []rune{base}
+		if caseInsensitive {
+			for c := unicode.SimpleFold(base); c != base; c = unicode.SimpleFold(c) {
+				cases = append(cases, c)
+			}
+		}
+		var class []rune
+		for _, c := range cases {
+			class = append(class, c)
+			if diacritics {
+				class = append(class, variants[c]...)
+			}
+		}
+		if len(class) == 1 {
+			pattern.WriteString(regexp.QuoteMeta(string(r)))
+		} else {
+			pattern.WriteRune('[')
+			for _, c := range class {
+				fmt.Fprintf(&pattern, `\x{%x}`, c)
+			}
+			pattern.WriteRune(']')
+		}
+		if diacritics && unicode.IsLetter(r) {
+			pattern.WriteString(`\p{Mn}*`)
+		}
+	}
+	return pattern.String()
+}
@@ -1,6 +1,10 @@
 package tview
 
-import "github.com/gdamore/tcell/v3"
+import (
+	"math"
+
+	"github.com/gdamore/tcell/v3"
+)
 
 // Semigraphics provides easy access to Unicode characters for drawing.
 // Using strings with \u escapes to keep the source ASCII-safe.
@@ -321,3 +325,42 @@ func PrintJoinedSemigraphics(screen tcell.Screen, x, y int, str string, style tc
 	// We only print something if we have something.
 	screen.Put(x, y, result, style)
 }
+
+// Eighth-block glyphs filling a cell from the bottom (vertical) and from the
+// left (horizontal), indexed by the number of filled eighths minus one.
+var (
+	fractionGlyphsVertical = [8]string{
+		BlockLowerOneEighthBlock, BlockLowerOneQuarterBlock, BlockLowerThreeEighthsBlock, BlockLowerHalfBlock,
+		BlockLowerFiveEighthsBlock, BlockLowerThreeQuartersBlock, BlockLowerSevenEighthsBlock, BlockFullBlock,
+	}
+	fractionGlyphsHorizontal = [8]string{
+		BlockLeftOneEighthBlock, BlockLeftOneQuarterBlock, BlockLeftThreeEighthsBlock, BlockLeftHalfBlock,
+		BlockLeftFiveEighthsBlock, BlockLeftThreeQuartersBlock, BlockLeftSevenEighthsBlock, BlockFullBlock,
+	}
+)
+
+// FractionGlyph returns the block element which fills a cell to the given
+// fraction, from the bottom for [ScrollBarVertical] and from the left for
+// [ScrollBarHorizontal]. The fraction is rounded to the nearest eighth and
+// clamped to [0, 1]. A space is returned if nothing is filled. These are the
+// same glyphs which scroll bars use for their thumbs, which makes the function
+// suitable for progress bars, gauges, and other meters:
+//
+//	filled := fraction * float64(width)
+//	for index := range width {
+//		glyph := FractionGlyph(filled-float64(index), ScrollBarHorizontal)
+//		screen.Put(x+index, y, glyph, style)
+//	}
+func FractionGlyph(fraction float64, orientation ScrollBarOrientation) string {
+	if math.IsNaN(fraction) {
+		return " "
+	}
+	eighths := int(math.Round(min(max(fraction, 0), 1) * 8))
+	if eighths == 0 {
+		return " "
+	}
+	if orientation == ScrollBarHorizontal {
+		return fractionGlyphsHorizontal[eighths-1]
+	}
+	return fractionGlyphsVertical[eighths-1]
+}
@@ -0,0 +1,412 @@
+package tview
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+// Slider implements a horizontal track for choosing a number from a range,
+// e.g. a volume or a percentage. The value is shown to the right of the track.
+//
+// The following keys are available:
+//
+//   - Left arrow, h, Down arrow: Decrease the value by one step.
+//   - Right arrow, l, Up arrow: Increase the value by one step.
+//   - Page Down / Page Up: Decrease/increase the value by ten steps.
+//   - Home / End: Set the value to the minimum/maximum.
+//   - Tab, Backtab, Escape, Enter: Finish, e.g. to move to the next form item.
+//
+// Clicking or dragging on the track sets the value.
+type Slider struct {
+	*Box
+
+	// Whether or not this slider is disabled/read-only.
+	disabled bool
+
+	// The range of values.
+	min, max float64
+
+	// The step size. Values are multiples of the step size, counted from the
+	// minimum. A value of 0 means no steps.
+	step float64
+
+	// The current value.
+	value float64
+
+	// The text to be displayed before the track.
+	label string
+
+	// The screen width of the label area. A value of 0 means use the width of
+	// the label text.
+	labelWidth int
+
+	// The screen width of the track and the value. A value of 0 means use all
+	// available width.
+	fieldWidth int
+
+	// The label style.
+	labelStyle tcell.Style
+
+	// The style of the track and of the track when it has focus.
+	fieldStyle, focusStyle tcell.Style
+
+	// Formats the value shown next to the track. If nil, the value is
+	// formatted with the fewest digits needed.
+	format func(value float64) string
+
+	// The track's screen area as of the last draw.
+	trackX, trackWidth int
+
+	// Whether the user is dragging the knob.
+	dragging bool
+
+	// An optional function which is called when the value changes.
+	changed func(value float64)
+
+	// An optional function which is called when the user indicated that they
+	// are done. The key which was pressed is provided (tab, shift-tab, enter,
+	// or escape).
+	done func(tcell.Key)
+
+	// A callback function set by the Form class and called when the user leaves
+	// this form item.
+	finished func(tcell.Key)
+}
+
+// NewSlider returns a new slider for values from 0 to 100 in steps of 1.
+func NewSlider() *Slider {
+	return &Slider{
+		Box:        NewBox(),
+		max:        100,
+		step:       1,
+		labelStyle: tcell.StyleDefault.Foreground(Styles.SecondaryTextColor),
+		fieldStyle: tcell.StyleDefault.Background(Styles.ContrastBackgroundColor).Foreground(Styles.PrimaryTextColor),
+		focusStyle: tcell.StyleDefault.Background(Styles.PrimaryTextColor).Foreground(Styles.ContrastBackgroundColor),
+	}
+}
+
+// applyTheme updates the slider's default styles after a theme change.
+func (s *Slider) applyTheme(change themeChange) {
+	s.Box.applyTheme(change)
+	s.labelStyle = change.style(s.labelStyle, themeSecondaryText, 0)
+	s.fieldStyle = change.style(s.fieldStyle, themePrimaryText, themeContrastBackground)
+	s.focusStyle = change.style(s.focusStyle, themeContrastBackground, themePrimaryText)
+}
+
+// SetRange sets the range of values and the step size. Values are multiples of
+// the step size, counted from the minimum. A step size of 0 allows any value.
+// The current value is adjusted to the new range.
+func (s *Slider) SetRange(min, max, step float64) *Slider {
+	if max < min {
+		min, max = max, min
+	}
+	s.min, s.max, s.step = min, max, math.Abs(step)
+	s.value = s.clamp(s.value)
+	return s
+}
+
+// GetRange returns the range of values and the step size.
+func (s *Slider) GetRange() (min, max, step float64) {
+	return s.min, s.max, s.step
+}
+
+// SetValue sets the value, adjusted to the range and the step size. This
+// triggers the "changed" callback if the value changes.
+func (s *Slider) SetValue(value float64) *Slider {
+	value = s.clamp(value)
+	if value == s.value {
+		return s
+	}
+	s.value = value
+	if s.changed != nil {
+		s.changed(value)
+	}
+	return s
+}
+
+// GetValue returns the current value.
+func (s *Slider) GetValue() float64 {
+	return s.value
+}
+
+// GetText returns the current value as it is shown next to the track.
+func (s *Slider) GetText() string {
+	return s.formatValue(s.value)
+}
+
+// formatValue formats the given value for display next to the track.
+func (s *Slider) formatValue(value float64) string {
+	if s.format != nil {
+		return s.format(value)
+	}
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// SetFormatFunc sets a function which formats the value shown next to the
+// track, e.g. to add a unit. A nil function restores the default format.
+func (s *Slider) SetFormatFunc(format func(value float64) string) *Slider {
+	s.format = format
+	return s
+}
+
+// SetLabel sets the text to be displayed before the track.
+func (s *Slider) SetLabel(label string) *Slider {
+	s.label = label
+	return s
+}
+
+// GetLabel returns the text to be displayed before the track.
+func (s *Slider) GetLabel() string {
+	return s.label
+}
+
+// SetLabelWidth sets the screen width of the label. A value of 0 will cause the
+// primitive to use the width of the label string.
+func (s *Slider) SetLabelWidth(width int) *Slider {
+	s.labelWidth = width
+	return s
+}
+
+// SetLabelStyle sets the style of the label.
+func (s *Slider) SetLabelStyle(style tcell.Style) *Slider {
+	s.labelStyle = style
+	return s
+}
+
+// SetFieldWidth sets the screen width of the track including the value shown
+// next to it. A value of 0 means the track uses all available width.
+func (s *Slider) SetFieldWidth(width int) *Slider {
+	s.fieldWidth = width
+	return s
+}
+
+// SetFieldStyle sets the style of the track.
+func (s *Slider) SetFieldStyle(style tcell.Style) *Slider {
+	s.fieldStyle = style
+	return s
+}
+
+// SetActivatedStyle sets the style of the track while the slider has focus.
+func (s *Slider) SetActivatedStyle(style tcell.Style) *Slider {
+	s.focusStyle = style
+	return s
+}
+
+// SetChangedFunc sets a handler which is called when the value changes.
+func (s *Slider) SetChangedFunc(handler func(value float64)) *Slider {
+	s.changed = handler
+	return s
+}
+
+// SetDoneFunc sets a handler which is called when the user is done using the
+// slider. The callback function is provided with the key that was pressed,
+// which is one of the following:
+//
+//   - KeyEscape: Abort.
+//   - KeyEnter: Done.
+//   - KeyTab: Move to the next field.
+//   - KeyBacktab: Move to the previous field.
+func (s *Slider) SetDoneFunc(handler func(key tcell.Key)) *Slider {
+	s.done = handler
+	return s
+}
+
+// SetFinishedFunc sets a callback invoked when the user leaves this form item.
+func (s *Slider) SetFinishedFunc(handler func(key tcell.Key)) FormItem {
+	s.finished = handler
+	return s
+}
+
+// SetFormAttributes sets attributes shared by all form items.
+func (s *Slider) SetFormAttributes(labelWidth int, labelColor, bgColor, fieldTextColor, fieldBgColor tcell.Color) FormItem {
+	s.labelWidth = labelWidth
+	s.labelStyle = s.labelStyle.Foreground(labelColor)
+	s.backgroundColor = bgColor
+	s.fieldStyle = s.fieldStyle.Foreground(fieldTextColor).Background(fieldBgColor)
+	s.focusStyle = s.focusStyle.Foreground(fieldBgColor).Background(fieldTextColor)
+	return s
+}
+
+// GetFieldWidth returns this primitive's field width.
+func (s *Slider) GetFieldWidth() int {
+	return s.fieldWidth
+}
+
+// GetFieldHeight returns this primitive's field height.
+func (s *Slider) GetFieldHeight() int {
+	return 1
+}
+
+// SetDisabled sets whether or not the item is disabled / read-only.
+func (s *Slider) SetDisabled(disabled bool) FormItem {
+	s.disabled = disabled
+	if s.finished != nil {
+		s.finished(-1)
+	}
+	return s
+}
+
+// GetDisabled returns whether or not the item is disabled / read-only.
+func (s *Slider) GetDisabled() bool {
+	return s.disabled
+}
+
+// Focus is called when this primitive receives focus.
+func (s *Slider) Focus(delegate func(p Primitive)) {
+	// If we're part of a form and this item is disabled, there's nothing the
+	// user can do here so we're finished.
+	if s.finished != nil && s.disabled {
+		s.finished(-1)
+		return
+	}
+	s.Box.Focus(delegate)
+}
+
+// clamp returns the given value snapped to the step size and limited to the
+// range.
+func (s *Slider) clamp(value float64) float64 {
+	if s.step > 0 {
+		value = s.min + math.Round((value-s.min)/s.step)*s.step
+		if value > s.max {
+			value -= s.step
+		}
+	}
+	return math.Max(s.min, math.Min(s.max, value))
+}
+
+// Draw draws this primitive onto the screen.
+func (s *Slider) Draw(screen tcell.Screen) {
+	s.DrawForSubclass(screen, s)
+	x, y, width, height := s.GetInnerRect()
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	// Draw the label.
+	x, width = printLabel(screen, s.label, s.labelWidth, s.labelStyle, x, y, width)
+
+	// Draw the track and the value. The space for the value fits the minimum
+	// and the maximum so that the track doesn't change its width.
+	text := " " + s.GetText()
+	textWidth := max(TaggedStringWidth(text), TaggedStringWidth(s.formatValue(s.min))+1, TaggedStringWidth(s.formatValue(s.max))+1)
+	if s.fieldWidth > 0 {
+		width = min(width, s.fieldWidth)
+	}
+	s.trackX, s.trackWidth = x, width-textWidth
+	if s.trackWidth <= 0 {
+		s.trackWidth = 0
+		return
+	}
+	style := s.fieldStyle
+	if s.disabled {
+		style = style.Background(s.backgroundColor)
+	}
+	if s.HasFocus() {
+		style = s.focusStyle
+	}
+	filled := s.trackWidth
+	if s.max > s.min {
+		filled = int(math.Round((s.value - s.min) / (s.max - s.min) * float64(s.trackWidth)))
+	}
+	for column := range s.trackWidth {
+		cell := BlockLightShade
+		if column < filled {
+			cell = BlockFullBlock
+		}
+		screen.Put(x+column, y, cell, style)
+	}
+	printWithStyle(screen, text, x+s.trackWidth, y, 0, width-s.trackWidth, AlignmentLeft, s.labelStyle, true)
+}
+
+// valueAt returns the value corresponding to the given screen column of the
+// track.
+func (s *Slider) valueAt(x int) float64 {
+	if s.trackWidth <= 1 {
+		return s.max
+	}
+	fraction := float64(x-s.trackX) / float64(s.trackWidth-1)
+	return s.min + math.Max(0, math.Min(1, fraction))*(s.max-s.min)
+}
+
+// HandleEvent handles input events for this primitive.
+func (s *Slider) HandleEvent(event tcell.Event) Command {
+	if s.disabled {
+		return nil
+	}
+
+	switch event := event.(type) {
+	case *KeyEvent:
+		step := s.step
+		if step == 0 {
+			step = (s.max - s.min) / 100
+		}
+		switch key := event.Key(); key {
+		case tcell.KeyLeft, tcell.KeyDown:
+			s.SetValue(s.value - step)
+		case tcell.KeyRight, tcell.KeyUp:
+			s.SetValue(s.value + step)
+		case tcell.KeyPgDn:
+			s.SetValue(s.value - 10*step)
+		case tcell.KeyPgUp:
+			s.SetValue(s.value + 10*step)
+		case tcell.KeyHome:
+			s.SetValue(s.min)
+		case tcell.KeyEnd:
+			s.SetValue(s.max)
+		case tcell.KeyRune:
+			switch event.Str() {
+			case "h":
+				s.SetValue(s.value - step)
+			case "l":
+				s.SetValue(s.value + step)
+			default:
+				return nil
+			}
+		case tcell.KeyTab, tcell.KeyBacktab, tcell.KeyEscape, tcell.KeyEnter: // We're done.
+			if s.done != nil {
+				s.done(key)
+			}
+			if s.finished != nil {
+				s.finished(key)
+			}
+		default:
+			return nil
+		}
+		return RedrawCommand{}
+	case *MouseEvent:
+		x, y := event.Position()
+		if s.dragging {
+			switch event.Action {
+			case MouseMove:
+				s.SetValue(s.valueAt(x))
+				return BatchCommand{SetMouseCaptureCommand{Target: s}, RedrawCommand{}}
+			case MouseLeftUp:
+				s.dragging = false
+				s.SetValue(s.valueAt(x))
+				return BatchCommand{SetMouseCaptureCommand{Target: nil}, RedrawCommand{}}
+			}
+		}
+		if !s.InRect(x, y) {
+			return nil
+		}
+		if event.Action == MouseLeftDown {
+			if x >= s.trackX && x < s.trackX+s.trackWidth {
+				s.dragging = true
+				s.SetValue(s.valueAt(x))
+				return BatchCommand{SetFocusCommand{Target: s}, SetMouseCaptureCommand{Target: s}, RedrawCommand{}}
+			}
+			return SetFocusCommand{Target: s}
+		}
+	}
+	return nil
+}
+
+// Inspect describes this primitive in the inspection tree. See [Inspect].
+func (s *Slider) Inspect(node *InspectNode) {
+	node.Role = RoleSlider
+	node.Label = s.label
+	node.Value = s.GetText()
+	node.Disabled = s.disabled
+}
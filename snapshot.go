@@ -0,0 +1,67 @@
+package tview
+
+import "github.com/gdamore/tcell/v3"
+
+// SnapshotCell is one cell of a [Snapshot].
+type SnapshotCell struct {
+	// The grapheme cluster shown in the cell, " " for empty cells.
+	Text string
+
+	// The cell's style.
+	Style tcell.Style
+
+	// The number of screen columns occupied by the cell's text.
+	Width int
+}
+
+// Snapshot is a copy of the screen contents after a draw. It can be used to
+// mirror the user interface elsewhere, e.g. to a web view, a recording, or a
+// remote display. See [Application.SetAfterDrawFunc].
+type Snapshot struct {
+	// The size of the screen in cells.
+	Width, Height int
+
+	// The cells, row by row.
+	cells []SnapshotCell
+}
+
+// newSnapshot copies the contents of the given screen.
+func newSnapshot(screen tcell.Screen) *Snapshot {
+	width, height := screen.Size()
+	snapshot := &Snapshot{
+		Width:  width,
+		Height: height,
+		cells:  make([]SnapshotCell, 0, width*height),
+	}
+	for y := range height {
+		for x := range width {
+			text, style, w := screen.Get(x, y)
+			snapshot.cells = append(snapshot.cells, SnapshotCell{Text: text, Style: style, Width: w})
+		}
+	}
+	return snapshot
+}
+
+// Cell returns the cell at the given position. Positions outside the screen
+// return an empty cell.
+func (s *Snapshot) Cell(x, y int) SnapshotCell {
+	if x < 0 || y < 0 || x >= s.Width || y >= s.Height {
+		return SnapshotCell{Text: " ", Width: 1}
+	}
+	return s.cells[y*s.Width+x]
+}
+
+// Range calls the given function for each cell, row by row from the top left
+// corner. Cells covered by the preceding cell of a wide character are
+// skipped. Iteration stops when the function returns false.
+func (s *Snapshot) Range(f func(x, y int, cell SnapshotCell) bool) {
+	for y := range s.Height {
+		for x := 0; x < s.Width; {
+			cell := s.cells[y*s.Width+x]
+			if !f(x, y, cell) {
+				return
+			}
+			x += max(cell.Width, 1)
+		}
+	}
+}
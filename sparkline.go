@@ -0,0 +1,127 @@
+package tview
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+// Sparkline displays a series of values as vertical bars, one column per
+// value, e.g. the request rate over the last minute in a [Dashboard]. The bars
+// fill the sparkline's inner height with a resolution of an eighth of a cell.
+// If there are more values than columns, only the most recent values are
+// drawn, aligned to the right.
+type Sparkline struct {
+	*Box
+
+	// The values, oldest first.
+	values []float64
+
+	// The maximum number of values kept by [Sparkline.AddValue], or 0 for no
+	// limit.
+	capacity int
+
+	// The value of a full bar, or 0 to use the largest drawn value.
+	max float64
+
+	// The style of the bars. They are drawn with the style's foreground color.
+	style tcell.Style
+}
+
+// NewSparkline returns a new sparkline without values. It keeps up to 256
+// values added with [Sparkline.AddValue].
+func NewSparkline() *Sparkline {
+	return &Sparkline{
+		Box:      NewBox(),
+		capacity: 256,
+		style:    tcell.StyleDefault.Foreground(Styles.PrimaryTextColor),
+	}
+}
+
+// applyTheme updates the sparkline's default style after a theme change.
+func (s *Sparkline) applyTheme(change themeChange) {
+	s.Box.applyTheme(change)
+	s.style = change.style(s.style, themePrimaryText, 0)
+}
+
+// SetValues replaces all values, oldest first.
+func (s *Sparkline) SetValues(values []float64) *Sparkline {
+	s.values = append(s.values[:0], values...)
+	return s
+}
+
+// AddValue appends a value. If this exceeds the capacity (see
+// [Sparkline.SetCapacity]), the oldest value is removed.
+func (s *Sparkline) AddValue(value float64) *Sparkline {
+	s.values = append(s.values, value)
+	if s.capacity > 0 && len(s.values) > s.capacity {
+		s.values = append(s.values[:0], s.values[len(s.values)-s.capacity:]...)
+	}
+	return s
+}
+
+// GetValues returns the values, oldest first.
+func (s *Sparkline) GetValues() []float64 {
+	return s.values
+}
+
+// SetCapacity sets the maximum number of values kept by [Sparkline.AddValue].
+// A value of 0 means that values are never removed.
+func (s *Sparkline) SetCapacity(capacity int) *Sparkline {
+	s.capacity = max(capacity, 0)
+	return s
+}
+
+// SetMax sets the value of a full bar. If set to 0 (the default), the largest
+// drawn value is used. Negative values are treated as 0.
+func (s *Sparkline) SetMax(max float64) *Sparkline {
+	s.max = math.Max(max, 0)
+	return s
+}
+
+// SetStyle sets the style of the bars. They are drawn with the style's
+// foreground color on the box's background color.
+func (s *Sparkline) SetStyle(style tcell.Style) *Sparkline {
+	s.style = style
+	return s
+}
+
+// Draw draws this primitive onto the screen.
+func (s *Sparkline) Draw(screen tcell.Screen) {
+	s.DrawForSubclass(screen, s)
+	x, y, width, height := s.GetInnerRect()
+	if width <= 0 || height <= 0 || len(s.values) == 0 {
+		return
+	}
+
+	values := s.values[max(len(s.values)-width, 0):]
+	top := s.max
+	if top == 0 {
+		for _, value := range values {
+			top = math.Max(top, value)
+		}
+	}
+	if top <= 0 {
+		return
+	}
+
+	style := s.style.Background(s.backgroundColor)
+	x += width - len(values)
+	for column, value := range values {
+		filled := min(max(value/top, 0), 1) * float64(height)
+		for row := range height {
+			glyph := FractionGlyph(filled-float64(row), ScrollBarVertical)
+			screen.Put(x+column, y+height-1-row, glyph, style)
+		}
+	}
+}
+
+// Inspect describes this primitive in the inspection tree. See [Inspect].
+func (s *Sparkline) Inspect(node *InspectNode) {
+	s.Box.Inspect(node)
+	node.Role = RoleImage
+	if len(s.values) > 0 {
+		node.Value = strconv.FormatFloat(s.values[len(s.values)-1], 'g', -1, 64)
+	}
+}
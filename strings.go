@@ -11,6 +11,11 @@ import (
 type Segment struct {
 	Text  string
 	Style tcell.Style
+
+	// The ID of the region this segment belongs to, if any. Adjacent segments
+	// with the same region ID form one region. Regions can be highlighted, see
+	// e.g. [TextView.Highlight].
+	Region string
 }
 
 // NewSegment returns a styled segment.
@@ -18,6 +23,12 @@ func NewSegment(text string, style tcell.Style) Segment {
 	return Segment{Text: text, Style: style}
 }
 
+// WithRegion sets the ID of the region the segment belongs to.
+func (s Segment) WithRegion(region string) Segment {
+	s.Region = region
+	return s
+}
+
 // Line is a list of styled segments with indent used on softwrapping.
 type Line struct {
 	Segments []Segment
@@ -31,6 +42,33 @@ func (l Line) Clone() Line {
 	return out
 }
 
+// String returns the unstyled text of the line's segments.
+func (l Line) String() string {
+	var b strings.Builder
+	for _, segment := range l.Segments {
+		b.WriteString(segment.Text)
+	}
+	return b.String()
+}
+
+// slice returns the part of the line between the given byte offsets of its
+// unstyled text (see [Line.String]).
+func (l Line) slice(start, end int) Line {
+	var (
+		out    Line
+		offset int
+	)
+	for _, segment := range l.Segments {
+		length := len(segment.Text)
+		if from, to := max(start-offset, 0), min(end-offset, length); from < to {
+			segment.Text = segment.Text[from:to]
+			out.Segments = append(out.Segments, segment)
+		}
+		offset += length
+	}
+	return out
+}
+
 // NewLine returns a line from the provided segments, skipping empty segments.
 func NewLine(segments ...Segment) Line {
 	line := Line{Segments: make([]Segment, 0, len(segments))}
@@ -164,6 +202,12 @@ func (s *stepState) LineBreak() (lineBreak, optional bool) {
 	return false, false
 }
 
+// SentenceBreak returns whether a sentence ends after the returned grapheme
+// cluster.
+func (s *stepState) SentenceBreak() bool {
+	return s.boundaries&uniseg.MaskSentence != 0
+}
+
 // Width returns the grapheme cluster's width in cells.
 func (s *stepState) Width() int {
 	return s.boundaries >> uniseg.ShiftWidth
@@ -256,3 +300,30 @@ func WordWrap(text string, width int) (lines []string) {
 
 	return
 }
+
+// The glyphs which make whitespace visible, see e.g.
+// [TextArea.SetShowWhitespace] and [TextView.SetShowWhitespace]. Tabs are drawn
+// as the tab glyph followed by spaces.
+var (
+	WhitespaceTab              = "\u00bb" // »
+	WhitespaceTrailingSpace    = "\u00b7" // ·
+	WhitespaceNonBreakingSpace = "\u00b0" // °
+)
+
+// whitespaceGlyph returns the glyph which makes the given grapheme cluster
+// visible, or an empty string if it is not visualized. Spaces are only
+// visualized if they are trailing, i.e. only followed by whitespace until the
+// end of the line.
+func whitespaceGlyph(cluster string, trailing bool) string {
+	switch cluster {
+	case "\t":
+		return WhitespaceTab
+	case "\u00a0", "\u202f": // No-break space, narrow no-break space.
+		return WhitespaceNonBreakingSpace
+	case " ":
+		if trailing {
+			return WhitespaceTrailingSpace
+		}
+	}
+	return ""
+}
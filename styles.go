@@ -23,6 +23,12 @@ type Theme struct {
 // Styles defines the theme for applications. The default is for a black
 // background and some basic colors: black, white, yellow, green, cyan, and
 // blue.
+//
+// Primitives derive their default styles from the theme when they are
+// created. When the theme changes at runtime, they update the styles which
+// still have their default values the next time they are drawn. Styles set
+// explicitly by the application are kept. Use [Application.SetTheme] to change
+// the theme of a running application safely.
 var Styles = Theme{
 	PrimitiveBackgroundColor:    color.Black,
 	ContrastBackgroundColor:     color.Blue,
@@ -36,3 +42,87 @@ var Styles = Theme{
 	InverseTextColor:            color.Blue,
 	ContrastSecondaryTextColor:  color.Navy,
 }
+
+// themeField identifies a color of a [Theme].
+type themeField int
+
+// The colors of a theme.
+const (
+	themePrimitiveBackground themeField = iota + 1
+	themeContrastBackground
+	themeMoreContrastBackground
+	themeBorder
+	themeTitle
+	themeGraphics
+	themePrimaryText
+	themeSecondaryText
+	themeTertiaryText
+	themeInverseText
+	themeContrastSecondaryText
+)
+
+// color returns the theme's color for the given field.
+func (t Theme) color(field themeField) tcell.Color {
+	switch field {
+	case themePrimitiveBackground:
+		return t.PrimitiveBackgroundColor
+	case themeContrastBackground:
+		return t.ContrastBackgroundColor
+	case themeMoreContrastBackground:
+		return t.MoreContrastBackgroundColor
+	case themeBorder:
+		return t.BorderColor
+	case themeTitle:
+		return t.TitleColor
+	case themeGraphics:
+		return t.GraphicsColor
+	case themePrimaryText:
+		return t.PrimaryTextColor
+	case themeSecondaryText:
+		return t.SecondaryTextColor
+	case themeTertiaryText:
+		return t.TertiaryTextColor
+	case themeInverseText:
+		return t.InverseTextColor
+	case themeContrastSecondaryText:
+		return t.ContrastSecondaryTextColor
+	}
+	return tcell.ColorDefault
+}
+
+// themeChange describes a change of [Styles]. Primitives derive their default
+// styles from the theme when they are created. When the theme changes, they
+// use a themeChange to update the colors which still have their default
+// values. Colors which differ from the old theme's were set by the application
+// and are kept.
+type themeChange struct {
+	old, new Theme
+}
+
+// color returns the new theme's color for the given field if the given color
+// is the old theme's color for that field. Otherwise, it returns the color
+// unchanged.
+func (c themeChange) color(color tcell.Color, field themeField) tcell.Color {
+	if color == c.old.color(field) {
+		return c.new.color(field)
+	}
+	return color
+}
+
+// style updates the foreground and background colors of the given style like
+// [themeChange.color]. A field of 0 leaves the respective color unchanged.
+func (c themeChange) style(style tcell.Style, foreground, background themeField) tcell.Style {
+	if foreground != 0 {
+		style = style.Foreground(c.color(style.GetForeground(), foreground))
+	}
+	if background != 0 {
+		style = style.Background(c.color(style.GetBackground(), background))
+	}
+	return style
+}
+
+// themedPrimitive is implemented by primitives which update their default
+// styles when the theme changes. All primitives implement it through [Box].
+type themedPrimitive interface {
+	applyTheme(change themeChange)
+}
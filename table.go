@@ -0,0 +1,804 @@
+package tview
+
+import (
+	"github.com/gdamore/tcell/v3"
+)
+
+// TableColumn defines a column of a [Table].
+type TableColumn struct {
+	// The text shown in the header row. If no column has a title, the table
+	// has no header row.
+	Title string
+
+	// The width of the column. If 0, the column is as wide as its title and
+	// the widest of its visible cells.
+	Width int
+
+	// The maximum width of a column without a fixed width. Ignored if 0.
+	MaxWidth int
+
+	// The proportion by which the column grows when all columns fit into the
+	// table and there is space left. A value of 0 means that the column does
+	// not grow.
+	Expansion int
+
+	// The alignment of the title and of cells which don't have their own
+	// alignment (see [TableCell.SetAlign]).
+	Align Alignment
+}
+
+// TableSelectionMode determines what the user can select in a [Table].
+type TableSelectionMode int
+
+// The selection modes of a table.
+const (
+	// Nothing can be selected. The navigation keys scroll the table.
+	TableSelectNone TableSelectionMode = iota
+
+	// Entire rows are selected.
+	TableSelectRows
+
+	// Entire columns are selected.
+	TableSelectColumns
+
+	// Individual cells are selected.
+	TableSelectCells
+)
+
+// tableDrawnColumn is a column placed on screen by the last draw.
+type tableDrawnColumn struct {
+	column, x, width int
+}
+
+// tableDrawnRow is a row placed on screen by the last draw. The header row
+// has the index -1.
+type tableDrawnRow struct {
+	row, y int
+}
+
+// tableKeyActions are the key actions supported by Table.
+var tableKeyActions = keyActionDefaults{
+	KeyActionUp:       {tcell.NewEventKey(tcell.KeyUp, "", tcell.ModNone), tcell.NewEventKey(tcell.KeyRune, "k", tcell.ModNone)},
+	KeyActionDown:     {tcell.NewEventKey(tcell.KeyDown, "", tcell.ModNone), tcell.NewEventKey(tcell.KeyRune, "j", tcell.ModNone)},
+	KeyActionLeft:     {tcell.NewEventKey(tcell.KeyLeft, "", tcell.ModNone), tcell.NewEventKey(tcell.KeyRune, "h", tcell.ModNone)},
+	KeyActionRight:    {tcell.NewEventKey(tcell.KeyRight, "", tcell.ModNone), tcell.NewEventKey(tcell.KeyRune, "l", tcell.ModNone)},
+	KeyActionPageUp:   {tcell.NewEventKey(tcell.KeyPgUp, "", tcell.ModNone)},
+	KeyActionPageDown: {tcell.NewEventKey(tcell.KeyPgDn, "", tcell.ModNone)},
+	KeyActionHome:     {tcell.NewEventKey(tcell.KeyHome, "", tcell.ModNone), tcell.NewEventKey(tcell.KeyRune, "g", tcell.ModNone)},
+	KeyActionEnd:      {tcell.NewEventKey(tcell.KeyEnd, "", tcell.ModNone), tcell.NewEventKey(tcell.KeyRune, "G", tcell.ModNone)},
+	KeyActionActivate: {tcell.NewEventKey(tcell.KeyEnter, "", tcell.ModNone)},
+}
+
+// Table shows the cells of a [TableDataSource] in rows and columns defined
+// with [Table.SetColumns]. Cells are only requested from the data source for
+// the rows which are drawn, so tables may have millions of rows. The header
+// row with the column titles, as well as a number of leading rows and columns
+// (see [Table.SetFixed]), stay in place while the rest of the table scrolls.
+//
+// Depending on the selection mode (see [Table.SetSelectionMode]), the user can
+// select rows, columns, or cells. The following keys are available:
+//
+//   - Up arrow / k, Down arrow / j: Move the selection up or down by one row,
+//     or scroll if no rows can be selected.
+//   - Left arrow / h, Right arrow / l: Move the selection left or right by one
+//     column, or scroll if no columns can be selected.
+//   - Page Up, Page Down: Move the selection or scroll by one page.
+//   - Home / g, End / G: Move the selection or scroll to the first or last row.
+//   - Enter: Activate the selection (see [Table.SetSelectedFunc]).
+//
+// Clicking a cell selects it, double-clicking activates it, and clicking a
+// column title sorts the table by that column if a sort handler is set (see
+// [Table.SetSortFunc]).
+type Table struct {
+	*Box
+
+	// The source of the table's cells.
+	source TableDataSource
+
+	// The column definitions.
+	columns []TableColumn
+
+	// The number of leading rows and columns which don't scroll.
+	fixedRows, fixedColumns int
+
+	// The first row and the first column shown after the fixed ones.
+	rowOffset, columnOffset int
+
+	// What the user can select.
+	selectionMode TableSelectionMode
+
+	// The selected row and column.
+	selectedRow, selectedColumn int
+
+	// Set to true if the selection must be scrolled into view on the next
+	// draw.
+	scrollToSelection bool
+
+	// The string drawn between columns.
+	separator string
+
+	// The styles of the table's parts.
+	cellStyle, headerStyle, selectedStyle, separatorStyle tcell.Style
+
+	// The column the table is sorted by (-1 for none) and its direction.
+	sortColumn    int
+	sortAscending bool
+
+	// An optional function which is called when the user clicks a column
+	// title.
+	sortFunc func(column int, ascending bool)
+
+	// An optional function which is called when the selection changes.
+	selectionChanged func(row, column int)
+
+	// An optional function which is called when the user activates the
+	// selection.
+	selected func(row, column int)
+
+	// When the vertical scroll bar is shown and the scroll bar itself.
+	scrollBarVisibility ScrollBarVisibility
+	scrollBar           *ScrollBar
+
+	// Custom keys for the built-in key actions.
+	keyMap KeyMap
+
+	// The layout of the last draw.
+	drawnColumns []tableDrawnColumn
+	drawnRows    []tableDrawnRow
+
+	// The number of scrolling rows shown by the last draw.
+	pageSize int
+
+	// What is shown while the data source has no rows.
+	placeholder placeholder
+}
+
+// NewTable returns a new table without columns and without data.
+func NewTable() *Table {
+	return &Table{
+		Box:                 NewBox(),
+		source:              NewTableData(),
+		separator:           " ",
+		cellStyle:           tcell.StyleDefault.Background(Styles.PrimitiveBackgroundColor).Foreground(Styles.PrimaryTextColor),
+		headerStyle:         tcell.StyleDefault.Background(Styles.PrimitiveBackgroundColor).Foreground(Styles.SecondaryTextColor).Bold(true),
+		selectedStyle:       tcell.StyleDefault.Background(Styles.PrimaryTextColor).Foreground(Styles.PrimitiveBackgroundColor),
+		separatorStyle:      tcell.StyleDefault.Background(Styles.PrimitiveBackgroundColor).Foreground(Styles.GraphicsColor),
+		sortColumn:          -1,
+		scrollBarVisibility: ScrollBarVisibilityAutomatic,
+		scrollBar:           NewScrollBar().SetArrows(ScrollBarArrowsNone),
+		placeholder:         newPlaceholder(),
+	}
+}
+
+// applyTheme updates the table's default styles after a theme change.
+func (t *Table) applyTheme(change themeChange) {
+	t.Box.applyTheme(change)
+	t.cellStyle = change.style(t.cellStyle, themePrimaryText, themePrimitiveBackground)
+	t.headerStyle = change.style(t.headerStyle, themeSecondaryText, themePrimitiveBackground)
+	t.selectedStyle = change.style(t.selectedStyle, themePrimitiveBackground, themePrimaryText)
+	t.separatorStyle = change.style(t.separatorStyle, themeGraphics, themePrimitiveBackground)
+	t.placeholder.applyTheme(change)
+}
+
+// SetDataSource sets the source of the table's cells. The table keeps no
+// cells itself, so changes of the data source are shown on the next draw.
+func (t *Table) SetDataSource(source TableDataSource) *Table {
+	t.source = source
+	return t
+}
+
+// GetDataSource returns the source of the table's cells. Unless another one
+// was set, this is a [TableData].
+func (t *Table) GetDataSource() TableDataSource {
+	return t.source
+}
+
+// SetColumns sets the definitions of the table's columns. The data source
+// provides the cells of these columns.
+func (t *Table) SetColumns(columns ...TableColumn) *Table {
+	t.columns = append([]TableColumn(nil), columns...)
+	return t
+}
+
+// GetColumns returns a copy of the definitions of the table's columns.
+func (t *Table) GetColumns() []TableColumn {
+	return append([]TableColumn(nil), t.columns...)
+}
+
+// SetFixed sets the number of leading data rows and columns which always
+// stay visible, e.g. to keep a column with row names in place while the
+// table scrolls horizontally. The header row is always fixed.
+func (t *Table) SetFixed(rows, columns int) *Table {
+	t.fixedRows, t.fixedColumns = max(rows, 0), max(columns, 0)
+	return t
+}
+
+// GetFixed returns the number of fixed rows and columns.
+func (t *Table) GetFixed() (rows, columns int) {
+	return t.fixedRows, t.fixedColumns
+}
+
+// SetSelectionMode sets what the user can select.
+func (t *Table) SetSelectionMode(mode TableSelectionMode) *Table {
+	t.selectionMode = mode
+	return t
+}
+
+// GetSelectionMode returns what the user can select.
+func (t *Table) GetSelectionMode() TableSelectionMode {
+	return t.selectionMode
+}
+
+// Select selects the cell at the given row and column and scrolls it into
+// view. In row selection mode, the column is ignored, in column selection
+// mode, the row. This triggers the "selection changed" callback if the
+// selection changes.
+func (t *Table) Select(row, column int) *Table {
+	row, column = max(row, 0), max(column, 0)
+	t.scrollToSelection = true
+	if row == t.selectedRow && column == t.selectedColumn {
+		return t
+	}
+	t.selectedRow, t.selectedColumn = row, column
+	if t.selectionChanged != nil {
+		t.selectionChanged(row, column)
+	}
+	return t
+}
+
+// GetSelection returns the selected row and column.
+func (t *Table) GetSelection() (row, column int) {
+	return t.selectedRow, t.selectedColumn
+}
+
+// SetOffset sets the first row and the first column shown after the fixed
+// rows and columns, i.e. it scrolls the table.
+func (t *Table) SetOffset(row, column int) *Table {
+	t.rowOffset, t.columnOffset = max(row, 0), max(column, 0)
+	t.scrollToSelection = false
+	return t
+}
+
+// GetOffset returns the first row and the first column shown after the fixed
+// rows and columns.
+func (t *Table) GetOffset() (row, column int) {
+	return t.rowOffset, t.columnOffset
+}
+
+// SetSeparator sets the string drawn between columns (defaults to a space),
+// e.g. "│". An empty string places the columns next to each other.
+func (t *Table) SetSeparator(separator string) *Table {
+	t.separator = separator
+	return t
+}
+
+// SetCellStyle sets the style of the cells. Each cell's own style is merged
+// over it.
+func (t *Table) SetCellStyle(style tcell.Style) *Table {
+	t.cellStyle = style
+	return t
+}
+
+// SetHeaderStyle sets the style of the header row.
+func (t *Table) SetHeaderStyle(style tcell.Style) *Table {
+	t.headerStyle = style
+	return t
+}
+
+// SetSelectedStyle sets the style of the selected cells.
+func (t *Table) SetSelectedStyle(style tcell.Style) *Table {
+	t.selectedStyle = style
+	return t
+}
+
+// SetSeparatorStyle sets the style of the separators between columns.
+func (t *Table) SetSeparatorStyle(style tcell.Style) *Table {
+	t.separatorStyle = style
+	return t
+}
+
+// SetSortFunc sets a handler which is called when the user clicks the title
+// of a column. It receives the column and the sort direction, which changes
+// each time the same title is clicked. The handler must reorder the data
+// source's rows, e.g. with [TableData.SortByColumn]. The title of the sort
+// column shows the direction.
+func (t *Table) SetSortFunc(handler func(column int, ascending bool)) *Table {
+	t.sortFunc = handler
+	return t
+}
+
+// SetSort sets the column which is shown as the sort column, and its
+// direction, without calling the sort handler. A negative column shows no
+// sort column.
+func (t *Table) SetSort(column int, ascending bool) *Table {
+	t.sortColumn, t.sortAscending = max(column, -1), ascending
+	return t
+}
+
+// GetSort returns the sort column (-1 if none) and its direction.
+func (t *Table) GetSort() (column int, ascending bool) {
+	return t.sortColumn, t.sortAscending
+}
+
+// SetSelectionChangedFunc sets a handler which is called when the selection
+// changes. It receives the selected row and column.
+func (t *Table) SetSelectionChangedFunc(handler func(row, column int)) *Table {
+	t.selectionChanged = handler
+	return t
+}
+
+// SetSelectedFunc sets a handler which is called when the user presses Enter
+// or double-clicks a cell while something can be selected. It receives the
+// selected row and column.
+func (t *Table) SetSelectedFunc(handler func(row, column int)) *Table {
+	t.selected = handler
+	return t
+}
+
+// SetScrollBarVisibility sets when the vertical scroll bar is shown.
+func (t *Table) SetScrollBarVisibility(visibility ScrollBarVisibility) *Table {
+	t.scrollBarVisibility = visibility
+	return t
+}
+
+// SetPlaceholder sets a primitive which is drawn into the table's inner area
+// while the data source has no rows. It replaces the text set with
+// [Table.SetPlaceholderText]. Set it to nil to show the text again.
+func (t *Table) SetPlaceholder(placeholder Primitive) *Table {
+	t.placeholder.primitive = placeholder
+	return t
+}
+
+// SetPlaceholderText sets the text which is shown centered in the table while
+// the data source has no rows, e.g. "No results". It may contain line breaks.
+// It is not shown if a primitive was set with [Table.SetPlaceholder].
+func (t *Table) SetPlaceholderText(text string) *Table {
+	t.placeholder.text = text
+	return t
+}
+
+// SetPlaceholderStyle sets the style of the placeholder text. Its background
+// color is ignored.
+func (t *Table) SetPlaceholderStyle(style tcell.Style) *Table {
+	t.placeholder.style = style
+	return t
+}
+
+// SetKeyMap sets custom keys for the table's key actions, replacing their
+// default keys. The supported actions are KeyActionUp, KeyActionDown,
+// KeyActionLeft, KeyActionRight, KeyActionPageUp, KeyActionPageDown,
+// KeyActionHome, KeyActionEnd, and KeyActionActivate.
+func (t *Table) SetKeyMap(keyMap KeyMap) *Table {
+	t.keyMap = keyMap
+	return t
+}
+
+// hasHeader returns whether the table has a header row.
+func (t *Table) hasHeader() bool {
+	for _, column := range t.columns {
+		if column.Title != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// title returns the title of the given column, including the sort direction.
+func (t *Table) title(column int) string {
+	title := t.columns[column].Title
+	if t.sortFunc != nil && column == t.sortColumn {
+		if t.sortAscending {
+			title += " ▲"
+		} else {
+			title += " ▼"
+		}
+	}
+	return title
+}
+
+// columnWidths returns the widths of the columns, measured on the given rows.
+func (t *Table) columnWidths(rows []tableDrawnRow) []int {
+	widths := make([]int, len(t.columns))
+	for index, column := range t.columns {
+		if column.Width > 0 {
+			widths[index] = column.Width
+			continue
+		}
+		width := TaggedStringWidth(column.Title)
+		if t.sortFunc != nil {
+			width += 2 // Leave room for the sort direction.
+		}
+		for _, row := range rows {
+			if row.row < 0 {
+				continue
+			}
+			if cell := t.source.GetCell(row.row, index); cell != nil {
+				width = max(width, TaggedStringWidth(cell.text))
+			}
+		}
+		if column.MaxWidth > 0 {
+			width = min(width, column.MaxWidth)
+		}
+		widths[index] = width
+	}
+	return widths
+}
+
+// layoutRows determines the rows shown in the given height, scrolling the
+// selection into view if requested.
+func (t *Table) layoutRows(y, height, rowCount int) (rows []tableDrawnRow, pageSize int) {
+	if t.hasHeader() && height > 0 {
+		rows = append(rows, tableDrawnRow{row: -1, y: y})
+		y++
+		height--
+	}
+	fixed := min(t.fixedRows, rowCount, height)
+	for row := range fixed {
+		rows = append(rows, tableDrawnRow{row: row, y: y + row})
+	}
+	y += fixed
+	pageSize = height - fixed
+
+	// Scroll the selection into view.
+	if t.scrollToSelection && t.selectionMode != TableSelectNone && t.selectionMode != TableSelectColumns && t.selectedRow >= t.fixedRows {
+		if t.selectedRow < t.rowOffset {
+			t.rowOffset = t.selectedRow
+		} else if t.selectedRow >= t.rowOffset+pageSize {
+			t.rowOffset = t.selectedRow - pageSize + 1
+		}
+	}
+	t.rowOffset = max(min(t.rowOffset, rowCount-pageSize), t.fixedRows)
+
+	for row := t.rowOffset; row < rowCount && row < t.rowOffset+pageSize; row++ {
+		rows = append(rows, tableDrawnRow{row: row, y: y + row - t.rowOffset})
+	}
+	return
+}
+
+// layoutColumns determines the columns shown in the given width, scrolling
+// the selection into view if requested.
+func (t *Table) layoutColumns(x, width int, widths []int) (columns []tableDrawnColumn) {
+	separatorWidth := TaggedStringWidth(t.separator)
+	spaceFrom := func(from int) int {
+		var space int
+		for column := from; column < len(widths); column++ {
+			space += widths[column] + separatorWidth
+		}
+		return space - separatorWidth
+	}
+
+	// All columns fit. Distribute the remaining space.
+	if total := spaceFrom(0); total <= width {
+		widths = append([]int(nil), widths...)
+		var expansions int
+		for _, column := range t.columns {
+			expansions += column.Expansion
+		}
+		remaining := width - total
+		for index, column := range t.columns {
+			if expansions > 0 && column.Expansion > 0 {
+				extra := remaining * column.Expansion / expansions
+				widths[index] += extra
+				remaining -= extra
+				expansions -= column.Expansion
+			}
+		}
+		for column := range widths {
+			columns = append(columns, tableDrawnColumn{column: column, x: x, width: widths[column]})
+			x += widths[column] + separatorWidth
+		}
+		t.columnOffset = 0
+		return
+	}
+
+	// Place the fixed columns.
+	right := x + width
+	fixed := min(t.fixedColumns, len(widths))
+	for column := range fixed {
+		if x >= right {
+			break
+		}
+		columns = append(columns, tableDrawnColumn{column: column, x: x, width: min(widths[column], right-x)})
+		x += widths[column] + separatorWidth
+	}
+	width = right - x
+
+	// Scroll the selection into view.
+	fits := func(from, to int) bool {
+		var space int
+		for column := from; column <= to; column++ {
+			space += widths[column] + separatorWidth
+		}
+		return space-separatorWidth <= width
+	}
+	if t.scrollToSelection && (t.selectionMode == TableSelectColumns || t.selectionMode == TableSelectCells) && t.selectedColumn >= fixed && t.selectedColumn < len(widths) {
+		if t.selectedColumn < t.columnOffset {
+			t.columnOffset = t.selectedColumn
+		}
+		for t.columnOffset < t.selectedColumn && !fits(t.columnOffset, t.selectedColumn) {
+			t.columnOffset++
+		}
+	}
+	t.columnOffset = max(min(t.columnOffset, len(widths)-1), fixed)
+	for t.columnOffset > fixed && spaceFrom(t.columnOffset-1) <= width {
+		t.columnOffset-- // Don't leave space on the right.
+	}
+
+	for column := t.columnOffset; column < len(widths) && x < right; column++ {
+		columns = append(columns, tableDrawnColumn{column: column, x: x, width: min(widths[column], right-x)})
+		x += widths[column] + separatorWidth
+	}
+	return
+}
+
+// isSelected returns whether the cell at the given row and column is
+// selected.
+func (t *Table) isSelected(row, column int) bool {
+	switch t.selectionMode {
+	case TableSelectRows:
+		return row == t.selectedRow
+	case TableSelectColumns:
+		return column == t.selectedColumn
+	case TableSelectCells:
+		return row == t.selectedRow && column == t.selectedColumn
+	}
+	return false
+}
+
+// Draw draws this primitive onto the screen.
+func (t *Table) Draw(screen tcell.Screen) {
+	t.DrawForSubclass(screen, t)
+	t.drawnRows, t.drawnColumns = nil, nil
+
+	if t.source == nil || t.source.GetRowCount() == 0 {
+		t.placeholder.draw(screen, t.Box)
+		return
+	}
+	x, y, width, height := t.GetInnerRect()
+	if width <= 0 || height <= 0 || len(t.columns) == 0 {
+		return
+	}
+
+	// Keep the selection within the table.
+	rowCount := t.source.GetRowCount()
+	t.selectedRow = max(min(t.selectedRow, rowCount-1), 0)
+	t.selectedColumn = max(min(t.selectedColumn, len(t.columns)-1), 0)
+
+	// Lay out the rows and the columns.
+	rows, pageSize := t.layoutRows(y, height, rowCount)
+	t.pageSize = pageSize
+	scrollBar := t.scrollBarVisibility == ScrollBarVisibilityAlways ||
+		t.scrollBarVisibility == ScrollBarVisibilityAutomatic && rowCount-min(t.fixedRows, rowCount) > pageSize
+	if scrollBar && width > 1 {
+		width--
+	} else {
+		scrollBar = false
+	}
+	columns := t.layoutColumns(x, width, t.columnWidths(rows))
+	t.scrollToSelection = false
+	t.drawnRows, t.drawnColumns = rows, columns
+
+	// Draw the cells.
+	for _, row := range rows {
+		for index, column := range columns {
+			style := t.cellStyle
+			text, line := "", Line{}
+			alignment := t.columns[column.column].Align
+			selected := row.row >= 0 && t.isSelected(row.row, column.column)
+			if row.row < 0 {
+				style, text = t.headerStyle, t.title(column.column)
+			} else if cell := t.source.GetCell(row.row, column.column); cell != nil {
+				style, text, line = mergeStyle(style, cell.style), cell.text, cell.line
+				if cell.aligned {
+					alignment = cell.align
+				}
+			}
+			if selected {
+				style = t.selectedStyle
+			}
+			for offset := range column.width {
+				screen.Put(column.x+offset, row.y, " ", style)
+			}
+			if len(line.Segments) > 0 {
+				printLine(screen, line, column.x, row.y, column.width, alignment, style, false)
+			} else {
+				printWithStyle(screen, text, column.x, row.y, 0, column.width, alignment, style, false)
+			}
+
+			// Draw the separator.
+			if index < len(columns)-1 && t.separator != "" {
+				separatorStyle := t.separatorStyle
+				if selected && t.selectionMode == TableSelectRows {
+					separatorStyle = t.selectedStyle
+				}
+				separatorX := column.x + column.width
+				printWithStyle(screen, t.separator, separatorX, row.y, 0, x+width-separatorX, AlignmentLeft, separatorStyle, false)
+			}
+		}
+	}
+
+	// Draw the scroll bar.
+	if scrollBar {
+		fixedHeight := len(rows) - min(pageSize, max(rowCount-t.rowOffset, 0))
+		t.scrollBar.SetRect(x+width, y+fixedHeight, 1, height-fixedHeight)
+		t.scrollBar.SetLengths(ScrollLengths{ContentLen: rowCount - min(t.fixedRows, rowCount), ViewportLen: pageSize}).
+			SetOffset(t.rowOffset - min(t.fixedRows, rowCount)).
+			Draw(screen)
+	}
+}
+
+// moveSelection moves the selection by the given number of rows and columns
+// and clamps it to the table.
+func (t *Table) moveSelection(rows, columns int) {
+	rowCount := t.source.GetRowCount()
+	row := max(min(t.selectedRow+rows, rowCount-1), 0)
+	column := max(min(t.selectedColumn+columns, len(t.columns)-1), 0)
+	t.Select(row, column)
+}
+
+// scroll scrolls the table by the given number of rows and columns, without
+// scrolling past the last row or column.
+func (t *Table) scroll(rows, columns int) {
+	rowCount := t.source.GetRowCount()
+	t.rowOffset = max(min(t.rowOffset+rows, rowCount-t.pageSize), t.fixedRows)
+	t.columnOffset = max(min(t.columnOffset+columns, len(t.columns)-1), t.fixedColumns)
+}
+
+// HandleEvent handles input events for this primitive.
+func (t *Table) HandleEvent(event tcell.Event) Command {
+	if t.source == nil {
+		return nil
+	}
+	switch event := event.(type) {
+	case *KeyEvent:
+		event, ok := t.keyMap.resolve(event, tableKeyActions)
+		if !ok {
+			return nil
+		}
+		rowsSelectable := t.selectionMode == TableSelectRows || t.selectionMode == TableSelectCells
+		columnsSelectable := t.selectionMode == TableSelectColumns || t.selectionMode == TableSelectCells
+		vertical := func(rows int) {
+			if rowsSelectable {
+				t.moveSelection(rows, 0)
+			} else {
+				t.scroll(rows, 0)
+			}
+		}
+		horizontal := func(columns int) {
+			if columnsSelectable {
+				t.moveSelection(0, columns)
+			} else {
+				t.scroll(0, columns)
+			}
+		}
+		rowCount := t.source.GetRowCount()
+		switch key := event.Key(); key {
+		case tcell.KeyRune:
+			switch event.Str() {
+			case "k":
+				vertical(-1)
+			case "j":
+				vertical(1)
+			case "h":
+				horizontal(-1)
+			case "l":
+				horizontal(1)
+			case "g":
+				vertical(-rowCount)
+			case "G":
+				vertical(rowCount)
+			default:
+				return nil
+			}
+		case tcell.KeyUp:
+			vertical(-1)
+		case tcell.KeyDown:
+			vertical(1)
+		case tcell.KeyLeft:
+			horizontal(-1)
+		case tcell.KeyRight:
+			horizontal(1)
+		case tcell.KeyPgUp:
+			vertical(-max(t.pageSize, 1))
+		case tcell.KeyPgDn:
+			vertical(max(t.pageSize, 1))
+		case tcell.KeyHome:
+			vertical(-rowCount)
+		case tcell.KeyEnd:
+			vertical(rowCount)
+		case tcell.KeyEnter:
+			if t.selectionMode != TableSelectNone && t.selected != nil && rowCount > 0 {
+				t.selected(t.selectedRow, t.selectedColumn)
+			}
+		default:
+			return nil
+		}
+		return RedrawCommand{}
+	case *MouseEvent:
+		x, y := event.Position()
+		if !t.InRect(x, y) {
+			return nil
+		}
+		switch event.Action {
+		case MouseLeftDown:
+			return SetFocusCommand{Target: t}
+		case MouseLeftClick, MouseLeftDoubleClick:
+			row, column, ok := t.cellAt(x, y)
+			if !ok {
+				return nil
+			}
+			if row < 0 {
+				if t.sortFunc != nil && event.Action == MouseLeftClick {
+					ascending := column != t.sortColumn || !t.sortAscending
+					t.sortColumn, t.sortAscending = column, ascending
+					t.sortFunc(column, ascending)
+				}
+				return RedrawCommand{}
+			}
+			if t.selectionMode == TableSelectNone {
+				return nil
+			}
+			t.Select(row, column)
+			if event.Action == MouseLeftDoubleClick && t.selected != nil {
+				t.selected(row, column)
+			}
+			return RedrawCommand{}
+		case MouseScrollUp:
+			t.scroll(-1, 0)
+			return RedrawCommand{}
+		case MouseScrollDown:
+			t.scroll(1, 0)
+			return RedrawCommand{}
+		case MouseScrollLeft:
+			t.scroll(0, -1)
+			return RedrawCommand{}
+		case MouseScrollRight:
+			t.scroll(0, 1)
+			return RedrawCommand{}
+		}
+	}
+	return nil
+}
+
+// cellAt returns the row (-1 for the header row) and the column of the cell
+// drawn last at the given screen position.
+func (t *Table) cellAt(x, y int) (row, column int, ok bool) {
+	for _, drawnRow := range t.drawnRows {
+		if drawnRow.y != y {
+			continue
+		}
+		for _, drawnColumn := range t.drawnColumns {
+			if x >= drawnColumn.x && x < drawnColumn.x+drawnColumn.width {
+				return drawnRow.row, drawnColumn.column, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// Inspect describes this primitive in the inspection tree. See [Inspect]. The
+// visible cells are reported as children.
+func (t *Table) Inspect(node *InspectNode) {
+	t.Box.Inspect(node)
+	node.Role = RoleTable
+	for _, row := range t.drawnRows {
+		for _, column := range t.drawnColumns {
+			child := &InspectNode{
+				Role:   RoleCell,
+				X:      column.x,
+				Y:      row.y,
+				Width:  column.width,
+				Height: 1,
+			}
+			if row.row < 0 {
+				child.Role, child.Label = RoleColumnHeader, t.columns[column.column].Title
+			} else {
+				if cell := t.source.GetCell(row.row, column.column); cell != nil {
+					child.Label = cell.text
+				}
+				child.Selected = t.isSelected(row.row, column.column)
+			}
+			node.Children = append(node.Children, child)
+		}
+	}
+}
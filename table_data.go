@@ -0,0 +1,177 @@
+package tview
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+// TableCell is a cell of a [Table], as provided by a [TableDataSource].
+type TableCell struct {
+	// The text of the cell.
+	text string
+
+	// The styled text of the cell. If it has no segments, text is displayed
+	// instead.
+	line Line
+
+	// The style of the cell, merged over the table's cell style.
+	style tcell.Style
+
+	// The alignment of the cell's text, if aligned is true. Otherwise, the
+	// column's alignment is used.
+	align   Alignment
+	aligned bool
+
+	// Application-defined data attached to the cell.
+	reference any
+}
+
+// NewTableCell returns a new table cell with the given text.
+func NewTableCell(text string) *TableCell {
+	return &TableCell{text: text}
+}
+
+// SetText sets the text of the cell.
+func (c *TableCell) SetText(text string) *TableCell {
+	c.text = text
+	c.line = Line{}
+	return c
+}
+
+// SetLine sets the text of the cell as a styled line. Each segment's style is
+// merged over the cell's style.
+func (c *TableCell) SetLine(line Line) *TableCell {
+	c.line = line.Clone()
+	c.text = line.String()
+	return c
+}
+
+// GetText returns the text of the cell.
+func (c *TableCell) GetText() string {
+	return c.text
+}
+
+// SetStyle sets the style of the cell. It is merged over the table's cell
+// style (see [Table.SetCellStyle]), so it may only set e.g. a foreground
+// color.
+func (c *TableCell) SetStyle(style tcell.Style) *TableCell {
+	c.style = style
+	return c
+}
+
+// GetStyle returns the style of the cell.
+func (c *TableCell) GetStyle() tcell.Style {
+	return c.style
+}
+
+// SetAlign sets the alignment of the cell's text, overriding the column's
+// alignment (see [TableColumn]).
+func (c *TableCell) SetAlign(align Alignment) *TableCell {
+	c.align, c.aligned = align, true
+	return c
+}
+
+// SetReference attaches application-defined data to the cell.
+func (c *TableCell) SetReference(reference any) *TableCell {
+	c.reference = reference
+	return c
+}
+
+// GetReference returns the data attached to the cell.
+func (c *TableCell) GetReference() any {
+	return c.reference
+}
+
+// TableDataSource provides the cells of a [Table]. The table only requests
+// the cells of the rows it draws (and measures for columns without a fixed
+// width), so a data source may generate cells on demand, e.g. from a database
+// cursor, instead of keeping them in memory.
+type TableDataSource interface {
+	// GetRowCount returns the number of rows, excluding the header row.
+	GetRowCount() int
+
+	// GetCell returns the cell at the given row and column, or nil for an
+	// empty cell.
+	GetCell(row, column int) *TableCell
+}
+
+// TableData is a [TableDataSource] which keeps all cells in memory.
+type TableData struct {
+	rows [][]*TableCell
+}
+
+// NewTableData returns a new data source without rows.
+func NewTableData() *TableData {
+	return &TableData{}
+}
+
+// SetCell sets the cell at the given row and column. The data grows as
+// needed.
+func (d *TableData) SetCell(row, column int, cell *TableCell) *TableData {
+	if row < 0 || column < 0 {
+		return d
+	}
+	for len(d.rows) <= row {
+		d.rows = append(d.rows, nil)
+	}
+	for len(d.rows[row]) <= column {
+		d.rows[row] = append(d.rows[row], nil)
+	}
+	d.rows[row][column] = cell
+	return d
+}
+
+// AppendRow adds a row with the given cells to the end of the data.
+func (d *TableData) AppendRow(cells ...*TableCell) *TableData {
+	d.rows = append(d.rows, cells)
+	return d
+}
+
+// RemoveRow removes the row at the given index.
+func (d *TableData) RemoveRow(row int) *TableData {
+	if row >= 0 && row < len(d.rows) {
+		d.rows = slices.Delete(d.rows, row, row+1)
+	}
+	return d
+}
+
+// Clear removes all rows.
+func (d *TableData) Clear() *TableData {
+	d.rows = nil
+	return d
+}
+
+// GetRowCount returns the number of rows.
+func (d *TableData) GetRowCount() int {
+	return len(d.rows)
+}
+
+// GetCell returns the cell at the given row and column, or nil if there is
+// no such cell.
+func (d *TableData) GetCell(row, column int) *TableCell {
+	if row < 0 || row >= len(d.rows) || column < 0 || column >= len(d.rows[row]) {
+		return nil
+	}
+	return d.rows[row][column]
+}
+
+// SortByColumn sorts the rows by the text of their cells in the given column,
+// e.g. from a sort handler (see [Table.SetSortFunc]). Empty cells come first
+// in ascending order. The sort is stable.
+func (d *TableData) SortByColumn(column int, ascending bool) *TableData {
+	text := func(row []*TableCell) string {
+		if column < len(row) && row[column] != nil {
+			return row[column].text
+		}
+		return ""
+	}
+	slices.SortStableFunc(d.rows, func(a, b []*TableCell) int {
+		if ascending {
+			return strings.Compare(text(a), text(b))
+		}
+		return strings.Compare(text(b), text(a))
+	})
+	return d
+}
@@ -1,8 +1,13 @@
 package tview
 
 import (
+	"context"
 	"math"
+	"regexp"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 	"unicode"
 	"unicode/utf8"
 
@@ -91,6 +96,32 @@ type textAreaUndoItem struct {
 	continuation                  bool   // If true, this item is a continuation of the previous undo item. It is handled together with all other undo items in the same continuation sequence.
 }
 
+// textAreaKeyActions are the key actions supported by TextArea.
+var textAreaKeyActions = keyActionDefaults{
+	KeyActionUp:              {tcell.NewEventKey(tcell.KeyUp, "", tcell.ModNone)},
+	KeyActionDown:            {tcell.NewEventKey(tcell.KeyDown, "", tcell.ModNone)},
+	KeyActionLeft:            {tcell.NewEventKey(tcell.KeyLeft, "", tcell.ModNone)},
+	KeyActionRight:           {tcell.NewEventKey(tcell.KeyRight, "", tcell.ModNone)},
+	KeyActionWordLeft:        {tcell.NewEventKey(tcell.KeyLeft, "", tcell.ModCtrl), tcell.NewEventKey(tcell.KeyRune, "b", tcell.ModAlt)},
+	KeyActionWordRight:       {tcell.NewEventKey(tcell.KeyRight, "", tcell.ModCtrl), tcell.NewEventKey(tcell.KeyRune, "f", tcell.ModAlt)},
+	KeyActionPageUp:          {tcell.NewEventKey(tcell.KeyPgUp, "", tcell.ModNone), tcell.NewEventKey(tcell.KeyRune, "b", tcell.ModCtrl)},
+	KeyActionPageDown:        {tcell.NewEventKey(tcell.KeyPgDn, "", tcell.ModNone), tcell.NewEventKey(tcell.KeyRune, "f", tcell.ModCtrl)},
+	KeyActionHome:            {tcell.NewEventKey(tcell.KeyHome, "", tcell.ModNone), tcell.NewEventKey(tcell.KeyRune, "a", tcell.ModCtrl)},
+	KeyActionEnd:             {tcell.NewEventKey(tcell.KeyEnd, "", tcell.ModNone), tcell.NewEventKey(tcell.KeyRune, "e", tcell.ModCtrl)},
+	KeyActionDeleteLeft:      {tcell.NewEventKey(tcell.KeyBackspace2, "", tcell.ModNone), tcell.NewEventKey(tcell.KeyBackspace, "", tcell.ModNone)},
+	KeyActionDeleteRight:     {tcell.NewEventKey(tcell.KeyDelete, "", tcell.ModNone), tcell.NewEventKey(tcell.KeyRune, "d", tcell.ModCtrl)},
+	KeyActionDeleteWordLeft:  {tcell.NewEventKey(tcell.KeyRune, "w", tcell.ModCtrl), tcell.NewEventKey(tcell.KeyBackspace2, "", tcell.ModAlt)},
+	KeyActionDeleteToLineEnd: {tcell.NewEventKey(tcell.KeyRune, "k", tcell.ModCtrl)},
+	KeyActionDeleteLine:      {tcell.NewEventKey(tcell.KeyRune, "u", tcell.ModCtrl)},
+	KeyActionSelectAll:       {tcell.NewEventKey(tcell.KeyRune, "l", tcell.ModCtrl)},
+	KeyActionCopy:            {tcell.NewEventKey(tcell.KeyRune, "q", tcell.ModCtrl)},
+	KeyActionCut:             {tcell.NewEventKey(tcell.KeyRune, "x", tcell.ModCtrl)},
+	KeyActionPaste:           {tcell.NewEventKey(tcell.KeyRune, "v", tcell.ModCtrl)},
+	KeyActionUndo:            {tcell.NewEventKey(tcell.KeyRune, "z", tcell.ModCtrl)},
+	KeyActionRedo:            {tcell.NewEventKey(tcell.KeyRune, "y", tcell.ModCtrl)},
+	KeyActionSelectNext:      {tcell.NewEventKey(tcell.KeyRune, "n", tcell.ModCtrl)},
+}
+
 // TextArea implements a simple text editor for multi-line text. Multi-color
 // text is not supported. Word-wrapping is enabled by default but can be turned
 // off or be changed to character-wrapping.
@@ -157,8 +188,9 @@ type textAreaUndoItem struct {
 //     selected, the clipboard text will be inserted at the cursor location.
 //
 // The Ctrl-Q key was chosen for the default "copy" function to avoid clashing
-// with common Ctrl-C quit bindings in user applications. You may remap keys in
-// your primitive's HandleEvent and implement copying to the clipboard. Note
+// with common Ctrl-C quit bindings in user applications. You may remap keys
+// with [TextArea.SetKeyMap] or in your primitive's HandleEvent and implement
+// copying to the clipboard. Note
 // that using your terminal's /
 // operating system's key bindings for copy+paste functionality may not have the
 // expected effect as tview will not be able to handle these keys. Pasting text
@@ -178,7 +210,22 @@ type textAreaUndoItem struct {
 //   - Ctrl-Z: Undo the last change.
 //   - Ctrl-Y: Redo the last Undo change.
 //
-// Undo does not affect the clipboard.
+// Undo does not affect the clipboard. Applications can also trigger undo and
+// redo with [TextArea.Undo] and [TextArea.Redo] and group several changes into
+// one undo step with [TextArea.BeginUndoGroup].
+//
+// # Multiple Cursors
+//
+// The text area supports multiple cursors, see [TextArea.AddCursor]. Typing,
+// deleting, pasting, and cursor movements are then applied at all cursors:
+//
+//   - Ctrl-N: Select the word under the cursor or, if text is selected, add a
+//     cursor selecting the next occurrence of that text.
+//   - Escape: Remove all cursors except the main cursor.
+//   - Ctrl-Q, Ctrl-X: Copy (cut) the selected texts of all cursors, separated
+//     by newlines.
+//   - Ctrl-V: Paste at all cursors. If the clipboard text has one line per
+//     cursor, each cursor receives one line.
 //
 // If the mouse is enabled, the following actions are available:
 //
@@ -186,8 +233,29 @@ type textAreaUndoItem struct {
 //     line if past the last character.
 //   - Left double-click: Select the word under the cursor.
 //   - Left click while holding the Shift key: Select text.
+//   - Left click while holding the Ctrl key: Add a cursor or remove the
+//     clicked cursor.
+//   - Left drag while holding the Alt key: Select a rectangular block of text,
+//     with one cursor per row.
 //   - Scroll wheel: Scroll the text.
 //
+// Use [TextArea.SetAutocompleteFunc] to offer completions for the word before
+// the cursor in a drop-down list. While the list is visible:
+//
+//   - Down arrow, Up arrow: Select the next/previous entry.
+//   - Enter, Tab: Replace the word with the selected entry (if one is
+//     selected).
+//   - Escape: Close the list.
+//
+// # Search and Replace
+//
+// [TextArea.Search] highlights all matches of a literal or regular expression
+// pattern and selects the first match at or after the selection, which allows
+// for incremental search when it is called for every change of the pattern.
+// Use [TextArea.NextMatch] and [TextArea.PrevMatch] to select other matches,
+// and [TextArea.ReplaceNext] and [TextArea.ReplaceAll] to replace them.
+// Matches are updated as the text changes.
+//
 // [Unicode Standard Annex #29]: https://unicode.org/reports/tr29/
 type TextArea struct {
 	*Box
@@ -301,6 +369,62 @@ type TextArea struct {
 	// Set to true when the mouse is dragging to select text.
 	dragging bool
 
+	// If set to true, tabs are drawn as "»" and trailing spaces as "·".
+	showWhitespace bool
+
+	// The style of visible whitespace and wrap indicators.
+	whitespaceStyle tcell.Style
+
+	// The text drawn in a gutter left of rows which continue a wrapped line.
+	// No gutter is shown if this is empty.
+	wrapIndicator string
+
+	// The text columns at which vertical guides are drawn.
+	columnGuides []int
+
+	// The style of column guides.
+	guideStyle tcell.Style
+
+	// Additional cursors besides the main cursor, sorted by position and not
+	// overlapping each other or the main cursor. See [TextArea.AddCursor].
+	extraCursors []textAreaCursor
+
+	// The cursors of remote collaborators, see
+	// [TextArea.SetCollaboratorCursor].
+	collaborators []textAreaCollaborator
+
+	// The style of collaborator cursors. The background is replaced by each
+	// collaborator's color.
+	collaboratorStyle tcell.Style
+
+	// Set to true while the mouse is dragging a block selection. The row and
+	// column where the drag started are stored in blockRow and blockColumn.
+	blockSelecting        bool
+	blockRow, blockColumn int
+
+	// Search related fields, see [TextArea.Search]:
+
+	// The compiled search pattern, or nil if there is no active search.
+	search *regexp.Regexp
+
+	// If true, replacements may refer to submatches of the search pattern.
+	searchRegexp bool
+
+	// The matches of the active search, in text order, as returned by
+	// [regexp.Regexp.FindAllStringSubmatchIndex]. The first two elements are
+	// the match's byte offsets.
+	matches [][]int
+
+	// Set to true when the text changed and the matches need to be recomputed.
+	matchesStale bool
+
+	// The styles applied on top of search matches and of the selected match.
+	matchStyle, currentMatchStyle tcell.Style
+
+	// If set to true and the text area is part of a form, Enter finishes
+	// editing and Alt-Enter inserts a newline.
+	finishOnEnter bool
+
 	// Clipboard related fields:
 
 	// The internal clipboard.
@@ -329,6 +453,30 @@ type TextArea struct {
 	// been performed yet, this is the same as len(undoStack).
 	nextUndo int
 
+	// The maximum number of undo steps kept on the undo stack. 0 means no
+	// limit.
+	undoLimit int
+
+	// The number of spans after the last removal of unreferenced spans, see
+	// [TextArea.collectSpans].
+	collectedSpans int
+
+	// The nesting depth of undo groups, see [TextArea.BeginUndoGroup].
+	undoGroupDepth int
+
+	// The number of undo items generated in the current undo group.
+	undoGroupItems int
+
+	// Custom keys for the built-in key actions.
+	keyMap KeyMap
+
+	// The autocomplete drop-down list.
+	autocompleter *autocompleter
+
+	// A function which returns whether a rune is part of a word to be
+	// completed.
+	autocompleteWordRune func(r rune) bool
+
 	// Event handlers:
 
 	// An optional function which is called when the input has changed.
@@ -347,18 +495,24 @@ type TextArea struct {
 // initial text.
 func NewTextArea() *TextArea {
 	t := &TextArea{
-		Box:             NewBox(),
-		wrap:            true,
-		wordWrap:        true,
-		labelStyle:      tcell.StyleDefault.Foreground(Styles.SecondaryTextColor),
-		textStyle:       tcell.StyleDefault.Background(Styles.PrimitiveBackgroundColor).Foreground(Styles.PrimaryTextColor),
-		selectedStyle:   tcell.StyleDefault.Background(Styles.PrimaryTextColor).Foreground(Styles.PrimitiveBackgroundColor),
-		spans:           make([]textAreaSpan, 2, pieceChainMinCap), // We reserve some space to avoid reallocations right when editing starts.
-		lastAction:      taActionOther,
-		minCursorPrefix: minCursorPrefixDefault,
-		minCursorSuffix: minCursorSuffixDefault,
-		lastWidth:       math.MaxInt / 2, // We need this so some functions work before the first draw.
-		lastHeight:      1,
+		Box:               NewBox(),
+		wrap:              true,
+		wordWrap:          true,
+		labelStyle:        tcell.StyleDefault.Foreground(Styles.SecondaryTextColor),
+		textStyle:         tcell.StyleDefault.Background(Styles.PrimitiveBackgroundColor).Foreground(Styles.PrimaryTextColor),
+		selectedStyle:     tcell.StyleDefault.Background(Styles.PrimaryTextColor).Foreground(Styles.PrimitiveBackgroundColor),
+		whitespaceStyle:   tcell.StyleDefault.Foreground(Styles.TertiaryTextColor).Dim(true),
+		guideStyle:        tcell.StyleDefault.Foreground(Styles.TertiaryTextColor).Dim(true),
+		matchStyle:        tcell.StyleDefault.Background(Styles.SecondaryTextColor).Foreground(Styles.PrimitiveBackgroundColor),
+		currentMatchStyle: tcell.StyleDefault.Background(Styles.TertiaryTextColor).Foreground(Styles.PrimitiveBackgroundColor),
+		collaboratorStyle: tcell.StyleDefault.Foreground(Styles.PrimitiveBackgroundColor),
+		spans:             make([]textAreaSpan, 2, pieceChainMinCap), // We reserve some space to avoid reallocations right when editing starts.
+		lastAction:        taActionOther,
+		autocompleter:     newAutocompleter(),
+		minCursorPrefix:   minCursorPrefixDefault,
+		minCursorSuffix:   minCursorSuffixDefault,
+		lastWidth:         math.MaxInt / 2, // We need this so some functions work before the first draw.
+		lastHeight:        1,
 	}
 	t.editText.Grow(editBufferMinCap)
 	t.spans[0] = textAreaSpan{previous: -1, next: 1}
@@ -370,6 +524,19 @@ func NewTextArea() *TextArea {
 	return t
 }
 
+// applyTheme updates the text area's default styles after a theme change.
+func (t *TextArea) applyTheme(change themeChange) {
+	t.Box.applyTheme(change)
+	t.labelStyle = change.style(t.labelStyle, themeSecondaryText, 0)
+	t.textStyle = change.style(t.textStyle, themePrimaryText, themePrimitiveBackground)
+	t.selectedStyle = change.style(t.selectedStyle, themePrimitiveBackground, themePrimaryText)
+	t.whitespaceStyle = change.style(t.whitespaceStyle, themeTertiaryText, 0)
+	t.guideStyle = change.style(t.guideStyle, themeTertiaryText, 0)
+	t.matchStyle = change.style(t.matchStyle, themePrimitiveBackground, themeSecondaryText)
+	t.currentMatchStyle = change.style(t.currentMatchStyle, themePrimitiveBackground, themeTertiaryText)
+	t.collaboratorStyle = change.style(t.collaboratorStyle, themePrimitiveBackground, 0)
+}
+
 // SetText sets the text of the text area. All existing text is deleted and
 // replaced with the new text. Any edits are discarded, no undos are available.
 // This function is typically only used to initialize the text area with a text
@@ -384,6 +551,9 @@ func NewTextArea() *TextArea {
 // If you want to set text and preserve undo functionality, use
 // [TextArea.Replace] instead.
 func (t *TextArea) SetText(text string, cursorAtTheEnd bool) *TextArea {
+	if len(t.collaborators) > 0 {
+		defer t.shiftCollaboratorsByDiff(t.GetText())
+	}
 	t.spans = t.spans[:2]
 	t.initialText = text
 	t.editText.Reset()
@@ -396,6 +566,8 @@ func (t *TextArea) SetText(text string, cursorAtTheEnd bool) *TextArea {
 	t.cursor.pos = [3]int{1, 0, -1}
 	t.undoStack = t.undoStack[:0]
 	t.nextUndo = 0
+	t.collectedSpans = 0
+	t.extraCursors = nil
 
 	if len(text) > 0 {
 		t.spans = append(t.spans, textAreaSpan{
@@ -419,6 +591,7 @@ func (t *TextArea) SetText(text string, cursorAtTheEnd bool) *TextArea {
 		t.spans[1].previous = 0
 	}
 	t.selectionStart = t.cursor
+	t.matchesStale = true
 
 	if t.changed != nil {
 		t.changed()
@@ -607,6 +780,262 @@ func (t *TextArea) GetWordUnderCursor(f func(rune) bool) (int, string, rune) {
 	return pos, word.String(), lastRune
 }
 
+// MoveCursorLeft moves the cursor one grapheme cluster to the left, like the
+// Left key. If extend is true, the selection is extended to the new position.
+// Otherwise, the selection is removed and, if there was one, the cursor is
+// placed at its start. A "moved" event is triggered if the cursor moved.
+//
+// This and the other MoveCursor functions allow external key handlers (e.g. for
+// vi-like key bindings) to drive the text area without synthesizing key events.
+func (t *TextArea) MoveCursorLeft(extend bool) *TextArea {
+	return t.moveCursorWith(func() { t.cursorLeft(extend) })
+}
+
+// MoveCursorRight moves the cursor one grapheme cluster to the right, like the
+// Right key. If extend is true, the selection is extended to the new position.
+// Otherwise, the selection is removed and, if there was one, the cursor is
+// placed at its end.
+func (t *TextArea) MoveCursorRight(extend bool) *TextArea {
+	return t.moveCursorWith(func() { t.cursorRight(extend) })
+}
+
+// MoveCursorUp moves the cursor one row up, like the Up key. If extend is true,
+// the selection is extended to the new position.
+func (t *TextArea) MoveCursorUp(extend bool) *TextArea {
+	return t.moveCursorWith(func() { t.cursorRows(-1, extend) })
+}
+
+// MoveCursorDown moves the cursor one row down, like the Down key. If extend is
+// true, the selection is extended to the new position.
+func (t *TextArea) MoveCursorDown(extend bool) *TextArea {
+	return t.moveCursorWith(func() { t.cursorRows(1, extend) })
+}
+
+// MoveCursorPageUp moves the cursor up by the height of the text area, like
+// the Page Up key. If extend is true, the selection is extended to the new
+// position.
+func (t *TextArea) MoveCursorPageUp(extend bool) *TextArea {
+	return t.moveCursorWith(func() { t.cursorRows(-t.lastHeight, extend) })
+}
+
+// MoveCursorPageDown moves the cursor down by the height of the text area, like
+// the Page Down key. If extend is true, the selection is extended to the new
+// position.
+func (t *TextArea) MoveCursorPageDown(extend bool) *TextArea {
+	return t.moveCursorWith(func() { t.cursorRows(t.lastHeight, extend) })
+}
+
+// MoveCursorWordLeft moves the cursor to the beginning of the current or
+// previous word, like Ctrl-Left. If extend is true, the selection is extended
+// to the new position.
+func (t *TextArea) MoveCursorWordLeft(extend bool) *TextArea {
+	return t.moveCursorWith(func() { t.cursorWordLeft(extend) })
+}
+
+// MoveCursorWordRight moves the cursor to the end of the current or next word,
+// like Ctrl-Right. If extend is true, the selection is extended to the new
+// position.
+func (t *TextArea) MoveCursorWordRight(extend bool) *TextArea {
+	return t.moveCursorWith(func() { t.cursorWordRight(extend) })
+}
+
+// MoveCursorRowStart moves the cursor to the start of its row, like the Home
+// key. If extend is true, the selection is extended to the new position.
+func (t *TextArea) MoveCursorRowStart(extend bool) *TextArea {
+	return t.moveCursorWith(func() { t.cursorRowEdge(false, extend) })
+}
+
+// MoveCursorRowEnd moves the cursor to the end of its row, like the End key.
+// If extend is true, the selection is extended to the new position.
+func (t *TextArea) MoveCursorRowEnd(extend bool) *TextArea {
+	return t.moveCursorWith(func() { t.cursorRowEdge(true, extend) })
+}
+
+// MoveCursorParagraphUp moves the cursor to the empty line before the current
+// paragraph or to the start of the text if there is none. Paragraphs are
+// separated by lines which are empty or contain only whitespace. If extend is
+// true, the selection is extended to the new position.
+func (t *TextArea) MoveCursorParagraphUp(extend bool) *TextArea {
+	return t.moveCursorWith(func() { t.cursorParagraph(false, extend) })
+}
+
+// MoveCursorParagraphDown moves the cursor to the empty line after the current
+// paragraph or to the end of the text if there is none. If extend is true, the
+// selection is extended to the new position.
+func (t *TextArea) MoveCursorParagraphDown(extend bool) *TextArea {
+	return t.moveCursorWith(func() { t.cursorParagraph(true, extend) })
+}
+
+// moveCursorWith calls the given function which moves the cursor and triggers
+// a "moved" event if the cursor or the selection changed.
+func (t *TextArea) moveCursorWith(move func()) *TextArea {
+	selectionStart, cursor := t.selectionStart, t.cursor
+	move()
+	t.lastAction = taActionOther
+	if t.moved != nil && (selectionStart != t.selectionStart || cursor != t.cursor) {
+		t.moved()
+	}
+	return t
+}
+
+// Undo reverts the last change, like Ctrl-Z. The selection is removed and the
+// cursor is placed where it was before the change. A "changed" event is
+// triggered if something was undone.
+func (t *TextArea) Undo() *TextArea {
+	return t.moveCursorWith(func() { t.undo() })
+}
+
+// Redo reapplies the last change reverted with [TextArea.Undo], like Ctrl-Y. A
+// "changed" event is triggered if something was redone.
+func (t *TextArea) Redo() *TextArea {
+	return t.moveCursorWith(func() { t.redo() })
+}
+
+// CanUndo returns whether there is a change which can be reverted with
+// [TextArea.Undo].
+func (t *TextArea) CanUndo() bool {
+	return t.nextUndo > 0
+}
+
+// CanRedo returns whether there is a change which can be reapplied with
+// [TextArea.Redo].
+func (t *TextArea) CanRedo() bool {
+	return t.nextUndo < len(t.undoStack)
+}
+
+// BeginUndoGroup starts a group of changes which are undone and redone
+// together in one step, e.g. several programmatic calls to
+// [TextArea.Replace]. Each call must be matched with a call to
+// [TextArea.EndUndoGroup]. Groups may be nested, in which case the outermost
+// group determines the undo step.
+func (t *TextArea) BeginUndoGroup() *TextArea {
+	if t.undoGroupDepth == 0 {
+		t.undoGroupItems = 0
+	}
+	t.undoGroupDepth++
+	t.lastAction = taActionOther
+	return t
+}
+
+// EndUndoGroup ends a group of changes started with [TextArea.BeginUndoGroup].
+func (t *TextArea) EndUndoGroup() *TextArea {
+	if t.undoGroupDepth > 0 {
+		t.undoGroupDepth--
+	}
+	if t.undoGroupDepth == 0 {
+		t.undoGroupItems = 0
+	}
+	t.lastAction = taActionOther
+	return t
+}
+
+// SetUndoLimit sets the maximum number of undo steps which are kept. Older
+// steps are discarded as new ones are added. A value of 0 (the default) means
+// that there is no limit.
+func (t *TextArea) SetUndoLimit(limit int) *TextArea {
+	t.undoLimit = max(limit, 0)
+	t.limitUndoStack()
+	t.cursor.pos = t.collectSpans(t.cursor.pos)
+	return t
+}
+
+// SetAutocompleteFunc sets a function which returns completions for the word
+// before the cursor. It is invoked while the user types or deletes characters
+// of a word and the completions are shown in a drop-down list below (or, if
+// there is not enough space, above) the cursor. Accepting an entry replaces
+// the word with it. Returning no completions closes the list. A nil function
+// disables autocomplete. Use [TextArea.SetAutocompleteWordFunc] to define
+// which characters make up a word.
+//
+// The function is called in its own goroutine so it may block, e.g. while
+// querying a language server. The provided context is cancelled as soon as the
+// user changes the text again, the list is closed, or the text area loses
+// focus, and results of cancelled lookups are discarded. The function must not
+// access the text area or other primitives.
+func (t *TextArea) SetAutocompleteFunc(handler func(ctx context.Context, word string) []string) *TextArea {
+	t.autocompleter.setLookup(autocompleteStrings(handler))
+	return t
+}
+
+// SetAutocompleteWordFunc sets a function which returns whether a rune is part
+// of a word to be completed, see [TextArea.SetAutocompleteFunc]. By default,
+// letters, digits, and underscores make up words.
+func (t *TextArea) SetAutocompleteWordFunc(isWordRune func(r rune) bool) *TextArea {
+	t.autocompleteWordRune = isWordRune
+	return t
+}
+
+// SetAutocompleteDebounce sets the time to wait after the last change of the
+// text before the autocomplete function is invoked. Changes within this time
+// restart the wait. A value of 0 invokes it on every change.
+func (t *TextArea) SetAutocompleteDebounce(debounce time.Duration) *TextArea {
+	t.autocompleter.setDebounce(debounce)
+	return t
+}
+
+// SetAutocompleteLoadingText sets the text shown in the autocomplete drop-down
+// list while completions are being looked up. An empty string disables the
+// loading indicator.
+func (t *TextArea) SetAutocompleteLoadingText(text string) *TextArea {
+	t.autocompleter.setLoadingText(text)
+	return t
+}
+
+// SetAutocompleteMaxHeight sets the maximum number of rows of the autocomplete
+// drop-down list.
+func (t *TextArea) SetAutocompleteMaxHeight(height int) *TextArea {
+	t.autocompleter.setMaxHeight(height)
+	return t
+}
+
+// SetAutocompleteStyles sets the styles of the autocomplete drop-down list
+// entries and of the selected entry.
+func (t *TextArea) SetAutocompleteStyles(main, selected tcell.Style) *TextArea {
+	t.autocompleter.setStyles(main, selected)
+	return t
+}
+
+// isAutocompleteWordRune returns whether the given rune is part of a word to
+// be completed.
+func (t *TextArea) isAutocompleteWordRune(r rune) bool {
+	if t.autocompleteWordRune != nil {
+		return t.autocompleteWordRune(r)
+	}
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+// updateAutocomplete starts a lookup for the word before the cursor if the
+// last action typed or deleted a character and closes the autocomplete list
+// otherwise. It returns the lookup command, if any.
+func (t *TextArea) updateAutocomplete() Command {
+	if !t.autocompleter.enabled() {
+		return nil
+	}
+	if t.lastAction != taActionTypeNonSpace && t.lastAction != taActionBackspace || t.HasSelection() {
+		t.autocompleter.close()
+		return nil
+	}
+	_, word, _ := t.GetWordUnderCursor(t.isAutocompleteWordRune)
+	if word == "" {
+		t.autocompleter.close()
+		return nil
+	}
+	return t.autocompleter.start(word)
+}
+
+// drawAutocomplete draws the autocomplete drop-down list for the cursor at the
+// given screen position, aligned with the start of the word being completed.
+func (t *TextArea) drawAutocomplete(screen tcell.Screen, cursorX, cursorY int) {
+	if !t.autocompleter.isVisible() {
+		return
+	}
+	_, word, _ := t.GetWordUnderCursor(t.isAutocompleteWordRune)
+	screenWidth, _ := screen.Size()
+	x := max(cursorX-uniseg.StringWidth(word), 0)
+	width := min(max(t.autocompleter.width()+2, 10), screenWidth-x)
+	t.autocompleter.draw(screen, x, cursorY, width)
+}
+
 // GetTextLength returns the string length of the text in the text area.
 func (t *TextArea) GetTextLength() int {
 	return t.length
@@ -625,6 +1054,7 @@ func (t *TextArea) GetTextLength() int {
 //
 // The effects of this function can be undone (and redone) by the user.
 func (t *TextArea) Replace(start, end int, text string) *TextArea {
+	t.extraCursors = nil
 	t.Select(start, end)
 	row := t.selectionStart.row
 	t.cursor.pos = t.replace(t.selectionStart.pos, t.cursor.pos, text, false)
@@ -676,8 +1106,8 @@ func (t *TextArea) Select(start, end int) *TextArea {
 	t.selectionStart = t.cursor
 RowLoop:
 	for {
-		if row >= len(t.lineStarts) {
-			t.extendLines(t.lastWidth, row)
+		if row+1 >= len(t.lineStarts) {
+			t.extendLines(t.lastWidth, row+1)
 			if row >= len(t.lineStarts) {
 				break
 			}
@@ -903,6 +1333,57 @@ func (t *TextArea) SetSelectedStyle(style tcell.Style) *TextArea {
 	return t
 }
 
+// SetShowWhitespace sets whether whitespace is made visible: tabs are drawn
+// as [WhitespaceTab] followed by spaces, spaces at the end of a line as
+// [WhitespaceTrailingSpace], and non-breaking spaces as
+// [WhitespaceNonBreakingSpace], using the style set with
+// [TextArea.SetWhitespaceStyle]. This can be toggled at any time.
+func (t *TextArea) SetShowWhitespace(show bool) *TextArea {
+	if t.showWhitespace != show {
+		t.showWhitespace = show
+	}
+	return t
+}
+
+// SetWrapIndicator sets the text drawn in a gutter to the left of rows which
+// continue a line that was wrapped, e.g. "↪". The gutter is as wide as the
+// text and only shown if wrapping is enabled (see [TextArea.SetWrap]). An
+// empty string (the default) removes the gutter.
+func (t *TextArea) SetWrapIndicator(indicator string) *TextArea {
+	if t.wrapIndicator != indicator {
+		t.wrapIndicator = indicator
+	}
+	return t
+}
+
+// SetWhitespaceStyle sets the style of visible whitespace (see
+// [TextArea.SetShowWhitespace]) and of wrap indicators (see
+// [TextArea.SetWrapIndicator]). Unset colors are taken from the text style.
+func (t *TextArea) SetWhitespaceStyle(style tcell.Style) *TextArea {
+	if t.whitespaceStyle != style {
+		t.whitespaceStyle = style
+	}
+	return t
+}
+
+// SetColumnGuides sets the text columns, starting at 0, at which vertical
+// guides are drawn behind the text, e.g. 80 to show where lines exceed that
+// width. Guides follow horizontal scrolling. Call without arguments to remove
+// all guides.
+func (t *TextArea) SetColumnGuides(columns ...int) *TextArea {
+	t.columnGuides = slices.Clone(columns)
+	return t
+}
+
+// SetGuideStyle sets the style of column guides (see
+// [TextArea.SetColumnGuides]). Unset colors are taken from the text style.
+func (t *TextArea) SetGuideStyle(style tcell.Style) *TextArea {
+	if t.guideStyle != style {
+		t.guideStyle = style
+	}
+	return t
+}
+
 // GetOffset returns the text's offset, that is, the number of rows and columns
 // skipped during drawing at the top or on the left, respectively. Note that the
 // column offset is ignored if wrapping is enabled.
@@ -960,6 +1441,14 @@ func (t *TextArea) SetChangedFunc(handler func()) *TextArea {
 	return t
 }
 
+// SetKeyMap sets custom keys for the text area's key actions, replacing their
+// default keys. All key actions are supported. KeyActionHome and KeyActionEnd
+// move to the start and end of the current line.
+func (t *TextArea) SetKeyMap(keyMap KeyMap) *TextArea {
+	t.keyMap = keyMap
+	return t
+}
+
 // SetMovedFunc sets a handler which is called whenever the cursor position or
 // the text selection has changed.
 func (t *TextArea) SetMovedFunc(handler func()) *TextArea {
@@ -973,6 +1462,17 @@ func (t *TextArea) SetFinishedFunc(handler func(key tcell.Key)) FormItem {
 	return t
 }
 
+// SetFinishOnEnter sets whether pressing Enter finishes editing when the text
+// area is part of a form, allowing the form to move on or to be submitted. In
+// that case, newlines are inserted with Alt-Enter instead. By default, Enter
+// inserts a newline.
+func (t *TextArea) SetFinishOnEnter(finish bool) *TextArea {
+	if t.finishOnEnter != finish {
+		t.finishOnEnter = finish
+	}
+	return t
+}
+
 // Focus is called when this primitive receives focus.
 func (t *TextArea) Focus(delegate func(p Primitive)) {
 	// If we're part of a form and this item is disabled, there's nothing the
@@ -985,6 +1485,12 @@ func (t *TextArea) Focus(delegate func(p Primitive)) {
 	t.Box.Focus(delegate)
 }
 
+// Blur is called when this primitive loses focus.
+func (t *TextArea) Blur() {
+	t.autocompleter.close()
+	t.Box.Blur()
+}
+
 // SetFormAttributes sets attributes shared by all form items.
 func (t *TextArea) SetFormAttributes(labelWidth int, labelColor, bgColor, fieldTextColor, fieldBgColor tcell.Color) FormItem {
 	if t.labelWidth != labelWidth {
@@ -1024,8 +1530,12 @@ func (t *TextArea) replace(deleteStart, deleteEnd [3]int, insert string, continu
 	if deleteStart == deleteEnd && insert == "" || t.maxLength > 0 && len(insert) > 0 && t.length+len(insert) >= t.maxLength {
 		return deleteEnd
 	}
+	if len(t.collaborators) > 0 {
+		t.shiftCollaborators(t.offsetOf(deleteStart), t.offsetOf(deleteEnd), len(insert))
+	}
 
 	// Notify at the end.
+	t.matchesStale = true
 	if t.changed != nil {
 		defer t.changed()
 	}
@@ -1086,11 +1596,15 @@ func (t *TextArea) replace(deleteStart, deleteEnd [3]int, insert string, continu
 		originalAfter:  after,
 		length:         t.length,
 		pos:            t.cursor.pos,
-		continuation:   continuation,
+		continuation:   continuation || t.undoGroupItems > 0,
 	})
 	t.spans = append(t.spans, t.spans[before])
 	t.spans = append(t.spans, t.spans[after])
 	t.nextUndo++
+	if t.undoGroupDepth > 0 {
+		t.undoGroupItems++
+	}
+	t.limitUndoStack()
 
 	// Adjust total text length by subtracting everything between "before" and
 	// "after". Inserted spans will be added back.
@@ -1170,7 +1684,7 @@ func (t *TextArea) replace(deleteStart, deleteEnd [3]int, insert string, continu
 		t.spans = append(t.spans, span)
 	}
 
-	return deleteEnd
+	return t.collectSpans(deleteEnd)
 }
 
 // Draw draws this primitive onto the screen.
@@ -1224,6 +1738,14 @@ func (t *TextArea) Draw(screen tcell.Screen) {
 		}
 	}
 
+	// Reserve the gutter for wrap indicators.
+	gutterX, gutterWidth := x, t.wrapIndicatorWidth()
+	if gutterWidth >= width {
+		gutterWidth = 0
+	}
+	x += gutterWidth
+	width -= gutterWidth
+
 	// Show/hide the cursor at the end.
 	defer func() {
 		if t.HasFocus() {
@@ -1235,7 +1757,9 @@ func (t *TextArea) Draw(screen tcell.Screen) {
 			if row >= 0 &&
 				row-t.rowOffset >= 0 && row-t.rowOffset < height &&
 				column-columnOffset >= 0 && column-columnOffset < width {
-				screen.ShowCursor(x+column-columnOffset, y+row-t.rowOffset)
+				cursorX, cursorY := x+column-columnOffset, y+row-t.rowOffset
+				screen.ShowCursor(cursorX, cursorY)
+				t.drawAutocomplete(screen, cursorX, cursorY)
 			} else {
 				screen.HideCursor()
 			}
@@ -1276,6 +1800,114 @@ func (t *TextArea) Draw(screen tcell.Screen) {
 		}
 	}
 
+	// Draw wrap indicators and column guides.
+	if gutterWidth > 0 {
+		style := mergeStyle(t.textStyle, t.whitespaceStyle)
+		for row := t.rowOffset; row < t.rowOffset+height && row < len(t.lineStarts); row++ {
+			if t.isContinuationRow(row) {
+				printWithStyle(screen, t.wrapIndicator, gutterX, y+row-t.rowOffset, 0, gutterWidth, AlignmentRight, style, false)
+			}
+		}
+	}
+	guideStyle := mergeStyle(t.textStyle, t.guideStyle)
+	for _, guide := range t.columnGuides {
+		if column := guide - columnOffset; column >= 0 && column < width {
+			for row := range height {
+				screen.Put(x+column, y+row, BoxDrawingsLightVertical, guideStyle)
+			}
+		}
+	}
+
+	// Find the secondary cursors and the collaborator cursors.
+	extraSelections, extraHeads := t.extraCursorPositions()
+	collaborators := t.collaboratorPositions()
+	defer t.drawCollaborators(screen, collaborators, x, y, width, height, columnOffset)
+	defer func() {
+		if !t.HasFocus() {
+			return
+		}
+		for _, head := range extraHeads {
+			column, row := head[1]-columnOffset, head[0]-t.rowOffset
+			if row < 0 || row >= height || column < 0 || column >= width {
+				continue
+			}
+			str, style, _ := screen.Get(x+column, y+row)
+			if str == "" || str == "\t" {
+				str = " "
+			}
+			screen.Put(x+column, y+row, str, style.Reverse(true))
+		}
+	}()
+
+	// drawCluster draws a grapheme cluster at the given position. Tabs,
+	// trailing spaces, and non-breaking spaces are marked if whitespace is
+	// shown.
+	drawCluster := func(cluster string, style tcell.Style, posX, posY, clusterWidth int, trailing bool) {
+		visible := func(colX int) bool {
+			return posX+colX-columnOffset >= 0 && posX+colX-columnOffset < width
+		}
+		if t.showWhitespace && cluster == "\t" {
+			for colX := range clusterWidth {
+				if visible(colX) {
+					screen.Put(x+posX+colX-columnOffset, y+posY, " ", style)
+				}
+			}
+			if clusterWidth > 0 && visible(0) {
+				screen.Put(x+posX-columnOffset, y+posY, WhitespaceTab, mergeStyle(style, t.whitespaceStyle))
+			}
+			return
+		}
+		if t.showWhitespace {
+			if glyph := whitespaceGlyph(cluster, trailing); glyph != "" {
+				cluster, style = glyph, mergeStyle(style, t.whitespaceStyle)
+			}
+		}
+
+		// Selected tabs are a bit special.
+		if cluster == "\t" && style == t.selectedStyle {
+			for colX := 0; colX < clusterWidth && posX+colX-columnOffset < width; colX++ {
+				screen.Put(x+posX+colX-columnOffset, y+posY, " ", style)
+			}
+		}
+
+		// Let column guides shine through unselected spaces.
+		if cluster == " " && style != t.selectedStyle && slices.Contains(t.columnGuides, posX) {
+			return
+		}
+
+		// Draw character.
+		if posX+clusterWidth-columnOffset <= width && posX-columnOffset >= 0 && clusterWidth > 0 {
+			screen.PutStrStyled(x+posX-columnOffset, y+posY, cluster, style)
+		}
+	}
+
+	// If whitespace is shown, spaces and tabs are only drawn once we know
+	// whether they are trailing.
+	type whitespaceCluster struct {
+		cluster                  string
+		style                    tcell.Style
+		posX, posY, clusterWidth int
+	}
+	var pending []whitespaceCluster
+	flush := func(trailing bool) {
+		for _, c := range pending {
+			drawCluster(c.cluster, c.style, c.posX, c.posY, c.clusterWidth, trailing)
+		}
+		pending = pending[:0]
+	}
+
+	// Find the search matches. Their offsets can't be mapped to transformed
+	// text.
+	var matchIndex, currentMatch, textIndex int
+	highlightMatches := t.search != nil && t.transform == nil
+	if highlightMatches {
+		currentMatch = t.GetCurrentMatch()
+		textIndex = t.offsetOf(t.lineStarts[t.rowOffset])
+		matchIndex = sort.Search(len(t.matches), func(index int) bool {
+			return t.matches[index][1] > textIndex
+		})
+	}
+
 	// Print the text.
 	var cluster, text string
 	line := t.rowOffset
@@ -1301,18 +1933,44 @@ func (t *TextArea) Draw(screen tcell.Screen) {
 			if t.disabled {
 				style = style.Background(t.backgroundColor)
 			}
+			for _, collaborator := range collaborators {
+				if selection := collaborator.selection; !(selection[2] < line ||
+					selection[2] == line && selection[3] <= posX ||
+					selection[0] > line ||
+					selection[0] == line && selection[1] > posX) {
+					style = t.collaboratorStyle.Background(collaborator.color)
+				}
+			}
+			for _, selection := range extraSelections {
+				if !(selection[2] < line ||
+					selection[2] == line && selection[3] <= posX ||
+					selection[0] > line ||
+					selection[0] == line && selection[1] > posX) {
+					style = t.selectedStyle
+					break
+				}
+			}
 		}
-
-		// Selected tabs are a bit special.
-		if cluster == "\t" && style == t.selectedStyle {
-			for colX := 0; colX < clusterWidth && posX+colX-columnOffset < width; colX++ {
-				screen.Put(x+posX+colX-columnOffset, y+posY, " ", style)
+		if highlightMatches {
+			for matchIndex < len(t.matches) && t.matches[matchIndex][1] <= textIndex {
+				matchIndex++
 			}
+			if matchIndex < len(t.matches) && t.matches[matchIndex][0] <= textIndex {
+				if matchIndex == currentMatch {
+					style = mergeStyle(style, t.currentMatchStyle)
+				} else if style != t.selectedStyle {
+					style = mergeStyle(style, t.matchStyle)
+				}
+			}
+			textIndex += len(cluster)
 		}
 
 		// Draw character.
-		if posX+clusterWidth-columnOffset <= width && posX-columnOffset >= 0 && clusterWidth > 0 {
-			screen.PutStrStyled(x+posX-columnOffset, y+posY, cluster, style)
+		if t.showWhitespace && (cluster == " " || cluster == "\t") {
+			pending = append(pending, whitespaceCluster{cluster, style, posX, posY, clusterWidth})
+		} else {
+			flush(uniseg.HasTrailingLineBreakInString(cluster))
+			drawCluster(cluster, style, posX, posY, clusterWidth, false)
 		}
 
 		// Advance.
@@ -1327,6 +1985,43 @@ func (t *TextArea) Draw(screen tcell.Screen) {
 			line++
 		}
 	}
+
+	// Find out if the remaining whitespace is trailing.
+	if len(pending) > 0 {
+		trailing := true
+		for pos[0] != 1 {
+			cluster, text, _, _, pos, endPos = t.step(text, pos, endPos)
+			if cluster != " " && cluster != "\t" {
+				trailing = uniseg.HasTrailingLineBreakInString(cluster)
+				break
+			}
+		}
+		flush(trailing)
+	}
+}
+
+// wrapIndicatorWidth returns the width of the gutter for wrap indicators, see
+// [TextArea.SetWrapIndicator].
+func (t *TextArea) wrapIndicatorWidth() int {
+	if !t.wrap || t.wrapIndicator == "" {
+		return 0
+	}
+	return uniseg.StringWidth(t.wrapIndicator)
+}
+
+// isContinuationRow returns whether the given row continues a line which was
+// wrapped. The row must be contained in [TextArea.lineStarts].
+func (t *TextArea) isContinuationRow(row int) bool {
+	if row <= 0 || row >= len(t.lineStarts) {
+		return false
+	}
+	var cluster, text string
+	pos := t.lineStarts[row-1]
+	endPos := pos
+	for pos != t.lineStarts[row] && pos[0] != 1 {
+		cluster, text, _, _, pos, endPos = t.step(text, pos, endPos)
+	}
+	return !uniseg.HasTrailingLineBreakInString(cluster)
 }
 
 // drawPlaceholder draws the placeholder text into the given rectangle. It does
@@ -1828,6 +2523,177 @@ func (t *TextArea) moveWordLeft(clamp bool) {
 	t.findCursor(clamp, row)
 }
 
+// cursorLeft moves the cursor one grapheme cluster to the left or, if there is
+// a selection and extend is false, to the start of the selection. If extend is
+// true, the selection is extended to the new position.
+func (t *TextArea) cursorLeft(extend bool) {
+	if !extend && t.selectionStart.pos != t.cursor.pos {
+		// Move to the start of the selection.
+		if t.selectionStart.row < t.cursor.row || (t.selectionStart.row == t.cursor.row && t.selectionStart.actualColumn < t.cursor.actualColumn) {
+			t.cursor = t.selectionStart
+		}
+		t.findCursor(true, t.cursor.row)
+	} else if t.cursor.actualColumn == 0 {
+		// Move to the end of the previous row.
+		if t.cursor.row > 0 {
+			t.moveCursor(t.cursor.row-1, -1)
+		}
+	} else {
+		// Move one grapheme cluster to the left.
+		t.moveCursor(t.cursor.row, t.cursor.actualColumn-1)
+	}
+	if !extend {
+		t.selectionStart = t.cursor
+	}
+}
+
+// cursorRight moves the cursor one grapheme cluster to the right or, if there
+// is a selection and extend is false, to the end of the selection. If extend
+// is true, the selection is extended to the new position.
+func (t *TextArea) cursorRight(extend bool) {
+	if !extend && t.selectionStart.pos != t.cursor.pos {
+		// Move to the end of the selection.
+		if t.selectionStart.row > t.cursor.row || (t.selectionStart.row == t.cursor.row && t.selectionStart.actualColumn > t.cursor.actualColumn) {
+			t.cursor = t.selectionStart
+		}
+		t.findCursor(true, t.cursor.row)
+	} else if t.cursor.pos[0] != 1 {
+		// Move one grapheme cluster to the right.
+		var clusterWidth int
+		_, _, _, clusterWidth, t.cursor.pos, _ = t.step("", t.cursor.pos, t.cursor.pos)
+		if len(t.lineStarts) <= t.cursor.row+1 {
+			t.extendLines(t.lastWidth, t.cursor.row+1)
+		}
+		if t.cursor.row+1 < len(t.lineStarts) && t.lineStarts[t.cursor.row+1] == t.cursor.pos {
+			// We've reached the end of the line.
+			t.cursor.row++
+			t.cursor.actualColumn = 0
+			t.cursor.column = 0
+			t.findCursor(true, t.cursor.row)
+		} else {
+			// Move one character to the right.
+			t.moveCursor(t.cursor.row, t.cursor.actualColumn+clusterWidth)
+		}
+	}
+	if !extend {
+		t.selectionStart = t.cursor
+	}
+}
+
+// cursorWordLeft moves the cursor to the beginning of the current or previous
+// word. If extend is true, the selection is extended to the new position.
+func (t *TextArea) cursorWordLeft(extend bool) {
+	t.moveWordLeft(true)
+	if !extend {
+		t.selectionStart = t.cursor
+	}
+}
+
+// cursorWordRight moves the cursor to the end of the current or next word. If
+// extend is true, the selection is extended to the new position, which is then
+// after the word instead of on its last character.
+func (t *TextArea) cursorWordRight(extend bool) {
+	if t.cursor.pos[0] != 1 {
+		t.moveWordRight(extend, true)
+	}
+	if !extend {
+		t.selectionStart = t.cursor
+	}
+}
+
+// cursorRows moves the cursor by the given number of rows, keeping its
+// column. If extend is true, the selection is extended to the new position.
+func (t *TextArea) cursorRows(rows int, extend bool) {
+	column := t.cursor.column
+	t.moveCursor(t.cursor.row+rows, t.cursor.column)
+	t.cursor.column = column
+	if !extend {
+		t.selectionStart = t.cursor
+	}
+}
+
+// cursorRowEdge moves the cursor to the start or, if end is true, to the end
+// of its row. If extend is true, the selection is extended to the new
+// position.
+func (t *TextArea) cursorRowEdge(end, extend bool) {
+	column := 0
+	if end {
+		column = -1
+	}
+	t.moveCursor(t.cursor.row, column)
+	if !extend {
+		t.selectionStart = t.cursor
+	}
+}
+
+// cursorParagraph moves the cursor to the empty line before (or, if forward
+// is true, after) the current paragraph, or to the start (end) of the text if
+// there is no such line. Paragraphs are separated by lines which are empty or
+// contain only whitespace. If extend is true, the selection is extended to the
+// new position.
+func (t *TextArea) cursorParagraph(forward, extend bool) {
+	text := t.GetText()
+	_, start, end := t.GetSelection()
+	offset := end
+	if t.cursor.row < t.selectionStart.row || t.cursor.row == t.selectionStart.row && t.cursor.actualColumn < t.selectionStart.actualColumn {
+		offset = start
+	}
+
+	// Split the text into lines.
+	var lineStarts []int
+	for index := 0; ; {
+		lineStarts = append(lineStarts, index)
+		newline := strings.Index(text[index:], TextAreaNewLine)
+		if newline < 0 {
+			break
+		}
+		index += newline + len(TextAreaNewLine)
+	}
+	line := sort.SearchInts(lineStarts, offset+1) - 1
+	blank := func(line int) bool {
+		end := len(text)
+		if line+1 < len(lineStarts) {
+			end = lineStarts[line+1]
+		}
+		return strings.TrimSpace(text[lineStarts[line]:end]) == ""
+	}
+
+	// Skip empty lines, then the paragraph.
+	target := 0
+	if forward {
+		for line < len(lineStarts) && blank(line) {
+			line++
+		}
+		for line < len(lineStarts) && !blank(line) {
+			line++
+		}
+		target = len(text)
+		if line < len(lineStarts) {
+			target = lineStarts[line]
+		}
+	} else {
+		for line >= 0 && blank(line) {
+			line--
+		}
+		for line >= 0 && !blank(line) {
+			line--
+		}
+		if line >= 0 {
+			target = lineStarts[line]
+		}
+	}
+
+	// Move the cursor there.
+	anchor, moved := t.selectionStart, t.moved
+	t.moved = nil
+	t.Select(target, target)
+	t.moved = moved
+	t.findCursor(true, t.cursor.row)
+	if extend {
+		t.selectionStart = anchor
+	}
+}
+
 // deleteLine deletes all characters between the last newline before the cursor
 // and the next newline after the cursor (inclusive).
 func (t *TextArea) deleteLine() {
@@ -1946,6 +2812,21 @@ func (t *TextArea) handleKeyEvent(event *tcell.EventKey) Command {
 	if t.disabled {
 		return nil
 	}
+	event, ok := t.keyMap.resolve(event, textAreaKeyActions)
+	if !ok {
+		return nil
+	}
+	if len(t.extraCursors) > 0 {
+		if cmd, handled := t.handleMultiCursorKey(event); handled {
+			return cmd
+		}
+	}
+	return t.processKeyEvent(event)
+}
+
+// processKeyEvent performs the action of a resolved key event at the main
+// cursor.
+func (t *TextArea) processKeyEvent(event *tcell.EventKey) Command {
 	var cmd Command
 
 	// All actions except a few specific ones are "other" actions.
@@ -1969,26 +2850,12 @@ func (t *TextArea) handleKeyEvent(event *tcell.EventKey) Command {
 	case tcell.KeyLeft: // Move one grapheme cluster to the left.
 		if event.Modifiers()&tcell.ModAlt == 0 {
 			// Regular movement.
-			if event.Modifiers()&tcell.ModShift == 0 && t.selectionStart.pos != t.cursor.pos {
-				// Move to the start of the selection.
-				if t.selectionStart.row < t.cursor.row || (t.selectionStart.row == t.cursor.row && t.selectionStart.actualColumn < t.cursor.actualColumn) {
-					t.cursor = t.selectionStart
-				}
-				t.findCursor(true, t.cursor.row)
-			} else if event.Modifiers()&tcell.ModMeta != 0 || event.Modifiers()&tcell.ModCtrl != 0 {
+			extend := event.Modifiers()&tcell.ModShift != 0
+			if (event.Modifiers()&tcell.ModMeta != 0 || event.Modifiers()&tcell.ModCtrl != 0) && (extend || t.selectionStart.pos == t.cursor.pos) {
 				// This captures Ctrl-Left on some systems.
-				t.moveWordLeft(event.Modifiers()&tcell.ModShift != 0)
-			} else if t.cursor.actualColumn == 0 {
-				// Move to the end of the previous row.
-				if t.cursor.row > 0 {
-					t.moveCursor(t.cursor.row-1, -1)
-				}
+				t.cursorWordLeft(extend)
 			} else {
-				// Move one grapheme cluster to the left.
-				t.moveCursor(t.cursor.row, t.cursor.actualColumn-1)
-			}
-			if event.Modifiers()&tcell.ModShift == 0 {
-				t.selectionStart = t.cursor
+				t.cursorLeft(extend)
 			}
 		} else if !t.wrap { // This doesn't work on all terminals.
 			// Just scroll.
@@ -2000,37 +2867,12 @@ func (t *TextArea) handleKeyEvent(event *tcell.EventKey) Command {
 	case tcell.KeyRight: // Move one grapheme cluster to the right.
 		if event.Modifiers()&tcell.ModAlt == 0 {
 			// Regular movement.
-			if event.Modifiers()&tcell.ModShift == 0 && t.selectionStart.pos != t.cursor.pos {
-				// Move to the end of the selection.
-				if t.selectionStart.row > t.cursor.row || (t.selectionStart.row == t.cursor.row && t.selectionStart.actualColumn > t.cursor.actualColumn) {
-					t.cursor = t.selectionStart
-				}
-				t.findCursor(true, t.cursor.row)
-			} else if t.cursor.pos[0] != 1 {
-				if event.Modifiers()&tcell.ModMeta != 0 || event.Modifiers()&tcell.ModCtrl != 0 {
-					// This captures Ctrl-Right on some systems.
-					t.moveWordRight(event.Modifiers()&tcell.ModShift != 0, true)
-				} else {
-					// Move one grapheme cluster to the right.
-					var clusterWidth int
-					_, _, _, clusterWidth, t.cursor.pos, _ = t.step("", t.cursor.pos, t.cursor.pos)
-					if len(t.lineStarts) <= t.cursor.row+1 {
-						t.extendLines(t.lastWidth, t.cursor.row+1)
-					}
-					if t.cursor.row+1 < len(t.lineStarts) && t.lineStarts[t.cursor.row+1] == t.cursor.pos {
-						// We've reached the end of the line.
-						t.cursor.row++
-						t.cursor.actualColumn = 0
-						t.cursor.column = 0
-						t.findCursor(true, t.cursor.row)
-					} else {
-						// Move one character to the right.
-						t.moveCursor(t.cursor.row, t.cursor.actualColumn+clusterWidth)
-					}
-				}
-			}
-			if event.Modifiers()&tcell.ModShift == 0 {
-				t.selectionStart = t.cursor
+			extend := event.Modifiers()&tcell.ModShift != 0
+			if (event.Modifiers()&tcell.ModMeta != 0 || event.Modifiers()&tcell.ModCtrl != 0) && (extend || t.selectionStart.pos == t.cursor.pos) {
+				// This captures Ctrl-Right on some systems.
+				t.cursorWordRight(extend)
+			} else {
+				t.cursorRight(extend)
 			}
 		} else if !t.wrap { // This doesn't work on all terminals.
 			// Just scroll.
@@ -2042,12 +2884,7 @@ func (t *TextArea) handleKeyEvent(event *tcell.EventKey) Command {
 	case tcell.KeyDown: // Move one row down.
 		if event.Modifiers()&tcell.ModAlt == 0 {
 			// Regular movement.
-			column := t.cursor.column
-			t.moveCursor(t.cursor.row+1, t.cursor.column)
-			t.cursor.column = column
-			if event.Modifiers()&tcell.ModShift == 0 {
-				t.selectionStart = t.cursor
-			}
+			t.cursorRows(1, event.Modifiers()&tcell.ModShift != 0)
 		} else {
 			// Just scroll.
 			t.rowOffset++
@@ -2061,12 +2898,7 @@ func (t *TextArea) handleKeyEvent(event *tcell.EventKey) Command {
 	case tcell.KeyUp: // Move one row up.
 		if event.Modifiers()&tcell.ModAlt == 0 {
 			// Regular movement.
-			column := t.cursor.column
-			t.moveCursor(t.cursor.row-1, t.cursor.column)
-			t.cursor.column = column
-			if event.Modifiers()&tcell.ModShift == 0 {
-				t.selectionStart = t.cursor
-			}
+			t.cursorRows(-1, event.Modifiers()&tcell.ModShift != 0)
 		} else {
 			// Just scroll.
 			t.rowOffset--
@@ -2075,30 +2907,20 @@ func (t *TextArea) handleKeyEvent(event *tcell.EventKey) Command {
 			}
 		}
 	case tcell.KeyHome, tcell.KeyCtrlA: // Move to the start of the line.
-		t.moveCursor(t.cursor.row, 0)
-		if event.Modifiers()&tcell.ModShift == 0 {
-			t.selectionStart = t.cursor
-		}
+		t.cursorRowEdge(false, event.Modifiers()&tcell.ModShift != 0)
 	case tcell.KeyEnd, tcell.KeyCtrlE: // Move to the end of the line.
-		t.moveCursor(t.cursor.row, -1)
-		if event.Modifiers()&tcell.ModShift == 0 {
-			t.selectionStart = t.cursor
-		}
+		t.cursorRowEdge(true, event.Modifiers()&tcell.ModShift != 0)
 	case tcell.KeyPgDn, tcell.KeyCtrlF: // Move one page down.
-		column := t.cursor.column
-		t.moveCursor(t.cursor.row+t.lastHeight, t.cursor.column)
-		t.cursor.column = column
-		if event.Modifiers()&tcell.ModShift == 0 {
-			t.selectionStart = t.cursor
-		}
+		t.cursorRows(t.lastHeight, event.Modifiers()&tcell.ModShift != 0)
 	case tcell.KeyPgUp, tcell.KeyCtrlB: // Move one page up.
-		column := t.cursor.column
-		t.moveCursor(t.cursor.row-t.lastHeight, t.cursor.column)
-		t.cursor.column = column
-		if event.Modifiers()&tcell.ModShift == 0 {
-			t.selectionStart = t.cursor
-		}
+		t.cursorRows(-t.lastHeight, event.Modifiers()&tcell.ModShift != 0)
 	case tcell.KeyEnter: // Insert a newline.
+		// But finishing takes precedence if so configured.
+		if t.finishOnEnter && t.finished != nil && event.Modifiers()&tcell.ModAlt == 0 {
+			t.finished(key)
+			return RedrawCommand{}
+		}
+
 		from, to, row := t.getSelection()
 		t.cursor.pos = t.replace(from, to, TextAreaNewLine, t.lastAction == taActionTypeSpace)
 		t.cursor.row = -1
@@ -2130,17 +2952,9 @@ func (t *TextArea) handleKeyEvent(event *tcell.EventKey) Command {
 			// We accept some Alt- key combinations.
 			switch event.Str() {
 			case "f":
-				if event.Modifiers()&tcell.ModShift == 0 {
-					t.moveWordRight(false, true)
-					t.selectionStart = t.cursor
-				} else {
-					t.moveWordRight(true, true)
-				}
+				t.cursorWordRight(event.Modifiers()&tcell.ModShift != 0)
 			case "b":
-				t.moveWordLeft(true)
-				if event.Modifiers()&tcell.ModShift == 0 {
-					t.selectionStart = t.cursor
-				}
+				t.cursorWordLeft(event.Modifiers()&tcell.ModShift != 0)
 			}
 		} else {
 			// Other keys are simply accepted as regular characters.
@@ -2279,51 +3093,182 @@ func (t *TextArea) handleKeyEvent(event *tcell.EventKey) Command {
 		t.findCursor(true, row)
 		t.selectionStart = t.cursor
 	case tcell.KeyCtrlZ: // Undo.
-		if t.nextUndo <= 0 {
+		t.undo()
+	case tcell.KeyCtrlY: // Redo.
+		t.redo()
+	case tcell.KeyCtrlN: // Select the next occurrence of the selection.
+		t.selectNextOccurrence()
+	}
+	return BatchCommand{cmd, RedrawCommand{}}
+}
+
+// undo reverts the last undo step. It returns false if there is nothing to
+// undo.
+func (t *TextArea) undo() bool {
+	if t.nextUndo <= 0 {
+		return false
+	}
+	if len(t.collaborators) > 0 {
+		defer t.shiftCollaboratorsByDiff(t.GetText())
+	}
+	for t.nextUndo > 0 {
+		t.nextUndo--
+		undo := t.undoStack[t.nextUndo]
+		t.spans[undo.originalBefore], t.spans[undo.before] = t.spans[undo.before], t.spans[undo.originalBefore]
+		t.spans[undo.originalAfter], t.spans[undo.after] = t.spans[undo.after], t.spans[undo.originalAfter]
+		t.cursor.pos, t.undoStack[t.nextUndo].pos = undo.pos, t.cursor.pos
+		t.length, t.undoStack[t.nextUndo].length = undo.length, t.length
+		if !undo.continuation {
 			break
 		}
-		for t.nextUndo > 0 {
-			t.nextUndo--
-			undo := t.undoStack[t.nextUndo]
-			t.spans[undo.originalBefore], t.spans[undo.before] = t.spans[undo.before], t.spans[undo.originalBefore]
-			t.spans[undo.originalAfter], t.spans[undo.after] = t.spans[undo.after], t.spans[undo.originalAfter]
-			t.cursor.pos, t.undoStack[t.nextUndo].pos = undo.pos, t.cursor.pos
-			t.length, t.undoStack[t.nextUndo].length = undo.length, t.length
-			if !undo.continuation {
-				break
-			}
-		}
-		t.cursor.row = -1
-		t.truncateLines(0) // This is why Undo is expensive for large texts. (t.lineStarts can get largely unusable after an undo.)
-		t.findCursor(true, 0)
-		t.selectionStart = t.cursor
-		if t.changed != nil {
-			defer t.changed()
-		}
-	case tcell.KeyCtrlY: // Redo.
-		if t.nextUndo >= len(t.undoStack) {
+	}
+	t.extraCursors = nil
+	t.cursor.row = -1
+	t.truncateLines(0) // This is why Undo is expensive for large texts. (t.lineStarts can get largely unusable after an undo.)
+	t.findCursor(true, 0)
+	t.selectionStart = t.cursor
+	t.matchesStale = true
+	if t.changed != nil {
+		t.changed()
+	}
+	return true
+}
+
+// redo reapplies the last undo step which was reverted. It returns false if
+// there is nothing to redo.
+func (t *TextArea) redo() bool {
+	if t.nextUndo >= len(t.undoStack) {
+		return false
+	}
+	if len(t.collaborators) > 0 {
+		defer t.shiftCollaboratorsByDiff(t.GetText())
+	}
+	for t.nextUndo < len(t.undoStack) {
+		undo := t.undoStack[t.nextUndo]
+		t.spans[undo.originalBefore], t.spans[undo.before] = t.spans[undo.before], t.spans[undo.originalBefore]
+		t.spans[undo.originalAfter], t.spans[undo.after] = t.spans[undo.after], t.spans[undo.originalAfter]
+		t.cursor.pos, t.undoStack[t.nextUndo].pos = undo.pos, t.cursor.pos
+		t.length, t.undoStack[t.nextUndo].length = undo.length, t.length
+		t.nextUndo++
+		if t.nextUndo < len(t.undoStack) && !t.undoStack[t.nextUndo].continuation {
 			break
 		}
-		for t.nextUndo < len(t.undoStack) {
-			undo := t.undoStack[t.nextUndo]
-			t.spans[undo.originalBefore], t.spans[undo.before] = t.spans[undo.before], t.spans[undo.originalBefore]
-			t.spans[undo.originalAfter], t.spans[undo.after] = t.spans[undo.after], t.spans[undo.originalAfter]
-			t.cursor.pos, t.undoStack[t.nextUndo].pos = undo.pos, t.cursor.pos
-			t.length, t.undoStack[t.nextUndo].length = undo.length, t.length
-			t.nextUndo++
-			if t.nextUndo < len(t.undoStack) && !t.undoStack[t.nextUndo].continuation {
-				break
-			}
+	}
+	t.extraCursors = nil
+	t.cursor.row = -1
+	t.truncateLines(0) // This is why Redo is expensive for large texts. (t.lineStarts can get largely unusable after an undo.)
+	t.findCursor(true, 0)
+	t.selectionStart = t.cursor
+	t.matchesStale = true
+	if t.changed != nil {
+		t.changed()
+	}
+	return true
+}
+
+// limitUndoStack removes the oldest undo steps which exceed the undo limit.
+func (t *TextArea) limitUndoStack() {
+	if t.undoLimit <= 0 {
+		return
+	}
+	steps, drop := 0, 0
+	for index := len(t.undoStack) - 1; index >= 0; index-- {
+		if t.undoStack[index].continuation {
+			continue
 		}
-		t.cursor.row = -1
-		t.truncateLines(0) // This is why Redo is expensive for large texts. (t.lineStarts can get largely unusable after an undo.)
-		t.findCursor(true, 0)
-		t.selectionStart = t.cursor
-		if t.changed != nil {
-			defer t.changed()
+		steps++
+		if steps > t.undoLimit {
+			drop = index + 1
+			for drop < len(t.undoStack) && t.undoStack[drop].continuation {
+				drop++
+			}
+			break
 		}
 	}
-	return BatchCommand{cmd, RedrawCommand{}}
+	if drop > 0 && drop <= t.nextUndo {
+		t.undoStack = slices.Delete(t.undoStack, 0, drop)
+		t.nextUndo -= drop
+	}
+}
+
+// collectSpans removes the spans which are neither part of the text nor needed
+// by the remaining undo and redo steps, e.g. after [TextArea.limitUndoStack]
+// discarded old steps. To keep edits cheap, this only happens once the number
+// of spans has doubled since the last collection. All span positions are
+// adjusted, including the given one which is returned.
+func (t *TextArea) collectSpans(pos [3]int) [3]int {
+	if len(t.spans) < 2*max(t.collectedSpans, 64) {
+		return pos
+	}
+
+	// Mark the spans of the text and of all texts reachable by undo or redo.
+	live := make([]bool, len(t.spans))
+	live[0], live[1] = true, true
+	mark := func(spans []textAreaSpan, from, to int) {
+		for index := from; index >= 0 && index != to; index = spans[index].next {
+			live[index] = true
+		}
+	}
+	mark(t.spans, t.spans[0].next, 1)
+	spans := make([]textAreaSpan, len(t.spans))
+	step := func(undo textAreaUndoItem) {
+		spans[undo.originalBefore], spans[undo.before] = spans[undo.before], spans[undo.originalBefore]
+		spans[undo.originalAfter], spans[undo.after] = spans[undo.after], spans[undo.originalAfter]
+		live[undo.before], live[undo.after] = true, true
+		live[undo.originalBefore], live[undo.originalAfter] = true, true
+		live[undo.pos[0]] = true
+		mark(spans, spans[undo.originalBefore].next, undo.originalAfter)
+	}
+	copy(spans, t.spans)
+	for index := t.nextUndo - 1; index >= 0; index-- {
+		step(t.undoStack[index])
+	}
+	copy(spans, t.spans)
+	for index := t.nextUndo; index < len(t.undoStack); index++ {
+		step(t.undoStack[index])
+	}
+	for _, p := range append([][3]int{pos, t.cursor.pos, t.selectionStart.pos}, t.lineStarts...) {
+		if p[0] >= 0 && p[0] < len(live) {
+			live[p[0]] = true
+		}
+	}
+
+	// Move the marked spans into a new slice and adjust all references.
+	indices := make([]int, len(t.spans))
+	spans = spans[:0]
+	for index, span := range t.spans {
+		indices[index] = -1
+		if live[index] {
+			indices[index] = len(spans)
+			spans = append(spans, span)
+		}
+	}
+	spans = slices.Clone(spans)
+	remap := func(index int) int {
+		if index < 0 || index >= len(indices) {
+			return index
+		}
+		return indices[index]
+	}
+	for index := range spans {
+		spans[index].previous = remap(spans[index].previous)
+		spans[index].next = remap(spans[index].next)
+	}
+	for index := range t.undoStack {
+		undo := &t.undoStack[index]
+		undo.before, undo.after = remap(undo.before), remap(undo.after)
+		undo.originalBefore, undo.originalAfter = remap(undo.originalBefore), remap(undo.originalAfter)
+		undo.pos[0] = remap(undo.pos[0])
+	}
+	for index := range t.lineStarts {
+		t.lineStarts[index][0] = remap(t.lineStarts[index][0])
+	}
+	t.cursor.pos[0] = remap(t.cursor.pos[0])
+	t.selectionStart.pos[0] = remap(t.selectionStart.pos[0])
+	pos[0] = remap(pos[0])
+	t.spans = spans
+	t.collectedSpans = len(spans)
+	return pos
 }
 
 func (t *TextArea) handleMouseEvent(event *MouseEvent) Command {
@@ -2337,6 +3282,7 @@ func (t *TextArea) handleMouseEvent(event *MouseEvent) Command {
 		if t.dragging {
 			if event.Action == MouseLeftUp {
 				t.dragging = false
+				t.blockSelecting = false
 			}
 			return SetMouseCaptureCommand{Target: nil}
 		}
@@ -2358,7 +3304,7 @@ func (t *TextArea) handleMouseEvent(event *MouseEvent) Command {
 	if labelWidth == 0 && t.label != "" {
 		labelWidth = TaggedStringWidth(t.label)
 	}
-	column := x - rectX - labelWidth
+	column := x - rectX - labelWidth - t.wrapIndicatorWidth()
 	row := y - rectY
 	if !t.wrap {
 		column += t.columnOffset
@@ -2369,9 +3315,22 @@ func (t *TextArea) handleMouseEvent(event *MouseEvent) Command {
 	var cmd BatchCommand
 	switch event.Action {
 	case MouseLeftDown:
-		t.moveCursor(row, column)
-		if event.Modifiers()&tcell.ModShift == 0 {
-			t.selectionStart = t.cursor
+		if event.Modifiers()&tcell.ModCtrl != 0 {
+			// Add or remove a cursor.
+			t.toggleCursorAt(row, column)
+			cmd = append(cmd, SetFocusCommand{Target: t}, RedrawCommand{})
+			break
+		}
+		t.extraCursors = nil
+		if event.Modifiers()&tcell.ModAlt != 0 {
+			// Start a block selection.
+			t.blockSelecting, t.blockRow, t.blockColumn = true, row, column
+			t.selectBlock(row, column)
+		} else {
+			t.moveCursor(row, column)
+			if event.Modifiers()&tcell.ModShift == 0 {
+				t.selectionStart = t.cursor
+			}
 		}
 		cmd = append(cmd, SetFocusCommand{Target: t}, SetMouseCaptureCommand{Target: t}, RedrawCommand{})
 		t.dragging = true
@@ -2379,12 +3338,21 @@ func (t *TextArea) handleMouseEvent(event *MouseEvent) Command {
 		if !t.dragging {
 			break
 		}
-		t.moveCursor(row, column)
+		if t.blockSelecting {
+			t.selectBlock(row, column)
+		} else {
+			t.moveCursor(row, column)
+		}
 		cmd = append(cmd, SetMouseCaptureCommand{Target: t}, RedrawCommand{})
 	case MouseLeftUp:
-		t.moveCursor(row, column)
+		if t.blockSelecting {
+			t.selectBlock(row, column)
+		} else if t.dragging || event.Modifiers()&tcell.ModCtrl == 0 { // Ctrl-clicks were handled on mouse down.
+			t.moveCursor(row, column)
+		}
 		cmd = append(cmd, SetMouseCaptureCommand{Target: nil}, RedrawCommand{})
 		t.dragging = false
+		t.blockSelecting = false
 	case MouseLeftDoubleClick: // Select word.
 		// Left down/up was already triggered so we are at the correct
 		// position.
@@ -2423,6 +3391,10 @@ func (t *TextArea) handleMouseEvent(event *MouseEvent) Command {
 }
 
 func (t *TextArea) handlePasteEvent(event *PasteEvent) Command {
+	if len(t.extraCursors) > 0 {
+		t.pasteAtCursors(event.Content)
+		return RedrawCommand{}
+	}
 	from, to, row := t.getSelection()
 	t.cursor.pos = t.replace(from, to, event.Content, false)
 	t.cursor.row = -1
@@ -2436,11 +3408,34 @@ func (t *TextArea) handlePasteEvent(event *PasteEvent) Command {
 func (t *TextArea) HandleEvent(event tcell.Event) Command {
 	switch event := event.(type) {
 	case *KeyEvent:
-		return t.handleKeyEvent(event)
+		if consumed, entry, accepted := t.autocompleter.handleKey(event); consumed {
+			if accepted {
+				pos, word, _ := t.GetWordUnderCursor(t.isAutocompleteWordRune)
+				t.Replace(pos-len(word), pos, entry)
+			}
+			return RedrawCommand{}
+		}
+		cmd := t.handleKeyEvent(event)
+		if lookup := t.updateAutocomplete(); lookup != nil {
+			return BatchCommand{cmd, lookup}
+		}
+		return cmd
 	case *MouseEvent:
+		if event.Action == MouseLeftDown || event.Action == MouseRightDown {
+			t.autocompleter.close()
+		}
 		return t.handleMouseEvent(event)
 	case *PasteEvent:
+		t.autocompleter.close()
 		return t.handlePasteEvent(event)
 	}
 	return nil
 }
+
+// Inspect describes this primitive in the inspection tree. See [Inspect].
+func (t *TextArea) Inspect(node *InspectNode) {
+	node.Role = RoleTextbox
+	node.Label = t.label
+	node.Value = t.GetText()
+	node.Disabled = t.disabled
+}
@@ -0,0 +1,181 @@
+package tview
+
+import (
+	"slices"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+// textAreaCollaborator is the cursor of a remote collaborator shown in a text
+// area, see [TextArea.SetCollaboratorCursor].
+type textAreaCollaborator struct {
+	textAreaCursor
+
+	// The collaborator's identifier.
+	id string
+
+	// The text shown next to the cursor.
+	label string
+
+	// The color of the cursor, its selection, and its label.
+	color tcell.Color
+}
+
+// SetCollaboratorCursor shows the cursor of a remote collaborator with the
+// given identifier, e.g. of another user editing the same document. The
+// cursor selects the text between the anchor and the head (index positions
+// within the entire text, as in [TextArea.Select]) and is located at the head.
+// If a cursor with the same identifier exists, it is replaced.
+//
+// Collaborator cursors are decorations only: they don't affect editing and
+// are not part of the text. The cursor and its selection are drawn with the
+// given color as the background (see [TextArea.SetCollaboratorStyle]), and
+// the label, if not empty, is drawn in the row above the cursor (or below if
+// the cursor is in the first visible row). When the text is edited locally,
+// the positions are shifted so that they stay at the same characters.
+// Positions in deleted text move to the start of the deletion.
+func (t *TextArea) SetCollaboratorCursor(id, label string, color tcell.Color, anchor, head int) *TextArea {
+	cursor := textAreaCursor{
+		anchor: max(min(anchor, t.length), 0),
+		head:   max(min(head, t.length), 0),
+	}
+	collaborator := textAreaCollaborator{textAreaCursor: cursor, id: id, label: label, color: color}
+	for index := range t.collaborators {
+		if t.collaborators[index].id == id {
+			t.collaborators[index] = collaborator
+			return t
+		}
+	}
+	t.collaborators = append(t.collaborators, collaborator)
+	return t
+}
+
+// GetCollaboratorCursor returns the anchor and the head of the collaborator
+// cursor with the given identifier, adjusted to the local edits since it was
+// set. If there is no such cursor, ok is false.
+func (t *TextArea) GetCollaboratorCursor(id string) (anchor, head int, ok bool) {
+	for _, collaborator := range t.collaborators {
+		if collaborator.id == id {
+			return collaborator.anchor, collaborator.head, true
+		}
+	}
+	return 0, 0, false
+}
+
+// RemoveCollaboratorCursor removes the collaborator cursor with the given
+// identifier, if any.
+func (t *TextArea) RemoveCollaboratorCursor(id string) *TextArea {
+	t.collaborators = slices.DeleteFunc(t.collaborators, func(collaborator textAreaCollaborator) bool {
+		return collaborator.id == id
+	})
+	return t
+}
+
+// ClearCollaboratorCursors removes all collaborator cursors.
+func (t *TextArea) ClearCollaboratorCursors() *TextArea {
+	t.collaborators = nil
+	return t
+}
+
+// SetCollaboratorStyle sets the style of collaborator cursors, their
+// selections, and their labels. The background color is replaced by each
+// collaborator's color.
+func (t *TextArea) SetCollaboratorStyle(style tcell.Style) *TextArea {
+	t.collaboratorStyle = style
+	return t
+}
+
+// shiftCollaborators adjusts the collaborator cursors to a local edit which
+// replaced the text between the byte offsets start and end with text of the
+// given length.
+func (t *TextArea) shiftCollaborators(start, end, length int) {
+	shift := func(offset int) int {
+		switch {
+		case offset <= start:
+			return offset
+		case offset >= end:
+			return offset + length - (end - start)
+		}
+		return start
+	}
+	for index := range t.collaborators {
+		collaborator := &t.collaborators[index]
+		collaborator.anchor, collaborator.head = shift(collaborator.anchor), shift(collaborator.head)
+	}
+}
+
+// shiftCollaboratorsByDiff adjusts the collaborator cursors to a change of
+// the text from the given previous text to the current text, e.g. after an
+// undo. The changed range is everything between the common prefix and the
+// common suffix of the two texts.
+func (t *TextArea) shiftCollaboratorsByDiff(previous string) {
+	text := t.GetText()
+	var prefix int
+	for prefix < len(previous) && prefix < len(text) && previous[prefix] == text[prefix] {
+		prefix++
+	}
+	var suffix int
+	for suffix < len(previous)-prefix && suffix < len(text)-prefix && previous[len(previous)-1-suffix] == text[len(text)-1-suffix] {
+		suffix++
+	}
+	t.shiftCollaborators(prefix, len(previous)-suffix, len(text)-suffix-prefix)
+}
+
+// collaboratorPosition is the screen position of a collaborator cursor,
+// relative to the text.
+type collaboratorPosition struct {
+	*textAreaCollaborator
+
+	// The selection as [fromRow, fromColumn, toRow, toColumn].
+	selection [4]int
+
+	// The head as [row, column].
+	head [2]int
+}
+
+// collaboratorPositions returns the screen positions of the collaborator
+// cursors. Cursors whose position is not known yet are omitted.
+func (t *TextArea) collaboratorPositions() []collaboratorPosition {
+	var positions []collaboratorPosition
+	for index := range t.collaborators {
+		selection, head, ok := t.cursorPosition(t.collaborators[index].textAreaCursor)
+		if ok {
+			positions = append(positions, collaboratorPosition{
+				textAreaCollaborator: &t.collaborators[index],
+				selection:            selection,
+				head:                 head,
+			})
+		}
+	}
+	return positions
+}
+
+// drawCollaborators draws the heads and the labels of the collaborator
+// cursors at the given positions onto the text area's text at (x,y) with the
+// given size, scrolled by the given column offset.
+func (t *TextArea) drawCollaborators(screen tcell.Screen, positions []collaboratorPosition, x, y, width, height, columnOffset int) {
+	for _, position := range positions {
+		column, row := position.head[1]-columnOffset, position.head[0]-t.rowOffset
+		if row < 0 || row >= height || column < 0 || column >= width {
+			continue
+		}
+		style := t.collaboratorStyle.Background(position.color)
+		str, _, _ := screen.Get(x+column, y+row)
+		if str == "" || str == "\t" {
+			str = " "
+		}
+		screen.Put(x+column, y+row, str, style)
+
+		// Draw the label.
+		if position.label == "" {
+			continue
+		}
+		labelRow := row - 1
+		if labelRow < 0 {
+			labelRow = row + 1
+		}
+		if labelRow < height {
+			printWithStyle(screen, position.label, x+column, y+labelRow, 0, width-column, AlignmentLeft, style, false)
+		}
+	}
+}
@@ -0,0 +1,428 @@
+package tview
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+// textAreaCursor is a cursor of a text area, given as byte offsets into the
+// text. The anchor is where the cursor's selection starts, the head is where
+// the cursor is located. They are the same if nothing is selected.
+type textAreaCursor struct {
+	anchor, head int
+}
+
+// start returns the start of the cursor's selection.
+func (c textAreaCursor) start() int {
+	return min(c.anchor, c.head)
+}
+
+// end returns the end (exclusive) of the cursor's selection.
+func (c textAreaCursor) end() int {
+	return max(c.anchor, c.head)
+}
+
+// AddCursor adds a cursor which selects the text between the given start and
+// end positions (a half-open interval of index positions within the entire
+// text, as in [TextArea.Select]), with the cursor located at the end. The new
+// cursor becomes the main cursor, i.e. the one shown by the terminal and kept
+// in view, while the previous main cursor becomes a secondary cursor.
+// Secondary cursors are drawn in reverse video.
+//
+// Typing, deleting, pasting, and cursor movements are applied at all cursors
+// simultaneously and can be undone in one step. Cursors which end up at the
+// same position or whose selections overlap are merged. Escape, a mouse click
+// without modifiers, undo, redo, and functions which replace the entire text
+// or parts of it remove the secondary cursors.
+func (t *TextArea) AddCursor(start, end int) *TextArea {
+	main := t.cursorOffsets()
+	added := textAreaCursor{anchor: max(min(start, end), 0), head: min(max(start, end), t.length)}
+	t.setCursors(added, append(t.extraCursors, main))
+	return t
+}
+
+// ClearCursors removes all secondary cursors, keeping only the main cursor.
+func (t *TextArea) ClearCursors() *TextArea {
+	t.extraCursors = nil
+	return t
+}
+
+// GetCursors returns the selections of all cursors, including the main
+// cursor, as half-open intervals of index positions within the entire text,
+// ordered by their position. The start and end positions are the same for
+// cursors without a selection.
+func (t *TextArea) GetCursors() [][2]int {
+	cursors := append([]textAreaCursor{t.cursorOffsets()}, t.extraCursors...)
+	slices.SortFunc(cursors, func(a, b textAreaCursor) int {
+		return a.start() - b.start()
+	})
+	ranges := make([][2]int, len(cursors))
+	for index, cursor := range cursors {
+		ranges[index] = [2]int{cursor.start(), cursor.end()}
+	}
+	return ranges
+}
+
+// SelectNextOccurrence selects the word under the main cursor if nothing is
+// selected. Otherwise, it adds a cursor which selects the next occurrence of
+// the main cursor's selected text, wrapping around at the end of the text (see
+// [TextArea.AddCursor]). This is bound to Ctrl-N by default, see
+// [KeyActionSelectNext].
+func (t *TextArea) SelectNextOccurrence() *TextArea {
+	selectionStart, cursor := t.selectionStart, t.cursor
+	t.selectNextOccurrence()
+	if (selectionStart != t.selectionStart || cursor != t.cursor) && t.moved != nil {
+		t.moved()
+	}
+	return t
+}
+
+// selectNextOccurrence implements [TextArea.SelectNextOccurrence] without
+// triggering a "moved" event.
+func (t *TextArea) selectNextOccurrence() {
+	main := t.cursorOffsets()
+	text := t.GetText()
+	if main.anchor == main.head {
+		// Select the word under the cursor.
+		isWord := func(r rune) bool {
+			return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+		}
+		start := strings.LastIndexFunc(text[:main.head], func(r rune) bool { return !isWord(r) }) + 1
+		end := strings.IndexFunc(text[main.head:], func(r rune) bool { return !isWord(r) })
+		if end < 0 {
+			end = len(text)
+		} else {
+			end += main.head
+		}
+		if start < end {
+			t.setCursors(textAreaCursor{anchor: start, head: end}, t.extraCursors)
+		}
+		return
+	}
+
+	// Find the next occurrence which is not selected yet.
+	needle := text[main.start():main.end()]
+	taken := func(start int) bool {
+		return slices.ContainsFunc(t.extraCursors, func(c textAreaCursor) bool {
+			return c.start() == start && c.end() == start+len(needle)
+		})
+	}
+	from := main.end()
+	for range 2 {
+		for from <= len(text) {
+			index := strings.Index(text[from:], needle)
+			if index < 0 {
+				break
+			}
+			start := from + index
+			if start == main.start() {
+				return // We wrapped around. All occurrences are selected.
+			}
+			if !taken(start) {
+				t.setCursors(textAreaCursor{anchor: start, head: start + len(needle)}, append(t.extraCursors, main))
+				return
+			}
+			from = start + len(needle)
+		}
+		from = 0
+	}
+}
+
+// cursorOffsets returns the main cursor as byte offsets into the text.
+func (t *TextArea) cursorOffsets() textAreaCursor {
+	_, start, end := t.GetSelection()
+	if t.cursor.row < t.selectionStart.row || t.cursor.row == t.selectionStart.row && t.cursor.actualColumn < t.selectionStart.actualColumn {
+		return textAreaCursor{anchor: end, head: start}
+	}
+	return textAreaCursor{anchor: start, head: end}
+}
+
+// setCursorOffsets places the main cursor at the given byte offsets. Scroll
+// offsets are not changed and no "moved" event is triggered.
+func (t *TextArea) setCursorOffsets(cursor textAreaCursor) {
+	moved := t.moved
+	t.moved = nil
+	t.Select(cursor.anchor, cursor.head)
+	t.moved = moved
+	if cursor.head < cursor.anchor {
+		t.cursor, t.selectionStart = t.selectionStart, t.cursor
+	}
+}
+
+// setCursors sets the main cursor and the secondary cursors. Secondary cursors
+// which overlap the main cursor or a preceding secondary cursor are dropped.
+// The main cursor is kept in view.
+func (t *TextArea) setCursors(main textAreaCursor, extra []textAreaCursor) {
+	extra = slices.Clone(extra)
+	slices.SortFunc(extra, func(a, b textAreaCursor) int {
+		return a.start() - b.start()
+	})
+	overlaps := func(a, b textAreaCursor) bool {
+		if a.start() > b.start() {
+			a, b = b, a
+		}
+		return a.start() == b.start() || b.start() < a.end()
+	}
+	t.extraCursors = t.extraCursors[:0]
+	for _, cursor := range extra {
+		if cursor.head < 0 || cursor.head > t.length || cursor.anchor < 0 || cursor.anchor > t.length || overlaps(cursor, main) {
+			continue
+		}
+		if last := len(t.extraCursors) - 1; last >= 0 && overlaps(t.extraCursors[last], cursor) {
+			continue
+		}
+		t.extraCursors = append(t.extraCursors, cursor)
+	}
+
+	t.setCursorOffsets(main)
+	t.findCursor(true, t.cursor.row)
+}
+
+// withCursors calls the given function once for every cursor, with the
+// respective cursor made the main cursor. The function receives the index of
+// the cursor in the order of the cursors' positions. Cursors are processed
+// from the last to the first so that the positions of cursors which were not
+// processed yet remain valid. All changes are grouped into one undo step and
+// "changed" and "moved" events are triggered at most once.
+func (t *TextArea) withCursors(f func(index int)) {
+	type cursor struct {
+		textAreaCursor
+		main bool
+	}
+	cursors := []cursor{{textAreaCursor: t.cursorOffsets(), main: true}}
+	for _, extra := range t.extraCursors {
+		cursors = append(cursors, cursor{textAreaCursor: extra})
+	}
+	slices.SortStableFunc(cursors, func(a, b cursor) int {
+		return a.start() - b.start()
+	})
+	before := slices.Clone(cursors)
+
+	// Suppress events while the cursors are processed.
+	changed, moved := t.changed, t.moved
+	var wasChanged bool
+	t.changed = func() {
+		wasChanged = true
+	}
+	t.moved = nil
+	rowOffset, columnOffset := t.rowOffset, t.columnOffset
+
+	t.BeginUndoGroup()
+	for index := len(cursors) - 1; index >= 0; index-- {
+		t.setCursorOffsets(cursors[index].textAreaCursor)
+		length := t.length
+		t.lastAction = taActionOther
+		f(index)
+		cursors[index].textAreaCursor = t.cursorOffsets()
+
+		// Shift the cursors after this one.
+		if delta := t.length - length; delta != 0 {
+			floor := cursors[index].start()
+			for later := index + 1; later < len(cursors); later++ {
+				cursors[later].anchor = max(cursors[later].anchor+delta, floor)
+				cursors[later].head = max(cursors[later].head+delta, floor)
+			}
+		}
+	}
+	t.EndUndoGroup()
+
+	t.changed, t.moved = changed, moved
+	t.rowOffset, t.columnOffset = rowOffset, columnOffset
+	var (
+		main  textAreaCursor
+		extra []textAreaCursor
+	)
+	for _, c := range cursors {
+		if c.main {
+			main = c.textAreaCursor
+		} else {
+			extra = append(extra, c.textAreaCursor)
+		}
+	}
+	t.setCursors(main, extra)
+
+	if wasChanged && t.changed != nil {
+		t.changed()
+	}
+	if !slices.Equal(before, cursors) && t.moved != nil {
+		t.moved()
+	}
+}
+
+// handleMultiCursorKey handles a resolved key event while there are secondary
+// cursors. It returns false if the event is to be handled at the main cursor
+// only.
+func (t *TextArea) handleMultiCursorKey(event *tcell.EventKey) (Command, bool) {
+	switch key, modifiers := event.Key(), event.Modifiers(); {
+	case key == tcell.KeyEscape:
+		t.extraCursors = nil
+		return RedrawCommand{}, true
+	case key == tcell.KeyBacktab,
+		key == tcell.KeyTab && t.finished != nil,
+		key == tcell.KeyEnter && t.finishOnEnter && t.finished != nil && modifiers&tcell.ModAlt == 0,
+		key == tcell.KeyCtrlN:
+		return nil, false // Handled once, at the main cursor.
+	case modifiers&tcell.ModAlt != 0 && (key == tcell.KeyLeft || key == tcell.KeyRight || key == tcell.KeyUp || key == tcell.KeyDown):
+		return nil, false // Scrolling.
+	case key == tcell.KeyCtrlL, key == tcell.KeyCtrlZ, key == tcell.KeyCtrlY:
+		t.extraCursors = nil
+		return nil, false
+	case key == tcell.KeyCtrlQ, key == tcell.KeyCtrlX:
+		t.copyToClipboard(t.getCursorsText())
+		t.withCursors(func(int) {
+			if key == tcell.KeyCtrlX {
+				t.deleteSelection()
+			}
+			t.selectionStart = t.cursor
+		})
+		return RedrawCommand{}, true
+	case key == tcell.KeyCtrlV:
+		t.pasteAtCursors(t.pasteFromClipboard())
+		return RedrawCommand{}, true
+	}
+
+	t.withCursors(func(int) {
+		t.processKeyEvent(event)
+	})
+	t.lastAction = taActionOther
+	return RedrawCommand{}, true
+}
+
+// getCursorsText returns the selected texts of all cursors, in the order of
+// their positions, separated by newlines.
+func (t *TextArea) getCursorsText() string {
+	text := t.GetText()
+	var selections []string
+	for _, r := range t.GetCursors() {
+		selections = append(selections, text[r[0]:r[1]])
+	}
+	return strings.Join(selections, "\n")
+}
+
+// deleteSelection deletes the text selected by the main cursor.
+func (t *TextArea) deleteSelection() {
+	from, to, row := t.getSelection()
+	if from == to {
+		return
+	}
+	t.cursor.pos = t.replace(from, to, "", false)
+	t.cursor.row = -1
+	t.truncateLines(row - 1)
+	t.findCursor(true, row)
+	t.selectionStart = t.cursor
+}
+
+// pasteAtCursors inserts the given text at all cursors, replacing their
+// selections. If the text has as many lines as there are cursors, each cursor
+// receives one line.
+func (t *TextArea) pasteAtCursors(text string) {
+	lines := strings.Split(text, "\n")
+	if len(lines) != len(t.extraCursors)+1 {
+		lines = nil
+	}
+	t.withCursors(func(index int) {
+		insert := text
+		if lines != nil {
+			insert = lines[index]
+		}
+		from, to, row := t.getSelection()
+		t.cursor.pos = t.replace(from, to, insert, false)
+		t.cursor.row = -1
+		t.truncateLines(row - 1)
+		t.findCursor(true, row)
+		t.selectionStart = t.cursor
+	})
+}
+
+// toggleCursorAt adds a cursor at the given screen position relative to the
+// text, making it the main cursor, or removes the cursor located there.
+func (t *TextArea) toggleCursorAt(row, column int) {
+	main := t.cursorOffsets()
+	t.moveCursor(row, column)
+	t.selectionStart = t.cursor
+	clicked := t.cursorOffsets()
+	if index := slices.IndexFunc(t.extraCursors, func(c textAreaCursor) bool {
+		return c.head == clicked.head
+	}); index >= 0 {
+		// Remove a secondary cursor.
+		t.setCursors(main, slices.Delete(slices.Clone(t.extraCursors), index, index+1))
+		return
+	}
+	if clicked.head == main.head && len(t.extraCursors) > 0 {
+		// Remove the main cursor. The last secondary cursor takes its place.
+		last := len(t.extraCursors) - 1
+		t.setCursors(t.extraCursors[last], t.extraCursors[:last])
+		return
+	}
+	t.setCursors(clicked, append(t.extraCursors, main))
+}
+
+// selectBlock places one cursor on each row between the row where the block
+// selection started and the given row, selecting the text between the
+// starting column and the given column. The cursor on the given row becomes
+// the main cursor.
+func (t *TextArea) selectBlock(row, column int) {
+	var (
+		main  textAreaCursor
+		extra []textAreaCursor
+	)
+	step := 1
+	if row < t.blockRow {
+		step = -1
+	}
+	for r := t.blockRow; ; r += step {
+		t.moveCursor(r, t.blockColumn)
+		anchor := t.cursor
+		t.moveCursor(r, column)
+		t.selectionStart = anchor
+		cursor := t.cursorOffsets()
+		if r == row {
+			main = cursor
+			break
+		}
+		extra = append(extra, cursor)
+	}
+	t.setCursors(main, extra)
+}
+
+// extraCursorPositions returns the screen positions, relative to the text, of
+// the selections and heads of the secondary cursors. The selections are
+// returned as [fromRow, fromColumn, toRow, toColumn] and the heads as [row,
+// column].
+func (t *TextArea) extraCursorPositions() (selections [][4]int, heads [][2]int) {
+	for _, extra := range t.extraCursors {
+		if selection, head, ok := t.cursorPosition(extra); ok {
+			selections = append(selections, selection)
+			heads = append(heads, head)
+		}
+	}
+	return
+}
+
+// cursorPosition returns the screen position, relative to the text, of the
+// selection and the head of the given cursor, see
+// [TextArea.extraCursorPositions]. It returns false if the position is not
+// known.
+func (t *TextArea) cursorPosition(c textAreaCursor) (selection [4]int, head [2]int, ok bool) {
+	cursor, selectionStart := t.cursor, t.selectionStart
+	defer func() {
+		t.cursor, t.selectionStart = cursor, selectionStart
+	}()
+	t.setCursorOffsets(c)
+	if t.cursor.row < 0 || t.selectionStart.row < 0 {
+		return
+	}
+	from, to := t.selectionStart, t.cursor
+	if to.row < from.row || to.row == from.row && to.actualColumn < from.actualColumn {
+		from, to = to, from
+	}
+	row, column := t.cursor.row, t.cursor.actualColumn
+	if t.wrap && column >= t.lastWidth {
+		row++
+		column = 0
+	}
+	return [4]int{from.row, from.actualColumn, to.row, to.actualColumn}, [2]int{row, column}, true
+}
@@ -0,0 +1,240 @@
+package tview
+
+import (
+	"sort"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+// Search highlights all matches of the given pattern and selects the first
+// match which starts at or after the start of the current selection (or the
+// cursor). Calling it whenever the pattern changes therefore results in an
+// incremental search. It returns the number of matches. An empty pattern ends
+// the search. An error is returned if a regular expression pattern is invalid,
+// in which case the search is ended, too.
+//
+// The matches are kept up to date when the text changes. Empty matches are
+// ignored. A "moved" event is triggered if the selection changes.
+func (t *TextArea) Search(pattern string, options SearchOptions) (int, error) {
+	search, err := compileSearch(pattern, options)
+	t.search, t.searchRegexp = search, options.Regexp
+	t.matches, t.matchesStale = nil, true
+	if err != nil || search == nil {
+		return 0, err
+	}
+
+	t.updateMatches()
+	_, start, _ := t.GetSelection()
+	index := sort.Search(len(t.matches), func(index int) bool {
+		return t.matches[index][0] >= start
+	})
+	if index == len(t.matches) {
+		index = 0 // Wrap around.
+	}
+	if len(t.matches) > 0 {
+		t.selectMatch(index)
+	}
+	return len(t.matches), nil
+}
+
+// ClearSearch ends the active search and removes all match highlights. The
+// selection is not changed.
+func (t *TextArea) ClearSearch() *TextArea {
+	t.search, t.matches = nil, nil
+	return t
+}
+
+// GetMatchCount returns the number of matches of the active search.
+func (t *TextArea) GetMatchCount() int {
+	t.updateMatches()
+	return len(t.matches)
+}
+
+// GetCurrentMatch returns the index of the match which is currently selected,
+// or -1 if the selection is not a match.
+func (t *TextArea) GetCurrentMatch() int {
+	t.updateMatches()
+	_, start, end := t.GetSelection()
+	return t.findMatch(start, end)
+}
+
+// NextMatch selects the first match after the cursor and scrolls it into view,
+// wrapping around at the end of the text. It returns false if there are no
+// matches. A "moved" event is triggered if the selection changes.
+func (t *TextArea) NextMatch() bool {
+	t.updateMatches()
+	if len(t.matches) == 0 {
+		return false
+	}
+	_, start, end := t.GetSelection()
+	index := sort.Search(len(t.matches), func(index int) bool {
+		return t.matches[index][0] > start || t.matches[index][0] == start && start == end
+	})
+	if index == len(t.matches) {
+		index = 0
+	}
+	t.selectMatch(index)
+	return true
+}
+
+// PrevMatch selects the last match before the cursor and scrolls it into view,
+// wrapping around at the start of the text. It returns false if there are no
+// matches. A "moved" event is triggered if the selection changes.
+func (t *TextArea) PrevMatch() bool {
+	t.updateMatches()
+	if len(t.matches) == 0 {
+		return false
+	}
+	_, start, _ := t.GetSelection()
+	index := sort.Search(len(t.matches), func(index int) bool {
+		return t.matches[index][0] >= start
+	}) - 1
+	if index < 0 {
+		index = len(t.matches) - 1
+	}
+	t.selectMatch(index)
+	return true
+}
+
+// ReplaceNext replaces the selected match with the given replacement and
+// selects the next match. If the selection is not a match, the next match is
+// selected without replacing anything, so that repeated calls replace one
+// match after another. It returns the number of replaced matches, i.e. 0 or 1.
+//
+// If the search pattern is a regular expression, the replacement may refer to
+// submatches, e.g. "$1" (see [regexp.Regexp.Expand]). The replacement can be
+// undone in one step.
+func (t *TextArea) ReplaceNext(replacement string) int {
+	t.updateMatches()
+	_, start, end := t.GetSelection()
+	index := t.findMatch(start, end)
+	if index < 0 {
+		t.NextMatch()
+		return 0
+	}
+
+	// Replace the match. Only one "moved" event is triggered.
+	text := t.GetText()
+	insert := t.expandReplacement(text, replacement, t.matches[index])
+	moved := t.moved
+	t.moved = nil
+	t.Replace(start, end, insert)
+	t.moved = moved
+	t.updateMatches()
+	if !t.NextMatch() && t.moved != nil {
+		t.moved()
+	}
+	return 1
+}
+
+// ReplaceAll replaces all matches of the active search with the given
+// replacement (see [TextArea.ReplaceNext] for the replacement syntax) and
+// returns the number of replaced matches. All replacements are undone in one
+// step. The cursor is placed after the first replacement and scroll offsets are
+// not changed. At most one "changed" and one "moved" event are triggered.
+func (t *TextArea) ReplaceAll(replacement string) int {
+	t.updateMatches()
+	if len(t.matches) == 0 {
+		return 0
+	}
+	text := t.GetText()
+	matches := t.matches
+
+	// Suppress events while replacing.
+	changed, moved := t.changed, t.moved
+	var wasChanged bool
+	t.changed = func() {
+		wasChanged = true
+	}
+	t.moved = nil
+
+	// Replace from the last match to the first so that the offsets of the
+	// remaining matches stay valid.
+	var count int
+	t.BeginUndoGroup()
+	for index := len(matches) - 1; index >= 0; index-- {
+		wasChanged = false
+		t.Replace(matches[index][0], matches[index][1], t.expandReplacement(text, replacement, matches[index]))
+		if wasChanged {
+			count++
+		}
+	}
+	t.EndUndoGroup()
+
+	t.changed, t.moved = changed, moved
+	if count > 0 && t.changed != nil {
+		t.changed()
+	}
+	if t.moved != nil {
+		t.moved()
+	}
+	return count
+}
+
+// SetSearchStyles sets the styles applied on top of the text of search matches
+// and of the selected match.
+func (t *TextArea) SetSearchStyles(match, current tcell.Style) *TextArea {
+	t.matchStyle = match
+	t.currentMatchStyle = current
+	return t
+}
+
+// updateMatches recomputes the matches of the active search if the text
+// changed.
+func (t *TextArea) updateMatches() {
+	if !t.matchesStale {
+		return
+	}
+	t.matchesStale = false
+	t.matches = t.matches[:0]
+	if t.search == nil {
+		return
+	}
+	for _, loc := range t.search.FindAllStringSubmatchIndex(t.GetText(), -1) {
+		if loc[0] < loc[1] {
+			t.matches = append(t.matches, loc)
+		}
+	}
+}
+
+// findMatch returns the index of the match spanning the given interval, or -1
+// if there is none.
+func (t *TextArea) findMatch(start, end int) int {
+	index := sort.Search(len(t.matches), func(index int) bool {
+		return t.matches[index][0] >= start
+	})
+	if index < len(t.matches) && t.matches[index][0] == start && t.matches[index][1] == end {
+		return index
+	}
+	return -1
+}
+
+// selectMatch selects the match with the given index and scrolls it into view.
+// Secondary cursors are removed.
+func (t *TextArea) selectMatch(index int) {
+	t.extraCursors = nil
+	t.Select(t.matches[index][0], t.matches[index][1])
+	t.findCursor(true, t.cursor.row)
+}
+
+// expandReplacement returns the replacement text for the given match of the
+// given text, expanding submatch references for regular expression searches.
+func (t *TextArea) expandReplacement(text, replacement string, match []int) string {
+	if !t.searchRegexp {
+		return replacement
+	}
+	return string(t.search.ExpandString(nil, replacement, text, match))
+}
+
+// offsetOf returns the byte offset into the text of the given span position.
+func (t *TextArea) offsetOf(pos [3]int) int {
+	var offset int
+	for index := t.spans[0].next; index != 1 && index != pos[0]; index = t.spans[index].next {
+		length := t.spans[index].length
+		if length < 0 {
+			length = -length
+		}
+		offset += length
+	}
+	return offset + pos[1]
+}
@@ -1,8 +1,17 @@
 package tview
 
 import (
+	"io"
 	"math"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/gdamore/tcell/v3"
 	"github.com/rivo/uniseg"
@@ -14,6 +23,7 @@ var TabSize = 4
 type textViewCell struct {
 	text          string
 	style         tcell.Style
+	region        string
 	width         int
 	optionalBreak bool
 	mustBreak     bool
@@ -23,6 +33,67 @@ type textViewLogicalLine struct {
 	line  Line
 	cells []textViewCell
 	width int
+
+	// The number of words and characters in the line, see
+	// [TextView.GetStats].
+	words, characters int
+
+	// The columns at which the line's tabs end when elastic tabstops are
+	// enabled.
+	tabStops []int
+
+	// Application-defined data attached to the line, see
+	// [TextView.SetLineData].
+	data any
+}
+
+// textViewMatch is a search match spanning the cells [start, end) of a
+// logical line.
+type textViewMatch struct {
+	line  int
+	start int
+	end   int
+}
+
+// textViewPosition is a position between two cells of a logical line.
+type textViewPosition struct {
+	line int
+	cell int
+}
+
+// before returns whether p comes before other.
+func (p textViewPosition) before(other textViewPosition) bool {
+	return p.line < other.line || p.line == other.line && p.cell < other.cell
+}
+
+// SearchOptions configures [TextView.Search] and [TextArea.Search].
+type SearchOptions struct {
+	// If set to true, letter case is ignored when matching. For literal
+	// patterns, letters are compared as by [FoldCase].
+	CaseInsensitive bool
+
+	// If set to true, the pattern is a regular expression (see package
+	// regexp). Otherwise, it is matched literally.
+	Regexp bool
+
+	// If set to true, letters in the pattern also match their variants with
+	// diacritics, e.g. "creme" matches "crème" (see [Fold]). This only
+	// applies to literal patterns.
+	IgnoreDiacritics bool
+}
+
+// compileSearch compiles a search pattern according to the given options. It
+// returns nil for an empty pattern.
+func compileSearch(pattern string, options SearchOptions) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	if !options.Regexp {
+		pattern = foldPattern(pattern, options.CaseInsensitive, options.IgnoreDiacritics)
+	} else if options.CaseInsensitive {
+		pattern = "(?i)" + pattern
+	}
+	return regexp.Compile(pattern)
 }
 
 type textViewLine struct {
@@ -32,6 +103,23 @@ type textViewLine struct {
 	width   int
 }
 
+const (
+	// The number of bytes read from a text view's reader at once.
+	textViewReaderChunk = 64 * 1024
+
+	// The number of lines between two line offsets kept for a text view's
+	// reader.
+	textViewReaderCheckpoint = 256
+
+	// The maximum number of bytes per line loaded from a text view's reader.
+	// The rest of the line is not shown.
+	textViewReaderMaxLine = 64 * 1024
+
+	// The minimum time between two calls of the changed handler while a text
+	// view's reader is scanned in the background.
+	textViewReaderProgress = 100 * time.Millisecond
+)
+
 // TextViewWriter is a writer that can be used to write to and clear a TextView
 // in batches, i.e. multiple writes with the lock only being acquired once. Don't
 // instantiated this class directly but use the TextView's BatchWriter method
@@ -62,6 +150,19 @@ func (w TextViewWriter) HasFocus() bool {
 	return w.t.hasFocus
 }
 
+// textViewKeyActions are the key actions supported by TextView.
+var textViewKeyActions = keyActionDefaults{
+	KeyActionUp:       {tcell.NewEventKey(tcell.KeyUp, "", tcell.ModNone), tcell.NewEventKey(tcell.KeyRune, "k", tcell.ModNone)},
+	KeyActionDown:     {tcell.NewEventKey(tcell.KeyDown, "", tcell.ModNone), tcell.NewEventKey(tcell.KeyRune, "j", tcell.ModNone)},
+	KeyActionLeft:     {tcell.NewEventKey(tcell.KeyLeft, "", tcell.ModNone), tcell.NewEventKey(tcell.KeyRune, "h", tcell.ModNone)},
+	KeyActionRight:    {tcell.NewEventKey(tcell.KeyRight, "", tcell.ModNone), tcell.NewEventKey(tcell.KeyRune, "l", tcell.ModNone)},
+	KeyActionPageUp:   {tcell.NewEventKey(tcell.KeyPgUp, "", tcell.ModNone), tcell.NewEventKey(tcell.KeyRune, "b", tcell.ModCtrl)},
+	KeyActionPageDown: {tcell.NewEventKey(tcell.KeyPgDn, "", tcell.ModNone), tcell.NewEventKey(tcell.KeyRune, "f", tcell.ModCtrl)},
+	KeyActionHome:     {tcell.NewEventKey(tcell.KeyHome, "", tcell.ModNone), tcell.NewEventKey(tcell.KeyRune, "g", tcell.ModNone)},
+	KeyActionEnd:      {tcell.NewEventKey(tcell.KeyEnd, "", tcell.ModNone), tcell.NewEventKey(tcell.KeyRune, "G", tcell.ModNone)},
+	KeyActionCopy:     {tcell.NewEventKey(tcell.KeyRune, "q", tcell.ModCtrl)},
+}
+
 // TextView is a component to display read-only text. The content is represented
 // as styled segments grouped by lines.
 type TextView struct {
@@ -75,6 +176,19 @@ type TextView struct {
 	// The logical lines.
 	lines []textViewLogicalLine
 
+	// The running word and character counts of the logical lines.
+	words, characters int
+
+	// An optional function which receives the text's statistics after
+	// changes, see [TextView.SetStatsFunc].
+	statsFunc func(stats TextViewStats)
+
+	// The minimum time between two calls of statsFunc.
+	statsInterval time.Duration
+
+	// The timer of the pending call of statsFunc, or nil.
+	statsTimer *time.Timer
+
 	// Wrapped visual lines for the current width.
 	wrapped []textViewLine
 
@@ -107,6 +221,35 @@ type TextView struct {
 	// mode).
 	columnOffset int
 
+	// Set to true after the "z" key was pressed, while waiting for the second
+	// key of a view positioning command ("zz", "zt", or "zb").
+	zPending bool
+
+	// The position of the word cursor which the "w", "b", and "e" keys move
+	// when lines are wrapped, and whether it is shown.
+	wordCursor    textViewPosition
+	hasWordCursor bool
+
+	// If set to true, the text view follows new text while scrolled to the end
+	// and pauses following while scrolled up.
+	followMode bool
+
+	// Whether the text view was following new text as of the last draw.
+	following bool
+
+	// An optional function which is called when following starts or stops.
+	followChanged func(following bool)
+
+	// The number of lines added while following was paused.
+	newLines int
+
+	// An optional function which returns the text of the indicator shown
+	// while following is paused and new lines were added.
+	newLinesIndicator func(count int) string
+
+	// The style of the new lines indicator.
+	newLinesStyle tcell.Style
+
 	// The maximum number of logical lines kept in memory. Ignored if 0.
 	maxLines int
 
@@ -123,9 +266,186 @@ type TextView struct {
 	// applied.
 	wordWrap bool
 
+	// The maximum width at which lines are wrapped. Ignored if 0.
+	wrapWidth int
+
+	// The indent of continuation rows of lines without their own indent.
+	hangingIndent []Segment
+
+	// The explicit tab stop columns, in ascending order. Beyond the last stop,
+	// tab stops are placed every TabSize columns. If empty, TabSize is used
+	// throughout.
+	tabStops []int
+
+	// If set to true, tab stops are placed such that the tab-separated columns
+	// of adjacent lines are aligned.
+	elasticTabstops bool
+
+	// The minimum number of cells between the end of an elastic column's
+	// content and the next column.
+	elasticTabPadding int
+
 	// The default style for newly written text.
 	textStyle tcell.Style
 
+	// The compiled search pattern, or nil if there is no active search.
+	search *regexp.Regexp
+
+	// The matches of the active search, in text order.
+	matches []textViewMatch
+
+	// The indices into matches, keyed by logical line.
+	matchesByLine map[int][]int
+
+	// The index of the current match, or -1 if there is none.
+	currentMatch int
+
+	// Set to true if the current match must be scrolled into view on the next
+	// draw.
+	scrollToMatch bool
+
+	// The styles applied on top of search matches and the current match.
+	matchStyle, currentMatchStyle tcell.Style
+
+	// The IDs of the currently highlighted regions.
+	highlights map[string]struct{}
+
+	// The styles applied to entire logical lines, keyed by line index.
+	highlightedLines map[int]tcell.Style
+
+	// If set to true, every other logical line is drawn with zebraStyle.
+	zebraStripes bool
+
+	// The style of striped lines.
+	zebraStyle tcell.Style
+
+	// The number of lines trimmed from the beginning of the text, which
+	// keeps the stripes with their lines.
+	zebraOffset int
+
+	// If set to true, Highlight toggles the given regions instead of replacing
+	// the current highlights.
+	toggleHighlights bool
+
+	// Set to true if the first highlighted region must be scrolled into view on
+	// the next draw.
+	scrollToHighlights bool
+
+	// The index of the paragraph which must be scrolled to the top on the next
+	// draw, or -1 if none.
+	scrollToParagraphIndex int
+
+	// An optional function which is called when the highlighted regions
+	// change.
+	highlighted func(added, removed, remaining []string)
+
+	// An optional function which is called when the user clicks on a
+	// hyperlink.
+	linkClicked func(url string)
+
+	// If set to true, URLs in the text are turned into hyperlinks.
+	autoLinkify bool
+
+	// The style added to automatically detected hyperlinks.
+	autoLinkStyle tcell.Style
+
+	// The anchor and the moving end of the text selection. The selection is
+	// empty if they are equal.
+	selectionStart, selectionEnd textViewPosition
+
+	// Set to true while the mouse is dragging to select text.
+	selecting bool
+
+	// The style of selected text.
+	selectedStyle tcell.Style
+
+	// An optional function which is called when the user copies the selected
+	// text. If nil, the text is copied to the system clipboard.
+	copy func(text string)
+
+	// The position and size of the text area (excluding the label) as of the
+	// last draw.
+	textX, textY, textWidth, textHeight int
+
+	// If set to true, line numbers are shown to the left of the text.
+	showLineNumbers bool
+
+	// The minimum number of digits of line numbers.
+	lineNumberWidth int
+
+	// The style of line numbers.
+	lineNumberStyle tcell.Style
+
+	// If set to true, line numbers are relative to the first visible line.
+	relativeLineNumbers bool
+
+	// The text columns at which vertical guides are drawn.
+	columnGuides []int
+
+	// If set to true, a ruler with column numbers is shown above the text.
+	ruler bool
+
+	// The style of column guides and the ruler.
+	guideStyle tcell.Style
+
+	// If set to true, tabs, trailing spaces, and non-breaking spaces are made
+	// visible.
+	showWhitespace bool
+
+	// The style of visible whitespace.
+	whitespaceStyle tcell.Style
+
+	// An optional function which is called after each visible line is drawn.
+	lineDecorator func(lineNo int, screen tcell.Screen, x, y, width int)
+
+	// The number of columns reserved for line decorations to the left of the
+	// text.
+	decorationWidth int
+
+	// The source of the text in reader mode, see SetReader. If nil, all text
+	// is held in lines.
+	reader io.ReaderAt
+
+	// The number of bytes available from the reader.
+	readerSize int64
+
+	// The byte offsets of every textViewReaderCheckpoint-th line of the
+	// reader, starting with line 0.
+	readerCheckpoints []int64
+
+	// The number of lines of the reader found so far.
+	readerLines int
+
+	// The byte offset up to which the reader was scanned for line breaks.
+	readerScanned int64
+
+	// The token of the goroutine scanning the rest of the reader, or nil if
+	// there is none. See scanReaderInBackground.
+	readerScan *struct{}
+
+	// The reader's line which is the first of the loaded lines.
+	readerTop int
+
+	// Custom keys for the built-in key actions.
+	keyMap KeyMap
+
+	// When the vertical and the horizontal scrollBar are shown.
+	scrollBarVisibility, horizontalScrollBarVisibility ScrollBarVisibility
+
+	// The vertical and the horizontal scrollBar.
+	scrollBar, horizontalScrollBar *ScrollBar
+
+	// Whether the vertical and the horizontal scrollBar were shown as of the
+	// last draw.
+	scrollBarShown, horizontalScrollBarShown bool
+
+	// The scrollBar whose thumb is being dragged with the mouse, or nil.
+	draggedScrollBar *ScrollBar
+
+	// The distance in subcells between the start of the dragged thumb and the
+	// mouse position.
+	scrollBarDragDelta int
+
 	// An optional function which is called when the content of the text view
 	// has changed.
 	changed func()
@@ -150,6 +470,51 @@ func NewTextView() *TextView {
 		wrap:       true,
 		wordWrap:   true,
 		textStyle:  tcell.StyleDefault.Background(Styles.PrimitiveBackgroundColor).Foreground(Styles.PrimaryTextColor),
+
+		currentMatch:           -1,
+		scrollToParagraphIndex: -1,
+		matchStyle:             tcell.StyleDefault.Background(Styles.SecondaryTextColor).Foreground(Styles.PrimitiveBackgroundColor),
+		currentMatchStyle:      tcell.StyleDefault.Background(Styles.TertiaryTextColor).Foreground(Styles.PrimitiveBackgroundColor),
+		selectedStyle:          tcell.StyleDefault.Background(Styles.PrimaryTextColor).Foreground(Styles.PrimitiveBackgroundColor),
+		lineNumberStyle:        tcell.StyleDefault.Background(Styles.PrimitiveBackgroundColor).Foreground(Styles.TertiaryTextColor),
+		newLinesStyle:          tcell.StyleDefault.Background(Styles.ContrastBackgroundColor).Foreground(Styles.PrimaryTextColor),
+		zebraStyle:             tcell.StyleDefault.Background(Styles.ContrastBackgroundColor),
+		autoLinkStyle:          tcell.StyleDefault.Underline(true),
+		guideStyle:             tcell.StyleDefault.Foreground(Styles.TertiaryTextColor).Dim(true),
+		whitespaceStyle:        tcell.StyleDefault.Foreground(Styles.TertiaryTextColor).Dim(true),
+
+		scrollBarVisibility:           ScrollBarVisibilityNever,
+		horizontalScrollBarVisibility: ScrollBarVisibilityNever,
+		scrollBar:                     NewScrollBar(),
+		horizontalScrollBar:           NewScrollBar().SetOrientation(ScrollBarHorizontal),
+	}
+}
+
+// applyTheme updates the text view's default styles after a theme change.
+func (t *TextView) applyTheme(change themeChange) {
+	t.Box.applyTheme(change)
+	t.labelStyle = change.style(t.labelStyle, themeSecondaryText, 0)
+	t.textStyle = change.style(t.textStyle, themePrimaryText, themePrimitiveBackground)
+	t.matchStyle = change.style(t.matchStyle, themePrimitiveBackground, themeSecondaryText)
+	t.currentMatchStyle = change.style(t.currentMatchStyle, themePrimitiveBackground, themeTertiaryText)
+	t.selectedStyle = change.style(t.selectedStyle, themePrimitiveBackground, themePrimaryText)
+	t.lineNumberStyle = change.style(t.lineNumberStyle, themeTertiaryText, themePrimitiveBackground)
+	t.newLinesStyle = change.style(t.newLinesStyle, themePrimaryText, themeContrastBackground)
+	t.zebraStyle = change.style(t.zebraStyle, 0, themeContrastBackground)
+	t.guideStyle = change.style(t.guideStyle, themeTertiaryText, 0)
+	t.whitespaceStyle = change.style(t.whitespaceStyle, themeTertiaryText, 0)
+
+	// Text written with the default text style follows the theme, too.
+	t.Lock()
+	defer t.Unlock()
+	for index := range t.lines {
+		logical := &t.lines[index]
+		for segment := range logical.line.Segments {
+			logical.line.Segments[segment].Style = change.style(logical.line.Segments[segment].Style, themePrimaryText, themePrimitiveBackground)
+		}
+		for cell := range logical.cells {
+			logical.cells[cell].style = change.style(logical.cells[cell].style, themePrimaryText, themePrimitiveBackground)
+		}
 	}
 }
 
@@ -228,6 +593,43 @@ func (t *TextView) SetWrap(wrap bool) *TextView {
 	return t
 }
 
+// SetWrapWidth sets the column at which lines are wrapped if wrapping is
+// enabled (see [TextView.SetWrap]), regardless of the width of the text view,
+// e.g. to limit the length of lines in a wide terminal. If the text view is
+// narrower, lines are wrapped at its width. A value of 0 (the default) wraps
+// at the width of the text view.
+func (t *TextView) SetWrapWidth(width int) *TextView {
+	t.Lock()
+	defer t.Unlock()
+	if t.wrapWidth != width {
+		t.wrapWidth = width
+		t.resetLayout()
+	}
+	return t
+}
+
+// SetHangingIndent sets the segments printed at the beginning of each
+// continuation row of a wrapped line, e.g. spaces for a hanging indent or "> "
+// for quoted blocks. This applies to all lines which do not have their own
+// indent (see [Line.WithIndent]). Indents are only printed for left-aligned
+// text. Calling this function without arguments removes the indent.
+func (t *TextView) SetHangingIndent(indent ...Segment) *TextView {
+	t.Lock()
+	defer t.Unlock()
+	t.hangingIndent = slices.Clone(indent)
+	t.resetLayout()
+	return t
+}
+
+// indent returns the segments printed at the beginning of continuation rows
+// of the given logical line.
+func (t *TextView) indent(lineIndex int) []Segment {
+	if indent := t.lines[lineIndex].line.Indent; len(indent) > 0 {
+		return indent
+	}
+	return t.hangingIndent
+}
+
 // SetWordWrap sets the flag that, if true and if the "wrap" flag is also true,
 // wraps according to Unicode line break opportunities.
 func (t *TextView) SetWordWrap(wrapOnWords bool) *TextView {
@@ -238,6 +640,324 @@ func (t *TextView) SetWordWrap(wrapOnWords bool) *TextView {
 	return t
 }
 
+// SetTabStops sets the columns (relative to the start of the line) at which tab
+// stops are placed. Beyond the last stop, tab stops continue every [TabSize]
+// columns. Calling this function without arguments restores the default of a
+// tab stop every [TabSize] columns. Tab stops only apply to left-aligned text.
+func (t *TextView) SetTabStops(stops ...int) *TextView {
+	t.Lock()
+	defer t.Unlock()
+	t.tabStops = t.tabStops[:0]
+	last := 0
+	for _, stop := range stops {
+		if stop > last {
+			t.tabStops = append(t.tabStops, stop)
+			last = stop
+		}
+	}
+	t.resetLayout()
+	return t
+}
+
+// SetElasticTabstops enables or disables elastic tabstops. When enabled, tabs
+// are treated as column separators and the tab stops of each column are placed
+// such that the column is aligned across all adjacent lines which contain that
+// column, as is the case for tab-separated command output. The padding is the
+// minimum number of cells between the widest text of a column and the next
+// column. Elastic tabstops take precedence over [TextView.SetTabStops] and only
+// apply to left-aligned text.
+func (t *TextView) SetElasticTabstops(enabled bool, padding int) *TextView {
+	t.Lock()
+	defer t.Unlock()
+	padding = max(padding, 1)
+	if t.elasticTabstops != enabled || t.elasticTabPadding != padding {
+		t.elasticTabstops = enabled
+		t.elasticTabPadding = padding
+		t.rebuildCells()
+		t.resetLayout()
+	}
+	return t
+}
+
+// SetFollowMode enables or disables follow mode, similar to "tail -f". In
+// follow mode, the text view starts out following new text, i.e. it stays
+// scrolled to the end as text is added. As soon as the user scrolls up,
+// following pauses and the number of lines added since then is counted (see
+// [TextView.SetNewLinesIndicator]). Following resumes when the user scrolls
+// back to the end, e.g. by pressing End.
+func (t *TextView) SetFollowMode(enabled bool) *TextView {
+	t.Lock()
+	defer t.Unlock()
+	if t.followMode == enabled {
+		return t
+	}
+	t.followMode = enabled
+	if enabled {
+		t.trackEnd = true
+		t.following = true
+		t.newLines = 0
+	}
+	return t
+}
+
+// IsFollowing returns whether the text view currently follows new text, i.e.
+// stays scrolled to the end as text is added.
+func (t *TextView) IsFollowing() bool {
+	t.Lock()
+	defer t.Unlock()
+	return t.trackEnd
+}
+
+// SetFollowChangedFunc sets a handler which is called in follow mode when
+// following pauses (false) or resumes (true). See [TextView.SetFollowMode].
+func (t *TextView) SetFollowChangedFunc(handler func(following bool)) *TextView {
+	t.followChanged = handler
+	return t
+}
+
+// SetNewLinesIndicator sets a function which returns the text of an indicator
+// shown in the bottom right corner while following is paused in follow mode
+// and new lines were added, e.g.:
+//
+//	textView.SetNewLinesIndicator(func(count int) string {
+//		return fmt.Sprintf(" %d new lines ↓ ", count)
+//	})
+//
+// No indicator is shown if the function is nil (the default) or returns an
+// empty string.
+func (t *TextView) SetNewLinesIndicator(indicator func(count int) string) *TextView {
+	t.newLinesIndicator = indicator
+	return t
+}
+
+// SetNewLinesIndicatorStyle sets the style of the new lines indicator.
+func (t *TextView) SetNewLinesIndicatorStyle(style tcell.Style) *TextView {
+	if t.newLinesStyle != style {
+		t.newLinesStyle = style
+	}
+	return t
+}
+
+// GetNewLineCount returns the number of lines added while following was paused
+// in follow mode.
+func (t *TextView) GetNewLineCount() int {
+	t.Lock()
+	defer t.Unlock()
+	return t.newLines
+}
+
+// updateFollowing updates the following state in follow mode after the scroll
+// position was determined for a text area of the given height. If following
+// started or stopped, it returns a function which notifies the handler and
+// must be called without holding the lock.
+func (t *TextView) updateFollowing(height int) (notify func()) {
+	if !t.trackEnd && t.lineOffset >= len(t.wrapped)-height {
+		// Scrolled (back) to the end.
+		t.trackEnd = true
+	}
+	if t.trackEnd == t.following {
+		return nil
+	}
+	t.following = t.trackEnd
+	t.newLines = 0
+	if t.followChanged != nil {
+		following, followChanged := t.following, t.followChanged
+		return func() {
+			followChanged(following)
+		}
+	}
+	return nil
+}
+
+// SetScrollBarVisibility sets when the vertical scrollBar is shown to the
+// right of the text. It is never shown by default. In reader mode (see
+// [TextView.SetReader]), the scrollBar is based on the lines of the reader
+// found so far.
+func (t *TextView) SetScrollBarVisibility(visibility ScrollBarVisibility) *TextView {
+	if t.scrollBarVisibility != visibility {
+		t.scrollBarVisibility = visibility
+	}
+	return t
+}
+
+// SetHorizontalScrollBarVisibility sets when the horizontal scrollBar is
+// shown below the text. It is never shown by default. The horizontal scrollBar
+// is only useful when lines are not wrapped, see [TextView.SetWrap].
+func (t *TextView) SetHorizontalScrollBarVisibility(visibility ScrollBarVisibility) *TextView {
+	if t.horizontalScrollBarVisibility != visibility {
+		t.horizontalScrollBarVisibility = visibility
+	}
+	return t
+}
+
+// SetScrollBar sets the ScrollBar primitive used as the vertical scrollBar.
+// Its lengths, offset, and position are set by the text view. If nil, no
+// vertical scrollBar is shown.
+func (t *TextView) SetScrollBar(scrollBar *ScrollBar) *TextView {
+	if scrollBar != nil {
+		scrollBar.SetOrientation(ScrollBarVertical)
+	}
+	t.scrollBar = scrollBar
+	return t
+}
+
+// SetHorizontalScrollBar sets the ScrollBar primitive used as the horizontal
+// scrollBar. Its lengths, offset, and position are set by the text view. If
+// nil, no horizontal scrollBar is shown.
+func (t *TextView) SetHorizontalScrollBar(scrollBar *ScrollBar) *TextView {
+	if scrollBar != nil {
+		scrollBar.SetOrientation(ScrollBarHorizontal)
+	}
+	t.horizontalScrollBar = scrollBar
+	return t
+}
+
+// layoutScrollBars returns whether the vertical and the horizontal scrollBar
+// are shown for a text area of the given size (including the scrollBars). The
+// layout of the last draw is tried first so the text is usually wrapped only
+// once.
+func (t *TextView) layoutScrollBars(width, height int) (vertical, horizontal bool) {
+	autoVertical := t.scrollBar != nil && width > 1 && t.scrollBarVisibility == ScrollBarVisibilityAutomatic
+	autoHorizontal := t.horizontalScrollBar != nil && height > 1 && t.horizontalScrollBarVisibility == ScrollBarVisibilityAutomatic
+	vertical = t.scrollBar != nil && width > 1 && (t.scrollBarVisibility == ScrollBarVisibilityAlways || autoVertical && t.scrollBarShown)
+	horizontal = t.horizontalScrollBar != nil && height > 1 && (t.horizontalScrollBarVisibility == ScrollBarVisibilityAlways || autoHorizontal && t.horizontalScrollBarShown)
+	if !autoVertical && !autoHorizontal {
+		return
+	}
+
+	// Showing one scrollBar may require showing the other, so we repeat until
+	// the layout is stable.
+	for range 3 {
+		w, h := width, height
+		if vertical {
+			w--
+		}
+		if horizontal {
+			h--
+		}
+		t.buildWrapped(w)
+		newVertical, newHorizontal := vertical, horizontal
+		if autoVertical {
+			newVertical = t.contentRows() > h
+		}
+		if autoHorizontal {
+			newHorizontal = t.longestLine > w
+		}
+		if newVertical == vertical && newHorizontal == horizontal {
+			break
+		}
+		vertical, horizontal = newVertical, newHorizontal
+	}
+	return
+}
+
+// contentRows returns the number of rows of the text, based on the wrapped
+// lines, or the number of reader lines found so far in reader mode.
+func (t *TextView) contentRows() int {
+	if t.reader != nil {
+		return t.readerLines
+	}
+	return len(t.wrapped)
+}
+
+// scrollBarOffset returns the current offset of the given scrollBar's
+// content.
+func (t *TextView) scrollBarOffset(scrollBar *ScrollBar) int {
+	if scrollBar == t.horizontalScrollBar {
+		if t.alignment == AlignmentCenter {
+			return t.columnOffset + max((t.longestLine-t.textWidth)/2, 0)
+		}
+		return t.columnOffset
+	}
+	if t.reader != nil {
+		return t.readerTop
+	}
+	return t.lineOffset
+}
+
+// setScrollBarOffset scrolls the text such that the given scrollBar's content
+// offset becomes the given value.
+func (t *TextView) setScrollBarOffset(scrollBar *ScrollBar, offset int) {
+	offset = max(offset, 0)
+	if scrollBar == t.horizontalScrollBar {
+		if t.alignment == AlignmentCenter {
+			offset -= max((t.longestLine-t.textWidth)/2, 0)
+		}
+		t.columnOffset = offset
+		return
+	}
+	t.trackEnd = false
+	if t.reader != nil {
+		t.readerTop = offset
+		t.lineOffset = 0
+		return
+	}
+	t.lineOffset = offset
+}
+
+// scrollBarIndex returns the given scrollBar's cell index at the given screen
+// coordinates.
+func (t *TextView) scrollBarIndex(scrollBar *ScrollBar, x, y int) int {
+	barX, barY, _, _ := scrollBar.GetInnerRect()
+	if scrollBar == t.horizontalScrollBar {
+		return x - barX
+	}
+	return y - barY
+}
+
+// scrollBarMouseDown handles a left mouse button press at the given screen
+// coordinates if it is on one of the scrollBars, returning the resulting
+// command, or nil if it is not on a scrollBar.
+func (t *TextView) scrollBarMouseDown(x, y int) Command {
+	t.Lock()
+	defer t.Unlock()
+
+	var scrollBar *ScrollBar
+	switch {
+	case t.scrollBarShown && t.scrollBar.InRect(x, y):
+		scrollBar = t.scrollBar
+	case t.horizontalScrollBarShown && t.horizontalScrollBar.InRect(x, y):
+		scrollBar = t.horizontalScrollBar
+	default:
+		return nil
+	}
+
+	cmd := BatchCommand{SetFocusCommand{Target: t}, RedrawCommand{}}
+	offset := t.scrollBarOffset(scrollBar)
+	part, trackPos := scrollBar.partAt(t.scrollBarIndex(scrollBar, x, y))
+	m := scrollBar.metrics(scrollBar.length())
+	switch part {
+	case scrollBarPartStartArrow:
+		t.setScrollBarOffset(scrollBar, offset-scrollBar.scrollStep)
+	case scrollBarPartEndArrow:
+		t.setScrollBarOffset(scrollBar, offset+scrollBar.scrollStep)
+	case scrollBarPartTrackBefore, scrollBarPartTrackAfter:
+		if scrollBar.trackClickBehavior == TrackClickBehaviorJumpToClick {
+			t.setScrollBarOffset(scrollBar, scrollBar.offsetForThumb(trackPos-m.thumbLen/2))
+			break
+		}
+		page := scrollBar.pageLength(scrollBar.length())
+		if part == scrollBarPartTrackBefore {
+			page = -page
+		}
+		t.setScrollBarOffset(scrollBar, offset+page)
+	case scrollBarPartThumb:
+		t.draggedScrollBar = scrollBar
+		t.scrollBarDragDelta = trackPos - m.thumbStart
+		cmd = append(cmd, SetMouseCaptureCommand{Target: t})
+	}
+	return cmd
+}
+
+// dragScrollBar moves the thumb of the dragged scrollBar to the given screen
+// coordinates.
+func (t *TextView) dragScrollBar(x, y int) {
+	scrollBar := t.draggedScrollBar
+	index := min(max(t.scrollBarIndex(scrollBar, x, y), 0), scrollBar.length()-1)
+	_, trackPos := scrollBar.partAt(index)
+	t.setScrollBarOffset(scrollBar, scrollBar.offsetForThumb(trackPos-t.scrollBarDragDelta))
+}
+
 // SetMaxLines sets the maximum number of logical lines for this text view.
 func (t *TextView) SetMaxLines(maxLines int) *TextView {
 	if t.maxLines != maxLines {
@@ -279,10 +999,8 @@ func (t *TextView) SetText(text string) *TextView {
 		return t
 	}
 	t.clear()
-	t.appendText(text, t.textStyle)
-	if t.changed != nil {
-		go t.changed()
-	}
+	t.appendText(Segment{Text: text, Style: t.textStyle})
+	t.notifyChanged()
 	return t
 }
 
@@ -291,7 +1009,9 @@ func (t *TextView) SetLines(lines []Line) *TextView {
 	t.Lock()
 	defer t.Unlock()
 
+	t.reader, t.readerScan = nil, nil
 	t.lines = make([]textViewLogicalLine, 0, len(lines))
+	t.words, t.characters = 0, 0
 	for _, line := range lines {
 		copied := Line{Segments: make([]Segment, 0, len(line.Segments)), Indent: line.Indent}
 		for _, seg := range line.Segments {
@@ -304,12 +1024,20 @@ func (t *TextView) SetLines(lines []Line) *TextView {
 	}
 	t.rebuildCells()
 	t.resetLayout()
-	if t.changed != nil {
-		go t.changed()
-	}
+	t.notifyChanged()
 	return t
 }
 
+// SetMarkdown replaces the content with the given Markdown text, rendered with
+// [Markdown] using [DefaultMarkdownStyles] based on the text style. To use
+// other styles, call [TextView.SetLines] with the result of [Markdown].
+func (t *TextView) SetMarkdown(markdown string) *TextView {
+	t.Lock()
+	style := t.textStyle
+	t.Unlock()
+	return t.SetLines(Markdown(markdown, DefaultMarkdownStyles(style)))
+}
+
 // GetLines returns a copy of the styled content.
 func (t *TextView) GetLines() []Line {
 	t.Lock()
@@ -329,11 +1057,9 @@ func (t *TextView) AppendSegments(segments ...Segment) *TextView {
 	t.Lock()
 	defer t.Unlock()
 	for _, seg := range segments {
-		t.appendText(seg.Text, seg.Style)
-	}
-	if t.changed != nil {
-		go t.changed()
+		t.appendText(seg)
 	}
+	t.notifyChanged()
 	return t
 }
 
@@ -345,44 +1071,225 @@ func (t *TextView) AppendLine(line Line) *TextView {
 		t.lines = append(t.lines, textViewLogicalLine{})
 	}
 	for _, seg := range line.Segments {
-		t.appendText(seg.Text, seg.Style)
+		t.appendText(seg)
 	}
 	t.lines = append(t.lines, textViewLogicalLine{})
-	t.rebuildCells()
-	t.resetLayout()
-	if t.changed != nil {
-		go t.changed()
+	if !t.trackEnd {
+		t.newLines++
 	}
+	t.linesReplaced(len(t.lines)-1, 0, 1)
+	t.notifyChanged()
 	return t
 }
 
-// GetText returns the current plain text of this text view.
-func (t *TextView) GetText() string {
-	if len(t.lines) == 0 {
-		return ""
-	}
-	result := ""
-	for i, logical := range t.lines {
-		for _, seg := range logical.line.Segments {
-			result += seg.Text
-		}
-		if i < len(t.lines)-1 {
-			result += "\n"
-		}
+// AppendText appends the given text to the end of the text view, using the
+// default text style. Unlike [TextView.SetText], only the last line and the
+// added lines are processed, making this function suitable for frequent small
+// additions to large texts. It is equivalent to [TextView.Write] with a string.
+func (t *TextView) AppendText(text string) *TextView {
+	t.Lock()
+	defer t.Unlock()
+	if text == "" {
+		return t
 	}
-	return result
+	t.appendText(Segment{Text: text, Style: t.textStyle})
+	t.notifyChanged()
+	return t
 }
 
-// GetOriginalLineCount returns the number of logical lines in the current text.
-func (t *TextView) GetOriginalLineCount() int {
-	if len(t.lines) == 0 {
-		return 0
-	}
-	return len(t.lines)
+// InsertText inserts the given text at the given byte offset of the text
+// returned by [TextView.GetText], using the default text style. Only the
+// affected lines are processed. See [TextView.ReplaceRange] for details.
+func (t *TextView) InsertText(offset int, text string) *TextView {
+	return t.ReplaceRange(offset, offset, text)
 }
 
-// GetWrappedLineCount returns the number of visual lines, taking wrapping into account.
-func (t *TextView) GetWrappedLineCount() int {
+// ReplaceRange replaces the text between the byte offsets start (inclusive)
+// and end (exclusive) of the text returned by [TextView.GetText] with the
+// provided text, which is given the default text style. Styles of the text
+// surrounding the range are preserved. Offsets are clamped to the text and
+// should fall on grapheme cluster boundaries.
+//
+// Only the affected lines are reprocessed, making this function suitable for
+// partial updates of large documents.
+func (t *TextView) ReplaceRange(start, end int, text string) *TextView {
+	t.Lock()
+	defer t.Unlock()
+
+	if len(t.lines) == 0 {
+		t.lines = append(t.lines, textViewLogicalLine{})
+	}
+	if end < start {
+		start, end = end, start
+	}
+	startLine, startOffset := t.lineAtOffset(start)
+	endLine, endOffset := t.lineAtOffset(end)
+
+	// Split the affected lines at the range boundaries.
+	head, _ := splitSegments(t.lines[startLine].line.Segments, startOffset)
+	_, tail := splitSegments(t.lines[endLine].line.Segments, endOffset)
+	firstIndent := t.lines[startLine].line.Indent
+
+	// Assemble the replacement lines.
+	var replacement []textViewLogicalLine
+	current := Line{Segments: head, Indent: firstIndent}
+	for {
+		nl := strings.IndexByte(text, '\n')
+		if nl < 0 {
+			break
+		}
+		current.Segments = appendSegment(current.Segments, Segment{Text: text[:nl], Style: t.textStyle})
+		replacement = append(replacement, textViewLogicalLine{line: current})
+		current = Line{}
+		text = text[nl+1:]
+	}
+	current.Segments = appendSegment(current.Segments, Segment{Text: text, Style: t.textStyle})
+	for _, seg := range tail {
+		current.Segments = appendSegment(current.Segments, seg)
+	}
+	replacement = append(replacement, textViewLogicalLine{line: current})
+
+	// Splice them into the buffer.
+	removed := endLine - startLine + 1
+	t.forgetLines(t.lines[startLine : endLine+1])
+	lines := make([]textViewLogicalLine, 0, len(t.lines)-removed+len(replacement))
+	lines = append(lines, t.lines[:startLine]...)
+	lines = append(lines, replacement...)
+	lines = append(lines, t.lines[endLine+1:]...)
+	t.lines = lines
+	t.linesReplaced(startLine, removed, len(replacement))
+	t.clearSelection()
+	t.trimMaxLines()
+
+	t.notifyChanged()
+	return t
+}
+
+// lineAtOffset returns the logical line containing the given byte offset of
+// the text returned by [TextView.GetText] and the byte offset within that line.
+// The offset is clamped to the text.
+func (t *TextView) lineAtOffset(offset int) (line, lineOffset int) {
+	offset = max(offset, 0)
+	for index, logical := range t.lines {
+		var length int
+		for _, seg := range logical.line.Segments {
+			length += len(seg.Text)
+		}
+		if offset <= length || index == len(t.lines)-1 {
+			return index, min(offset, length)
+		}
+		offset -= length + 1 // Including the newline.
+	}
+	return 0, 0
+}
+
+// repairWrapped updates the wrapped lines after the logical lines starting at
+// startLine were replaced, removing "removed" lines and inserting "inserted"
+// lines, without rewrapping the unaffected lines.
+func (t *TextView) repairWrapped(startLine, removed, inserted int) {
+	if t.wrapped == nil {
+		return // Layout will be built on the next draw.
+	}
+
+	// Find the visual lines of the replaced logical lines.
+	from := sort.Search(len(t.wrapped), func(row int) bool {
+		return t.wrapped[row].logical >= startLine
+	})
+	to := sort.Search(len(t.wrapped), func(row int) bool {
+		return t.wrapped[row].logical >= startLine+removed
+	})
+	var removedWidth int
+	for _, info := range t.wrapped[from:to] {
+		removedWidth = max(removedWidth, info.width)
+	}
+
+	var replacement []textViewLine
+	for i := range inserted {
+		replacement = t.wrapLine(replacement, startLine+i, t.lastWidth)
+	}
+
+	t.wrapped = slices.Replace(t.wrapped, from, to, replacement...)
+	if delta := inserted - removed; delta != 0 {
+		for row := from + len(replacement); row < len(t.wrapped); row++ {
+			t.wrapped[row].logical += delta
+		}
+	}
+
+	// Only rescan for the longest line if it may have been removed.
+	if to > from && removedWidth >= t.longestLine {
+		t.longestLine = 0
+		for _, info := range t.wrapped {
+			t.longestLine = max(t.longestLine, info.width)
+		}
+		return
+	}
+	for _, info := range replacement {
+		t.longestLine = max(t.longestLine, info.width)
+	}
+}
+
+// splitSegments splits the segments at the given byte offset of their
+// combined text.
+func splitSegments(segments []Segment, offset int) (before, after []Segment) {
+	for index, seg := range segments {
+		if offset >= len(seg.Text) {
+			before = append(before, seg)
+			offset -= len(seg.Text)
+			continue
+		}
+		if offset > 0 {
+			head := seg
+			head.Text = seg.Text[:offset]
+			before = append(before, head)
+			seg.Text = seg.Text[offset:]
+		}
+		after = append(after, seg)
+		after = append(after, segments[index+1:]...)
+		break
+	}
+	return
+}
+
+// appendSegment appends a segment, merging it into the last one if they share
+// the same style and region. Empty segments are skipped.
+func appendSegment(segments []Segment, seg Segment) []Segment {
+	if seg.Text == "" {
+		return segments
+	}
+	if n := len(segments); n > 0 && segments[n-1].Style == seg.Style && segments[n-1].Region == seg.Region {
+		segments[n-1].Text += seg.Text
+		return segments
+	}
+	return append(segments, seg)
+}
+
+// GetText returns the current plain text of this text view.
+func (t *TextView) GetText() string {
+	if len(t.lines) == 0 {
+		return ""
+	}
+	result := ""
+	for i, logical := range t.lines {
+		for _, seg := range logical.line.Segments {
+			result += seg.Text
+		}
+		if i < len(t.lines)-1 {
+			result += "\n"
+		}
+	}
+	return result
+}
+
+// GetOriginalLineCount returns the number of logical lines in the current text.
+func (t *TextView) GetOriginalLineCount() int {
+	if len(t.lines) == 0 {
+		return 0
+	}
+	return len(t.lines)
+}
+
+// GetWrappedLineCount returns the number of visual lines, taking wrapping into account.
+func (t *TextView) GetWrappedLineCount() int {
 	if len(t.lines) == 0 {
 		return 0
 	}
@@ -395,7 +1302,7 @@ func (t *TextView) GetWrappedLineCount() int {
 }
 
 // Height returns the required height for rendering the text view at the given
-// width when used as a scroll list item.
+// width when used as a [List] item.
 func (t *TextView) Height(width int) int {
 	if width < 1 {
 		return 1
@@ -403,11 +1310,33 @@ func (t *TextView) Height(width int) int {
 	if len(t.lines) == 0 {
 		return 1
 	}
+	if gutterWidth := t.gutterWidth(0) + t.decorationWidth; gutterWidth < width {
+		width -= gutterWidth
+	}
+	var extraHeight int
+	if t.ruler && t.alignment == AlignmentLeft {
+		extraHeight++
+	}
+	if t.scrollBar != nil && width > 1 && t.scrollBarVisibility == ScrollBarVisibilityAlways {
+		width--
+	}
+	if t.horizontalScrollBar != nil && t.horizontalScrollBarVisibility == ScrollBarVisibilityAlways {
+		extraHeight++
+	}
 	t.buildWrapped(width)
 	if len(t.wrapped) == 0 {
-		return 1
+		return 1 + extraHeight
 	}
-	return len(t.wrapped)
+	return len(t.wrapped) + extraHeight
+}
+
+// SetKeyMap sets custom keys for the text view's key actions, replacing their
+// default keys. The supported actions are KeyActionUp, KeyActionDown,
+// KeyActionLeft, KeyActionRight, KeyActionPageUp, KeyActionPageDown,
+// KeyActionHome, KeyActionEnd, and KeyActionCopy.
+func (t *TextView) SetKeyMap(keyMap KeyMap) *TextView {
+	t.keyMap = keyMap
+	return t
 }
 
 // SetChangedFunc sets a handler function which is called when the text of the
@@ -442,76 +1371,1673 @@ func (t *TextView) SetFormAttributes(labelWidth int, labelColor, bgColor, fieldT
 	if t.labelStyle != labelStyle {
 		t.labelStyle = labelStyle
 	}
-	textStyle := tcell.StyleDefault.Foreground(fieldTextColor).Background(bgColor)
-	if t.textStyle != textStyle {
-		t.textStyle = textStyle
+	textStyle := tcell.StyleDefault.Foreground(fieldTextColor).Background(bgColor)
+	if t.textStyle != textStyle {
+		t.textStyle = textStyle
+	}
+	return t
+}
+
+// ScrollTo scrolls to the specified row and column (both starting with 0). In
+// reader mode (see [TextView.SetReader]), the row is a line of the reader.
+func (t *TextView) ScrollTo(row, column int) *TextView {
+	if !t.scrollable {
+		return t
+	}
+	if t.reader != nil {
+		t.readerTop = row
+		row = 0
+	}
+	if t.lineOffset != row || t.columnOffset != column || t.trackEnd {
+		t.lineOffset = row
+		t.columnOffset = column
+		t.trackEnd = false
+	}
+	return t
+}
+
+// ScrollToBeginning scrolls to the top left corner of the text if the text view
+// is scrollable.
+func (t *TextView) ScrollToBeginning() *TextView {
+	if !t.scrollable {
+		return t
+	}
+	if t.trackEnd || t.lineOffset != 0 || t.columnOffset != 0 || t.readerTop != 0 {
+		t.trackEnd = false
+		t.lineOffset = 0
+		t.columnOffset = 0
+		t.readerTop = 0
+	}
+	return t
+}
+
+// ScrollToEnd scrolls to the bottom left corner of the text if the text view
+// is scrollable.
+func (t *TextView) ScrollToEnd() *TextView {
+	if !t.scrollable {
+		return t
+	}
+	if !t.trackEnd || t.columnOffset != 0 {
+		t.trackEnd = true
+		t.columnOffset = 0
+	}
+	return t
+}
+
+// ScrollToParagraph scrolls the text view such that the paragraph with the
+// given index (starting with 0) is at the top the next time the text view is
+// drawn. Paragraphs are separated by blank lines. If there are fewer
+// paragraphs, the view scrolls to the last one. This also stops the text view
+// from following newly added text. In reader mode (see [TextView.SetReader]),
+// only the loaded lines are considered.
+//
+// The keys "{" and "}" scroll to the previous and next paragraph, "(" and ")"
+// to the previous and next sentence.
+func (t *TextView) ScrollToParagraph(index int) *TextView {
+	t.Lock()
+	defer t.Unlock()
+	if t.scrollable {
+		t.scrollToParagraphIndex = max(index, 0)
+		t.trackEnd = false
+	}
+	return t
+}
+
+// GetParagraphCount returns the number of paragraphs, i.e. runs of non-blank
+// lines separated by blank lines. In reader mode (see [TextView.SetReader]),
+// only the loaded lines are considered.
+func (t *TextView) GetParagraphCount() int {
+	t.Lock()
+	defer t.Unlock()
+	return len(t.paragraphStarts())
+}
+
+// GetScrollOffset returns the number of rows and columns that are skipped at
+// the top left corner when the text view has been scrolled. In reader mode
+// (see [TextView.SetReader]), the row is the reader's first loaded line.
+func (t *TextView) GetScrollOffset() (row, column int) {
+	if t.reader != nil {
+		return t.readerTop, t.columnOffset
+	}
+	return t.lineOffset, t.columnOffset
+}
+
+// Clear removes all text from the buffer. This triggers the "changed" callback.
+func (t *TextView) Clear() *TextView {
+	t.Lock()
+	defer t.Unlock()
+	if len(t.lines) == 0 && t.reader == nil {
+		return t
+	}
+	t.clear()
+	t.notifyChanged()
+	return t
+}
+
+func (t *TextView) clear() {
+	t.reader, t.readerScan = nil, nil
+	t.lines = nil
+	t.words, t.characters = 0, 0
+	t.newLines = 0
+	t.zebraOffset = 0
+	t.hasWordCursor = false
+	t.resetLayout()
+	t.updateSearch()
+	t.clearSelection()
+}
+
+// SetShowLineNumbers sets whether line numbers are shown in a gutter to the
+// left of the text. With wrapping, a line's number is only shown next to its
+// first row.
+func (t *TextView) SetShowLineNumbers(show bool) *TextView {
+	if t.showLineNumbers != show {
+		t.showLineNumbers = show
+	}
+	return t
+}
+
+// SetLineNumberWidth sets the minimum number of digits reserved for line
+// numbers. The gutter grows if more digits are needed, and it is followed by
+// one column of padding. The default is 0, i.e. the gutter is as wide as the
+// highest line number.
+func (t *TextView) SetLineNumberWidth(width int) *TextView {
+	if t.lineNumberWidth != width {
+		t.lineNumberWidth = width
+	}
+	return t
+}
+
+// SetLineNumberStyle sets the style of line numbers.
+func (t *TextView) SetLineNumberStyle(style tcell.Style) *TextView {
+	if t.lineNumberStyle != style {
+		t.lineNumberStyle = style
+	}
+	return t
+}
+
+// SetRelativeLineNumbers sets whether line numbers are shown relative to the
+// first visible line, which itself shows its absolute number.
+func (t *TextView) SetRelativeLineNumbers(relative bool) *TextView {
+	if t.relativeLineNumbers != relative {
+		t.relativeLineNumbers = relative
+	}
+	return t
+}
+
+// SetColumnGuides sets the text columns, starting at 0, at which vertical
+// guides are drawn behind the text, e.g. 80 and 120 to show where lines exceed
+// these widths. Guides follow horizontal scrolling and are only drawn for
+// left-aligned text. Call without arguments to remove all guides.
+func (t *TextView) SetColumnGuides(columns ...int) *TextView {
+	t.Lock()
+	defer t.Unlock()
+	t.columnGuides = slices.Clone(columns)
+	return t
+}
+
+// SetRuler sets whether a ruler with column numbers is shown in the row above
+// the text. Like column guides, it is only drawn for left-aligned text.
+func (t *TextView) SetRuler(ruler bool) *TextView {
+	if t.ruler != ruler {
+		t.ruler = ruler
+	}
+	return t
+}
+
+// SetGuideStyle sets the style of column guides (see
+// [TextView.SetColumnGuides]) and the ruler (see [TextView.SetRuler]). Unset
+// colors are taken from the text style.
+func (t *TextView) SetGuideStyle(style tcell.Style) *TextView {
+	if t.guideStyle != style {
+		t.guideStyle = style
+	}
+	return t
+}
+
+// SetShowWhitespace sets whether whitespace is made visible: tabs are drawn
+// as [WhitespaceTab] followed by spaces, spaces at the end of a line as
+// [WhitespaceTrailingSpace], and non-breaking spaces as
+// [WhitespaceNonBreakingSpace], using the style set with
+// [TextView.SetWhitespaceStyle]. This can be toggled at any time, e.g. in diff
+// viewers.
+func (t *TextView) SetShowWhitespace(show bool) *TextView {
+	t.Lock()
+	defer t.Unlock()
+	t.showWhitespace = show
+	return t
+}
+
+// SetWhitespaceStyle sets the style of visible whitespace, see
+// [TextView.SetShowWhitespace]. Unset colors are taken from the text's style.
+func (t *TextView) SetWhitespaceStyle(style tcell.Style) *TextView {
+	t.Lock()
+	defer t.Unlock()
+	t.whitespaceStyle = style
+	return t
+}
+
+// trailingWhitespace returns the index of the first cell of the given logical
+// line's trailing whitespace, or the number of cells if there is none.
+func (t *TextView) trailingWhitespace(logical int) int {
+	cells := t.lines[logical].cells
+	index := len(cells)
+	for index > 0 && (cells[index-1].text == " " || cells[index-1].text == "\t") {
+		index--
+	}
+	return index
+}
+
+// rulerShown returns whether the ruler is drawn for a text area of the given
+// height.
+func (t *TextView) rulerShown(height int) bool {
+	return t.ruler && t.alignment == AlignmentLeft && height > 1
+}
+
+// drawGuides draws the ruler into the given row and the column guides into
+// the text area with the given position and size.
+func (t *TextView) drawGuides(screen tcell.Screen, x, y, width, height, rulerY int, ruler bool) {
+	if t.alignment != AlignmentLeft {
+		return
+	}
+	style := mergeStyle(t.textStyle, t.guideStyle)
+	for _, guide := range t.columnGuides {
+		column := guide - t.columnOffset
+		if column < 0 || column >= width {
+			continue
+		}
+		for row := range height {
+			screen.Put(x+column, y+row, BoxDrawingsLightVertical, style)
+		}
+	}
+	if !ruler {
+		return
+	}
+	for column := range width {
+		position, mark := t.columnOffset+column+1, "·"
+		switch {
+		case position%10 == 0:
+			mark = strconv.Itoa(position / 10 % 10)
+		case position%5 == 0:
+			mark = "+"
+		}
+		screen.Put(x+column, rulerY, mark, style)
+	}
+}
+
+// SetLineDecoratorFunc sets a function which is called after each visible
+// line is drawn, e.g. to add gutter icons, diff markers, or breakpoints. It
+// receives the index of the logical line (counted from the reader's first line
+// in reader mode, see [TextView.SetReader]) and the screen position of the
+// line's first visible row: x and width cover the columns reserved with
+// [TextView.SetDecorationWidth], followed by the text. Wrapped lines are
+// decorated only once. Use [TextView.GetLineData] to look up data attached to
+// the line.
+func (t *TextView) SetLineDecoratorFunc(handler func(lineNo int, screen tcell.Screen, x, y, width int)) *TextView {
+	t.lineDecorator = handler
+	return t
+}
+
+// SetDecorationWidth sets the number of columns reserved to the left of the
+// text (and to the right of line numbers, if shown) for line decorations, see
+// [TextView.SetLineDecoratorFunc]. The columns are filled with the text style
+// before the decorator is called.
+func (t *TextView) SetDecorationWidth(width int) *TextView {
+	width = max(width, 0)
+	if t.decorationWidth != width {
+		t.decorationWidth = width
+	}
+	return t
+}
+
+// SetLineData attaches application-defined data to the logical line with the
+// given index, e.g. for a line decorator (see [TextView.SetLineDecoratorFunc]).
+// The data stays with the line when lines are added or removed and is
+// discarded when the line is removed or the content is replaced. Lines which
+// are not loaded are ignored. In reader mode, line indices are counted from
+// the reader's first line and data is discarded when other lines are loaded.
+func (t *TextView) SetLineData(line int, data any) *TextView {
+	t.Lock()
+	defer t.Unlock()
+	if t.reader != nil {
+		line -= t.readerTop
+	}
+	if line >= 0 && line < len(t.lines) {
+		t.lines[line].data = data
+	}
+	return t
+}
+
+// GetLineData returns the data attached to the logical line with the given
+// index with [TextView.SetLineData], or nil if there is none.
+func (t *TextView) GetLineData(line int) any {
+	t.Lock()
+	defer t.Unlock()
+	if t.reader != nil {
+		line -= t.readerTop
+	}
+	if line < 0 || line >= len(t.lines) {
+		return nil
+	}
+	return t.lines[line].data
+}
+
+// gutterWidth returns the width of the line number gutter (including its
+// padding) for a text area of the given height, or 0 if line numbers are not
+// shown.
+func (t *TextView) gutterWidth(height int) int {
+	if !t.showLineNumbers {
+		return 0
+	}
+	count := len(t.lines)
+	if t.reader != nil {
+		count = max(t.readerLines, t.readerTop+height)
+	}
+	return max(len(strconv.Itoa(max(count, 1))), t.lineNumberWidth) + 1
+}
+
+// drawLineNumber draws the number of the given logical line into the gutter
+// at the given position. The first visible logical line is needed for
+// relative line numbers.
+func (t *TextView) drawLineNumber(screen tcell.Screen, lineIndex, firstLine, x, y, gutterWidth int) {
+	number := lineIndex + 1
+	if t.reader != nil {
+		number += t.readerTop
+	}
+	if t.relativeLineNumbers && lineIndex != firstLine {
+		number = lineIndex - firstLine
+		if number < 0 {
+			number = -number
+		}
+	}
+	text := strconv.Itoa(number)
+	for column := range gutterWidth {
+		screen.Put(x+column, y, " ", t.lineNumberStyle)
+	}
+	printWithStyle(screen, text, x, y, 0, gutterWidth-1, AlignmentRight, t.lineNumberStyle, false)
+}
+
+// SetReader replaces the text with the text provided by the given reader, of
+// which the first size bytes are shown. Text is loaded lazily: only the lines
+// shown in the text view are read, and only line offsets are kept in memory.
+// This allows showing files which are too large to be held in memory, e.g. an
+// [os.File] of a huge log file. Lines are separated by "\n". Line contents are
+// shown with the text view's text style, truncated to 64 KiB.
+//
+// In reader mode, the text view scrolls by the reader's lines. Scrolling to the
+// end requires the entire reader to be scanned for line breaks once. This
+// happens in a separate goroutine so drawing is not blocked. Until it is done,
+// the end of the lines found so far is shown and the changed handler (see
+// [TextView.SetChangedFunc]) is called periodically to redraw. Search,
+// regions, text selection, and GetText operate on the currently loaded lines
+// only. Writing or appending text is not supported. Calling SetText, SetLines,
+// or Clear ends reader mode.
+//
+// The reader's contents must not change, except that text may be appended to
+// it. Calling SetReader again with the same reader and a larger size keeps the
+// position and the line offsets found so far, e.g. to follow a growing log file
+// (combined with [TextView.ScrollToEnd]). Read errors are treated like the end
+// of the text and the read is retried on the next draw.
+func (t *TextView) SetReader(reader io.ReaderAt, size int64) *TextView {
+	t.Lock()
+	defer t.Unlock()
+	if reader != t.reader || size < t.readerSize {
+		t.clear()
+		t.reader = reader
+		t.readerCheckpoints = nil
+		t.readerLines = 0
+		t.readerScanned = 0
+		t.readerTop = 0
+		t.lineOffset = 0
+	}
+	t.readerSize = max(size, 0)
+	if t.readerLines == 0 && t.readerSize > 0 {
+		t.readerCheckpoints = []int64{0}
+		t.readerLines = 1
+	}
+	t.notifyChanged()
+	return t
+}
+
+// scanReader scans the reader for line breaks until the given number of lines
+// was found or the end of the reader is reached.
+func (t *TextView) scanReader(lines int) {
+	var buffer []byte
+	for t.readerLines < lines && t.readerScanned < t.readerSize {
+		if buffer == nil {
+			buffer = make([]byte, textViewReaderChunk)
+		}
+		if !t.scanReaderChunk(buffer) {
+			return
+		}
+	}
+}
+
+// scanReaderChunk scans the next chunk of the reader for line breaks, using
+// the given buffer. It returns false if nothing could be read.
+func (t *TextView) scanReaderChunk(buffer []byte) bool {
+	n, err := t.reader.ReadAt(buffer[:min(int64(len(buffer)), t.readerSize-t.readerScanned)], t.readerScanned)
+	for index, b := range buffer[:n] {
+		if b != '\n' {
+			continue
+		}
+		if t.readerLines%textViewReaderCheckpoint == 0 {
+			t.readerCheckpoints = append(t.readerCheckpoints, t.readerScanned+int64(index)+1)
+		}
+		t.readerLines++
+	}
+	t.readerScanned += int64(n)
+	return n > 0 && (err == nil || err == io.EOF)
+}
+
+// scanReaderInBackground starts scanning the rest of the reader for line
+// breaks in a separate goroutine unless that is already happening or the
+// reader was scanned entirely. The text view is only locked while a chunk is
+// scanned. The changed handler is called periodically and when the scan is
+// done, so the text view can be redrawn. The text view must be locked.
+func (t *TextView) scanReaderInBackground() {
+	if t.readerScan != nil || t.readerScanned >= t.readerSize {
+		return
+	}
+	scan := &struct{}{}
+	t.readerScan = scan
+	go func() {
+		buffer := make([]byte, textViewReaderChunk)
+		notified := time.Now()
+		for {
+			t.Lock()
+			if t.readerScan != scan {
+				t.Unlock()
+				return // Reader mode ended or the reader was replaced.
+			}
+			more := t.readerScanned < t.readerSize && t.scanReaderChunk(buffer)
+			if !more {
+				t.readerScan = nil
+			}
+			if !more || time.Since(notified) >= textViewReaderProgress {
+				notified = time.Now()
+				t.notifyChanged()
+			}
+			t.Unlock()
+			if !more {
+				return
+			}
+		}
+	}()
+}
+
+// readReaderLines returns up to count lines of the reader, starting with the
+// given line. The lines must have been scanned.
+func (t *TextView) readReaderLines(top, count int) (lines []string) {
+	if top < 0 || top >= t.readerLines || count <= 0 {
+		return nil
+	}
+	checkpoint := top / textViewReaderCheckpoint
+	offset := t.readerCheckpoints[checkpoint]
+	skip := top - checkpoint*textViewReaderCheckpoint
+	buffer := make([]byte, textViewReaderChunk)
+	var current []byte
+	addLine := func() {
+		lines = append(lines, strings.TrimSuffix(string(current), "\r"))
+		current = current[:0]
+	}
+Reading:
+	for offset < t.readerSize {
+		n, err := t.reader.ReadAt(buffer[:min(int64(len(buffer)), t.readerSize-offset)], offset)
+		for _, b := range buffer[:n] {
+			offset++
+			if b == '\n' {
+				if skip > 0 {
+					skip--
+					continue
+				}
+				addLine()
+				if len(lines) >= count {
+					break Reading
+				}
+			} else if skip == 0 && len(current) < textViewReaderMaxLine {
+				current = append(current, b)
+			}
+		}
+		if n == 0 || err != nil && err != io.EOF {
+			return
+		}
+	}
+	if skip == 0 && len(lines) < count && offset >= t.readerSize {
+		addLine() // The last line.
+	}
+	return
+}
+
+// loadReaderLines loads the lines of the reader to be shown in a text area of
+// the given height into lines, based on the current scroll position.
+func (t *TextView) loadReaderLines(height int) {
+	// Translate the scroll position into a reader line and the number of rows
+	// to skip of that line.
+	top, rows := t.readerTop, 0
+	switch {
+	case t.lineOffset < 0:
+		top += t.lineOffset
+	case t.lineOffset < len(t.wrapped):
+		info := t.wrapped[t.lineOffset]
+		top += info.logical
+		for row := t.lineOffset - 1; row >= 0 && t.wrapped[row].logical == info.logical; row-- {
+			rows++
+		}
+	default:
+		top += len(t.lines) + t.lineOffset - len(t.wrapped)
+	}
+
+	if t.trackEnd {
+		t.scanReaderInBackground()
+		top = t.readerLines - height
+	} else {
+		t.scanReader(top + height)
+	}
+	if t.readerLines < top+height {
+		top = t.readerLines - height
+	}
+	top = max(top, 0)
+	if top != t.readerTop {
+		t.clearSelection()
+	}
+	t.readerTop = top
+
+	t.lines = t.lines[:0]
+	t.words, t.characters = 0, 0
+	for _, text := range t.readReaderLines(top, height) {
+		var line Line
+		if text != "" {
+			line.Segments = []Segment{{Text: text, Style: t.textStyle}}
+		}
+		t.lines = append(t.lines, textViewLogicalLine{line: line})
+	}
+	t.rebuildCells()
+	t.resetLayout()
+	t.lineOffset = rows
+}
+
+// Highlight highlights the regions with the given IDs, i.e. the text of all
+// segments whose Region field is one of the IDs. Any previous highlights are
+// removed unless toggling is enabled with [TextView.SetToggleHighlights], in
+// which case the given regions are toggled. Calling Highlight without IDs
+// removes all highlights (when not toggling).
+//
+// Highlighted text is drawn with reversed colors. Regions are also highlighted
+// when the user clicks on them.
+func (t *TextView) Highlight(regionIDs ...string) *TextView {
+	t.Lock()
+	added, removed, remaining := t.highlight(regionIDs)
+	highlighted := t.highlighted
+	t.Unlock()
+
+	if highlighted != nil && (len(added) > 0 || len(removed) > 0) {
+		highlighted(added, removed, remaining)
+	}
+	return t
+}
+
+// highlight updates the highlighted regions as described in Highlight and
+// returns the regions which were added, removed, and which remain.
+func (t *TextView) highlight(regionIDs []string) (added, removed, remaining []string) {
+	next := make(map[string]struct{})
+	if t.toggleHighlights {
+		for id := range t.highlights {
+			next[id] = struct{}{}
+		}
+		for _, id := range regionIDs {
+			if _, ok := next[id]; ok {
+				delete(next, id)
+			} else {
+				next[id] = struct{}{}
+			}
+		}
+	} else {
+		for _, id := range regionIDs {
+			if id != "" {
+				next[id] = struct{}{}
+			}
+		}
+	}
+
+	for id := range next {
+		if _, ok := t.highlights[id]; ok {
+			remaining = append(remaining, id)
+		} else {
+			added = append(added, id)
+		}
+	}
+	for id := range t.highlights {
+		if _, ok := next[id]; !ok {
+			removed = append(removed, id)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	sort.Strings(remaining)
+	t.highlights = next
+	return
+}
+
+// GetHighlights returns the IDs of all currently highlighted regions, sorted
+// alphabetically.
+func (t *TextView) GetHighlights() (regionIDs []string) {
+	t.Lock()
+	defer t.Unlock()
+	for id := range t.highlights {
+		regionIDs = append(regionIDs, id)
+	}
+	sort.Strings(regionIDs)
+	return
+}
+
+// SetToggleHighlights sets a flag which determines how regions are
+// highlighted. If set to true, calling Highlight (or clicking on a region)
+// toggles the given regions and leaves all other highlights alone. If set to
+// false, Highlight replaces all highlights with the given regions.
+func (t *TextView) SetToggleHighlights(toggle bool) *TextView {
+	if t.toggleHighlights != toggle {
+		t.toggleHighlights = toggle
+	}
+	return t
+}
+
+// SetHighlightedFunc sets a handler which is called when the highlighted
+// regions change. The handler receives the IDs of the regions which were
+// added, removed, and which remain highlighted, each sorted alphabetically.
+func (t *TextView) SetHighlightedFunc(handler func(added, removed, remaining []string)) *TextView {
+	t.highlighted = handler
+	return t
+}
+
+// SetLinkClickedFunc sets a handler which is called when the user clicks on a
+// hyperlink, i.e. on text whose style has a URL (see [tcell.Style.Url]). The
+// handler receives the link's URL. Note that terminals which support OSC 8
+// hyperlinks may additionally open the link themselves, e.g. on Ctrl-click.
+func (t *TextView) SetLinkClickedFunc(handler func(url string)) *TextView {
+	t.linkClicked = handler
+	return t
+}
+
+// SetAutoLinkify sets whether "http://", "https://", and "mailto:" URLs in the
+// text are detected and turned into hyperlinks, i.e. whether they are drawn
+// with the style set with [TextView.SetAutoLinkStyle] and the URL attached
+// (see [tcell.Style.Url]). Clicking on them calls the handler set with
+// [TextView.SetLinkClickedFunc]. Text which already has a URL is not changed.
+func (t *TextView) SetAutoLinkify(linkify bool) *TextView {
+	t.Lock()
+	defer t.Unlock()
+	if t.autoLinkify != linkify {
+		t.autoLinkify = linkify
+		t.rebuildCells()
+		t.resetLayout()
+	}
+	return t
+}
+
+// SetAutoLinkStyle sets the style which is added to the style of hyperlinks
+// detected with [TextView.SetAutoLinkify]. Only attributes and colors which are
+// set in the given style are changed. The default is underlined text.
+func (t *TextView) SetAutoLinkStyle(style tcell.Style) *TextView {
+	t.Lock()
+	defer t.Unlock()
+	if t.autoLinkStyle != style {
+		t.autoLinkStyle = style
+		t.rebuildCells()
+	}
+	return t
+}
+
+// ScrollToHighlight scrolls the text view such that the first highlighted
+// region is visible the next time the text view is drawn. It has no effect if
+// there are no highlights. This also stops the text view from following newly
+// added text.
+func (t *TextView) ScrollToHighlight() *TextView {
+	t.Lock()
+	defer t.Unlock()
+	if len(t.highlights) > 0 && t.scrollable {
+		t.scrollToHighlights = true
+		t.trackEnd = false
+	}
+	return t
+}
+
+// GetRegionText returns the text of the region with the given ID. If the
+// region occurs multiple times, its parts are joined.
+func (t *TextView) GetRegionText(regionID string) string {
+	t.Lock()
+	defer t.Unlock()
+	if regionID == "" {
+		return ""
+	}
+	var (
+		b      strings.Builder
+		inside bool
+	)
+	for _, logical := range t.lines {
+		// A region continues on this line if the previous line ended in it
+		// and this line starts with it.
+		continued, first := inside, true
+		inside = false
+		for _, seg := range logical.line.Segments {
+			if seg.Text == "" {
+				continue
+			}
+			if seg.Region == regionID {
+				if first && continued {
+					b.WriteByte('\n')
+				}
+				b.WriteString(seg.Text)
+				inside = true
+			} else {
+				inside = false
+			}
+			first = false
+		}
+	}
+	return b.String()
+}
+
+// highlightedAt returns whether the given cell belongs to a highlighted
+// region.
+func (t *TextView) highlightedAt(cell textViewCell) bool {
+	if cell.region == "" {
+		return false
+	}
+	_, ok := t.highlights[cell.region]
+	return ok
+}
+
+// cellAt returns the cell drawn at the given screen coordinates, based on the
+// last draw. It returns false if there is no cell at these coordinates.
+func (t *TextView) cellAt(x, y int) (textViewCell, bool) {
+	row := t.lineOffset + y - t.textY
+	if y < t.textY || y >= t.textY+t.textHeight || row < 0 || row >= len(t.wrapped) {
+		return textViewCell{}, false
+	}
+	info := t.wrapped[row]
+	column := x - t.textX
+	skipWidth, xPos := t.lineStart(info, t.textWidth)
+	for _, cell := range t.lines[info.logical].cells[info.start:info.end] {
+		w := t.cellWidth(info.logical, cell, xPos)
+		if skipWidth > 0 {
+			skipWidth -= w
+			continue
+		}
+		if column < xPos+w {
+			return cell, column >= xPos
+		}
+		xPos += w
+	}
+	return textViewCell{}, false
+}
+
+// scrollToHighlight scrolls the first highlighted region into view. The
+// wrapped lines must have been built for the given viewport.
+func (t *TextView) scrollToHighlight(width, height int) {
+	t.scrollToHighlights = false
+	for row, info := range t.wrapped {
+		cells := t.lines[info.logical].cells
+		for index := info.start; index < info.end; index++ {
+			if !t.highlightedAt(cells[index]) {
+				continue
+			}
+			if row < t.lineOffset || row >= t.lineOffset+height {
+				t.lineOffset = row
+			}
+			if !t.wrap && t.alignment == AlignmentLeft {
+				var start int
+				for i := 0; i < index; i++ {
+					start += t.cellWidth(info.logical, cells[i], start)
+				}
+				if start < t.columnOffset || start >= t.columnOffset+width {
+					t.columnOffset = start
+				}
+			}
+			return
+		}
+	}
+}
+
+// SetSelectedStyle sets the style of selected text.
+func (t *TextView) SetSelectedStyle(style tcell.Style) *TextView {
+	t.Lock()
+	defer t.Unlock()
+	if t.selectedStyle != style {
+		t.selectedStyle = style
+	}
+	return t
+}
+
+// SetCopyFunc sets a handler which is called with the selected text when the
+// user copies it by pressing Ctrl-Q. If no handler is set, the text is copied
+// to the system clipboard (see [SetClipboardCommand]).
+//
+// Text is selected by dragging the mouse or by pressing Shift together with
+// the arrow keys, Home, or End.
+func (t *TextView) SetCopyFunc(handler func(text string)) *TextView {
+	t.copy = handler
+	return t
+}
+
+// GetSelection returns the currently selected text. Lines are separated by
+// newline characters. An empty string is returned if no text is selected.
+func (t *TextView) GetSelection() string {
+	t.Lock()
+	defer t.Unlock()
+	return t.selectedText()
+}
+
+// ClearSelection unselects any selected text.
+func (t *TextView) ClearSelection() *TextView {
+	t.Lock()
+	defer t.Unlock()
+	t.clearSelection()
+	return t
+}
+
+// clearSelection unselects any selected text.
+func (t *TextView) clearSelection() {
+	t.selectionStart = textViewPosition{}
+	t.selectionEnd = textViewPosition{}
+	t.selecting = false
+}
+
+// selection returns the normalized selection range and whether it is not
+// empty.
+func (t *TextView) selection() (from, to textViewPosition, ok bool) {
+	from, to = t.selectionStart, t.selectionEnd
+	if to.before(from) {
+		from, to = to, from
+	}
+	return from, to, from != to
+}
+
+// selected returns whether the given cell is selected.
+func (t *TextView) selected(lineIndex, cellIndex int) bool {
+	from, to, ok := t.selection()
+	if !ok {
+		return false
+	}
+	position := textViewPosition{line: lineIndex, cell: cellIndex}
+	return !position.before(from) && position.before(to)
+}
+
+// selectedText returns the currently selected text.
+func (t *TextView) selectedText() string {
+	from, to, ok := t.selection()
+	if !ok || from.line >= len(t.lines) {
+		return ""
+	}
+	var b strings.Builder
+	for lineIndex := from.line; lineIndex <= to.line && lineIndex < len(t.lines); lineIndex++ {
+		cells := t.lines[lineIndex].cells
+		start, end := 0, len(cells)
+		if lineIndex == from.line {
+			start = min(from.cell, len(cells))
+		}
+		if lineIndex == to.line {
+			end = min(to.cell, len(cells))
+		}
+		if lineIndex > from.line {
+			b.WriteByte('\n')
+		}
+		for _, cell := range cells[start:max(start, end)] {
+			b.WriteString(cell.text)
+		}
+	}
+	return b.String()
+}
+
+// lineStart returns the number of cells to skip at the beginning of the given
+// visual line and the column at which to start printing it, for a text area of
+// the given width.
+func (t *TextView) lineStart(info textViewLine, width int) (skipWidth, xPos int) {
+	switch t.alignment {
+	case AlignmentLeft:
+		skipWidth = t.columnOffset
+		if info.start != 0 {
+			for _, seg := range t.indent(info.logical) {
+				xPos += uniseg.StringWidth(seg.Text)
+			}
+		}
+	case AlignmentCenter:
+		skipWidth = t.columnOffset + (info.width-width)/2
+		if skipWidth < 0 {
+			skipWidth = 0
+			xPos = (width-info.width)/2 - t.columnOffset
+		}
+	case AlignmentRight:
+		maxWidth := max(t.longestLine, width)
+		skipWidth = t.columnOffset - (maxWidth - info.width)
+		if skipWidth < 0 {
+			skipWidth = 0
+			xPos = maxWidth - info.width - t.columnOffset
+		}
+	}
+	return
+}
+
+// positionAt returns the text position closest to the given screen
+// coordinates, based on the last draw. It returns false if nothing was drawn
+// yet.
+func (t *TextView) positionAt(x, y int) (textViewPosition, bool) {
+	if len(t.wrapped) == 0 || t.textWidth <= 0 {
+		return textViewPosition{}, false
+	}
+	row := min(max(t.lineOffset+y-t.textY, 0), len(t.wrapped)-1)
+	info := t.wrapped[row]
+	if y < t.textY {
+		return textViewPosition{line: info.logical, cell: info.start}, true
+	} else if y >= t.textY+t.textHeight {
+		return textViewPosition{line: info.logical, cell: info.end}, true
+	}
+
+	column := x - t.textX
+	skipWidth, xPos := t.lineStart(info, t.textWidth)
+	for index, cell := range t.lines[info.logical].cells[info.start:info.end] {
+		w := t.cellWidth(info.logical, cell, xPos)
+		if skipWidth > 0 {
+			skipWidth -= w
+			continue
+		}
+		if column < xPos+(w+1)/2 {
+			return textViewPosition{line: info.logical, cell: info.start + index}, true
+		}
+		xPos += w
+	}
+	return textViewPosition{line: info.logical, cell: info.end}, true
+}
+
+// extendSelection moves the end of the selection in response to the given
+// key, starting a new selection at the top of the view if there is none. It
+// returns false if the key does not extend the selection.
+func (t *TextView) extendSelection(key tcell.Key) bool {
+	if len(t.lines) == 0 {
+		return false
+	}
+	if _, _, ok := t.selection(); !ok {
+		start := textViewPosition{}
+		if t.lineOffset >= 0 && t.lineOffset < len(t.wrapped) {
+			info := t.wrapped[t.lineOffset]
+			start = textViewPosition{line: info.logical, cell: info.start}
+		}
+		t.selectionStart, t.selectionEnd = start, start
+	}
+
+	end := t.selectionEnd
+	end.line = min(end.line, len(t.lines)-1)
+	lineLength := len(t.lines[end.line].cells)
+	switch key {
+	case tcell.KeyLeft:
+		if end.cell > 0 {
+			end.cell = min(end.cell-1, lineLength)
+		} else if end.line > 0 {
+			end.line--
+			end.cell = len(t.lines[end.line].cells)
+		}
+	case tcell.KeyRight:
+		if end.cell < lineLength {
+			end.cell++
+		} else if end.line < len(t.lines)-1 {
+			end.line++
+			end.cell = 0
+		}
+	case tcell.KeyUp:
+		if end.line > 0 {
+			end.line--
+			end.cell = min(end.cell, len(t.lines[end.line].cells))
+		} else {
+			end.cell = 0
+		}
+	case tcell.KeyDown:
+		if end.line < len(t.lines)-1 {
+			end.line++
+			end.cell = min(end.cell, len(t.lines[end.line].cells))
+		} else {
+			end.cell = lineLength
+		}
+	case tcell.KeyHome:
+		end.cell = 0
+	case tcell.KeyEnd:
+		end.cell = lineLength
+	default:
+		return false
+	}
+	t.selectionEnd = end
+	t.scrollToLine(end.line)
+	return true
+}
+
+// scrollToLine scrolls the view such that the first visual line of the given
+// logical line is visible, based on the last draw.
+func (t *TextView) scrollToLine(lineIndex int) {
+	for row, info := range t.wrapped {
+		if info.logical != lineIndex {
+			continue
+		}
+		if row < t.lineOffset {
+			t.trackEnd = false
+			t.lineOffset = row
+		} else if row >= t.lineOffset+t.textHeight {
+			t.lineOffset = row - t.textHeight + 1
+		}
+		return
+	}
+}
+
+// GetVisibleRange returns the indices of the first and last logical lines
+// which are at least partially visible, based on the last draw. In reader mode
+// (see [TextView.SetReader]), these are lines of the reader. If no text is
+// visible, both are -1.
+func (t *TextView) GetVisibleRange() (firstLine, lastLine int) {
+	t.Lock()
+	defer t.Unlock()
+	if len(t.wrapped) == 0 || t.textHeight <= 0 {
+		return -1, -1
+	}
+	first := min(max(t.lineOffset, 0), len(t.wrapped)-1)
+	last := min(first+t.textHeight, len(t.wrapped)) - 1
+	return t.readerTop + t.wrapped[first].logical, t.readerTop + t.wrapped[last].logical
+}
+
+// wordClass returns the class of the given character for word motions: 0 for
+// whitespace, 1 for letters, digits, and underscores, and 2 for everything
+// else.
+func wordClass(text string) int {
+	r, _ := utf8.DecodeRuneInString(text)
+	switch {
+	case text == "" || unicode.IsSpace(r):
+		return 0
+	case r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+		return 1
+	}
+	return 2
+}
+
+// scrollToWord scrolls horizontally to the start of the next word ("w"), the
+// start of the previous word ("b"), or the last character of the next word
+// ("e") of the first visible line, based on the last draw. Words are runs of
+// letters, digits, and underscores, or runs of other non-blank characters. It
+// only has an effect on left-aligned text. When lines are wrapped, the word
+// cursor is moved instead, see [TextView.moveWordCursor].
+func (t *TextView) scrollToWord(motion string) {
+	if t.wrap {
+		t.moveWordCursor(motion)
+		return
+	}
+	if t.alignment != AlignmentLeft || len(t.wrapped) == 0 {
+		return
+	}
+	info := t.wrapped[min(max(t.lineOffset, 0), len(t.wrapped)-1)]
+
+	// Collect the columns where words start and end.
+	var (
+		starts, ends      []int
+		column, lastClass int
+	)
+	for _, cell := range t.lines[info.logical].cells[info.start:info.end] {
+		class := wordClass(cell.text)
+		if class != lastClass {
+			if class != 0 {
+				starts = append(starts, column)
+			}
+			if lastClass != 0 {
+				ends = append(ends, column-1)
+			}
+		}
+		lastClass = class
+		column += t.cellWidth(info.logical, cell, column)
+	}
+	if lastClass != 0 {
+		ends = append(ends, column-1)
+	}
+
+	switch motion {
+	case "w":
+		if index := sort.SearchInts(starts, t.columnOffset+1); index < len(starts) {
+			t.columnOffset = starts[index]
+		}
+	case "b":
+		if index := sort.SearchInts(starts, t.columnOffset); index > 0 {
+			t.columnOffset = starts[index-1]
+		} else {
+			t.columnOffset = 0
+		}
+	case "e":
+		if index := sort.SearchInts(ends, t.columnOffset+1); index < len(ends) {
+			t.columnOffset = ends[index]
+		}
+	}
+}
+
+// moveWordCursor moves the word cursor to the start of the next word ("w"),
+// the start of the previous word ("b"), or the last character of the next word
+// ("e"), across lines, and scrolls it into view, based on the last draw. If
+// the cursor is not visible, it starts at the first visible cell. Line breaks
+// separate words like blanks.
+func (t *TextView) moveWordCursor(motion string) {
+	if len(t.wrapped) == 0 {
+		return
+	}
+
+	// Start at the cursor if it is visible.
+	first := min(max(t.lineOffset, 0), len(t.wrapped)-1)
+	last := min(first+max(t.textHeight, 1), len(t.wrapped)) - 1
+	top, bottom := t.wrapped[first], t.wrapped[last]
+	from := textViewPosition{line: top.logical, cell: top.start}
+	to := textViewPosition{line: bottom.logical, cell: bottom.end}
+	pos := t.wordCursor
+	if !t.hasWordCursor || pos.before(from) || !pos.before(to) || pos.line >= len(t.lines) {
+		pos = from
+		if motion == "w" {
+			pos.cell-- // A word at the first visible cell is the next word.
+		}
+	}
+
+	// class returns the word class of the cell at the given position, 0
+	// outside the line.
+	class := func(p textViewPosition) int {
+		if p.cell < 0 || p.cell >= len(t.lines[p.line].cells) {
+			return 0
+		}
+		return wordClass(t.lines[p.line].cells[p.cell].text)
+	}
+	step := func(p textViewPosition, direction int) (textViewPosition, bool) {
+		p.cell += direction
+		for p.cell < -1 || p.cell > len(t.lines[p.line].cells) {
+			if p.line+direction < 0 || p.line+direction >= len(t.lines) {
+				return p, false
+			}
+			p.line += direction
+			p.cell = -1
+			if direction < 0 {
+				p.cell = len(t.lines[p.line].cells)
+			}
+		}
+		return p, true
+	}
+
+	direction := 1
+	if motion == "b" {
+		direction = -1
+	}
+	for {
+		next, ok := step(pos, direction)
+		if !ok {
+			return // No more words.
+		}
+		pos = next
+		c := class(pos)
+		if c == 0 {
+			continue
+		}
+		before, after := pos, pos
+		before.cell--
+		after.cell++
+		if motion == "e" && class(after) != c || motion != "e" && class(before) != c {
+			break
+		}
+	}
+	t.wordCursor, t.hasWordCursor = pos, true
+
+	// Scroll the cursor into view.
+	for row, info := range t.wrapped {
+		if info.logical == pos.line && pos.cell >= info.start && (pos.cell < info.end || row == len(t.wrapped)-1 || t.wrapped[row+1].logical != pos.line) {
+			t.trackEnd = false
+			if row < t.lineOffset {
+				t.lineOffset = row
+			} else if row >= t.lineOffset+t.textHeight {
+				t.lineOffset = row - t.textHeight + 1
+			}
+			break
+		}
+	}
+}
+
+// scrollToParagraph scrolls to the next (if forward is true) or previous
+// blank line separating paragraphs, relative to the first visible line, based
+// on the last draw. If there is no such line, it scrolls to the end or the
+// beginning of the text, respectively.
+func (t *TextView) scrollToParagraph(forward bool) {
+	if len(t.wrapped) == 0 {
+		return
+	}
+	blank := t.blankLine
+	line := t.wrapped[min(max(t.lineOffset, 0), len(t.wrapped)-1)].logical
+	t.trackEnd = false
+	if forward {
+		line++
+		for line < len(t.lines) && blank(line) {
+			line++
+		}
+		for line < len(t.lines) && !blank(line) {
+			line++
+		}
+		if line >= len(t.lines) {
+			t.lineOffset = len(t.wrapped) // Will be clamped (or load more lines in reader mode).
+			return
+		}
+	} else {
+		line--
+		for line >= 0 && blank(line) {
+			line--
+		}
+		for line >= 0 && !blank(line) {
+			line--
+		}
+		if line < 0 {
+			if t.reader != nil {
+				t.lineOffset = -t.textHeight // Load the previous lines.
+			} else {
+				t.lineOffset = 0
+			}
+			return
+		}
+	}
+	for row, info := range t.wrapped {
+		if info.logical == line {
+			t.lineOffset = row
+			return
+		}
+	}
+}
+
+// blankLine returns whether the given logical line contains only whitespace.
+func (t *TextView) blankLine(lineIndex int) bool {
+	return strings.TrimSpace(t.lines[lineIndex].line.String()) == ""
+}
+
+// paragraphStarts returns the indices of the logical lines starting a
+// paragraph.
+func (t *TextView) paragraphStarts() (starts []int) {
+	for index := range t.lines {
+		if !t.blankLine(index) && (index == 0 || t.blankLine(index-1)) {
+			starts = append(starts, index)
+		}
+	}
+	return
+}
+
+// scrollToParagraphStart scrolls the paragraph requested with
+// [TextView.ScrollToParagraph] to the top. The wrapped lines must have been
+// built.
+func (t *TextView) scrollToParagraphStart() {
+	index := t.scrollToParagraphIndex
+	t.scrollToParagraphIndex = -1
+	starts := t.paragraphStarts()
+	if len(starts) == 0 {
+		return
+	}
+	line := starts[min(index, len(starts)-1)]
+	for row, info := range t.wrapped {
+		if info.logical == line {
+			t.lineOffset = row
+			return
+		}
+	}
+}
+
+// sentenceStarts returns the indices of the cells of the given logical line at
+// which sentences start, skipping leading whitespace.
+func (t *TextView) sentenceStarts(lineIndex int) (starts []int) {
+	cells := t.lines[lineIndex].cells
+
+	// Find the byte offsets at which uniseg reports sentence boundaries.
+	var text strings.Builder
+	for _, cell := range cells {
+		text.WriteString(cell.text)
+	}
+	boundaries := map[int]bool{0: true}
+	var (
+		state  *stepState
+		offset int
+	)
+	for str := text.String(); len(str) > 0; {
+		_, str, state = step(str, state)
+		offset += state.GrossLength()
+		if state.SentenceBreak() {
+			boundaries[offset] = true
+		}
+	}
+
+	// Map them to the first non-blank cell of each sentence.
+	offset = 0
+	pending := false
+	for index, cell := range cells {
+		if boundaries[offset] {
+			pending = true
+		}
+		if pending && strings.TrimSpace(cell.text) != "" {
+			starts = append(starts, index)
+			pending = false
+		}
+		offset += len(cell.text)
+	}
+	return
+}
+
+// scrollToSentence scrolls such that the start of the next (if forward is
+// true) or previous sentence is in the first visible line, based on the last
+// draw. Unwrapped, left-aligned text is also scrolled horizontally to the
+// sentence's start. If there is no such sentence, it scrolls to the end or the
+// beginning of the text, respectively.
+func (t *TextView) scrollToSentence(forward bool) {
+	if len(t.wrapped) == 0 {
+		return
+	}
+	horizontal := !t.wrap && t.alignment == AlignmentLeft
+	topRow := min(max(t.lineOffset, 0), len(t.wrapped)-1)
+	current := 0
+	if horizontal {
+		current = t.columnOffset
+	}
+
+	// Collect the (row, column) positions of sentence starts line by line,
+	// moving away from the top row.
+	type position struct{ row, column int }
+	positions := func(lineIndex int) (result []position) {
+		starts := t.sentenceStarts(lineIndex)
+		row := sort.Search(len(t.wrapped), func(row int) bool {
+			return t.wrapped[row].logical >= lineIndex
+		})
+		for ; row < len(t.wrapped) && t.wrapped[row].logical == lineIndex; row++ {
+			info := t.wrapped[row]
+			column := 0
+			cells := t.lines[lineIndex].cells
+			for index := info.start; index < info.end; index++ {
+				if len(starts) > 0 && starts[0] == index {
+					result = append(result, position{row, column})
+					starts = starts[1:]
+				}
+				column += t.cellWidth(lineIndex, cells[index], column)
+			}
+		}
+		return
+	}
+	after := func(p position) bool {
+		return p.row > topRow || horizontal && p.row == topRow && p.column > current
+	}
+	before := func(p position) bool {
+		return p.row < topRow || horizontal && p.row == topRow && p.column < current
+	}
+
+	t.trackEnd = false
+	line := t.wrapped[topRow].logical
+	if forward {
+		for ; line < len(t.lines); line++ {
+			for _, p := range positions(line) {
+				if after(p) {
+					t.lineOffset = p.row
+					if horizontal {
+						t.columnOffset = p.column
+					}
+					return
+				}
+			}
+		}
+		t.lineOffset = len(t.wrapped) // Will be clamped (or load more lines in reader mode).
+		return
+	}
+	for ; line >= 0; line-- {
+		found := positions(line)
+		for index := len(found) - 1; index >= 0; index-- {
+			if p := found[index]; before(p) {
+				t.lineOffset = p.row
+				if horizontal {
+					t.columnOffset = p.column
+				}
+				return
+			}
+		}
+	}
+	if t.reader != nil {
+		t.lineOffset = -t.textHeight // Load the previous lines.
+	} else {
+		t.lineOffset = 0
+	}
+	t.columnOffset = 0
+}
+
+// positionView scrolls such that the reference line is at the top ("t"), in
+// the center ("z"), or at the bottom ("b") of the view, based on the last
+// draw. The reference line is the line with the end of the selection or, if
+// there is no selection, the line with the current search match, the line
+// with the word cursor, or the first visible line, in this order.
+func (t *TextView) positionView(motion string) {
+	var reference textViewPosition
+	if _, _, ok := t.selection(); ok {
+		reference = t.selectionEnd
+	} else if t.currentMatch >= 0 && t.currentMatch < len(t.matches) {
+		match := t.matches[t.currentMatch]
+		reference = textViewPosition{line: match.line, cell: match.start}
+	} else if t.hasWordCursor && t.wordCursor.line < len(t.lines) {
+		reference = t.wordCursor
+	} else if len(t.wrapped) > 0 {
+		info := t.wrapped[min(max(t.lineOffset, 0), len(t.wrapped)-1)]
+		reference = textViewPosition{line: info.logical, cell: info.start}
+	} else {
+		return
+	}
+
+	row := -1
+	for index, info := range t.wrapped {
+		if info.logical == reference.line {
+			row = index
+			if reference.cell < info.end {
+				break
+			}
+		} else if row >= 0 {
+			break
+		}
+	}
+	if row < 0 {
+		return
+	}
+
+	t.trackEnd = false
+	switch motion {
+	case "t":
+		t.lineOffset = row
+	case "z":
+		t.lineOffset = row - t.textHeight/2
+	case "b":
+		t.lineOffset = row - t.textHeight + 1
+	}
+}
+
+// copySelection copies the selected text, returning the command needed to do
+// so, if any.
+func (t *TextView) copySelection() Command {
+	text := t.selectedText()
+	if text == "" {
+		return nil
+	}
+	if t.copy != nil {
+		t.copy(text)
+		return nil
+	}
+	return SetClipboardCommand(text)
+}
+
+// Search highlights all matches of the given pattern and returns the number of
+// matches. An empty pattern ends the search. The search is kept up to date when
+// the text changes. Use [TextView.NextMatch] and [TextView.PrevMatch] to scroll
+// to the matches. An error is returned if a regular expression pattern is
+// invalid.
+func (t *TextView) Search(pattern string, options SearchOptions) (int, error) {
+	t.Lock()
+	defer t.Unlock()
+
+	search, err := compileSearch(pattern, options)
+	t.search = search
+	t.currentMatch = -1
+	t.updateSearch()
+	if err != nil {
+		return 0, err
+	}
+	return len(t.matches), nil
+}
+
+// ClearSearch ends the active search and removes all match highlights.
+func (t *TextView) ClearSearch() *TextView {
+	t.Search("", SearchOptions{})
+	return t
+}
+
+// GetMatchCount returns the number of matches of the active search.
+func (t *TextView) GetMatchCount() int {
+	t.Lock()
+	defer t.Unlock()
+	return len(t.matches)
+}
+
+// GetCurrentMatch returns the index of the current match, or -1 if no match
+// was navigated to yet.
+func (t *TextView) GetCurrentMatch() int {
+	t.Lock()
+	defer t.Unlock()
+	return t.currentMatch
+}
+
+// NextMatch makes the match after the current one the current match and
+// scrolls it into view, wrapping around at the end. It returns false if there
+// are no matches.
+func (t *TextView) NextMatch() bool {
+	t.Lock()
+	defer t.Unlock()
+	if len(t.matches) == 0 {
+		return false
+	}
+	t.currentMatch = (t.currentMatch + 1) % len(t.matches)
+	t.scrollToMatch = true
+	return true
+}
+
+// PrevMatch makes the match before the current one the current match and
+// scrolls it into view, wrapping around at the start. It returns false if
+// there are no matches.
+func (t *TextView) PrevMatch() bool {
+	t.Lock()
+	defer t.Unlock()
+	if len(t.matches) == 0 {
+		return false
+	}
+	t.currentMatch--
+	if t.currentMatch < 0 {
+		t.currentMatch = len(t.matches) - 1
 	}
+	t.scrollToMatch = true
+	return true
+}
+
+// SetSearchStyles sets the styles applied on top of the text of search matches
+// and of the current match.
+func (t *TextView) SetSearchStyles(match, current tcell.Style) *TextView {
+	t.Lock()
+	defer t.Unlock()
+	t.matchStyle = match
+	t.currentMatchStyle = current
 	return t
 }
 
-// ScrollTo scrolls to the specified row and column (both starting with 0).
-func (t *TextView) ScrollTo(row, column int) *TextView {
-	if !t.scrollable {
-		return t
+// updateSearch recomputes the matches of the active search.
+func (t *TextView) updateSearch() {
+	t.matches = t.matches[:0]
+	t.matchesByLine = nil
+	if t.search == nil {
+		t.currentMatch = -1
+		return
 	}
-	if t.lineOffset != row || t.columnOffset != column || t.trackEnd {
-		t.lineOffset = row
-		t.columnOffset = column
-		t.trackEnd = false
+
+	t.matchesByLine = make(map[int][]int)
+	for lineIndex := range t.lines {
+		for _, match := range t.lineMatches(lineIndex) {
+			t.matchesByLine[lineIndex] = append(t.matchesByLine[lineIndex], len(t.matches))
+			t.matches = append(t.matches, match)
+		}
+	}
+	if t.currentMatch >= len(t.matches) {
+		t.currentMatch = len(t.matches) - 1
 	}
-	return t
 }
 
-// ScrollToBeginning scrolls to the top left corner of the text if the text view
-// is scrollable.
-func (t *TextView) ScrollToBeginning() *TextView {
-	if !t.scrollable {
-		return t
+// updateSearchLines updates the matches of the active search after the
+// logical lines starting at startLine were replaced, removing "removed" lines
+// and inserting "inserted" lines. Only the inserted lines are searched again.
+func (t *TextView) updateSearchLines(startLine, removed, inserted int) {
+	if t.search == nil {
+		return
 	}
-	if t.trackEnd || t.lineOffset != 0 || t.columnOffset != 0 {
-		t.trackEnd = false
-		t.lineOffset = 0
-		t.columnOffset = 0
+
+	// Find the matches of the replaced lines.
+	first := sort.Search(len(t.matches), func(index int) bool {
+		return t.matches[index].line >= startLine
+	})
+	last := sort.Search(len(t.matches), func(index int) bool {
+		return t.matches[index].line >= startLine+removed
+	})
+	var replacement []textViewMatch
+	for lineIndex := startLine; lineIndex < startLine+inserted; lineIndex++ {
+		replacement = append(replacement, t.lineMatches(lineIndex)...)
 	}
-	return t
-}
 
-// ScrollToEnd scrolls to the bottom left corner of the text if the text view
-// is scrollable.
-func (t *TextView) ScrollToEnd() *TextView {
-	if !t.scrollable {
-		return t
+	// Splice in the new matches and shift the following ones.
+	for _, match := range t.matches[first:] {
+		delete(t.matchesByLine, match.line)
 	}
-	if !t.trackEnd || t.columnOffset != 0 {
-		t.trackEnd = true
-		t.columnOffset = 0
+	t.matches = slices.Replace(t.matches, first, last, replacement...)
+	delta := inserted - removed
+	for index := first; index < len(t.matches); index++ {
+		if index >= first+len(replacement) {
+			t.matches[index].line += delta
+		}
+		line := t.matches[index].line
+		t.matchesByLine[line] = append(t.matchesByLine[line], index)
 	}
-	return t
-}
 
-// GetScrollOffset returns the number of rows and columns that are skipped at
-// the top left corner when the text view has been scrolled.
-func (t *TextView) GetScrollOffset() (row, column int) {
-	return t.lineOffset, t.columnOffset
+	if t.currentMatch >= last {
+		t.currentMatch += len(replacement) - (last - first)
+	}
+	if t.currentMatch >= len(t.matches) {
+		t.currentMatch = len(t.matches) - 1
+	}
 }
 
-// Clear removes all text from the buffer. This triggers the "changed" callback.
-func (t *TextView) Clear() *TextView {
-	t.Lock()
-	defer t.Unlock()
-	if len(t.lines) == 0 {
-		return t
+// lineMatches returns the matches of the active search in the given logical
+// line.
+func (t *TextView) lineMatches(lineIndex int) (matches []textViewMatch) {
+	// Map byte offsets of the line's text to cells.
+	var text strings.Builder
+	offsets := make([]int, 0, len(t.lines[lineIndex].cells)+1)
+	for _, cell := range t.lines[lineIndex].cells {
+		offsets = append(offsets, text.Len())
+		text.WriteString(cell.text)
+	}
+	offsets = append(offsets, text.Len())
+
+	for _, loc := range t.search.FindAllStringIndex(text.String(), -1) {
+		if loc[0] == loc[1] {
+			continue // Empty matches can't be highlighted.
+		}
+		start := sort.SearchInts(offsets, loc[0])
+		if offsets[start] > loc[0] {
+			start-- // The match starts within a cluster.
+		}
+		end := sort.SearchInts(offsets, loc[1])
+		matches = append(matches, textViewMatch{line: lineIndex, start: start, end: end})
 	}
-	t.clear()
-	if t.changed != nil {
-		go t.changed()
+	return
+}
+
+// matchStyleAt returns the given style with search highlighting applied if the
+// given cell of the given logical line is part of a match.
+func (t *TextView) matchStyleAt(lineIndex, cellIndex int, style tcell.Style) tcell.Style {
+	for _, index := range t.matchesByLine[lineIndex] {
+		match := t.matches[index]
+		if cellIndex < match.start || cellIndex >= match.end {
+			continue
+		}
+		if index == t.currentMatch {
+			return mergeStyle(style, t.currentMatchStyle)
+		}
+		return mergeStyle(style, t.matchStyle)
 	}
-	return t
+	return style
 }
 
-func (t *TextView) clear() {
-	t.lines = nil
-	t.resetLayout()
+// scrollToCurrentMatch scrolls the current match into view. The wrapped lines
+// must have been built for the given viewport.
+func (t *TextView) scrollToCurrentMatch(width, height int) {
+	t.scrollToMatch = false
+	if t.currentMatch < 0 || t.currentMatch >= len(t.matches) {
+		return
+	}
+	match := t.matches[t.currentMatch]
+	for row, info := range t.wrapped {
+		if info.logical != match.line || match.start >= info.end {
+			continue
+		}
+		t.trackEnd = false
+		if row < t.lineOffset || row >= t.lineOffset+height {
+			t.lineOffset = row - height/2
+		}
+		if !t.wrap && t.alignment == AlignmentLeft {
+			// Bring the match's columns into view.
+			var start, end int
+			cells := t.lines[match.line].cells
+			for index := 0; index < match.end && index < len(cells); index++ {
+				w := t.cellWidth(match.line, cells[index], end)
+				if index < match.start {
+					start += w
+				}
+				end += w
+			}
+			if start < t.columnOffset || end > t.columnOffset+width {
+				t.columnOffset = start
+			}
+		}
+		return
+	}
 }
 
 // Focus is called when this primitive receives focus.
@@ -541,18 +3067,13 @@ func (t *TextView) Write(p []byte) (n int, err error) {
 }
 
 func (t *TextView) write(p []byte) (n int, err error) {
-	changed := t.changed
-	if changed != nil {
-		defer func() {
-			go changed()
-		}()
-	}
+	defer t.notifyChanged()
 
 	if len(p) == 0 {
 		return 0, nil
 	}
 
-	t.appendText(string(p), t.textStyle)
+	t.appendText(Segment{Text: string(p), Style: t.textStyle})
 	return len(p), nil
 }
 
@@ -563,7 +3084,12 @@ func (t *TextView) BatchWriter() TextViewWriter {
 	return TextViewWriter{t: t}
 }
 
-func (t *TextView) appendText(text string, style tcell.Style) {
+// appendText appends the segment's text to the last line, starting new lines
+// at newline characters. The segment's style and region are kept. Only the
+// last line and the new lines are reprocessed.
+func (t *TextView) appendText(seg Segment) {
+	text := seg.Text
+	startLine, removed := max(len(t.lines)-1, 0), min(len(t.lines), 1)
 	if len(t.lines) == 0 {
 		t.lines = append(t.lines, textViewLogicalLine{})
 	}
@@ -579,21 +3105,57 @@ func (t *TextView) appendText(text string, style tcell.Style) {
 		}
 
 		if nl < 0 {
-			t.appendSegment(lineIndex, Segment{Text: text, Style: style})
+			seg.Text = text
+			t.appendSegment(lineIndex, seg)
 			break
 		}
 
 		if nl > 0 {
-			t.appendSegment(lineIndex, Segment{Text: text[:nl], Style: style})
+			seg.Text = text[:nl]
+			t.appendSegment(lineIndex, seg)
 		}
 
 		t.lines = append(t.lines, textViewLogicalLine{})
 		lineIndex = len(t.lines) - 1
 		text = text[nl+1:]
+		if !t.trackEnd {
+			t.newLines++
+		}
 	}
 
-	t.rebuildCells()
-	t.resetLayout()
+	t.linesReplaced(startLine, removed, len(t.lines)-startLine)
+}
+
+// linesReplaced updates the cells and the layout after the logical lines
+// starting at startLine were replaced, removing "removed" lines and inserting
+// "inserted" lines. Unaffected lines are not reprocessed unless elastic
+// tabstops are enabled.
+func (t *TextView) linesReplaced(startLine, removed, inserted int) {
+	for i := range inserted {
+		t.rebuildLineCells(startLine + i)
+	}
+	if t.elasticTabstops {
+		// Tab stops may have moved anywhere in the affected column blocks.
+		t.computeElasticTabstops()
+		t.resetLayout()
+	} else {
+		t.repairWrapped(startLine, removed, inserted)
+	}
+	t.shiftLineStyles(startLine, removed, inserted)
+	t.shiftWordCursor(startLine, removed, inserted)
+	t.updateSearchLines(startLine, removed, inserted)
+}
+
+// shiftWordCursor keeps the word cursor on its line after the logical lines
+// starting at startLine were replaced, removing "removed" lines and inserting
+// "inserted" lines. The cursor is hidden if its line was replaced.
+func (t *TextView) shiftWordCursor(startLine, removed, inserted int) {
+	switch {
+	case t.wordCursor.line >= startLine+removed:
+		t.wordCursor.line += inserted - removed
+	case t.wordCursor.line >= startLine:
+		t.hasWordCursor = false
+	}
 }
 
 func (t *TextView) appendSegment(lineIndex int, seg Segment) {
@@ -601,7 +3163,7 @@ func (t *TextView) appendSegment(lineIndex int, seg Segment) {
 		return
 	}
 	logical := &t.lines[lineIndex]
-	if n := len(logical.line.Segments); n > 0 && logical.line.Segments[n-1].Style == seg.Style {
+	if n := len(logical.line.Segments); n > 0 && logical.line.Segments[n-1].Style == seg.Style && logical.line.Segments[n-1].Region == seg.Region {
 		logical.line.Segments[n-1].Text += seg.Text
 		return
 	}
@@ -610,39 +3172,171 @@ func (t *TextView) appendSegment(lineIndex int, seg Segment) {
 
 func (t *TextView) rebuildCells() {
 	for i := range t.lines {
-		logical := &t.lines[i]
-		cells := make([]textViewCell, 0)
-		width := 0
-		for _, seg := range logical.line.Segments {
-			state := -1
-			str := seg.Text
-			for len(str) > 0 {
-				cluster, rest, boundaries, next := uniseg.StepString(str, state)
-				state = next
-				str = rest
-				if cluster == "" {
-					continue
-				}
-				// Treat each segment as an in-line fragment unless it explicitly
-				// ends with a line break.
-				if rest == "" && !uniseg.HasTrailingLineBreakInString(cluster) {
-					boundaries &^= uniseg.MaskLine
+		t.rebuildLineCells(i)
+	}
+	t.computeElasticTabstops()
+	t.updateSearch()
+}
+
+// rebuildLineCells splits the given logical line into grapheme cells.
+func (t *TextView) rebuildLineCells(lineIndex int) {
+	logical := &t.lines[lineIndex]
+	cells := make([]textViewCell, 0)
+	width := 0
+	for _, seg := range logical.line.Segments {
+		state := -1
+		str := seg.Text
+		for len(str) > 0 {
+			cluster, rest, boundaries, next := uniseg.StepString(str, state)
+			state = next
+			str = rest
+			if cluster == "" {
+				continue
+			}
+			// Treat each segment as an in-line fragment unless it explicitly
+			// ends with a line break.
+			if rest == "" && !uniseg.HasTrailingLineBreakInString(cluster) {
+				boundaries &^= uniseg.MaskLine
+			}
+			cellWidth := boundaries >> uniseg.ShiftWidth
+			optionalBreak := (boundaries & uniseg.MaskLine) == uniseg.LineCanBreak
+			mustBreak := (boundaries & uniseg.MaskLine) == uniseg.LineMustBreak
+			cells = append(cells, textViewCell{
+				text:          cluster,
+				style:         seg.Style,
+				region:        seg.Region,
+				width:         cellWidth,
+				optionalBreak: optionalBreak,
+				mustBreak:     mustBreak,
+			})
+			width += cellWidth
+		}
+	}
+	if t.autoLinkify {
+		t.linkifyCells(cells)
+	}
+	logical.cells = cells
+	logical.width = width
+
+	// Update the running counts.
+	words, blank := 0, true
+	for _, cell := range cells {
+		if cellBlank := strings.TrimSpace(cell.text) == ""; blank != cellBlank {
+			blank = cellBlank
+			if !blank {
+				words++
+			}
+		}
+	}
+	t.words += words - logical.words
+	t.characters += len(cells) - logical.characters
+	logical.words, logical.characters = words, len(cells)
+}
+
+// textViewURL matches URLs detected by [TextView.SetAutoLinkify].
+var textViewURL = regexp.MustCompile(`(?i)\b(?:https?://|mailto:)[^\s<>"]+`)
+
+// linkifyCells attaches the URLs found in the text of the given cells to the
+// cells' styles.
+func (t *TextView) linkifyCells(cells []textViewCell) {
+	// Map byte offsets of the line's text to cells.
+	var (
+		text    strings.Builder
+		offsets = make([]int, 0, len(cells)+1)
+	)
+	for _, cell := range cells {
+		offsets = append(offsets, text.Len())
+		text.WriteString(cell.text)
+	}
+	offsets = append(offsets, text.Len())
+
+	line := text.String()
+	for _, match := range textViewURL.FindAllStringIndex(line, -1) {
+		start, end := match[0], trimURL(line[match[0]:match[1]])+match[0]
+		url := line[start:end]
+		for index := range cells {
+			if offsets[index] < start || offsets[index+1] > end {
+				continue
+			}
+			if _, existing := cells[index].style.GetUrl(); existing != "" {
+				continue
+			}
+			cells[index].style = mergeStyle(cells[index].style, t.autoLinkStyle).Url(url)
+		}
+	}
+}
+
+// trimURL returns the length of the given detected URL without trailing
+// punctuation which most likely belongs to the surrounding text. Closing
+// parentheses are kept if they are balanced within the URL.
+func trimURL(url string) int {
+	for len(url) > 0 {
+		last := url[len(url)-1]
+		switch {
+		case strings.IndexByte(".,:;!?'*", last) >= 0:
+		case last == ')' && strings.Count(url, "(") < strings.Count(url, ")"):
+		case last == ']' && strings.Count(url, "[") < strings.Count(url, "]"):
+		default:
+			return len(url)
+		}
+		url = url[:len(url)-1]
+	}
+	return 0
+}
+
+// computeElasticTabstops determines the tab stops of all lines for elastic
+// tabstops. A column is the text preceding a line's n-th tab. Each run of
+// adjacent lines which have an n-th column shares the width of its widest
+// column text.
+func (t *TextView) computeElasticTabstops() {
+	for i := range t.lines {
+		t.lines[i].tabStops = nil
+	}
+	if !t.elasticTabstops {
+		return
+	}
+
+	// Measure the text of each line's columns.
+	widths := make([][]int, len(t.lines))
+	for i, logical := range t.lines {
+		var width int
+		for _, cell := range logical.cells {
+			if cell.text == "\t" {
+				widths[i] = append(widths[i], width)
+				width = 0
+				continue
+			}
+			width += cell.width
+		}
+	}
+
+	// Align each column across runs of adjacent lines.
+	for column := 0; ; column++ {
+		var found bool
+		for start := 0; start < len(widths); {
+			if len(widths[start]) <= column {
+				start++
+				continue
+			}
+			found = true
+			end, columnWidth := start, 0
+			for end < len(widths) && len(widths[end]) > column {
+				columnWidth = max(columnWidth, widths[end][column])
+				end++
+			}
+			columnWidth += t.elasticTabPadding
+			for i := start; i < end; i++ {
+				var previous int
+				if column > 0 {
+					previous = t.lines[i].tabStops[column-1]
 				}
-				cellWidth := boundaries >> uniseg.ShiftWidth
-				optionalBreak := (boundaries & uniseg.MaskLine) == uniseg.LineCanBreak
-				mustBreak := (boundaries & uniseg.MaskLine) == uniseg.LineMustBreak
-				cells = append(cells, textViewCell{
-					text:          cluster,
-					style:         seg.Style,
-					width:         cellWidth,
-					optionalBreak: optionalBreak,
-					mustBreak:     mustBreak,
-				})
-				width += cellWidth
+				t.lines[i].tabStops = append(t.lines[i].tabStops, previous+columnWidth)
 			}
+			start = end
+		}
+		if !found {
+			break
 		}
-		logical.cells = cells
-		logical.width = width
 	}
 }
 
@@ -656,6 +3350,9 @@ func (t *TextView) buildWrapped(width int) {
 	if width <= 0 {
 		width = math.MaxInt
 	}
+	if t.wrapWidth > 0 && t.wrapWidth < width {
+		width = t.wrapWidth
+	}
 	if t.lastWidth == width && t.wrapped != nil {
 		return
 	}
@@ -663,88 +3360,123 @@ func (t *TextView) buildWrapped(width int) {
 	t.wrapped = nil
 	t.longestLine = 0
 
-	for lineIndex, logical := range t.lines {
-		cells := logical.cells
-		if len(cells) == 0 {
-			t.wrapped = append(t.wrapped, textViewLine{logical: lineIndex, start: 0, end: 0, width: 0})
-			continue
-		}
+	for lineIndex := range t.lines {
+		t.wrapped = t.wrapLine(t.wrapped, lineIndex, width)
+	}
+	for _, info := range t.wrapped {
+		t.longestLine = max(t.longestLine, info.width)
+	}
 
-		if !t.wrap || width == math.MaxInt {
-			t.wrapped = append(t.wrapped, textViewLine{logical: lineIndex, start: 0, end: len(cells), width: logical.width})
-			if logical.width > t.longestLine {
-				t.longestLine = logical.width
-			}
-			continue
-		}
+	t.lastWidth = width
+}
 
-		start := 0
-		for start < len(cells) {
-			pos := start
-			lineWidth := 0
-			lastOption := -1
-			lastOptionWidth := 0
-			mustBreak := false
+// wrapLine appends the visual lines of the given logical line, wrapped to the
+// given width, to wrapped and returns the extended slice.
+func (t *TextView) wrapLine(wrapped []textViewLine, lineIndex, width int) []textViewLine {
+	logical := t.lines[lineIndex]
+	cells := logical.cells
+	if len(cells) == 0 {
+		return append(wrapped, textViewLine{logical: lineIndex, start: 0, end: 0, width: 0})
+	}
 
-			if start != 0 {
-				for _, seg := range logical.line.Indent {
-					lineWidth += uniseg.StringWidth(seg.Text)
-				}
-			}
+	if !t.wrap || width == math.MaxInt {
+		return append(wrapped, textViewLine{logical: lineIndex, start: 0, end: len(cells), width: logical.width})
+	}
 
-			for pos < len(cells) {
-				cw := t.cellWidth(cells[pos], lineWidth)
-				if lineWidth+cw > width {
-					break
-				}
-				lineWidth += cw
-				if t.wordWrap && cells[pos].optionalBreak {
-					lastOption = pos + 1
-					lastOptionWidth = lineWidth
-				}
-				if cells[pos].mustBreak {
-					pos++
-					mustBreak = true
-					break
-				}
-				pos++
+	start := 0
+	for start < len(cells) {
+		pos := start
+		lineWidth := 0
+		lastOption := -1
+		lastOptionWidth := 0
+		mustBreak := false
+
+		if start != 0 {
+			for _, seg := range t.indent(lineIndex) {
+				lineWidth += uniseg.StringWidth(seg.Text)
 			}
+		}
 
-			if pos == start {
-				cw := t.cellWidth(cells[pos], 0)
+		for pos < len(cells) {
+			cw := t.cellWidth(lineIndex, cells[pos], lineWidth)
+			if lineWidth+cw > width {
+				break
+			}
+			lineWidth += cw
+			if t.wordWrap && cells[pos].optionalBreak {
+				lastOption = pos + 1
+				lastOptionWidth = lineWidth
+			}
+			if cells[pos].mustBreak {
 				pos++
-				lineWidth = cw
+				mustBreak = true
+				break
 			}
+			pos++
+		}
 
-			if !mustBreak && pos < len(cells) && t.wordWrap && lastOption > start {
-				pos = lastOption
-				lineWidth = lastOptionWidth
-			}
+		if pos == start {
+			cw := t.cellWidth(lineIndex, cells[pos], 0)
+			pos++
+			lineWidth = cw
+		}
 
-			t.wrapped = append(t.wrapped, textViewLine{logical: lineIndex, start: start, end: pos, width: lineWidth})
-			if lineWidth > t.longestLine {
-				t.longestLine = lineWidth
-			}
-			start = pos
+		if !mustBreak && pos < len(cells) && t.wordWrap && lastOption > start {
+			pos = lastOption
+			lineWidth = lastOptionWidth
 		}
-	}
 
-	t.lastWidth = width
+		wrapped = append(wrapped, textViewLine{logical: lineIndex, start: start, end: pos, width: lineWidth})
+		start = pos
+	}
+	return wrapped
 }
 
-func (t *TextView) cellWidth(cell textViewCell, leftPos int) int {
-	if cell.text == "\t" {
-		if t.alignment == AlignmentLeft {
-			return TabSize - leftPos%TabSize
-		}
+// cellWidth returns the screen width of the given cell of the given logical
+// line when it is printed at the leftPos column.
+func (t *TextView) cellWidth(lineIndex int, cell textViewCell, leftPos int) int {
+	if cell.text != "\t" {
+		return cell.width
+	}
+	if t.alignment != AlignmentLeft {
 		return TabSize
 	}
-	return cell.width
+
+	// Find the next tab stop.
+	stops := t.tabStops
+	if t.elasticTabstops {
+		stops = t.lines[lineIndex].tabStops
+	}
+	for _, stop := range stops {
+		if stop > leftPos {
+			return stop - leftPos
+		}
+	}
+	if TabSize <= 0 {
+		return 0
+	}
+	var last int
+	if len(stops) > 0 {
+		last = stops[len(stops)-1]
+	}
+	return TabSize - (leftPos-last)%TabSize
 }
 
 // Draw draws this primitive onto the screen.
 func (t *TextView) Draw(screen tcell.Screen) {
 	t.DrawForSubclass(screen, t)
+	var (
+		notifyFollowing func()
+		decorations     []func()
+	)
+	defer func() {
+		for _, decorate := range decorations {
+			decorate()
+		}
+		if notifyFollowing != nil {
+			notifyFollowing()
+		}
+	}()
 	t.Lock()
 	defer t.Unlock()
 
@@ -780,7 +3512,50 @@ func (t *TextView) Draw(screen tcell.Screen) {
 		}
 	}
 
+	if t.reader != nil {
+		t.loadReaderLines(height)
+	}
+
+	// Make room for line numbers.
+	gutterX, gutterWidth := x, t.gutterWidth(height)
+	if gutterWidth >= width {
+		gutterWidth = 0
+	}
+	x += gutterWidth
+	width -= gutterWidth
+
+	// Make room for line decorations.
+	decorationX, decorationWidth := x, min(t.decorationWidth, max(width-1, 0))
+	x += decorationWidth
+	width -= decorationWidth
+
+	// Make room for the ruler.
+	rulerY, ruler := y, t.rulerShown(height)
+	if ruler {
+		y++
+		height--
+	}
+
+	// Make room for the scrollBars.
+	t.scrollBarShown, t.horizontalScrollBarShown = t.layoutScrollBars(width, height)
+	if t.scrollBarShown {
+		width--
+	}
+	if t.horizontalScrollBarShown {
+		height--
+	}
+
+	t.textX, t.textY, t.textWidth, t.textHeight = x, y, width, height
 	t.buildWrapped(width)
+	if t.scrollToMatch {
+		t.scrollToCurrentMatch(width, height)
+	}
+	if t.scrollToHighlights {
+		t.scrollToHighlight(width, height)
+	}
+	if t.scrollToParagraphIndex >= 0 {
+		t.scrollToParagraphStart()
+	}
 
 	if t.trackEnd {
 		t.lineOffset = len(t.wrapped) - height
@@ -791,6 +3566,9 @@ func (t *TextView) Draw(screen tcell.Screen) {
 	if t.lineOffset < 0 {
 		t.lineOffset = 0
 	}
+	if t.followMode {
+		notifyFollowing = t.updateFollowing(height)
+	}
 
 	if t.alignment == AlignmentLeft || t.alignment == AlignmentRight {
 		if t.columnOffset+width > t.longestLine {
@@ -813,46 +3591,47 @@ func (t *TextView) Draw(screen tcell.Screen) {
 		}
 	}
 
+	t.drawGuides(screen, x, y, width, height, rulerY, ruler)
+
 	for line := t.lineOffset; line < len(t.wrapped); line++ {
 		if line-t.lineOffset >= height {
 			break
 		}
 
 		info := t.wrapped[line]
+		lineStyle, styled := t.lineStyle(info.logical)
+		if styled {
+			for column := range width {
+				screen.Put(x+column, y+line-t.lineOffset, " ", mergeStyle(t.textStyle, lineStyle))
+			}
+		}
+		if gutterWidth > 0 && info.start == 0 {
+			t.drawLineNumber(screen, info.logical, t.wrapped[t.lineOffset].logical, gutterX, y+line-t.lineOffset, gutterWidth)
+		}
 		cells := t.lines[info.logical].cells[info.start:info.end]
-		var skipWidth, xPos int
-		switch t.alignment {
-		case AlignmentLeft:
-			skipWidth = t.columnOffset
-			if info.start != 0 {
-				indent := t.lines[info.logical].line.Indent
-				for _, seg := range indent {
-					screen.PutStrStyled(x, y+line-t.lineOffset, seg.Text, seg.Style)
-					xPos += uniseg.StringWidth(seg.Text)
+		skipWidth, xPos := t.lineStart(info, width)
+		trailing := math.MaxInt
+		if t.showWhitespace {
+			trailing = t.trailingWhitespace(info.logical)
+		}
+		if t.alignment == AlignmentLeft && info.start != 0 {
+			indentX := x
+			for _, seg := range t.indent(info.logical) {
+				style := seg.Style
+				if styled {
+					style = mergeStyle(style, lineStyle)
 				}
-			}
-
-		case AlignmentCenter:
-			skipWidth = t.columnOffset + (info.width-width)/2
-			if skipWidth < 0 {
-				skipWidth = 0
-				xPos = (width-info.width)/2 - t.columnOffset
-			}
-		case AlignmentRight:
-			maxWidth := max(t.longestLine, width)
-			skipWidth = t.columnOffset - (maxWidth - info.width)
-			if skipWidth < 0 {
-				skipWidth = 0
-				xPos = maxWidth - info.width - t.columnOffset
+				screen.PutStrStyled(indentX, y+line-t.lineOffset, seg.Text, style)
+				indentX += uniseg.StringWidth(seg.Text)
 			}
 		}
 
-		for _, cell := range cells {
+		for cellIndex, cell := range cells {
 			if xPos >= width {
 				break
 			}
 
-			w := t.cellWidth(cell, xPos)
+			w := t.cellWidth(info.logical, cell, xPos)
 			if skipWidth > 0 {
 				skipWidth -= w
 				continue
@@ -860,43 +3639,143 @@ func (t *TextView) Draw(screen tcell.Screen) {
 
 			if w > 0 {
 				ch := cell.text
+				style := cell.style
+				if t.showWhitespace {
+					if glyph := whitespaceGlyph(ch, info.start+cellIndex >= trailing); glyph != "" {
+						ch, style = glyph, mergeStyle(style, t.whitespaceStyle)
+					}
+				}
 				if ch == "\t" {
 					ch = " "
 				}
+				if styled {
+					style = mergeStyle(style, lineStyle)
+				}
+				if t.highlightedAt(cell) {
+					style = style.Reverse(!style.HasReverse())
+				}
+				if t.matchesByLine != nil {
+					style = t.matchStyleAt(info.logical, info.start+cellIndex, style)
+				}
+				if t.selected(info.logical, info.start+cellIndex) {
+					style = t.selectedStyle
+				}
+				if t.hasWordCursor && t.wordCursor == (textViewPosition{line: info.logical, cell: info.start + cellIndex}) {
+					style = style.Reverse(!style.HasReverse())
+				}
 				for offset := w - 1; offset >= 0; offset-- {
 					if offset == 0 {
-						screen.PutStrStyled(x+xPos+offset, y+line-t.lineOffset, ch, cell.style)
+						screen.PutStrStyled(x+xPos+offset, y+line-t.lineOffset, ch, style)
 					} else {
-						screen.Put(x+xPos+offset, y+line-t.lineOffset, " ", cell.style)
+						screen.Put(x+xPos+offset, y+line-t.lineOffset, " ", style)
 					}
 				}
 			}
 
 			xPos += w
 		}
+
+		if decorator := t.lineDecorator; decorator != nil && (info.start == 0 || line == t.lineOffset) {
+			lineNo := info.logical
+			if t.reader != nil {
+				lineNo += t.readerTop
+			}
+			row := y + line - t.lineOffset
+			for column := range decorationWidth {
+				screen.Put(decorationX+column, row, " ", t.textStyle)
+			}
+			decorations = append(decorations, func() {
+				decorator(lineNo, screen, decorationX, row, decorationWidth+width)
+			})
+		}
+	}
+
+	// Draw the scrollBars.
+	if t.scrollBarShown {
+		t.scrollBar.SetRect(x+width, y, 1, height)
+		t.scrollBar.SetLengths(ScrollLengths{ContentLen: t.contentRows(), ViewportLen: height}).
+			SetOffset(t.scrollBarOffset(t.scrollBar)).
+			Draw(screen)
+	}
+	if t.horizontalScrollBarShown {
+		t.horizontalScrollBar.SetRect(x, y+height, width, 1)
+		t.horizontalScrollBar.SetLengths(ScrollLengths{ContentLen: t.longestLine, ViewportLen: width}).
+			SetOffset(t.scrollBarOffset(t.horizontalScrollBar)).
+			Draw(screen)
+	}
+
+	// Draw the new lines indicator.
+	if t.followMode && !t.following && t.newLines > 0 && t.newLinesIndicator != nil {
+		if text := t.newLinesIndicator(t.newLines); text != "" {
+			textWidth := min(TaggedStringWidth(text), width)
+			printWithStyle(screen, text, x+width-textWidth, y+height-1, 0, textWidth, AlignmentLeft, t.newLinesStyle, false)
+		}
 	}
 
+	if t.reader != nil {
+		return
+	}
 	if !t.scrollable && len(t.lines) > height {
 		trim := len(t.lines) - height
+		t.forgetLines(t.lines[:trim])
 		t.lines = t.lines[trim:]
-		t.resetLayout()
+		t.repairWrapped(0, trim, 0)
+		t.linesTrimmed(trim)
+		t.shiftWordCursor(0, trim, 0)
+		t.scheduleStats()
+		t.updateSearchLines(0, trim, 0)
+		t.clearSelection()
 		t.lineOffset = 0
 	}
-	if t.maxLines > 0 && len(t.lines) > t.maxLines {
-		trim := len(t.lines) - t.maxLines
-		t.lines = t.lines[trim:]
-		t.resetLayout()
-		t.lineOffset = 0
+	t.trimMaxLines()
+}
+
+// trimMaxLines removes the oldest lines exceeding the maximum number of lines
+// set with [TextView.SetMaxLines].
+func (t *TextView) trimMaxLines() {
+	if t.maxLines <= 0 || len(t.lines) <= t.maxLines {
+		return
 	}
+	trim := len(t.lines) - t.maxLines
+	t.forgetLines(t.lines[:trim])
+	t.lines = t.lines[trim:]
+	t.repairWrapped(0, trim, 0)
+	t.linesTrimmed(trim)
+	t.shiftWordCursor(0, trim, 0)
+	t.scheduleStats()
+	t.updateSearchLines(0, trim, 0)
+	t.clearSelection()
+	t.lineOffset = 0
 }
 
 // HandleEvent handles input events for this primitive.
 func (t *TextView) HandleEvent(event tcell.Event) Command {
 	switch event := event.(type) {
 	case *KeyEvent:
-		previousLineOffset, previousColumnOffset, previousTrackEnd := t.lineOffset, t.columnOffset, t.trackEnd
+		event, ok := t.keyMap.resolve(event, textViewKeyActions)
+		if !ok {
+			return nil
+		}
+		previousLineOffset, previousColumnOffset, previousTrackEnd, previousReaderTop := t.lineOffset, t.columnOffset, t.trackEnd, t.readerTop
 		key := event.Key()
 
+		// Text selection.
+		t.Lock()
+		switch {
+		case key == tcell.KeyCtrlQ:
+			cmd := t.copySelection()
+			t.Unlock()
+			return cmd
+		case key == tcell.KeyEscape && t.selectionStart != t.selectionEnd:
+			t.clearSelection()
+			t.Unlock()
+			return RedrawCommand{}
+		case event.Modifiers()&tcell.ModShift != 0 && t.extendSelection(key):
+			t.Unlock()
+			return RedrawCommand{}
+		}
+		t.Unlock()
+
 		if key == tcell.KeyEscape || key == tcell.KeyEnter || key == tcell.KeyTab || key == tcell.KeyBacktab {
 			if t.done != nil {
 				t.done(key)
@@ -911,12 +3790,41 @@ func (t *TextView) HandleEvent(event tcell.Event) Command {
 			return nil
 		}
 
+		// View positioning commands consist of two keys.
+		if t.zPending {
+			t.zPending = false
+			if key == tcell.KeyRune {
+				switch motion := event.Str(); motion {
+				case "z", "t", "b":
+					t.Lock()
+					t.positionView(motion)
+					t.Unlock()
+					return RedrawCommand{}
+				}
+			}
+		}
+
 		switch key {
 		case tcell.KeyRune:
-			switch event.Str() {
+			switch motion := event.Str(); motion {
+			case "z":
+				t.zPending = true
+			case "w", "b", "e":
+				t.Lock()
+				t.scrollToWord(motion)
+				t.Unlock()
+			case "{", "}":
+				t.Lock()
+				t.scrollToParagraph(motion == "}")
+				t.Unlock()
+			case "(", ")":
+				t.Lock()
+				t.scrollToSentence(motion == ")")
+				t.Unlock()
 			case "g":
 				t.trackEnd = false
 				t.lineOffset = 0
+				t.readerTop = 0
 				t.columnOffset = 0
 			case "G":
 				t.trackEnd = true
@@ -934,6 +3842,7 @@ func (t *TextView) HandleEvent(event tcell.Event) Command {
 		case tcell.KeyHome:
 			t.trackEnd = false
 			t.lineOffset = 0
+			t.readerTop = 0
 			t.columnOffset = 0
 		case tcell.KeyEnd:
 			t.trackEnd = true
@@ -955,21 +3864,81 @@ func (t *TextView) HandleEvent(event tcell.Event) Command {
 			t.trackEnd = false
 			t.lineOffset -= pageSize
 		}
-		if t.lineOffset != previousLineOffset || t.columnOffset != previousColumnOffset || t.trackEnd != previousTrackEnd {
+		if t.lineOffset != previousLineOffset || t.columnOffset != previousColumnOffset || t.trackEnd != previousTrackEnd || t.readerTop != previousReaderTop {
 			return RedrawCommand{}
 		}
 	case *MouseEvent:
 		var cmd BatchCommand
 		x, y := event.Position()
+
+		// Continue selecting text while dragging.
+		if t.selecting {
+			switch event.Action {
+			case MouseMove:
+				t.Lock()
+				if y < t.textY {
+					t.trackEnd = false
+					t.lineOffset--
+				} else if y >= t.textY+t.textHeight {
+					t.lineOffset++
+				}
+				if position, ok := t.positionAt(x, y); ok {
+					t.selectionEnd = position
+				}
+				t.Unlock()
+				return BatchCommand{SetMouseCaptureCommand{Target: t}, RedrawCommand{}}
+			case MouseLeftUp:
+				t.selecting = false
+				return BatchCommand{SetMouseCaptureCommand{Target: nil}, RedrawCommand{}}
+			}
+		}
+
+		// Continue dragging a scrollBar thumb.
+		if t.draggedScrollBar != nil {
+			switch event.Action {
+			case MouseMove:
+				t.Lock()
+				t.dragScrollBar(x, y)
+				t.Unlock()
+				return BatchCommand{SetMouseCaptureCommand{Target: t}, RedrawCommand{}}
+			case MouseLeftUp:
+				t.draggedScrollBar = nil
+				return BatchCommand{SetMouseCaptureCommand{Target: nil}, RedrawCommand{}}
+			}
+		}
+
 		if !t.InRect(x, y) {
 			return nil
 		}
 
+		if event.Action == MouseLeftDown && t.scrollable {
+			if cmd := t.scrollBarMouseDown(x, y); cmd != nil {
+				return cmd
+			}
+		}
+
 		_, _, width, _ := t.GetInnerRect()
 		switch event.Action {
 		case MouseLeftDown:
 			cmd = append(cmd, SetFocusCommand{Target: t}, RedrawCommand{})
+			t.Lock()
+			if position, ok := t.positionAt(x, y); ok {
+				t.selectionStart, t.selectionEnd = position, position
+				t.selecting = true
+				cmd = append(cmd, SetMouseCaptureCommand{Target: t})
+			}
+			t.Unlock()
 		case MouseLeftClick:
+			t.Lock()
+			cell, _ := t.cellAt(x, y)
+			linkClicked := t.linkClicked
+			t.Unlock()
+			if cell.region != "" {
+				t.Highlight(cell.region)
+			}
+			if _, url := cell.style.GetUrl(); url != "" && linkClicked != nil {
+				linkClicked(url)
+			}
 			cmd = append(cmd, RedrawCommand{})
 		case MouseScrollUp:
 			if !t.scrollable {
@@ -1004,3 +3973,13 @@ func (t *TextView) HandleEvent(event tcell.Event) Command {
 	}
 	return nil
 }
+
+// Inspect describes this primitive in the inspection tree. See [Inspect].
+func (t *TextView) Inspect(node *InspectNode) {
+	node.Role = RoleText
+	node.Label = t.GetLabel()
+	if node.Label == "" {
+		node.Label = t.GetTitle()
+	}
+	node.Value = t.GetText()
+}
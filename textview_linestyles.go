@@ -0,0 +1,95 @@
+package tview
+
+import (
+	"maps"
+
+	"github.com/gdamore/tcell/v3"
+)
+
+// SetHighlightedLines sets styles which are applied to entire logical lines,
+// keyed by the index of the line (counted from the reader's first line in
+// reader mode, see [TextView.SetReader]), e.g. to color log lines by severity.
+// A style is merged over the style of each character of the line, so it may
+// only set a background color, and the background of all rows of the line is
+// filled across the full width of the text area. The map replaces any
+// previously set styles. Pass nil to remove all line highlights.
+//
+// When lines are removed from the beginning of the text (e.g. because of
+// [TextView.SetMaxLines]) or replaced, the indices are adjusted so that the
+// styles stay with their lines. Styles of removed lines are discarded.
+func (t *TextView) SetHighlightedLines(lines map[int]tcell.Style) *TextView {
+	t.Lock()
+	defer t.Unlock()
+	t.highlightedLines = maps.Clone(lines)
+	return t
+}
+
+// GetHighlightedLines returns a copy of the line styles set with
+// [TextView.SetHighlightedLines], with their current indices.
+func (t *TextView) GetHighlightedLines() map[int]tcell.Style {
+	t.Lock()
+	defer t.Unlock()
+	return maps.Clone(t.highlightedLines)
+}
+
+// SetZebraStripes sets whether every other logical line is drawn with the
+// zebra style (see [TextView.SetZebraStyle]), making it easier to follow
+// table-like output across the screen. The stripes stay with their lines when
+// lines are removed from the beginning of the text. Line highlights (see
+// [TextView.SetHighlightedLines]) are applied on top of the stripes.
+func (t *TextView) SetZebraStripes(stripes bool) *TextView {
+	t.Lock()
+	defer t.Unlock()
+	t.zebraStripes = stripes
+	return t
+}
+
+// SetZebraStyle sets the style of the striped lines, see
+// [TextView.SetZebraStripes].
+func (t *TextView) SetZebraStyle(style tcell.Style) *TextView {
+	t.Lock()
+	defer t.Unlock()
+	t.zebraStyle = style
+	return t
+}
+
+// lineStyle returns the style applied to the entire logical line with the
+// given index. If the line has no such style, ok is false.
+func (t *TextView) lineStyle(logical int) (style tcell.Style, ok bool) {
+	if t.reader != nil {
+		logical += t.readerTop
+	}
+	if t.zebraStripes && (logical+t.zebraOffset)%2 == 1 {
+		style, ok = t.zebraStyle, true
+	}
+	if highlight, highlighted := t.highlightedLines[logical]; highlighted {
+		style, ok = mergeStyle(style, highlight), true
+	}
+	return
+}
+
+// shiftLineStyles adjusts the indices of the line highlights after the
+// logical lines starting at startLine were replaced, removing "removed" lines
+// and inserting "inserted" lines. Replacing lines keeps their highlights.
+func (t *TextView) shiftLineStyles(startLine, removed, inserted int) {
+	if len(t.highlightedLines) == 0 || t.reader != nil || removed == 0 && inserted == 0 {
+		return
+	}
+	lines := make(map[int]tcell.Style, len(t.highlightedLines))
+	for line, style := range t.highlightedLines {
+		switch {
+		case line < startLine+min(removed, inserted):
+			lines[line] = style
+		case line >= startLine+removed:
+			lines[line+inserted-removed] = style
+		}
+	}
+	t.highlightedLines = lines
+}
+
+// linesTrimmed adjusts the line styles after the given number of lines were
+// removed from the beginning of the text.
+func (t *TextView) linesTrimmed(trim int) {
+	t.shiftLineStyles(0, trim, 0)
+	t.zebraOffset += trim
+}
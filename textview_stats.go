@@ -0,0 +1,84 @@
+package tview
+
+import "time"
+
+// TextViewStats contains statistics about the text of a [TextView], see
+// [TextView.GetStats].
+type TextViewStats struct {
+	// The number of logical lines.
+	Lines int
+
+	// The number of words, i.e. runs of non-whitespace characters.
+	Words int
+
+	// The number of characters (grapheme clusters), excluding line breaks.
+	Characters int
+}
+
+// GetStats returns the current statistics of the text. The counts are
+// maintained while text is written, so this function is cheap even for large
+// texts. In reader mode (see [TextView.SetReader]), only the loaded lines are
+// counted.
+func (t *TextView) GetStats() TextViewStats {
+	t.Lock()
+	defer t.Unlock()
+	return t.stats()
+}
+
+// SetStatsFunc sets a handler which receives the statistics of the text (see
+// [TextView.GetStats]) after it has changed, e.g. to show word counts in a
+// status bar. To avoid excessive updates, the handler is called at most once
+// per the given interval, with the statistics at the time of the call. It is
+// called from a separate goroutine, so it should use
+// [Application.QueueUpdateDraw] to update the user interface.
+func (t *TextView) SetStatsFunc(handler func(stats TextViewStats), interval time.Duration) *TextView {
+	t.Lock()
+	defer t.Unlock()
+	t.statsFunc = handler
+	t.statsInterval = max(interval, 0)
+	return t
+}
+
+// stats returns the current statistics. The text view must be locked.
+func (t *TextView) stats() TextViewStats {
+	return TextViewStats{
+		Lines:      len(t.lines),
+		Words:      t.words,
+		Characters: t.characters,
+	}
+}
+
+// forgetLines removes the counts of the given lines, which are about to be
+// removed, from the running counts.
+func (t *TextView) forgetLines(lines []textViewLogicalLine) {
+	for _, line := range lines {
+		t.words -= line.words
+		t.characters -= line.characters
+	}
+}
+
+// notifyChanged calls the changed handler and schedules a call of the stats
+// handler. The text view must be locked.
+func (t *TextView) notifyChanged() {
+	if t.changed != nil {
+		go t.changed()
+	}
+	t.scheduleStats()
+}
+
+// scheduleStats schedules a call of the stats handler unless one is already
+// pending. The text view must be locked.
+func (t *TextView) scheduleStats() {
+	if t.statsFunc == nil || t.statsTimer != nil {
+		return
+	}
+	t.statsTimer = time.AfterFunc(t.statsInterval, func() {
+		t.Lock()
+		t.statsTimer = nil
+		handler, stats := t.statsFunc, t.stats()
+		t.Unlock()
+		if handler != nil {
+			handler(stats)
+		}
+	})
+}
@@ -395,6 +395,9 @@ type TreeView struct {
 
 	// Internal mouse track data.
 	lastMouseY int
+
+	// The content shown while there are no nodes to show.
+	placeholder placeholder
 }
 
 // TreeMarkers are glyphs drawn before node text.
@@ -416,10 +419,18 @@ func NewTreeView() *TreeView {
 			Collapsed: "▸ ",
 			Leaf:      "",
 		},
-		lastMouseY: -1,
+		lastMouseY:  -1,
+		placeholder: newPlaceholder(),
 	}
 }
 
+// applyTheme updates the tree view's default styles after a theme change.
+func (t *TreeView) applyTheme(change themeChange) {
+	t.Box.applyTheme(change)
+	t.graphicsColor = change.color(t.graphicsColor, themeGraphics)
+	t.placeholder.applyTheme(change)
+}
+
 // SetRoot sets the root node of the tree.
 func (t *TreeView) SetRoot(root *TreeNode) *TreeView {
 	if t.root != root {
@@ -572,6 +583,32 @@ func (t *TreeView) SetGraphicsColor(color tcell.Color) *TreeView {
 	return t
 }
 
+// SetPlaceholder sets a primitive which is drawn into the tree view's inner
+// area while there are no nodes to show, i.e. there is no root node or the
+// root node is hidden (see [TreeView.SetTopLevel]) and has no children. It
+// replaces the text set with [TreeView.SetPlaceholderText]. Set it to nil to
+// show the text again.
+func (t *TreeView) SetPlaceholder(placeholder Primitive) *TreeView {
+	t.placeholder.primitive = placeholder
+	return t
+}
+
+// SetPlaceholderText sets the text which is shown centered in the tree view
+// while there are no nodes to show, e.g. "No files". It may contain line
+// breaks. It is not shown if a primitive was set with
+// [TreeView.SetPlaceholder].
+func (t *TreeView) SetPlaceholderText(text string) *TreeView {
+	t.placeholder.text = text
+	return t
+}
+
+// SetPlaceholderStyle sets the style of the placeholder text. Its background
+// color is ignored.
+func (t *TreeView) SetPlaceholderStyle(style tcell.Style) *TreeView {
+	t.placeholder.style = style
+	return t
+}
+
 // SetChangedFunc sets the function which is called when the currently selected
 // node changes, for example when the user navigates to a new tree node.
 func (t *TreeView) SetChangedFunc(handler func(node *TreeNode)) *TreeView {
@@ -807,6 +844,7 @@ func (t *TreeView) process(drawingAfter bool) {
 func (t *TreeView) Draw(screen tcell.Screen) {
 	t.DrawForSubclass(screen, t)
 	if t.root == nil {
+		t.placeholder.draw(screen, t.Box)
 		return
 	}
 	_, totalHeight := screen.Size()
@@ -816,6 +854,10 @@ func (t *TreeView) Draw(screen tcell.Screen) {
 	} else {
 		t.stableNodes = false
 	}
+	if len(t.nodes) == 0 {
+		t.placeholder.draw(screen, t.Box)
+		return
+	}
 
 	// Scroll the tree, t.movement is treeNone after process() when there is a
 	// cursor, except for treeScroll, treeHome, and treeEnd.
@@ -1143,3 +1185,27 @@ func (t *TreeView) HandleEvent(event tcell.Event) Command {
 	}
 	return nil
 }
+
+// Inspect describes this primitive in the inspection tree. See [Inspect]. The
+// nodes drawn last are reported as children with the [RoleTreeItem] role.
+func (t *TreeView) Inspect(node *InspectNode) {
+	t.Box.Inspect(node)
+	node.Role = RoleTree
+	x, y, width, height := t.GetInnerRect()
+	for index := t.offsetY; index >= 0 && index < len(t.nodes) && index-t.offsetY < height; index++ {
+		treeNode := t.nodes[index]
+		if treeNode.textX >= width {
+			continue
+		}
+		node.Children = append(node.Children, &InspectNode{
+			Role:     RoleTreeItem,
+			Label:    treeNode.line.String(),
+			X:        x + treeNode.textX,
+			Y:        y + index - t.offsetY,
+			Width:    width - treeNode.textX,
+			Height:   1,
+			Selected: treeNode == t.currentNode,
+			Expanded: treeNode.expanded && len(treeNode.children) > 0,
+		})
+	}
+}
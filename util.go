@@ -34,6 +34,28 @@ func PrintWithStyle(screen tcell.Screen, text string, x, y, maxWidth int, alignm
 	return end - start, width
 }
 
+// PrintedCluster describes the position of a grapheme cluster printed by
+// [PrintWithPositions].
+type PrintedCluster struct {
+	// The byte range of the cluster in the printed text.
+	Start, End int
+
+	// The screen column of the cluster's first cell and the number of cells
+	// it occupies. Zero-width clusters have a width of 0.
+	X, Width int
+}
+
+// PrintWithPositions works like [PrintWithStyle] but returns the positions of
+// all grapheme clusters actually printed, in text order. This can be used to
+// place cursors, to find the text under the mouse, or to highlight parts of
+// the printed text without stepping through the text again.
+func PrintWithPositions(screen tcell.Screen, text string, x, y, maxWidth int, alignment Alignment, style tcell.Style) (clusters []PrintedCluster) {
+	printText(screen, text, x, y, 0, maxWidth, alignment, style, false, func(cluster PrintedCluster) {
+		clusters = append(clusters, cluster)
+	})
+	return
+}
+
 // printWithStyle works like [Print] but it takes a style instead of just a
 // foreground color. The skipWidth parameter specifies the number of cells
 // skipped at the beginning of the text. It returns the start index, end index
@@ -41,6 +63,12 @@ func PrintWithStyle(screen tcell.Screen, text string, x, y, maxWidth int, alignm
 // maintainBackground is "true", the existing screen background is not changed
 // (i.e. the style's background color is ignored).
 func printWithStyle(screen tcell.Screen, text string, x, y, skipWidth, maxWidth int, alignment Alignment, style tcell.Style, maintainBackground bool) (start, end, printedWidth int) {
+	return printText(screen, text, x, y, skipWidth, maxWidth, alignment, style, maintainBackground, nil)
+}
+
+// printText implements [printWithStyle]. If report is not nil, it is called
+// with the position of each printed grapheme cluster.
+func printText(screen tcell.Screen, text string, x, y, skipWidth, maxWidth int, alignment Alignment, style tcell.Style, maintainBackground bool, report func(cluster PrintedCluster)) (start, end, printedWidth int) {
 	totalWidth, totalHeight := screen.Size()
 	if maxWidth <= 0 || len(text) == 0 || y < 0 || y >= totalHeight {
 		return 0, 0, 0
@@ -126,6 +154,9 @@ func printWithStyle(screen tcell.Screen, text string, x, y, skipWidth, maxWidth
 			}
 		}
 
+		if report != nil {
+			report(PrintedCluster{Start: end, End: end + state.GrossLength(), X: x, Width: width})
+		}
 		x += width
 		end += state.GrossLength()
 		printedWidth += width
@@ -134,6 +165,48 @@ func printWithStyle(screen tcell.Screen, text string, x, y, skipWidth, maxWidth
 	return
 }
 
+// printLine prints the segments of a styled line into the box at
+// (x,y,maxWidth,1) like [printWithStyle]. Each segment's style is merged over
+// the base style so segments only need to specify what differs. It returns the
+// screen width of the text actually printed.
+func printLine(screen tcell.Screen, line Line, x, y, maxWidth int, alignment Alignment, base tcell.Style, maintainBackground bool) (printedWidth int) {
+	var width int
+	for _, segment := range line.Segments {
+		width += TaggedStringWidth(segment.Text)
+	}
+	if width < maxWidth {
+		switch alignment {
+		case AlignmentCenter:
+			x += (maxWidth - width) / 2
+		case AlignmentRight:
+			x += maxWidth - width
+		}
+	}
+
+	for _, segment := range line.Segments {
+		if printedWidth >= maxWidth {
+			break
+		}
+		_, _, segmentWidth := printWithStyle(screen, segment.Text, x+printedWidth, y, 0, maxWidth-printedWidth, AlignmentLeft, mergeStyle(base, segment.Style), maintainBackground)
+		printedWidth += segmentWidth
+	}
+	return
+}
+
+// printLabel prints the label of a form item into the box at (x,y,width,1).
+// The label occupies the given label width, or its own width if the label
+// width is 0. It returns the column and the width of the area after the label.
+func printLabel(screen tcell.Screen, label string, labelWidth int, style tcell.Style, x, y, width int) (fieldX, fieldWidth int) {
+	maintainBackground := style.GetBackground() == tcell.ColorDefault
+	if labelWidth > 0 {
+		labelWidth = min(labelWidth, width)
+		printWithStyle(screen, label, x, y, 0, labelWidth, AlignmentLeft, style, maintainBackground)
+	} else {
+		_, _, labelWidth = printWithStyle(screen, label, x, y, 0, width, AlignmentLeft, style, maintainBackground)
+	}
+	return x + labelWidth, width - labelWidth
+}
+
 // PrintSimple prints white text to the screen at the given position.
 func PrintSimple(screen tcell.Screen, text string, x, y int) {
 	Print(screen, text, x, y, math.MaxInt32, AlignmentLeft, Styles.PrimaryTextColor)
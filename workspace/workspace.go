@@ -0,0 +1,546 @@
+package workspace
+
+import (
+	"math"
+	"slices"
+
+	"github.com/ayn2op/tview"
+	"github.com/ayn2op/tview/layers"
+	"github.com/gdamore/tcell/v3"
+)
+
+// Preset determines how a workspace tiles its windows.
+type Preset string
+
+const (
+	// PresetColumns places the windows side by side.
+	PresetColumns Preset = "columns"
+
+	// PresetRows stacks the windows on top of each other.
+	PresetRows Preset = "rows"
+
+	// PresetMain gives the first window the left part of the workspace (see
+	// [Workspace.SetMainRatio]) and stacks the other windows on the right.
+	PresetMain Preset = "main"
+
+	// PresetGrid arranges the windows in a grid with about as many columns as
+	// rows.
+	PresetGrid Preset = "grid"
+
+	// PresetMonocle shows only the focused window, using the entire workspace.
+	PresetMonocle Preset = "monocle"
+)
+
+// Arrangement describes the arrangement of a workspace's windows. It can be
+// serialized (e.g. as JSON) to restore the arrangement in a later session, see
+// [Workspace.GetArrangement] and [Workspace.SetArrangement].
+type Arrangement struct {
+	// The tiling preset.
+	Preset Preset `json:"preset"`
+
+	// The share of the main window with [PresetMain].
+	MainRatio float64 `json:"mainRatio"`
+
+	// The names of the windows in tiling order.
+	Order []string `json:"order"`
+
+	// The name of the focused window.
+	Focused string `json:"focused,omitempty"`
+}
+
+// window is one window of a workspace.
+type window struct {
+	name string          // The window's name.
+	item tview.Primitive // The window's primitive.
+}
+
+// Option configures a workspace created with [New].
+type Option func(w *Workspace)
+
+// WithPreset sets the tiling preset. The default is [PresetColumns].
+func WithPreset(preset Preset) Option {
+	return func(w *Workspace) {
+		w.preset = preset
+	}
+}
+
+// WithMainRatio sets the share of the main window with [PresetMain]. The
+// default is 0.6.
+func WithMainRatio(ratio float64) Option {
+	return func(w *Workspace) {
+		w.mainRatio = clampRatio(ratio)
+	}
+}
+
+// Workspace manages multiple top-level windows, typically boxes with borders
+// and titles, which are tiled according to a preset and placed in a
+// [layers.Layers] container. Only one window has focus at a time. Alt-1 to
+// Alt-9 move the focus to the first to the ninth window. Other events are
+// passed on to the windows.
+//
+// The arrangement of the windows can be retrieved and restored for persistence
+// across sessions, see [Workspace.GetArrangement].
+type Workspace struct {
+	*tview.Box
+
+	// The container holding the windows.
+	layers *layers.Layers
+
+	// The windows in tiling order.
+	windows []*window
+
+	// The tiling preset.
+	preset Preset
+
+	// The preset restored by ToggleMaximized, if a window is maximized.
+	restorePreset Preset
+
+	// The share of the main window with PresetMain.
+	mainRatio float64
+
+	// The name of the focused window, or the window to be focused when the
+	// workspace receives focus.
+	focused string
+
+	// We keep a reference to the function which allows us to set the focus to
+	// another window.
+	setFocus func(p tview.Primitive)
+
+	// An optional handler which is called whenever the arrangement changes.
+	changed func()
+
+	// Set to true when a focus change was found while drawing and the changed
+	// handler has not been called for it yet.
+	focusUnreported bool
+}
+
+// New returns a new workspace without windows.
+func New(opts ...Option) *Workspace {
+	w := &Workspace{
+		Box:       tview.NewBox(),
+		layers:    layers.New(),
+		preset:    PresetColumns,
+		mainRatio: 0.6,
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// SetChangedFunc sets a handler which is called whenever the arrangement of
+// the windows changes, e.g. to persist it. See [Workspace.GetArrangement].
+// Moving the focus to a window with [tview.Application.SetFocus] is reported
+// when the workspace handles the next event or when
+// [Workspace.GetArrangement] or [Workspace.GetFocusedWindow] is called.
+func (w *Workspace) SetChangedFunc(handler func()) *Workspace {
+	w.changed = handler
+	return w
+}
+
+// AddWindow adds a window with the given name after the existing windows. A
+// window with the same name is replaced. The first window receives the focus.
+func (w *Workspace) AddWindow(name string, item tview.Primitive) *Workspace {
+	if index := w.index(name); index >= 0 {
+		w.windows[index].item = item
+		w.layers.RemoveLayer(name)
+	} else {
+		w.windows = append(w.windows, &window{name: name, item: item})
+	}
+	w.layers.AddLayer(item, layers.WithName(name), layers.WithResize(false), layers.WithVisible(w.visible(name)))
+	if w.focused == "" {
+		w.focused = name
+	}
+	w.update()
+	return w
+}
+
+// RemoveWindow removes the window with the given name. If it had focus, the
+// focus moves to the first window.
+func (w *Workspace) RemoveWindow(name string) *Workspace {
+	index := w.index(name)
+	if index < 0 {
+		return w
+	}
+	hadFocus := w.windows[index].item.HasFocus()
+	w.windows = slices.Delete(w.windows, index, index+1)
+	w.layers.RemoveLayer(name)
+	if w.focused == name {
+		w.focused = ""
+		if len(w.windows) > 0 {
+			w.focused = w.windows[0].name
+		}
+	}
+	w.update()
+	if hadFocus {
+		w.refocus()
+	}
+	return w
+}
+
+// GetWindow returns the window with the given name or nil if there is no such
+// window.
+func (w *Workspace) GetWindow(name string) tview.Primitive {
+	if index := w.index(name); index >= 0 {
+		return w.windows[index].item
+	}
+	return nil
+}
+
+// GetWindowNames returns the names of all windows in tiling order.
+func (w *Workspace) GetWindowNames() []string {
+	names := make([]string, len(w.windows))
+	for index, window := range w.windows {
+		names[index] = window.name
+	}
+	return names
+}
+
+// MoveWindow moves the window with the given name to the given position in the
+// tiling order. Moving a window to position 0 makes it the main window with
+// [PresetMain].
+func (w *Workspace) MoveWindow(name string, position int) *Workspace {
+	index := w.index(name)
+	if index < 0 {
+		return w
+	}
+	position = min(max(position, 0), len(w.windows)-1)
+	if position == index {
+		return w
+	}
+	window := w.windows[index]
+	w.windows = slices.Insert(slices.Delete(w.windows, index, index+1), position, window)
+	w.update()
+	return w
+}
+
+// SetPreset sets the tiling preset.
+func (w *Workspace) SetPreset(preset Preset) *Workspace {
+	w.restorePreset = ""
+	if w.preset != preset {
+		w.preset = preset
+		w.update()
+	}
+	return w
+}
+
+// ToggleMaximized maximizes the window with the given name, i.e. shows it
+// with [PresetMonocle] and gives it the focus, or, if the workspace already
+// uses [PresetMonocle], restores the previous preset ([PresetColumns] if the
+// preset was set explicitly). This is typically called when the title bar of
+// a window is double-clicked, see [tview.Box.SetTitleDoubleClickFunc].
+func (w *Workspace) ToggleMaximized(name string) *Workspace {
+	if w.preset == PresetMonocle {
+		w.preset = w.restorePreset
+		if w.preset == "" {
+			w.preset = PresetColumns
+		}
+		w.restorePreset = ""
+		w.update()
+		return w
+	}
+	if w.index(name) < 0 {
+		return w
+	}
+	w.restorePreset, w.preset = w.preset, PresetMonocle
+	return w.FocusWindow(name)
+}
+
+// GetPreset returns the tiling preset.
+func (w *Workspace) GetPreset() Preset {
+	return w.preset
+}
+
+// SetMainRatio sets the share of the workspace's width which the main window
+// receives with [PresetMain], between 0.1 and 0.9.
+func (w *Workspace) SetMainRatio(ratio float64) *Workspace {
+	if ratio = clampRatio(ratio); w.mainRatio != ratio {
+		w.mainRatio = ratio
+		w.update()
+	}
+	return w
+}
+
+// FocusWindow moves the focus to the window with the given name. If the
+// workspace does not have focus, the window receives it when the workspace
+// does.
+func (w *Workspace) FocusWindow(name string) *Workspace {
+	index := w.index(name)
+	if index < 0 {
+		return w
+	}
+	w.focused = name
+	w.update()
+	if w.HasFocus() {
+		w.refocus()
+	}
+	return w
+}
+
+// GetFocusedWindow returns the name of the focused window, or of the window
+// which receives the focus when the workspace does. It returns an empty string
+// if there are no windows.
+func (w *Workspace) GetFocusedWindow() string {
+	w.reportFocus()
+	return w.focused
+}
+
+// GetArrangement returns the current arrangement of the windows.
+func (w *Workspace) GetArrangement() Arrangement {
+	w.reportFocus()
+	return Arrangement{
+		Preset:    w.preset,
+		MainRatio: w.mainRatio,
+		Order:     w.GetWindowNames(),
+		Focused:   w.focused,
+	}
+}
+
+// SetArrangement restores an arrangement returned by
+// [Workspace.GetArrangement]. Windows in the arrangement's order which don't
+// exist are ignored. Windows missing from it keep their relative order after
+// the others. An empty preset or a ratio of 0 keep the current values.
+func (w *Workspace) SetArrangement(arrangement Arrangement) *Workspace {
+	if arrangement.Preset != "" {
+		w.preset = arrangement.Preset
+	}
+	if arrangement.MainRatio > 0 {
+		w.mainRatio = clampRatio(arrangement.MainRatio)
+	}
+	ordered := make([]*window, 0, len(w.windows))
+	for _, name := range arrangement.Order {
+		if index := w.index(name); index >= 0 && !slices.Contains(ordered, w.windows[index]) {
+			ordered = append(ordered, w.windows[index])
+		}
+	}
+	for _, window := range w.windows {
+		if !slices.Contains(ordered, window) {
+			ordered = append(ordered, window)
+		}
+	}
+	w.windows = ordered
+	if w.index(arrangement.Focused) >= 0 {
+		w.focused = arrangement.Focused
+	}
+	w.update()
+	if w.HasFocus() {
+		w.refocus()
+	}
+	return w
+}
+
+// HasFocus returns whether or not this primitive has focus.
+func (w *Workspace) HasFocus() bool {
+	return w.layers.HasFocus() || w.Box.HasFocus()
+}
+
+// Focus is called by the application when the primitive receives focus.
+func (w *Workspace) Focus(delegate func(p tview.Primitive)) {
+	if delegate == nil {
+		return
+	}
+	w.setFocus = delegate
+	if item := w.GetWindow(w.focused); item != nil {
+		delegate(item)
+		return
+	}
+	w.Box.Focus(delegate)
+}
+
+// Draw draws this primitive onto the screen.
+func (w *Workspace) Draw(screen tcell.Screen) {
+	w.DrawForSubclass(screen, w)
+	if w.trackFocus() {
+		// The changed handler is not called while drawing, see reportFocus.
+		w.showWindows()
+		w.focusUnreported = true
+	}
+	x, y, width, height := w.GetInnerRect()
+	w.layout(x, y, width, height)
+	w.layers.SetRect(x, y, width, height)
+	w.layers.Draw(screen)
+}
+
+// HandleEvent handles input events for this primitive.
+func (w *Workspace) HandleEvent(event tcell.Event) tview.Command {
+	if key, ok := event.(*tview.KeyEvent); ok && key.Key() == tcell.KeyRune && key.Modifiers() == tcell.ModAlt {
+		if str := key.Str(); len(str) == 1 && str[0] >= '1' && str[0] <= '9' {
+			index := int(str[0] - '1')
+			if index >= len(w.windows) {
+				return nil
+			}
+			w.focused = w.windows[index].name
+			w.update()
+			return tview.BatchCommand{tview.SetFocusCommand{Target: w.windows[index].item}, tview.RedrawCommand{}}
+		}
+	}
+	cmd := w.layers.HandleEvent(event)
+	if w.setFocus != nil {
+		cmd = w.applyFocus(cmd)
+	}
+	w.reportFocus()
+	return cmd
+}
+
+// applyFocus moves the focus as requested by the focus commands contained in
+// the given command right away, so that a window which received the focus,
+// e.g. because it was clicked, is known before the event has been handled. The
+// focus commands are replaced with redraws.
+func (w *Workspace) applyFocus(cmd tview.Command) tview.Command {
+	switch c := cmd.(type) {
+	case tview.SetFocusCommand:
+		if c.Target != nil {
+			w.setFocus(c.Target)
+			return tview.RedrawCommand{}
+		}
+	case tview.BatchCommand:
+		batch := make(tview.BatchCommand, len(c))
+		for index, item := range c {
+			batch[index] = w.applyFocus(item)
+		}
+		return batch
+	}
+	return cmd
+}
+
+// Inspect describes the workspace and its visible windows for the
+// inspection tree.
+func (w *Workspace) Inspect(node *tview.InspectNode) {
+	w.Box.Inspect(node)
+	w.layers.Inspect(node)
+}
+
+// index returns the index of the window with the given name or -1 if there is
+// no such window.
+func (w *Workspace) index(name string) int {
+	return slices.IndexFunc(w.windows, func(window *window) bool {
+		return window.name == name
+	})
+}
+
+// visible returns whether the window with the given name is shown with the
+// current preset.
+func (w *Workspace) visible(name string) bool {
+	return w.preset != PresetMonocle || name == w.focused
+}
+
+// update applies the preset's visibility to the windows and notifies the
+// changed handler.
+func (w *Workspace) update() {
+	w.showWindows()
+	w.focusUnreported = false
+	if w.changed != nil {
+		w.changed()
+	}
+}
+
+// showWindows applies the preset's visibility to the windows.
+func (w *Workspace) showWindows() {
+	for _, window := range w.windows {
+		if w.visible(window.name) {
+			w.layers.ShowLayer(window.name)
+		} else {
+			w.layers.HideLayer(window.name)
+		}
+	}
+}
+
+// refocus moves the focus to the focused window.
+func (w *Workspace) refocus() {
+	if w.setFocus == nil {
+		return
+	}
+	if item := w.GetWindow(w.focused); item != nil {
+		w.setFocus(item)
+	} else {
+		w.setFocus(w)
+	}
+}
+
+// trackFocus remembers which window has focus, e.g. after it was clicked. It
+// returns whether the focused window changed.
+func (w *Workspace) trackFocus() bool {
+	for _, window := range w.windows {
+		if window.name != w.focused && window.item.HasFocus() {
+			w.focused = window.name
+			return true
+		}
+	}
+	return false
+}
+
+// reportFocus remembers which window has focus and notifies the changed
+// handler if that changed, including changes found while drawing.
+func (w *Workspace) reportFocus() {
+	if w.trackFocus() || w.focusUnreported {
+		w.update()
+	}
+}
+
+// layout sets the rectangles of the windows according to the preset.
+func (w *Workspace) layout(x, y, width, height int) {
+	count := len(w.windows)
+	if count == 0 {
+		return
+	}
+	switch w.preset {
+	case PresetRows:
+		for index, row := range split(y, height, count) {
+			w.windows[index].item.SetRect(x, row[0], width, row[1])
+		}
+	case PresetMain:
+		if count == 1 {
+			w.windows[0].item.SetRect(x, y, width, height)
+			return
+		}
+		mainWidth := int(math.Round(float64(width) * w.mainRatio))
+		w.windows[0].item.SetRect(x, y, mainWidth, height)
+		for index, row := range split(y, height, count-1) {
+			w.windows[index+1].item.SetRect(x+mainWidth, row[0], width-mainWidth, row[1])
+		}
+	case PresetGrid:
+		columns := int(math.Ceil(math.Sqrt(float64(count))))
+		rows := (count + columns - 1) / columns
+		for rowIndex, row := range split(y, height, rows) {
+			// The last row may have fewer windows which share its width.
+			first := rowIndex * columns
+			inRow := min(columns, count-first)
+			for columnIndex, column := range split(x, width, inRow) {
+				w.windows[first+columnIndex].item.SetRect(column[0], row[0], column[1], row[1])
+			}
+		}
+	case PresetMonocle:
+		for _, window := range w.windows {
+			window.item.SetRect(x, y, width, height)
+		}
+	default: // PresetColumns
+		for index, column := range split(x, width, count) {
+			w.windows[index].item.SetRect(column[0], y, column[1], height)
+		}
+	}
+}
+
+// split divides the given length, starting at the given position, into the
+// given number of parts and returns their positions and lengths. The remainder
+// is distributed over the first parts.
+func split(start, length, parts int) [][2]int {
+	result := make([][2]int, parts)
+	size, remainder := length/parts, length%parts
+	for index := range result {
+		partLength := size
+		if index < remainder {
+			partLength++
+		}
+		result[index] = [2]int{start, partLength}
+		start += partLength
+	}
+	return result
+}
+
+// clampRatio clamps the share of the main window to a sensible range.
+func clampRatio(ratio float64) float64 {
+	if math.IsNaN(ratio) {
+		return 0.6
+	}
+	return min(max(ratio, 0.1), 0.9)
+}